@@ -0,0 +1,80 @@
+package resource
+
+import (
+	"encoding"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/cccteam/httpio"
+	"github.com/go-playground/errors/v5"
+)
+
+// MaxBatchReadIDs is the maximum number of IDs DecodeIDs accepts in one request.
+const MaxBatchReadIDs = 100
+
+// DecodeIDs decodes the comma-separated ids query parameter of a batch-read request. It also
+// returns a copy of r without the ids parameter, to be passed on to QueryDecoder.Decode; r
+// itself is left unchanged. An ID is decoded with UnmarshalText when T implements
+// encoding.TextUnmarshaler, otherwise T must be a string or integer type.
+func DecodeIDs[T any](r *http.Request) ([]T, *http.Request, error) {
+	query := r.URL.Query()
+	idsStr := query.Get(idsParam)
+	if idsStr == "" {
+		return nil, nil, httpio.NewBadRequestMessagef("missing %s parameter", idsParam)
+	}
+
+	parts := strings.Split(idsStr, ",")
+	if len(parts) > MaxBatchReadIDs {
+		return nil, nil, httpio.NewBadRequestMessagef("too many ids: %d, maximum is %d", len(parts), MaxBatchReadIDs)
+	}
+
+	ids := make([]T, 0, len(parts))
+	for _, s := range parts {
+		id, err := parseID[T](s)
+		if err != nil {
+			return nil, nil, httpio.NewBadRequestMessagef("invalid id %q", s)
+		}
+		ids = append(ids, id)
+	}
+
+	query.Del(idsParam)
+	withoutIDs := r.Clone(r.Context())
+	withoutIDs.URL.RawQuery = query.Encode()
+
+	return ids, withoutIDs, nil
+}
+
+func parseID[T any](s string) (T, error) {
+	var id T
+	if u, ok := any(&id).(encoding.TextUnmarshaler); ok {
+		if err := u.UnmarshalText([]byte(s)); err != nil {
+			return id, errors.Wrap(err, "encoding.TextUnmarshaler.UnmarshalText()")
+		}
+
+		return id, nil
+	}
+
+	v := reflect.ValueOf(&id).Elem()
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return id, errors.Wrap(err, "strconv.ParseInt()")
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return id, errors.Wrap(err, "strconv.ParseUint()")
+		}
+		v.SetUint(n)
+	default:
+		return id, errors.Newf("unsupported id type %T", id)
+	}
+
+	return id, nil
+}
@@ -0,0 +1,78 @@
+package resource
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cccteam/ccc"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDecodeIDs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("string ids", func(t *testing.T) {
+		t.Parallel()
+
+		r := httptest.NewRequest("GET", "/ships:batchGet?ids=a,b,c&columns=name", nil)
+		got, withoutIDs, err := DecodeIDs[string](r)
+		if err != nil {
+			t.Fatalf("DecodeIDs() error = %v", err)
+		}
+		if diff := cmp.Diff([]string{"a", "b", "c"}, got); diff != "" {
+			t.Errorf("DecodeIDs() mismatch (-want +got):\n%s", diff)
+		}
+		if got, want := withoutIDs.URL.RawQuery, "columns=name"; got != want {
+			t.Errorf("DecodeIDs() request URL.RawQuery = %q, want %q", got, want)
+		}
+		if got, want := r.URL.RawQuery, "ids=a,b,c&columns=name"; got != want {
+			t.Errorf("URL.RawQuery = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("int ids", func(t *testing.T) {
+		t.Parallel()
+
+		r := httptest.NewRequest("GET", "/ships:batchGet?ids=1,2", nil)
+		got, _, err := DecodeIDs[int64](r)
+		if err != nil {
+			t.Fatalf("DecodeIDs() error = %v", err)
+		}
+		if diff := cmp.Diff([]int64{1, 2}, got); diff != "" {
+			t.Errorf("DecodeIDs() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("uuid ids", func(t *testing.T) {
+		t.Parallel()
+
+		r := httptest.NewRequest("GET", "/ships:batchGet?ids=4c3e1a52-5d0e-4f07-9f3b-0b5a7f1c2d3e", nil)
+		got, _, err := DecodeIDs[ccc.UUID](r)
+		if err != nil {
+			t.Fatalf("DecodeIDs() error = %v", err)
+		}
+		if len(got) != 1 || got[0].String() != "4c3e1a52-5d0e-4f07-9f3b-0b5a7f1c2d3e" {
+			t.Errorf("DecodeIDs() = %v, want [4c3e1a52-5d0e-4f07-9f3b-0b5a7f1c2d3e]", got)
+		}
+	})
+
+	errorTests := []struct {
+		name  string
+		query string
+	}{
+		{name: "missing ids", query: "columns=name"},
+		{name: "invalid int", query: "ids=1,two"},
+		{name: "too many ids", query: "ids=" + strings.Repeat("1,", MaxBatchReadIDs) + "1"},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := httptest.NewRequest("GET", "/ships:batchGet?"+tt.query, nil)
+			if _, _, err := DecodeIDs[int](r); err == nil {
+				t.Errorf("DecodeIDs() error = nil, want error")
+			}
+		})
+	}
+}
@@ -0,0 +1,418 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"github.com/cccteam/ccc/accesstypes"
+	"github.com/cccteam/httpio"
+	"github.com/go-playground/errors/v5"
+)
+
+// UpdateWhere sets the fields of patch on every row matched by the query's QueryClause, filter,
+// or KeySet with a single UPDATE statement, and returns the number of rows updated.
+//
+// Permissions are enforced as for a single-row update: enable enforcement on patch with
+// accesstypes.Update to require Update on the resource and on every field the patch sets. The
+// resource's row policy is ANDed into the WHERE clause. When change tracking is enabled, the
+// matched rows are read first in txn and a DataChangeEvent is buffered for each changed row.
+//
+// Output-only update funcs (e.g. @auditable columns) are applied as on a single-row update; a
+// patch with per-row defaults or validate funcs is rejected, since they cannot run on the rows
+// of a DML statement.
+func (q *QuerySet[Resource]) UpdateWhere(ctx context.Context, txn ReadWriteTransaction, patch *PatchSet[Resource], eventSource ...string) (int64, error) {
+	event, err := patch.validateEventSource(eventSource)
+	if err != nil {
+		return 0, err
+	}
+
+	stmt, err := q.updateStmt(ctx, txn, txn, patch)
+	if err != nil {
+		return 0, err
+	}
+
+	var oldRows []*Resource
+	if q.rMeta.trackChanges {
+		if oldRows, err = q.preImages(ctx, txn); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := executeDML(ctx, txn, stmt)
+	if err != nil {
+		return 0, errors.Wrap(err, "executeDML()")
+	}
+
+	for _, old := range oldRows {
+		changeSet, err := patch.Diff(old)
+		if err != nil {
+			return 0, errors.Wrap(err, "PatchSet.Diff()")
+		}
+		if len(changeSet) == 0 {
+			continue
+		}
+		redactChangeSet(q.rMeta.encryptedFields, changeSet)
+
+		if err := q.bufferDataChangeEvent(txn, old, changeSet, event); err != nil {
+			return 0, err
+		}
+	}
+
+	return n, nil
+}
+
+// DeleteWhere deletes every row matched by the query's QueryClause, filter, or KeySet with a
+// single DELETE statement, and returns the number of rows deleted.
+//
+// Enable enforcement on the QuerySet with accesstypes.Delete to require Delete on the resource
+// and its fields. The resource's row policy is ANDed into the WHERE clause. When change tracking
+// is enabled, the matched rows are read first in txn and a DataChangeEvent is buffered for each.
+func (q *QuerySet[Resource]) DeleteWhere(ctx context.Context, txn ReadWriteTransaction, eventSource ...string) (int64, error) {
+	p := NewPatchSet(q.rMeta)
+	event, err := p.validateEventSource(eventSource)
+	if err != nil {
+		return 0, err
+	}
+
+	stmt, err := q.deleteStmt(ctx, txn.DBType())
+	if err != nil {
+		return 0, err
+	}
+
+	var oldRows []*Resource
+	if q.rMeta.trackChanges {
+		if oldRows, err = q.preImages(ctx, txn); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := executeDML(ctx, txn, stmt)
+	if err != nil {
+		return 0, errors.Wrap(err, "executeDML()")
+	}
+
+	for _, old := range oldRows {
+		changeSet, err := p.deleteChangeSet(old)
+		if err != nil {
+			return 0, errors.Wrap(err, "PatchSet.deleteChangeSet()")
+		}
+		redactChangeSet(q.rMeta.encryptedFields, changeSet)
+
+		if err := q.bufferDataChangeEvent(txn, old, changeSet, event); err != nil {
+			return 0, err
+		}
+	}
+
+	return n, nil
+}
+
+// PartitionedUpdateWhere is UpdateWhere run as Partitioned DML, outside of a transaction, for
+// updates too large for one transaction. Partitioned DML is not atomic and cannot read the rows
+// it changes, so it is rejected for resources with change tracking enabled.
+func (q *QuerySet[Resource]) PartitionedUpdateWhere(ctx context.Context, client PartitionedExecutor, patch *PatchSet[Resource]) (int64, error) {
+	if q.rMeta.trackChanges {
+		return 0, errors.Newf("partitioned DML is not supported for %s because change tracking is enabled", q.Resource())
+	}
+
+	stmt, err := q.updateStmt(ctx, nil, client, patch)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := client.PartitionedExecuteDML(ctx, stmt)
+	if err != nil {
+		return 0, errors.Wrap(err, "PartitionedExecutor.PartitionedExecuteDML()")
+	}
+
+	return n, nil
+}
+
+// PartitionedDeleteWhere is DeleteWhere run as Partitioned DML, outside of a transaction. It is
+// rejected for resources with change tracking enabled.
+func (q *QuerySet[Resource]) PartitionedDeleteWhere(ctx context.Context, client PartitionedExecutor) (int64, error) {
+	if q.rMeta.trackChanges {
+		return 0, errors.Newf("partitioned DML is not supported for %s because change tracking is enabled", q.Resource())
+	}
+
+	stmt, err := q.deleteStmt(ctx, SpannerDBType)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := client.PartitionedExecuteDML(ctx, stmt)
+	if err != nil {
+		return 0, errors.Wrap(err, "PartitionedExecutor.PartitionedExecuteDML()")
+	}
+
+	return n, nil
+}
+
+// executeDML runs stmt in txn, which must implement DMLExecutor.
+func executeDML(ctx context.Context, txn ReadWriteTransaction, stmt *Statement) (int64, error) {
+	e, ok := txn.(DMLExecutor)
+	if !ok {
+		return 0, errors.Newf("%T does not implement DMLExecutor", txn)
+	}
+
+	n, err := e.ExecuteDML(ctx, stmt)
+	if err != nil {
+		return 0, errors.Wrap(err, "DMLExecutor.ExecuteDML()")
+	}
+
+	return n, nil
+}
+
+// updateStmt checks permissions and builds the UPDATE statement for UpdateWhere. txn is nil for
+// Partitioned DML. Encrypted fields are encrypted with the KeyProvider of keys, the transaction or
+// the client the statement runs on.
+func (q *QuerySet[Resource]) updateStmt(ctx context.Context, txn ReadWriteTransaction, keys any, patch *PatchSet[Resource]) (*Statement, error) {
+	dbType := SpannerDBType
+	if txn != nil {
+		dbType = txn.DBType()
+	}
+
+	if err := patch.checkPermissions(ctx, dbType); err != nil {
+		return nil, err
+	}
+
+	if patch.defaultsUpdateFunc != nil || patch.validateUpdateFunc != nil {
+		return nil, errors.Newf("bulk updates of %s do not support per-row defaults or validate funcs", q.Resource())
+	}
+
+	for field, defaultFunc := range patch.outputOnlyUpdateFuncs {
+		if !patch.IsSet(field) {
+			d, err := defaultFunc(ctx, txn)
+			if err != nil {
+				return nil, errors.Wrap(err, "defaultFunc()")
+			}
+			patch.Set(field, d)
+		}
+	}
+
+	if patch.Len() == 0 {
+		return nil, httpio.NewBadRequestMessagef("No changes to apply for %s", q.Resource())
+	}
+
+	if err := patch.querySet.applyRowPolicy(ctx); err != nil {
+		return nil, err
+	}
+
+	where, err := q.bulkWhere(ctx, dbType, patch.querySet.rowFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	var kp KeyProvider
+	if len(q.rMeta.encryptedFields) != 0 {
+		if kp, err = keyProviderOf(keys); err != nil {
+			return nil, err
+		}
+	}
+
+	fields := slices.Sorted(slices.Values(patch.Fields()))
+
+	set := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if slices.Contains(q.rMeta.primaryKey, field) {
+			return nil, httpio.NewBadRequestMessagef("cannot update primary key field %s", field)
+		}
+
+		f, ok := q.rMeta.dbFieldMap(dbType)[field]
+		if !ok {
+			return nil, errors.Newf("field %s not found in struct", field)
+		}
+
+		column, err := quoteColumn(dbType, f.ColumnName)
+		if err != nil {
+			return nil, err
+		}
+
+		value := patch.Get(field)
+		if isCommitTimestamp(value) {
+			set = append(set, fmt.Sprintf("%s = PENDING_COMMIT_TIMESTAMP()", column))
+
+			continue
+		}
+
+		if q.rMeta.IsEncrypted(field) {
+			if value, err = encryptFieldValue(ctx, kp, field, value); err != nil {
+				return nil, err
+			}
+		}
+
+		name := "_set_" + strings.ToLower(f.ColumnName)
+		where.Params[name] = value
+		set = append(set, fmt.Sprintf("%s = @%s", column, name))
+	}
+
+	return &Statement{
+		resolvedWhereClause: where.resolvedWhereClause,
+		SQL:                 fmt.Sprintf("UPDATE %s SET %s %s", q.Resource(), strings.Join(set, ", "), where.SQL),
+		Params:              where.Params,
+	}, nil
+}
+
+// deleteStmt checks permissions and builds the DELETE statement for DeleteWhere.
+func (q *QuerySet[Resource]) deleteStmt(ctx context.Context, dbType DBType) (*Statement, error) {
+	if err := q.checkPermissions(ctx, dbType); err != nil {
+		return nil, err
+	}
+
+	where, err := q.bulkWhere(ctx, dbType, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Statement{
+		resolvedWhereClause: where.resolvedWhereClause,
+		SQL:                 fmt.Sprintf("DELETE FROM %s %s", q.Resource(), where.SQL),
+		Params:              where.Params,
+	}, nil
+}
+
+// bulkWhere builds the WHERE clause of a bulk mutation from the query's QueryClause, filter,
+// KeySet, or KeyRange, ANDed with the resource's row policy and rowFilter. A bulk mutation must
+// have one of them, so a missing filter can not update or delete every row of the table. A
+// KeyRange with neither a Start nor an End matches every row, so it does not count.
+func (q *QuerySet[Resource]) bulkWhere(ctx context.Context, dbType DBType, rowFilter ExpressionNode) (*Statement, error) {
+	if _, ok := any(*new(Resource)).(virtualQuerier); ok {
+		return nil, errors.Newf("bulk mutations are not supported for virtual resource %s", q.Resource())
+	}
+	if q.search != "" {
+		return nil, httpio.NewBadRequestMessagef("bulk mutations of %s can not use search", q.Resource())
+	}
+
+	filterAst, err := q.FilterAst(dbType)
+	if err != nil {
+		return nil, errors.Wrap(err, "QuerySet.FilterAst()")
+	}
+
+	if moreThan(1, q.KeySet().Len() != 0, len(q.keySets) != 0, filterAst != nil) {
+		return nil, httpio.NewBadRequestMessage("cannot use multiple sources for WHERE clause together (e.g. QueryClause and KeySet)")
+	}
+	if filterAst == nil && q.KeySet().Len() == 0 && len(q.keySets) == 0 && (q.keyRange == nil || q.keyRange.unbounded()) {
+		return nil, httpio.NewBadRequestMessagef("bulk mutations of %s require a QueryClause, filter, KeySet, or KeyRange with a Start or End", q.Resource())
+	}
+
+	if err := q.applyRowPolicy(ctx); err != nil {
+		return nil, err
+	}
+	switch {
+	case q.rowFilter == nil:
+		q.rowFilter = rowFilter
+	case rowFilter != nil:
+		q.rowFilter = andExpression(q.rowFilter, rowFilter)
+	}
+
+	for field := range q.rMeta.encryptedFields {
+		dbField, ok := q.rMeta.dbFieldMap(dbType)[field]
+		if ok && expressionReferences(filterAst, dbField.ColumnName) {
+			return nil, httpio.NewBadRequestMessagef("cannot filter on encrypted field: %s", field)
+		}
+	}
+
+	where, err := q.where(dbType, filterAst)
+	if err != nil {
+		return nil, errors.Wrap(err, "QuerySet.where()")
+	}
+
+	resolvedSQL, err := substituteSQLParams(where.SQL, where.Params)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to substitute SQL params for resolvedWhereClause")
+	}
+	where.resolvedWhereClause = resolvedSQL
+
+	return where, nil
+}
+
+// preImages reads every column of the rows a bulk mutation matches, for their DataChangeEvents.
+func (q *QuerySet[Resource]) preImages(ctx context.Context, txn ReadWriteTransaction) ([]*Resource, error) {
+	if len(q.rMeta.primaryKey) == 0 {
+		return nil, errors.Newf("change tracking for bulk mutations of %s requires its PrimaryKeyFields", q.Resource())
+	}
+
+	qSet := NewQuerySet(q.rMeta)
+	qSet.keys = q.keys
+	qSet.keySets = q.keySets
+	qSet.filterAst = q.filterAst
+	qSet.filterParser = q.filterParser
+	qSet.rowFilter = q.rowFilter
+	qSet.parentKey = q.parentKey
+	qSet.keyRange = q.keyRange
+	for _, field := range q.rMeta.DBFields(txn.DBType()) {
+		qSet.AddField(field)
+	}
+
+	stmt, err := qSet.stmt(txn.DBType())
+	if err != nil {
+		return nil, errors.Wrap(err, "QuerySet.stmt()")
+	}
+
+	var rows []*Resource
+	for row, err := range newReader[Resource](txn).List(ctx, stmt) {
+		if err != nil {
+			return nil, errors.Wrapf(err, "Reader[%s].List()", q.Resource())
+		}
+
+		// decrypt so unchanged encrypted values compare equal to the patch
+		if err := decryptFields(ctx, txn, q.rMeta.encryptedFields, row); err != nil {
+			return nil, errors.Wrap(err, "decryptFields()")
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// bufferDataChangeEvent buffers the DataChangeEvent of one row changed by a bulk mutation.
+func (q *QuerySet[Resource]) bufferDataChangeEvent(txn ReadWriteTransaction, row *Resource, changeSet map[accesstypes.Field]DiffElem, eventSource string) error {
+	var pk KeySet
+	for _, field := range q.rMeta.primaryKey {
+		pk = pk.Add(field, nil)
+	}
+
+	rowID := rowKeySet(row, pk).RowID()
+	event := &DataChangeEvent{
+		TableName:   q.Resource(),
+		RowID:       rowID,
+		Sequence:    txn.DataChangeEventIndex(q.Resource(), rowID),
+		EventTime:   spanner.CommitTimestamp,
+		EventSource: eventSource,
+		ChangeSet:   spanner.NullJSON{Valid: true, Value: changeSet},
+	}
+
+	if err := txn.BufferStruct(event); err != nil {
+		return errors.Wrap(err, "ReadWriteTransaction.BufferStruct()")
+	}
+
+	return nil
+}
+
+// quoteColumn quotes a column name for dbType.
+func quoteColumn(dbType DBType, column string) (string, error) {
+	switch dbType {
+	case SpannerDBType:
+		return fmt.Sprintf("`%s`", column), nil
+	case PostgresDBType:
+		return fmt.Sprintf(`"%s"`, column), nil
+	default:
+		return "", errors.Newf("unsupported dbType: %s", dbType)
+	}
+}
+
+// isCommitTimestamp reports whether v is the spanner.CommitTimestamp placeholder, which DML
+// must write with PENDING_COMMIT_TIMESTAMP() instead of a parameter.
+func isCommitTimestamp(v any) bool {
+	switch t := v.(type) {
+	case time.Time:
+		return t.Equal(spanner.CommitTimestamp)
+	case *time.Time:
+		return t != nil && t.Equal(spanner.CommitTimestamp)
+	default:
+		return false
+	}
+}
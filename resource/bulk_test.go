@@ -0,0 +1,215 @@
+package resource
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/spanner"
+	"github.com/cccteam/ccc/accesstypes"
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/mock/gomock"
+)
+
+// dmlTxn records the DML statements and buffered structs of a bulk mutation.
+type dmlTxn struct {
+	recordingTxn
+	stmts   []*Statement
+	structs []PatchSetMetadata
+}
+
+func (d *dmlTxn) ExecuteDML(_ context.Context, stmt *Statement) (int64, error) {
+	d.stmts = append(d.stmts, stmt)
+
+	return 2, nil
+}
+
+func (d *dmlTxn) BufferStruct(p PatchSetMetadata) error {
+	d.structs = append(d.structs, p)
+
+	return nil
+}
+
+type trackedBulkResource struct {
+	ID     string `spanner:"Id"     db:"Id"`
+	Status string `spanner:"Status" db:"Status"`
+}
+
+func (trackedBulkResource) Resource() accesstypes.Resource {
+	return "TrackedBulkResources"
+}
+
+func (trackedBulkResource) DefaultConfig() Config {
+	return Config{TrackChanges: true}
+}
+
+func (trackedBulkResource) PrimaryKeyFields() []accesstypes.Field {
+	return []accesstypes.Field{"ID"}
+}
+
+func TestQuerySet_UpdateWhere(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		filter     ExpressionNode
+		keyRange   *KeyRange
+		set        map[accesstypes.Field]any
+		wantSQL    string
+		wantParams map[string]any
+		wantErr    string
+	}{
+		{
+			name:       "filter",
+			filter:     &ConditionNode{Condition: Condition{Field: "ShipId", Operator: eqStr, Value: "ship-1"}},
+			set:        map[accesstypes.Field]any{"Details": "archived"},
+			wantSQL:    "UPDATE CargoManifests SET `Details` = @_set_details WHERE `ShipId` = @_p1",
+			wantParams: map[string]any{"_p1": "ship-1", "_set_details": "archived"},
+		},
+		{
+			name:       "commit timestamp",
+			filter:     &ConditionNode{Condition: Condition{Field: "ShipId", Operator: eqStr, Value: "ship-1"}},
+			set:        map[accesstypes.Field]any{"Details": spanner.CommitTimestamp},
+			wantSQL:    "UPDATE CargoManifests SET `Details` = PENDING_COMMIT_TIMESTAMP() WHERE `ShipId` = @_p1",
+			wantParams: map[string]any{"_p1": "ship-1"},
+		},
+		{
+			name:    "no filter",
+			set:     map[accesstypes.Field]any{"Details": "archived"},
+			wantErr: "require a QueryClause, filter, KeySet, or KeyRange with a Start or End",
+		},
+		{
+			name:       "key range",
+			keyRange:   &KeyRange{Start: KeySet{}.Add("ShipID", "ship-1"), Kind: spanner.ClosedOpen},
+			set:        map[accesstypes.Field]any{"Details": "archived"},
+			wantSQL:    "UPDATE CargoManifests SET `Details` = @_set_details WHERE `ShipId` >= @_start_shipid",
+			wantParams: map[string]any{"_start_shipid": "ship-1", "_set_details": "archived"},
+		},
+		{
+			name:     "key range with no start or end",
+			keyRange: &KeyRange{Kind: spanner.ClosedOpen},
+			set:      map[accesstypes.Field]any{"Details": "archived"},
+			wantErr:  "require a QueryClause, filter, KeySet, or KeyRange with a Start or End",
+		},
+		{
+			name:    "primary key field",
+			filter:  &ConditionNode{Condition: Condition{Field: "ShipId", Operator: eqStr, Value: "ship-1"}},
+			set:     map[accesstypes.Field]any{"LineNumber": int64(3)},
+			wantErr: "cannot update primary key field LineNumber",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			qSet := NewQuerySet(NewMetadata[keyReadTestResource]())
+			if tt.filter != nil {
+				qSet.SetFilterAst(tt.filter)
+			}
+			if tt.keyRange != nil {
+				qSet.SetKeyRange(*tt.keyRange)
+			}
+			patch := NewPatchSet(NewMetadata[keyReadTestResource]())
+			for field, value := range tt.set {
+				patch.Set(field, value)
+			}
+
+			txn := &dmlTxn{}
+			n, err := qSet.UpdateWhere(t.Context(), txn, patch)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("QuerySet.UpdateWhere() error = %v, want %q", err, tt.wantErr)
+				}
+				if len(txn.stmts) != 0 {
+					t.Errorf("QuerySet.UpdateWhere() executed %d statements, want 0", len(txn.stmts))
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("QuerySet.UpdateWhere() error = %v", err)
+			}
+			if n != 2 {
+				t.Errorf("QuerySet.UpdateWhere() = %d, want 2", n)
+			}
+			if len(txn.stmts) != 1 {
+				t.Fatalf("QuerySet.UpdateWhere() executed %d statements, want 1", len(txn.stmts))
+			}
+			if got := txn.stmts[0].SQL; got != tt.wantSQL {
+				t.Errorf("Statement.SQL = %q, want %q", got, tt.wantSQL)
+			}
+			if diff := cmp.Diff(tt.wantParams, txn.stmts[0].Params); diff != "" {
+				t.Errorf("Statement.Params mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestQuerySet_DeleteWhere_trackChanges(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	reader := NewMockReader[trackedBulkResource](ctrl)
+	reader.EXPECT().List(gomock.Any(), gomock.Any()).Return(MockIterSeq2(nil,
+		&trackedBulkResource{ID: "1", Status: "stale"},
+		&trackedBulkResource{ID: "2", Status: "stale"},
+	))
+
+	qSet := NewQuerySet(NewMetadata[trackedBulkResource]())
+	qSet.SetFilterAst(&ConditionNode{Condition: Condition{Field: "Status", Operator: eqStr, Value: "stale"}})
+
+	txn := &dmlTxn{}
+	if _, err := qSet.DeleteWhere(t.Context(), NewMockReadWriteTransaction(txn, reader), "nightly-cleanup"); err != nil {
+		t.Fatalf("QuerySet.DeleteWhere() error = %v", err)
+	}
+
+	if len(txn.stmts) != 1 {
+		t.Fatalf("QuerySet.DeleteWhere() executed %d statements, want 1", len(txn.stmts))
+	}
+	if got, want := txn.stmts[0].SQL, "DELETE FROM TrackedBulkResources WHERE `Status` = @_p1"; got != want {
+		t.Errorf("Statement.SQL = %q, want %q", got, want)
+	}
+
+	var rowIDs []string
+	for _, s := range txn.structs {
+		event, ok := s.(*DataChangeEvent)
+		if !ok {
+			t.Fatalf("buffered %T, want *DataChangeEvent", s)
+		}
+		if event.EventSource != "nightly-cleanup" {
+			t.Errorf("DataChangeEvent.EventSource = %q, want %q", event.EventSource, "nightly-cleanup")
+		}
+		rowIDs = append(rowIDs, event.RowID)
+	}
+	if diff := cmp.Diff([]string{"1", "2"}, rowIDs); diff != "" {
+		t.Errorf("DataChangeEvent.RowID mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestQuerySet_DeleteWhere_noDMLExecutor(t *testing.T) {
+	t.Parallel()
+
+	qSet := NewQuerySet(NewMetadata[keyReadTestResource]())
+	qSet.SetFilterAst(&ConditionNode{Condition: Condition{Field: "ShipId", Operator: eqStr, Value: "ship-1"}})
+
+	_, err := qSet.DeleteWhere(t.Context(), &recordingTxn{})
+	if err == nil || !strings.Contains(err.Error(), "does not implement DMLExecutor") {
+		t.Errorf("QuerySet.DeleteWhere() error = %v, want DMLExecutor error", err)
+	}
+}
+
+func TestQuerySet_PartitionedDeleteWhere_trackChanges(t *testing.T) {
+	t.Parallel()
+
+	qSet := NewQuerySet(NewMetadata[trackedBulkResource]())
+	qSet.SetFilterAst(&ConditionNode{Condition: Condition{Field: "Status", Operator: eqStr, Value: "stale"}})
+
+	txn := &dmlTxn{}
+	if _, err := qSet.PartitionedDeleteWhere(t.Context(), NewMockClient(txn, nil, nil)); err == nil {
+		t.Fatalf("QuerySet.PartitionedDeleteWhere() error = nil, want error")
+	}
+	if len(txn.stmts) != 0 {
+		t.Errorf("QuerySet.PartitionedDeleteWhere() executed %d statements, want 0", len(txn.stmts))
+	}
+}
@@ -1,6 +1,10 @@
 package resource
 
-import "cloud.google.com/go/spanner"
+import (
+	"time"
+
+	"cloud.google.com/go/spanner"
+)
 
 // DBType represents the type of database, such as Spanner or PostgreSQL.
 type DBType string
@@ -16,6 +20,10 @@ const (
 	MockDBType DBType = "mock"
 )
 
+// pgxTagKey is the struct tag pgx maps columns by, read as a Postgres column when a field
+// has no postgres tag.
+const pgxTagKey = "db"
+
 // dbTypes should return DBType constants for all supported databases
 func dbTypes() []DBType {
 	return []DBType{SpannerDBType, PostgresDBType}
@@ -27,8 +35,27 @@ type Statement struct {
 	// resolvedWhereClause is used to carry contextual information for error messages
 	// and is not used in the query.
 	resolvedWhereClause string
-	SQL                 string
-	Params              map[string]any
+	// keyRead is set when the statement reads a single row by its full primary key, or the
+	// rows of a key range, so a reader that supports it can skip the query and read by key.
+	keyRead *keyRead
+	SQL     string
+	Params  map[string]any
+}
+
+// keyRead holds the arguments of a read by primary key. keyRange is set for a range read,
+// which returns at most limit rows when limit is not zero.
+type keyRead struct {
+	table    string
+	key      spanner.Key
+	keys     []spanner.Key // the keys of a ReadMany
+	keyRange *spanner.KeyRange
+	limit    int
+	columns  []string
+}
+
+// isList reports whether the read returns any number of rows, rather than the one row of key.
+func (k *keyRead) isList() bool {
+	return k.keys != nil || k.keyRange != nil
 }
 
 // SpannerStatement converts the generic Statement into a Spanner-specific Statement.
@@ -42,10 +69,17 @@ func (s *Statement) SpannerStatement() spanner.Statement {
 // Columns is a string representing a comma-separated list of database column names.
 type Columns string
 
-// Config holds database-specific configuration for a resource.
+// Config holds database-specific configuration for a resource. The query policy fields bound
+// the List queries clients can run; a zero value places no bound.
 type Config struct {
 	ChangeTrackingTable string
 	TrackChanges        bool
+
+	MaxLimit             uint64        // Largest limit a List may request
+	MaxOffset            uint64        // Largest offset a List may request
+	QueryTimeout         time.Duration // Deadline for each List statement
+	RequireIndexedFilter bool          // Rejects a List that scans the whole resource
+	RequireIndexedSort   bool          // Rejects a sort on a field that isn't a key, indexed or allow_sort
 }
 
 // SetChangeTrackingTable returns a new Config with the change tracking table name set.
@@ -61,3 +95,38 @@ func (c Config) SetTrackChanges(trackChanges bool) Config {
 
 	return c
 }
+
+// SetMaxLimit returns a new Config with the maximum List limit set.
+func (c Config) SetMaxLimit(maxLimit uint64) Config {
+	c.MaxLimit = maxLimit
+
+	return c
+}
+
+// SetMaxOffset returns a new Config with the maximum List offset set.
+func (c Config) SetMaxOffset(maxOffset uint64) Config {
+	c.MaxOffset = maxOffset
+
+	return c
+}
+
+// SetQueryTimeout returns a new Config with the List statement timeout set.
+func (c Config) SetQueryTimeout(timeout time.Duration) Config {
+	c.QueryTimeout = timeout
+
+	return c
+}
+
+// SetRequireIndexedFilter returns a new Config with the indexed filter requirement set.
+func (c Config) SetRequireIndexedFilter(require bool) Config {
+	c.RequireIndexedFilter = require
+
+	return c
+}
+
+// SetRequireIndexedSort returns a new Config with the indexed sort requirement set.
+func (c Config) SetRequireIndexedSort(require bool) Config {
+	c.RequireIndexedSort = require
+
+	return c
+}
@@ -0,0 +1,311 @@
+package resource
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"reflect"
+	"strings"
+
+	"github.com/cccteam/ccc/accesstypes"
+	"github.com/go-playground/errors/v5"
+)
+
+// envelopePrefix marks a column value written by encryptValue and versions its layout:
+// "enc:v1:<key id>:<wrapped data key>:<nonce and ciphertext>", both binary parts
+// base64 (raw URL) encoded.
+const envelopePrefix = "enc:v1:"
+
+// redactedValue replaces the values of encrypted fields in DataChangeEvent change sets.
+const redactedValue = "[REDACTED]"
+
+const dataKeySize = 32
+
+// encryptedFielder is an interface for types with fields that are encrypted before they are
+// written to the database.
+type encryptedFielder interface {
+	EncryptedFields() []accesstypes.Field
+}
+
+// KeyProvider wraps and unwraps the per-value data keys used to encrypt fields with the
+// encrypted condition (envelope encryption). WrapKey encrypts a data key with the current
+// key-encryption key and returns that key's ID, which is stored with the value; UnwrapKey
+// must keep accepting the IDs of retired keys so values written before a rotation remain
+// readable.
+type KeyProvider interface {
+	WrapKey(ctx context.Context, dataKey []byte) (keyID string, wrappedKey []byte, err error)
+	UnwrapKey(ctx context.Context, keyID string, wrappedKey []byte) ([]byte, error)
+}
+
+// keyProviderer is implemented by the clients and transactions carrying the KeyProvider set on
+// the client with SetKeyProvider.
+type keyProviderer interface {
+	KeyProvider() KeyProvider
+}
+
+// keyProviderOf returns the KeyProvider of db, the client or transaction encrypted fields are
+// read or written with.
+func keyProviderOf(db any) (KeyProvider, error) {
+	if k, ok := db.(keyProviderer); ok && k.KeyProvider() != nil {
+		return k.KeyProvider(), nil
+	}
+
+	return nil, errors.New("no KeyProvider set for encrypted fields, call SetKeyProvider() on the client")
+}
+
+var _ KeyProvider = (*LocalKeyProvider)(nil)
+
+// LocalKeyProvider is a KeyProvider holding AES-GCM key-encryption keys in memory. It is
+// intended for tests and local development; production deployments should wrap data keys
+// with a key management service.
+type LocalKeyProvider struct {
+	currentKeyID string
+	keys         map[string]cipher.AEAD
+}
+
+// NewLocalKeyProvider creates a LocalKeyProvider from AES keys (16, 24, or 32 bytes) by ID.
+// New values are wrapped with currentKeyID; to rotate, add a new key and make it current
+// while keeping the old ones for reading.
+func NewLocalKeyProvider(currentKeyID string, keys map[string][]byte) (*LocalKeyProvider, error) {
+	if _, ok := keys[currentKeyID]; !ok {
+		return nil, errors.Newf("current key %q not found in keys", currentKeyID)
+	}
+
+	aeads := make(map[string]cipher.AEAD, len(keys))
+	for id, key := range keys {
+		if id == "" || strings.Contains(id, ":") {
+			return nil, errors.Newf("invalid key id %q, must be non-empty and not contain ':'", id)
+		}
+
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, errors.Wrapf(err, "key %q", id)
+		}
+		aeads[id] = aead
+	}
+
+	return &LocalKeyProvider{currentKeyID: currentKeyID, keys: aeads}, nil
+}
+
+// WrapKey encrypts dataKey with the current key.
+func (l *LocalKeyProvider) WrapKey(_ context.Context, dataKey []byte) (keyID string, wrappedKey []byte, err error) {
+	wrappedKey, err = sealAEAD(l.keys[l.currentKeyID], dataKey)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return l.currentKeyID, wrappedKey, nil
+}
+
+// UnwrapKey decrypts a data key wrapped with the key keyID.
+func (l *LocalKeyProvider) UnwrapKey(_ context.Context, keyID string, wrappedKey []byte) ([]byte, error) {
+	aead, ok := l.keys[keyID]
+	if !ok {
+		return nil, errors.Newf("unknown key %q", keyID)
+	}
+
+	return openAEAD(aead, wrappedKey)
+}
+
+// encryptValue encrypts plaintext with a new data key and returns the envelope stored in the database.
+func encryptValue(ctx context.Context, kp KeyProvider, plaintext string) (string, error) {
+	dataKey := make([]byte, dataKeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return "", errors.Wrap(err, "rand.Read()")
+	}
+
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return "", err
+	}
+
+	ciphertext, err := sealAEAD(aead, []byte(plaintext))
+	if err != nil {
+		return "", err
+	}
+
+	keyID, wrappedKey, err := kp.WrapKey(ctx, dataKey)
+	if err != nil {
+		return "", errors.Wrap(err, "KeyProvider.WrapKey()")
+	}
+	if keyID == "" || strings.Contains(keyID, ":") {
+		return "", errors.Newf("KeyProvider.WrapKey() returned invalid key id %q", keyID)
+	}
+
+	return envelopePrefix + keyID + ":" + base64.RawURLEncoding.EncodeToString(wrappedKey) + ":" + base64.RawURLEncoding.EncodeToString(ciphertext), nil
+}
+
+// decryptValue decrypts an envelope written by encryptValue.
+func decryptValue(ctx context.Context, kp KeyProvider, envelope string) (string, error) {
+	rest, ok := strings.CutPrefix(envelope, envelopePrefix)
+	if !ok {
+		return "", errors.New("value is not an encryption envelope")
+	}
+
+	parts := strings.Split(rest, ":")
+	if len(parts) != 3 {
+		return "", errors.New("malformed encryption envelope")
+	}
+
+	wrappedKey, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", errors.Wrap(err, "base64.DecodeString()")
+	}
+
+	ciphertext, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", errors.Wrap(err, "base64.DecodeString()")
+	}
+
+	dataKey, err := kp.UnwrapKey(ctx, parts[0], wrappedKey)
+	if err != nil {
+		return "", errors.Wrap(err, "KeyProvider.UnwrapKey()")
+	}
+
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return "", err
+	}
+
+	plaintext, err := openAEAD(aead, ciphertext)
+	if err != nil {
+		return "", err
+	}
+
+	return string(plaintext), nil
+}
+
+// encryptFieldValue encrypts a string or *string patch value. A nil value is left as-is so
+// the column can still be cleared.
+func encryptFieldValue(ctx context.Context, kp KeyProvider, field accesstypes.Field, value any) (any, error) {
+	v := reflect.ValueOf(value)
+	switch {
+	case !v.IsValid():
+		return value, nil
+	case v.Kind() == reflect.String:
+		return encryptValue(ctx, kp, v.String())
+	case v.Kind() == reflect.Pointer && v.Type().Elem().Kind() == reflect.String:
+		if v.IsNil() {
+			return value, nil
+		}
+		envelope, err := encryptValue(ctx, kp, v.Elem().String())
+		if err != nil {
+			return nil, err
+		}
+
+		return &envelope, nil
+	default:
+		return nil, errors.Newf("encrypted field %s must be a string or *string, found %T", field, value)
+	}
+}
+
+// decryptFields replaces the envelopes in the encrypted fields of the struct dst points to with
+// their plaintext, using the KeyProvider of db, the client or transaction dst was read with.
+func decryptFields(ctx context.Context, db any, encryptedFields map[accesstypes.Field]struct{}, dst any) error {
+	if len(encryptedFields) == 0 || reflect.ValueOf(dst).IsNil() {
+		return nil
+	}
+
+	kp, err := keyProviderOf(db)
+	if err != nil {
+		return err
+	}
+
+	v := reflect.ValueOf(dst).Elem()
+	for field := range encryptedFields {
+		f := v.FieldByName(string(field))
+		if !f.IsValid() {
+			continue
+		}
+
+		switch {
+		case f.Kind() == reflect.String:
+			if f.String() == "" {
+				continue
+			}
+			plaintext, err := decryptValue(ctx, kp, f.String())
+			if err != nil {
+				return errors.Wrapf(err, "field %s", field)
+			}
+			f.SetString(plaintext)
+		case f.Kind() == reflect.Pointer && f.Type().Elem().Kind() == reflect.String:
+			if f.IsNil() {
+				continue
+			}
+			plaintext, err := decryptValue(ctx, kp, f.Elem().String())
+			if err != nil {
+				return errors.Wrapf(err, "field %s", field)
+			}
+			decrypted := reflect.New(f.Type().Elem())
+			decrypted.Elem().SetString(plaintext)
+			f.Set(decrypted)
+		default:
+			return errors.Newf("encrypted field %s must be a string or *string, found %s", field, f.Type())
+		}
+	}
+
+	return nil
+}
+
+// redactChangeSet replaces the non-nil values of encrypted fields in a change set so plaintext
+// never reaches the change tracking table.
+func redactChangeSet(encryptedFields map[accesstypes.Field]struct{}, changeSet map[accesstypes.Field]DiffElem) {
+	for field, diff := range changeSet {
+		if _, ok := encryptedFields[field]; !ok {
+			continue
+		}
+
+		if !isNil(diff.Old) {
+			diff.Old = redactedValue
+		}
+		if !isNil(diff.New) {
+			diff.New = redactedValue
+		}
+		changeSet[field] = diff
+	}
+}
+
+func isNil(v any) bool {
+	rv := reflect.ValueOf(v)
+
+	return !rv.IsValid() || (rv.Kind() == reflect.Pointer && rv.IsNil())
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "aes.NewCipher()")
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "cipher.NewGCM()")
+	}
+
+	return aead, nil
+}
+
+// sealAEAD encrypts plaintext with a random nonce, which is prepended to the ciphertext.
+func sealAEAD(aead cipher.AEAD, plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, errors.Wrap(err, "rand.Read()")
+	}
+
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func openAEAD(aead cipher.AEAD, ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+
+	plaintext, err := aead.Open(nil, ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():], nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "cipher.AEAD.Open()")
+	}
+
+	return plaintext, nil
+}
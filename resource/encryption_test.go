@@ -0,0 +1,222 @@
+package resource
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/cccteam/ccc/accesstypes"
+	"go.uber.org/mock/gomock"
+)
+
+func mustLocalKeyProvider(t *testing.T, currentKeyID string, keys map[string][]byte) *LocalKeyProvider {
+	t.Helper()
+
+	kp, err := NewLocalKeyProvider(currentKeyID, keys)
+	if err != nil {
+		t.Fatalf("NewLocalKeyProvider() error = %v", err)
+	}
+
+	return kp
+}
+
+func Test_encryptValue_decryptValue(t *testing.T) {
+	t.Parallel()
+
+	key1 := bytes.Repeat([]byte{1}, 32)
+	key2 := bytes.Repeat([]byte{2}, 32)
+
+	kp := mustLocalKeyProvider(t, "k1", map[string][]byte{"k1": key1})
+
+	envelope, err := encryptValue(t.Context(), kp, "allergic to plomeek")
+	if err != nil {
+		t.Fatalf("encryptValue() error = %v", err)
+	}
+	if !strings.HasPrefix(envelope, envelopePrefix+"k1:") {
+		t.Errorf("encryptValue() = %q, want prefix %q", envelope, envelopePrefix+"k1:")
+	}
+	if strings.Contains(envelope, "plomeek") {
+		t.Errorf("encryptValue() = %q, contains plaintext", envelope)
+	}
+
+	again, err := encryptValue(t.Context(), kp, "allergic to plomeek")
+	if err != nil {
+		t.Fatalf("encryptValue() error = %v", err)
+	}
+	if again == envelope {
+		t.Errorf("encryptValue() produced identical envelopes for the same plaintext")
+	}
+
+	// after rotation new values use k2 while values written under k1 stay readable
+	rotated := mustLocalKeyProvider(t, "k2", map[string][]byte{"k1": key1, "k2": key2})
+
+	got, err := decryptValue(t.Context(), rotated, envelope)
+	if err != nil {
+		t.Fatalf("decryptValue() error = %v", err)
+	}
+	if got != "allergic to plomeek" {
+		t.Errorf("decryptValue() = %q, want %q", got, "allergic to plomeek")
+	}
+
+	rotatedEnvelope, err := encryptValue(t.Context(), rotated, "allergic to plomeek")
+	if err != nil {
+		t.Fatalf("encryptValue() error = %v", err)
+	}
+	if !strings.HasPrefix(rotatedEnvelope, envelopePrefix+"k2:") {
+		t.Errorf("encryptValue() = %q, want prefix %q", rotatedEnvelope, envelopePrefix+"k2:")
+	}
+
+	if _, err := decryptValue(t.Context(), kp, rotatedEnvelope); err == nil {
+		t.Errorf("decryptValue() with retired provider error = nil, want unknown key error")
+	}
+
+	tampered := envelope[:len(envelope)-2] + "AA"
+	if tampered == envelope {
+		tampered = envelope[:len(envelope)-2] + "BB"
+	}
+	if _, err := decryptValue(t.Context(), kp, tampered); err == nil {
+		t.Errorf("decryptValue() of tampered envelope error = nil, want error")
+	}
+
+	if _, err := decryptValue(t.Context(), kp, "allergic to plomeek"); err == nil {
+		t.Errorf("decryptValue() of plaintext error = nil, want error")
+	}
+}
+
+func TestNewLocalKeyProvider(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		currentKeyID string
+		keys         map[string][]byte
+		wantErr      bool
+	}{
+		{name: "valid", currentKeyID: "k1", keys: map[string][]byte{"k1": bytes.Repeat([]byte{1}, 16)}},
+		{name: "missing current key", currentKeyID: "k2", keys: map[string][]byte{"k1": bytes.Repeat([]byte{1}, 16)}, wantErr: true},
+		{name: "invalid key size", currentKeyID: "k1", keys: map[string][]byte{"k1": bytes.Repeat([]byte{1}, 10)}, wantErr: true},
+		{name: "key id with separator", currentKeyID: "k:1", keys: map[string][]byte{"k:1": bytes.Repeat([]byte{1}, 16)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := NewLocalKeyProvider(tt.currentKeyID, tt.keys); (err != nil) != tt.wantErr {
+				t.Errorf("NewLocalKeyProvider() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+type encryptedResource struct {
+	ID           int     `spanner:"Id"`
+	Name         string  `spanner:"Name"`
+	MedicalNotes *string `spanner:"MedicalNotes"`
+}
+
+func (encryptedResource) Resource() accesstypes.Resource { return "encryptedResources" }
+
+func (encryptedResource) EncryptedFields() []accesstypes.Field {
+	return []accesstypes.Field{"MedicalNotes"}
+}
+
+func TestEncryptedFields(t *testing.T) {
+	t.Parallel()
+
+	kp := mustLocalKeyProvider(t, "k1", map[string][]byte{"k1": bytes.Repeat([]byte{1}, 32)})
+	notes := "allergic to plomeek"
+
+	t.Run("Resolve encrypts", func(t *testing.T) {
+		p := NewPatchSet(NewMetadata[encryptedResource]())
+		p.SetKey("ID", 1)
+		p.Set("Name", "Tuvok")
+		p.Set("MedicalNotes", &notes)
+
+		if _, err := p.Resolve(SpannerDBType); err == nil {
+			t.Errorf("PatchSet.Resolve() without a KeyProvider error = nil, want error")
+		}
+
+		got, err := p.ResolveContext(t.Context(), SpannerDBType, kp)
+		if err != nil {
+			t.Fatalf("PatchSet.ResolveContext() error = %v", err)
+		}
+		if got["Name"] != "Tuvok" {
+			t.Errorf("Resolve()[Name] = %v, want %q", got["Name"], "Tuvok")
+		}
+		envelope, ok := got["MedicalNotes"].(*string)
+		if !ok || !strings.HasPrefix(*envelope, envelopePrefix) {
+			t.Fatalf("Resolve()[MedicalNotes] = %v, want an encryption envelope", got["MedicalNotes"])
+		}
+
+		dst := &encryptedResource{MedicalNotes: envelope}
+		if err := decryptFields(t.Context(), NewMockClient(nil, nil, nil).SetKeyProvider(kp), NewMetadata[encryptedResource]().encryptedFields, dst); err != nil {
+			t.Fatalf("decryptFields() error = %v", err)
+		}
+		if *dst.MedicalNotes != notes {
+			t.Errorf("decryptFields() MedicalNotes = %q, want %q", *dst.MedicalNotes, notes)
+		}
+	})
+
+	t.Run("Read decrypts", func(t *testing.T) {
+		envelope, err := encryptValue(t.Context(), kp, notes)
+		if err != nil {
+			t.Fatalf("encryptValue() error = %v", err)
+		}
+
+		qSet := NewQuerySet(NewMetadata[encryptedResource]())
+		qSet.SetKey("ID", 1)
+		qSet.AddField("ID").AddField("MedicalNotes")
+
+		ctrl := gomock.NewController(t)
+		reader := NewMockReader[encryptedResource](ctrl)
+		reader.EXPECT().DBType().MinTimes(1).Return(SpannerDBType)
+		reader.EXPECT().Read(gomock.Any(), gomock.Any()).Return(&encryptedResource{ID: 1, MedicalNotes: &envelope}, nil).Times(2)
+
+		if _, err := qSet.Read(t.Context(), NewMockClient(nil, []any{reader}, nil)); err == nil {
+			t.Errorf("QuerySet.Read() without a KeyProvider error = nil, want error")
+		}
+
+		got, err := qSet.Read(t.Context(), NewMockClient(nil, []any{reader}, nil).SetKeyProvider(kp))
+		if err != nil {
+			t.Fatalf("QuerySet.Read() error = %v", err)
+		}
+		if got.MedicalNotes == nil || *got.MedicalNotes != notes {
+			t.Errorf("MedicalNotes = %v, want %q", got.MedicalNotes, notes)
+		}
+	})
+
+	t.Run("sort and filter are rejected", func(t *testing.T) {
+		qSet := NewQuerySet(NewMetadata[encryptedResource]())
+		qSet.AddField("ID")
+		qSet.SetSortFields([]SortField{{Field: "MedicalNotes", Direction: SortAscending}})
+		if _, err := qSet.stmt(SpannerDBType); err == nil {
+			t.Errorf("QuerySet.stmt() with encrypted sort field error = nil, want error")
+		}
+
+		qSet = NewQuerySet(NewMetadata[encryptedResource]())
+		qSet.AddField("ID")
+		qSet.SetFilterAst(&ConditionNode{Condition: Condition{Field: "MedicalNotes", Operator: eqStr, Value: notes}})
+		if _, err := qSet.stmt(SpannerDBType); err == nil {
+			t.Errorf("QuerySet.stmt() with encrypted filter field error = nil, want error")
+		}
+	})
+
+	t.Run("change sets are redacted", func(t *testing.T) {
+		p := NewPatchSet(NewMetadata[encryptedResource]())
+		p.SetKey("ID", 1)
+		p.Set("Name", "Tuvok")
+		p.Set("MedicalNotes", &notes)
+
+		changeSet, err := p.insertChangeSet()
+		if err != nil {
+			t.Fatalf("PatchSet.insertChangeSet() error = %v", err)
+		}
+		if got := changeSet["MedicalNotes"].New; got != redactedValue {
+			t.Errorf("changeSet[MedicalNotes].New = %v, want %q", got, redactedValue)
+		}
+		if got := changeSet["Name"].New; got != "Tuvok" {
+			t.Errorf("changeSet[Name].New = %v, want %q", got, "Tuvok")
+		}
+	})
+}
@@ -539,3 +539,408 @@ func TestRPCDecoder_Decode_permissionEnforcement(t *testing.T) {
 		})
 	}
 }
+
+const policyResourceName = accesstypes.Resource("policyResources")
+
+// policyResource restricts rows to the docking bay named by the user's login.
+type policyResource struct {
+	ID           ccc.UUID `spanner:"Id"`
+	DockingBayID string   `spanner:"DockingBayId"`
+	Name         string   `spanner:"Name"`
+}
+
+func (policyResource) Resource() accesstypes.Resource { return policyResourceName }
+
+func (policyResource) DefaultConfig() Config { return Config{} }
+
+type policyReadRequest struct {
+	ID ccc.UUID `json:"id"`
+}
+
+type policyPatchRequest struct {
+	DockingBayID string `json:"dockingBayId"`
+	Name         string `json:"name"`
+}
+
+func (policyResource) RowPolicy(_ context.Context, userPermissions UserPermissions) (ExpressionNode, error) {
+	if userPermissions.User() == "admin" {
+		return nil, nil
+	}
+
+	return &ConditionNode{Condition: Condition{Field: "DockingBayId", Operator: eqStr, Value: string(userPermissions.User())}}, nil
+}
+
+type policyUserPermissions struct {
+	fakeUserPermissions
+	user accesstypes.User
+}
+
+func (p *policyUserPermissions) User() accesstypes.User { return p.user }
+
+func TestQuerySet_Read_rowPolicy(t *testing.T) {
+	t.Parallel()
+
+	id := mustUUIDFromString("8a6570c8-1e51-4870-9def-3f68d0447d09")
+
+	tests := []struct {
+		name         string
+		user         accesstypes.User
+		enforce      bool
+		wantSQL      string
+		wantParams   map[string]any
+		wantNoPolicy bool
+		readErr      error
+		wantNotFound bool
+	}{
+		{
+			name:       "policy is ANDed into the primary key predicate",
+			user:       "bay-7",
+			enforce:    true,
+			wantSQL:    "WHERE `Id` = @_id AND (`DockingBayId` = @_p1)",
+			wantParams: map[string]any{"_id": id, "_p1": "bay-7"},
+		},
+		{
+			name:         "nil policy places no restriction",
+			user:         "admin",
+			enforce:      true,
+			wantSQL:      "WHERE `Id` = @_id",
+			wantParams:   map[string]any{"_id": id},
+			wantNoPolicy: true,
+		},
+		{
+			name:         "policy is not applied without permission enforcement",
+			user:         "bay-7",
+			wantSQL:      "WHERE `Id` = @_id",
+			wantParams:   map[string]any{"_id": id},
+			wantNoPolicy: true,
+		},
+		{
+			name:         "row outside the policy is not found",
+			user:         "bay-7",
+			enforce:      true,
+			wantSQL:      "WHERE `Id` = @_id AND (`DockingBayId` = @_p1)",
+			wantParams:   map[string]any{"_id": id, "_p1": "bay-7"},
+			readErr:      httpio.NewNotFoundMessage("policyResources not found"),
+			wantNotFound: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			qSet := NewQuerySet(NewMetadata[policyResource]())
+			qSet.SetKey("ID", id)
+			qSet.AddField("ID")
+			if tt.enforce {
+				resSet, err := NewSet[policyResource, policyReadRequest]()
+				if err != nil {
+					t.Fatalf("NewSet() error = %v", err)
+				}
+				userPermissions := &policyUserPermissions{
+					fakeUserPermissions: fakeUserPermissions{granted: map[accesstypes.Permission][]accesstypes.Resource{accesstypes.Read: {policyResourceName}}},
+					user:                tt.user,
+				}
+				qSet.EnableUserPermissionEnforcement(resSet, userPermissions, accesstypes.Read)
+			}
+
+			ctrl := gomock.NewController(t)
+			reader := NewMockReader[policyResource](ctrl)
+			reader.EXPECT().DBType().MinTimes(1).Return(SpannerDBType)
+			reader.EXPECT().Read(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, stmt *Statement) (*policyResource, error) {
+				if !strings.Contains(stmt.SQL, tt.wantSQL) {
+					t.Errorf("Statement.SQL = %s, want to contain %s", stmt.SQL, tt.wantSQL)
+				}
+				if tt.wantNoPolicy && strings.Contains(stmt.SQL, "DockingBayId") {
+					t.Errorf("Statement.SQL = %s, want no row policy", stmt.SQL)
+				}
+				if len(stmt.Params) != len(tt.wantParams) {
+					t.Errorf("Statement.Params = %v, want %v", stmt.Params, tt.wantParams)
+				}
+				for k, v := range tt.wantParams {
+					if stmt.Params[k] != v {
+						t.Errorf("Statement.Params[%q] = %v, want %v", k, stmt.Params[k], v)
+					}
+				}
+
+				if tt.readErr != nil {
+					return nil, tt.readErr
+				}
+
+				return &policyResource{ID: id}, nil
+			})
+
+			_, err := qSet.Read(t.Context(), NewMockClient(nil, []any{reader}, nil))
+			if tt.wantNotFound {
+				if !httpio.HasNotFound(err) {
+					t.Fatalf("QuerySet.Read() error = %v, want not found", err)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("QuerySet.Read() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestPatchSet_Buffer_rowPolicy(t *testing.T) {
+	t.Parallel()
+
+	id := mustUUIDFromString("8a6570c8-1e51-4870-9def-3f68d0447d09")
+
+	tests := []struct {
+		name          string
+		patchType     PatchType
+		set           map[accesstypes.Field]any
+		preImageErr   error
+		wantNotFound  bool
+		wantForbidden bool
+	}{
+		{
+			name:      "update of a row inside the policy is buffered",
+			patchType: UpdatePatchType,
+			set:       map[accesstypes.Field]any{"Name": "Vanta"},
+		},
+		{
+			name:          "update moving a row outside the policy is forbidden",
+			patchType:     UpdatePatchType,
+			set:           map[accesstypes.Field]any{"DockingBayID": "bay-9"},
+			wantForbidden: true,
+		},
+		{
+			name:         "update of a row outside the policy is not found",
+			patchType:    UpdatePatchType,
+			set:          map[accesstypes.Field]any{"Name": "Vanta"},
+			preImageErr:  httpio.NewNotFoundMessage("policyResources not found"),
+			wantNotFound: true,
+		},
+		{
+			name:      "delete of a row inside the policy is buffered",
+			patchType: DeletePatchType,
+		},
+		{
+			name:         "delete of a row outside the policy is not found",
+			patchType:    DeletePatchType,
+			preImageErr:  httpio.NewNotFoundMessage("policyResources not found"),
+			wantNotFound: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			resSet, err := NewSet[policyResource, policyPatchRequest]()
+			if err != nil {
+				t.Fatalf("NewSet() error = %v", err)
+			}
+
+			perm := accesstypes.Update
+			if tt.patchType == DeletePatchType {
+				perm = accesstypes.Delete
+			}
+			userPermissions := &policyUserPermissions{
+				fakeUserPermissions: fakeUserPermissions{granted: map[accesstypes.Permission][]accesstypes.Resource{perm: {policyResourceName}}},
+				user:                "bay-7",
+			}
+
+			patchSet := NewPatchSet(NewMetadata[policyResource]()).SetPatchType(tt.patchType)
+			patchSet.EnableUserPermissionEnforcement(resSet, userPermissions, perm)
+			patchSet.SetKey("ID", id)
+			for field, v := range tt.set {
+				patchSet.Set(field, v)
+			}
+
+			ctrl := gomock.NewController(t)
+			reader := NewMockReader[policyResource](ctrl)
+			reader.EXPECT().DBType().AnyTimes().Return(SpannerDBType)
+			reader.EXPECT().Read(gomock.Any(), gomock.Any()).MaxTimes(1).DoAndReturn(func(_ context.Context, stmt *Statement) (*policyResource, error) {
+				if want := "WHERE `Id` = @_id AND (`DockingBayId` = @_p1)"; !strings.Contains(stmt.SQL, want) {
+					t.Errorf("pre-image Statement.SQL = %s, want to contain %s", stmt.SQL, want)
+				}
+				if tt.preImageErr != nil {
+					return nil, tt.preImageErr
+				}
+
+				return &policyResource{ID: id, DockingBayID: "bay-7"}, nil
+			})
+
+			txn := &recordingTxn{}
+			err = patchSet.Buffer(t.Context(), NewMockReadWriteTransaction(txn, reader))
+			if tt.wantForbidden {
+				if !httpio.HasForbidden(err) {
+					t.Fatalf("PatchSet.Buffer() error = %v, want forbidden", err)
+				}
+				if len(txn.bufferMapCalls) != 0 {
+					t.Errorf("PatchSet.Buffer() buffered %d mutations for a row leaving the policy, want 0", len(txn.bufferMapCalls))
+				}
+
+				return
+			}
+			if tt.wantNotFound {
+				if !httpio.HasNotFound(err) {
+					t.Fatalf("PatchSet.Buffer() error = %v, want not found", err)
+				}
+				if len(txn.bufferMapCalls) != 0 {
+					t.Errorf("PatchSet.Buffer() buffered %d mutations for a row outside the policy, want 0", len(txn.bufferMapCalls))
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("PatchSet.Buffer() error = %v", err)
+			}
+			if len(txn.bufferMapCalls) != 1 {
+				t.Errorf("PatchSet.Buffer() buffered %d mutations, want 1", len(txn.bufferMapCalls))
+			}
+		})
+	}
+}
+
+const maskedResourceName = accesstypes.Resource("maskedResources")
+
+type maskedResource struct {
+	ID             ccc.UUID `spanner:"Id"`
+	InspectorBadge *string  `spanner:"InspectorBadge"`
+	Notes          string   `spanner:"Notes"`
+}
+
+func (maskedResource) Resource() accesstypes.Resource { return maskedResourceName }
+
+func (maskedResource) DefaultConfig() Config { return Config{} }
+
+type maskedReadRequest struct {
+	ID             ccc.UUID `json:"id"`
+	InspectorBadge *string  `json:"inspectorBadge" perm:"Read" pii:"true" mask:"last4"`
+	Notes          string   `json:"notes" mask:"full"`
+}
+
+func TestQuerySet_Read_mask(t *testing.T) {
+	t.Parallel()
+
+	id := mustUUIDFromString("8a6570c8-1e51-4870-9def-3f68d0447d09")
+	badge := "A-12345678"
+
+	tests := []struct {
+		name          string
+		grants        map[accesstypes.Permission][]accesstypes.Resource
+		sort          []SortField
+		filter        *QueryClause
+		wantBadge     string
+		wantNotes     string
+		wantForbidden bool
+	}{
+		{
+			name: "read without reveal returns masked values",
+			grants: map[accesstypes.Permission][]accesstypes.Resource{
+				accesstypes.Read: {maskedResourceName, maskedResourceName + ".inspectorBadge"},
+			},
+			wantBadge: "****5678",
+			wantNotes: "****",
+		},
+		{
+			name: "reveal returns the stored value",
+			grants: map[accesstypes.Permission][]accesstypes.Resource{
+				accesstypes.Read: {maskedResourceName, maskedResourceName + ".inspectorBadge"},
+				Reveal:           {maskedResourceName + ".inspectorBadge"},
+			},
+			wantBadge: badge,
+			wantNotes: "****",
+		},
+		{
+			name: "sorting on a masked field without reveal is forbidden",
+			grants: map[accesstypes.Permission][]accesstypes.Resource{
+				accesstypes.Read: {maskedResourceName, maskedResourceName + ".inspectorBadge"},
+			},
+			sort:          []SortField{{Field: "InspectorBadge", Direction: SortAscending}},
+			wantForbidden: true,
+		},
+		{
+			name: "filtering on a masked field with a query clause without reveal is forbidden",
+			grants: map[accesstypes.Permission][]accesstypes.Resource{
+				accesstypes.Read: {maskedResourceName, maskedResourceName + ".inspectorBadge"},
+			},
+			filter:        ccc.Ptr(NewIdent[string]("InspectorBadge", NewPartialQueryClause(), false).Equal(badge)),
+			wantForbidden: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			resSet, err := NewSet[maskedResource, maskedReadRequest]()
+			if err != nil {
+				t.Fatalf("NewSet() error = %v", err)
+			}
+
+			qSet := NewQuerySet(resSet.ResourceMetadata())
+			if tt.filter != nil {
+				qSet.SetWhereClause(*tt.filter)
+			} else {
+				qSet.SetKey("ID", id)
+			}
+			qSet.AddField("ID").AddField("InspectorBadge").AddField("Notes")
+			qSet.SetSortFields(tt.sort)
+			qSet.EnableUserPermissionEnforcement(resSet, &fakeUserPermissions{granted: tt.grants}, accesstypes.Read)
+
+			ctrl := gomock.NewController(t)
+			reader := NewMockReader[maskedResource](ctrl)
+			reader.EXPECT().DBType().MinTimes(1).Return(SpannerDBType)
+			if !tt.wantForbidden {
+				stored := badge
+				reader.EXPECT().Read(gomock.Any(), gomock.Any()).Return(&maskedResource{ID: id, InspectorBadge: &stored, Notes: "quarantine bay"}, nil)
+			}
+
+			got, err := qSet.Read(t.Context(), NewMockClient(nil, []any{reader}, nil))
+			if tt.wantForbidden {
+				if !httpio.HasForbidden(err) {
+					t.Fatalf("QuerySet.Read() error = %v, want forbidden", err)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("QuerySet.Read() error = %v", err)
+			}
+
+			if got.InspectorBadge == nil || *got.InspectorBadge != tt.wantBadge {
+				t.Errorf("InspectorBadge = %v, want %q", got.InspectorBadge, tt.wantBadge)
+			}
+			if got.Notes != tt.wantNotes {
+				t.Errorf("Notes = %q, want %q", got.Notes, tt.wantNotes)
+			}
+			if badge != "A-12345678" {
+				t.Errorf("masking modified the stored value: %q", badge)
+			}
+		})
+	}
+}
+
+func TestNewSet_mask(t *testing.T) {
+	t.Parallel()
+
+	resSet, err := NewSet[maskedResource, maskedReadRequest]()
+	if err != nil {
+		t.Fatalf("NewSet() error = %v", err)
+	}
+
+	if m, ok := resSet.MaskStrategy("InspectorBadge"); !ok || m != MaskLast4 {
+		t.Errorf("Set.MaskStrategy(InspectorBadge) = %q, %v, want %q, true", m, ok, MaskLast4)
+	}
+	if got := resSet.TagPermissions()["notes"]; !slices.Equal(got, []accesstypes.Permission{Reveal}) {
+		t.Errorf("TagPermissions()[notes] = %v, want [%s]", got, Reveal)
+	}
+	if got := resSet.Permissions(); !slices.Equal(got, []accesstypes.Permission{accesstypes.Read}) {
+		t.Errorf("Set.Permissions() = %v, want [%s]", got, accesstypes.Read)
+	}
+
+	type badMaskRequest struct {
+		Notes string `json:"notes" mask:"first4"`
+	}
+	if _, err := NewSet[maskedResource, badMaskRequest](); err == nil {
+		t.Errorf("NewSet() with unknown mask strategy error = nil, want error")
+	}
+}
@@ -2,26 +2,209 @@ package resource
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net"
+	"net/http"
+	"strings"
 
 	"github.com/cccteam/session/sessioninfo"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-playground/errors/v5"
+	"go.opentelemetry.io/otel/trace"
 )
 
+const legacyProcessPrefix = "Process "
+
+// RequestIDHeader is the header RequestInfoMiddleware reads the request ID from.
+const RequestIDHeader = "X-Request-Id"
+
+// EventSource identifies who or what made a change recorded in a DataChangeEvent. It is stored
+// in the EventSource column as JSON, so change events can be queried by any of its fields, e.g.
+// JSON_VALUE(EventSource, '$.actorId').
+type EventSource struct {
+	ActorID   string `json:"actorId,omitempty"`
+	Username  string `json:"username,omitempty"`
+	Process   string `json:"process,omitempty"`
+	RequestID string `json:"requestId,omitempty"`
+	TraceID   string `json:"traceId,omitempty"`
+	ClientIP  string `json:"clientIp,omitempty"`
+}
+
+// NewUserEventSource returns the EventSource for an action performed by the user in the context.
+func NewUserEventSource(ctx context.Context) EventSource {
+	user := sessioninfo.FromCtx(ctx)
+
+	e := newEventSource(ctx)
+	e.ActorID = user.ID.String()
+	e.Username = user.Username
+
+	return e
+}
+
+// NewProcessEventSource returns the EventSource for an action performed by a system process.
+func NewProcessEventSource(ctx context.Context, processName string) EventSource {
+	e := newEventSource(ctx)
+	e.Process = processName
+
+	return e
+}
+
+// NewUserProcessEventSource returns the EventSource for an action performed by the user in the
+// context within a specific system process.
+func NewUserProcessEventSource(ctx context.Context, processName string) EventSource {
+	e := NewUserEventSource(ctx)
+	e.Process = processName
+
+	return e
+}
+
+// newEventSource returns an EventSource with the request information and the trace ID of the
+// span in the context.
+func newEventSource(ctx context.Context) EventSource {
+	var e EventSource
+	if info, ok := ctx.Value(requestInfoCtxKey{}).(requestInfo); ok {
+		e.RequestID = info.requestID
+		e.ClientIP = info.clientIP
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+		e.TraceID = sc.TraceID().String()
+	}
+
+	return e
+}
+
+// String returns the JSON form of the EventSource, as stored in the EventSource column.
+func (e EventSource) String() (string, error) {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return "", errors.Wrap(err, "json.Marshal()")
+	}
+
+	return string(b), nil
+}
+
+// legacyString returns the legacy form of the EventSource, which ParseEventSource reads back
+// without the request information and trace ID.
+func (e EventSource) legacyString() string {
+	user := fmt.Sprintf("%s (%s)", e.Username, e.ActorID)
+	switch {
+	case e.Process == "":
+		return user
+	case e.ActorID == "" && e.Username == "":
+		return legacyProcessPrefix + e.Process
+	default:
+		return user + ": " + legacyProcessPrefix + e.Process
+	}
+}
+
+// eventSourceString returns the JSON form of e, or its legacy form when it can not be encoded.
+func eventSourceString(e EventSource) string {
+	s, err := e.String()
+	if err != nil {
+		return e.legacyString()
+	}
+
+	return s
+}
+
+// ParseEventSource reads the EventSource column of a DataChangeEvent. Besides the JSON form, it
+// reads the legacy strings written before EventSource was structured: "username (id)",
+// "Process name", and "username (id): Process name". Any other legacy string is returned as the
+// Process.
+func ParseEventSource(s string) (EventSource, error) {
+	if strings.HasPrefix(s, "{") {
+		var e EventSource
+		if err := json.Unmarshal([]byte(s), &e); err != nil {
+			return EventSource{}, errors.Wrap(err, "json.Unmarshal()")
+		}
+
+		return e, nil
+	}
+
+	if user, process, ok := strings.Cut(s, ": "+legacyProcessPrefix); ok {
+		e, ok := parseLegacyUser(user)
+		if ok {
+			e.Process = process
+
+			return e, nil
+		}
+	}
+
+	if process, ok := strings.CutPrefix(s, legacyProcessPrefix); ok {
+		return EventSource{Process: process}, nil
+	}
+
+	if e, ok := parseLegacyUser(s); ok {
+		return e, nil
+	}
+
+	return EventSource{Process: s}, nil
+}
+
+// parseLegacyUser parses the legacy "username (id)" form.
+func parseLegacyUser(s string) (EventSource, bool) {
+	i := strings.LastIndex(s, " (")
+	if i < 0 || !strings.HasSuffix(s, ")") {
+		return EventSource{}, false
+	}
+
+	return EventSource{Username: s[:i], ActorID: s[i+2 : len(s)-1]}, true
+}
+
+type requestInfoCtxKey struct{}
+
+type requestInfo struct {
+	requestID string
+	clientIP  string
+}
+
+// WithRequestInfo returns a copy of ctx carrying the request ID and client IP recorded in the
+// EventSource of changes made with it, typically set by RequestInfoMiddleware.
+func WithRequestInfo(ctx context.Context, requestID, clientIP string) context.Context {
+	return context.WithValue(ctx, requestInfoCtxKey{}, requestInfo{requestID: requestID, clientIP: clientIP})
+}
+
+// RequestInfoMiddleware is HTTP middleware that calls WithRequestInfo for each request, so the
+// changes its handler makes record the request. The request ID is read from the X-Request-Id
+// header, or else from chi's middleware.RequestID, and the client IP from r.RemoteAddr. Behind a
+// proxy, put chi's middleware.RealIP before it.
+func RequestInfoMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(RequestIDHeader)
+		if requestID == "" {
+			requestID = middleware.GetReqID(r.Context())
+		}
+		clientIP := r.RemoteAddr
+		if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+			clientIP = host
+		}
+
+		next.ServeHTTP(w, r.WithContext(WithRequestInfo(r.Context(), requestID, clientIP)))
+	})
+}
+
 // UserEvent generates a standard event source string for an action performed by a user.
 // It extracts user information from the context.
 func UserEvent(ctx context.Context) string {
-	user := sessioninfo.FromCtx(ctx)
-
-	return fmt.Sprintf("%s (%s)", user.Username, user.ID)
+	return eventSourceString(NewUserEventSource(ctx))
 }
 
-// ProcessEvent generates a standard event source string for a system process.
-func ProcessEvent(processName string) string {
-	return fmt.Sprintf("Process %s", processName)
+// ProcessEvent generates a standard event source string for a system process, recording the
+// request information and trace in ctx.
+func ProcessEvent(ctx context.Context, processName string) string {
+	return eventSourceString(NewProcessEventSource(ctx, processName))
 }
 
-// UserProcessEvent generates a standard event source string for an action performed
-// by a user within a specific system process.
+// UserProcessEvent generates a standard event source string for an action performed by a user
+// within a specific system process.
 func UserProcessEvent(ctx context.Context, processName string) string {
-	return fmt.Sprintf("%s: %s", UserEvent(ctx), ProcessEvent(processName))
+	return eventSourceString(NewUserProcessEventSource(ctx, processName))
+}
+
+// userDisplayName returns the "username (id)" form of the user in the context.
+func userDisplayName(ctx context.Context) string {
+	user := sessioninfo.FromCtx(ctx)
+
+	return fmt.Sprintf("%s (%s)", user.Username, user.ID)
 }
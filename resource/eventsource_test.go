@@ -0,0 +1,167 @@
+package resource
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cccteam/ccc"
+	"github.com/cccteam/session/sessioninfo"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/go-cmp/cmp"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestNewUserProcessEventSource(t *testing.T) {
+	t.Parallel()
+
+	sessionID, err := ccc.UUIDFromString("4c3e1a52-5d0e-4f07-9f3b-0b5a7f1c2d3e")
+	if err != nil {
+		t.Fatalf("ccc.UUIDFromString() error = %v", err)
+	}
+	traceID, err := trace.TraceIDFromHex("0af7651916cd43dd8448eb211c80319c")
+	if err != nil {
+		t.Fatalf("trace.TraceIDFromHex() error = %v", err)
+	}
+
+	ctx := context.WithValue(t.Context(), sessioninfo.CtxSessionInfo, &sessioninfo.SessionInfo{ID: sessionID, Username: "janeway"})
+	ctx = trace.ContextWithSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID}))
+	ctx = WithRequestInfo(ctx, "req-74656", "10.0.0.1")
+
+	got := NewUserProcessEventSource(ctx, "Resupply")
+	want := EventSource{
+		ActorID:   sessionID.String(),
+		Username:  "janeway",
+		Process:   "Resupply",
+		RequestID: "req-74656",
+		TraceID:   "0af7651916cd43dd8448eb211c80319c",
+		ClientIP:  "10.0.0.1",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("NewUserProcessEventSource() mismatch (-want +got):\n%s", diff)
+	}
+
+	s, err := got.String()
+	if err != nil {
+		t.Fatalf("EventSource.String() error = %v", err)
+	}
+	parsed, err := ParseEventSource(s)
+	if err != nil {
+		t.Fatalf("ParseEventSource() error = %v", err)
+	}
+	if diff := cmp.Diff(want, parsed); diff != "" {
+		t.Errorf("ParseEventSource(String()) mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestRequestInfoMiddleware(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		header        string
+		remoteAddr    string
+		chiRequestID  bool
+		wantRequestID string
+		wantClientIP  string
+	}{
+		{name: "header", header: "req-74656", remoteAddr: "10.0.0.1:51234", wantRequestID: "req-74656", wantClientIP: "10.0.0.1"},
+		{name: "chi request id", remoteAddr: "10.0.0.1:51234", chiRequestID: true, wantClientIP: "10.0.0.1"},
+		{name: "remote addr without port", header: "req-74656", remoteAddr: "10.0.0.1", wantRequestID: "req-74656", wantClientIP: "10.0.0.1"},
+		{name: "ipv6", remoteAddr: "[::1]:51234", wantClientIP: "::1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got EventSource
+			var chiRequestID string
+			var handler http.Handler = RequestInfoMiddleware(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				chiRequestID = middleware.GetReqID(r.Context())
+
+				var err error
+				if got, err = ParseEventSource(ProcessEvent(r.Context(), "Resupply")); err != nil {
+					t.Errorf("ParseEventSource() error = %v", err)
+				}
+			}))
+			if tt.chiRequestID {
+				handler = middleware.RequestID(handler)
+			}
+
+			req := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/crewMembers", http.NoBody)
+			req.RemoteAddr = tt.remoteAddr
+			if tt.header != "" {
+				req.Header.Set(RequestIDHeader, tt.header)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			want := EventSource{Process: "Resupply", RequestID: tt.wantRequestID, ClientIP: tt.wantClientIP}
+			if tt.chiRequestID {
+				if chiRequestID == "" {
+					t.Fatalf("middleware.GetReqID() = %q, want a request ID", chiRequestID)
+				}
+				want.RequestID = chiRequestID
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("EventSource mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseEventSource(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		source  string
+		want    EventSource
+		wantErr bool
+	}{
+		{
+			name:   "json",
+			source: `{"actorId":"123","username":"janeway","traceId":"abc"}`,
+			want:   EventSource{ActorID: "123", Username: "janeway", TraceID: "abc"},
+		},
+		{
+			name:   "legacy user",
+			source: "Kathryn Janeway (123)",
+			want:   EventSource{Username: "Kathryn Janeway", ActorID: "123"},
+		},
+		{
+			name:   "legacy process",
+			source: "Process Resupply",
+			want:   EventSource{Process: "Resupply"},
+		},
+		{
+			name:   "legacy user process",
+			source: "Kathryn Janeway (123): Process Resupply",
+			want:   EventSource{Username: "Kathryn Janeway", ActorID: "123", Process: "Resupply"},
+		},
+		{
+			name:   "legacy free-form",
+			source: "nightly-import",
+			want:   EventSource{Process: "nightly-import"},
+		},
+		{
+			name:    "malformed json",
+			source:  `{"actorId":`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseEventSource(tt.source)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseEventSource() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ParseEventSource() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -3,9 +3,12 @@ package resource
 import (
 	"fmt"
 	"reflect"
+	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
+	"cloud.google.com/go/spanner"
 	"github.com/cccteam/httpio"
 	"github.com/go-playground/errors/v5"
 )
@@ -122,9 +125,10 @@ type ExpressionNode interface {
 // Condition represents a single condition (e.g., name:eq:John).
 type Condition struct {
 	Field    string
+	Path     []string // For comparisons on a value inside a JSON column (e.g., details.cargo.weight:gt:10)
 	Operator string
-	Value    any   // For eq, ne, gt, lt, gte, lte
-	Values   []any // For in, notin
+	Value    any   // For eq, ne, gt, lt, gte, lte, has
+	Values   []any // For in, notin, hasany, hasall, between
 	IsNullOp bool  // For isnull, isnotnull
 }
 
@@ -135,8 +139,12 @@ type ConditionNode struct {
 
 // String returns a string representation of the ConditionNode.
 func (cn *ConditionNode) String() string {
+	field := cn.Condition.Field
+	if len(cn.Condition.Path) > 0 {
+		field += "." + strings.Join(cn.Condition.Path, ".")
+	}
 	if cn.Condition.IsNullOp {
-		return fmt.Sprintf("%s:%s", cn.Condition.Field, cn.Condition.Operator)
+		return fmt.Sprintf("%s:%s", field, cn.Condition.Operator)
 	}
 	if len(cn.Condition.Values) > 0 {
 		strValues := make([]string, len(cn.Condition.Values))
@@ -144,10 +152,10 @@ func (cn *ConditionNode) String() string {
 			strValues[i] = fmt.Sprintf("%v", v)
 		}
 
-		return fmt.Sprintf("%s:%s:(%s)", cn.Condition.Field, cn.Condition.Operator, strings.Join(strValues, ","))
+		return fmt.Sprintf("%s:%s:(%s)", field, cn.Condition.Operator, strings.Join(strValues, ","))
 	}
 
-	return fmt.Sprintf("%s:%s:%v", cn.Condition.Field, cn.Condition.Operator, cn.Condition.Value)
+	return fmt.Sprintf("%s:%s:%v", field, cn.Condition.Operator, cn.Condition.Value)
 }
 
 // LogicalOperator defines the type of logical operator (AND, OR).
@@ -182,6 +190,103 @@ func (gn *GroupNode) String() string {
 	return fmt.Sprintf("(%s)", gn.Expression.String())
 }
 
+// FormatFilter writes an expression tree in the filter syntax the list handlers parse, e.g.
+// name:eq:John,age:gt:30. Logical operations are written flat, the way the SQL generator
+// writes them, so AND binds tighter than OR and only a GroupNode adds parentheses. Times are
+// written in RFC 3339, and other values with their String method when they have one. The
+// syntax has no escapes, so a value with a ',', '|', '(' or ')', or with leading or trailing
+// space, is an error, as is an empty value in a list. A string compared with a JSON path that
+// would parse as a boolean or number is written in double quotes.
+func FormatFilter(node ExpressionNode) (string, error) {
+	switch n := node.(type) {
+	case *ConditionNode:
+		field := n.Condition.Field
+		for _, segment := range n.Condition.Path {
+			if !jsonPathSegment.MatchString(segment) {
+				return "", errors.Newf("JSON path segment %q of %s can not be written in a filter", segment, field)
+			}
+		}
+		if len(n.Condition.Path) > 0 {
+			field += "." + strings.Join(n.Condition.Path, ".")
+		}
+		if n.Condition.IsNullOp {
+			return fmt.Sprintf("%s:%s", field, n.Condition.Operator), nil
+		}
+		isPath := len(n.Condition.Path) > 0
+		if len(n.Condition.Values) > 0 {
+			values := make([]string, len(n.Condition.Values))
+			for i, v := range n.Condition.Values {
+				value, err := formatFilterValue(field, v, isPath)
+				if err != nil {
+					return "", err
+				}
+				if value == "" {
+					return "", errors.Newf("empty value in the %s list of %s can not be written in a filter", n.Condition.Operator, field)
+				}
+				values[i] = value
+			}
+
+			return fmt.Sprintf("%s:%s:(%s)", field, n.Condition.Operator, strings.Join(values, ",")), nil
+		}
+
+		value, err := formatFilterValue(field, n.Condition.Value, isPath)
+		if err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf("%s:%s:%s", field, n.Condition.Operator, value), nil
+	case *LogicalOpNode:
+		sep := ","
+		if n.Operator == OperatorOr {
+			sep = "|"
+		}
+
+		left, err := FormatFilter(n.Left)
+		if err != nil {
+			return "", err
+		}
+		right, err := FormatFilter(n.Right)
+		if err != nil {
+			return "", err
+		}
+
+		return left + sep + right, nil
+	case *GroupNode:
+		expression, err := FormatFilter(n.Expression)
+		if err != nil {
+			return "", err
+		}
+
+		return "(" + expression + ")", nil
+	default:
+		return "", nil
+	}
+}
+
+func formatFilterValue(field string, v any, isPath bool) (string, error) {
+	var value string
+	switch t := v.(type) {
+	case time.Time:
+		value = t.Format(time.RFC3339Nano)
+	case fmt.Stringer:
+		value = t.String()
+	default:
+		value = fmt.Sprint(v)
+	}
+
+	if s, ok := v.(string); ok && isPath {
+		if converted, _ := convertJSONValue(s); converted != s {
+			value = `"` + s + `"`
+		}
+	}
+
+	if strings.ContainsAny(value, ",|()") || value != strings.TrimSpace(value) {
+		return "", errors.Newf("value %q of %s can not be written in a filter: it has a ',', '|', '(' or ')', or leading or trailing space", value, field)
+	}
+
+	return value, nil
+}
+
 type (
 	prefixParseFn func(DBType) (ExpressionNode, error)
 	infixParseFn  func(ExpressionNode, DBType) (ExpressionNode, error)
@@ -198,6 +303,17 @@ type FilterFieldInfo struct {
 	PII           bool
 }
 
+// isArray reports whether the field is an ARRAY column, which the has, hasany and hasall
+// operators match against. []byte is a BYTES column, not an array.
+func (f FilterFieldInfo) isArray() bool {
+	return (f.Kind == reflect.Slice || f.Kind == reflect.Array) && f.FieldType != nil && f.FieldType.Elem().Kind() != reflect.Uint8
+}
+
+// isJSON reports whether the field is a JSON column, whose values can be compared by path.
+func (f FilterFieldInfo) isJSON() bool {
+	return f.FieldType == reflect.TypeFor[spanner.NullJSON]() || f.FieldType == reflect.TypeFor[spanner.PGJsonB]()
+}
+
 // ColumnName returns the column name for the given DBType.
 func (f FilterFieldInfo) ColumnName(dbType DBType) (string, error) {
 	name, ok := f.dbColumnNames[dbType]
@@ -218,6 +334,7 @@ type FilterParser struct {
 	prefixParseFns  map[TokenType]prefixParseFn
 	infixParseFns   map[TokenType]infixParseFn
 	jsonToFieldInfo map[jsonFieldName]FilterFieldInfo
+	now             func() time.Time // resolves relative time expressions
 
 	parsedExpression map[DBType]ExpressionNode
 }
@@ -229,6 +346,7 @@ func NewFilterParser(lexer *FilterLexer, jsonToFieldInfo map[jsonFieldName]Filte
 		prefixParseFns:   make(map[TokenType]prefixParseFn),
 		infixParseFns:    make(map[TokenType]infixParseFn),
 		jsonToFieldInfo:  jsonToFieldInfo,
+		now:              time.Now,
 		parsedExpression: make(map[DBType]ExpressionNode),
 	}
 
@@ -388,7 +506,7 @@ func (p *FilterParser) parseConditionToken(dbType DBType) (ExpressionNode, error
 		return nil, httpio.NewBadRequestMessagef("condition '%s' must have at least field:operator", p.current.Value)
 	}
 
-	jsonFieldNameStr := strings.TrimSpace(parts[0])
+	jsonFieldNameStr, pathStr, hasPath := strings.Cut(strings.TrimSpace(parts[0]), ".")
 	if jsonFieldNameStr == "" {
 		return nil, httpio.NewBadRequestMessagef("field name cannot be empty in condition '%s'", p.current.Value)
 	}
@@ -411,6 +529,31 @@ func (p *FilterParser) parseConditionToken(dbType DBType) (ExpressionNode, error
 		Operator: strings.ToLower(strings.TrimSpace(parts[1])),
 	}
 
+	// convert types each value of the condition: a JSON path value by its own syntax, an
+	// array element by the element type, and anything else by the field type
+	convert := func(strValue string) (any, error) {
+		return p.convertFieldValue(strValue, fieldInfo.FieldType, fieldInfo.Kind)
+	}
+	switch {
+	case hasPath:
+		if !fieldInfo.isJSON() {
+			return nil, httpio.NewBadRequestMessagef("'%s' is not a JSON field and can not have a path in condition '%s'", jsonFieldNameStr, p.current.Value)
+		}
+		condition.Path, err = p.parseJSONPath(pathStr)
+		if err != nil {
+			return nil, err
+		}
+		convert = convertJSONValue
+	case fieldInfo.Kind == reflect.Slice || fieldInfo.Kind == reflect.Array:
+		convert = func(strValue string) (any, error) {
+			if fieldInfo.FieldType == nil {
+				return nil, errors.Newf("FieldType not available in FieldInfo for slice/array field '%s' to determine element kind", field)
+			}
+
+			return p.convertFieldValue(strValue, fieldInfo.FieldType.Elem(), fieldInfo.FieldType.Elem().Kind())
+		}
+	}
+
 	switch condition.Operator {
 	case isnullStr, isnotnullStr:
 		if len(parts) > 2 && strings.TrimSpace(parts[2]) != "" {
@@ -418,49 +561,53 @@ func (p *FilterParser) parseConditionToken(dbType DBType) (ExpressionNode, error
 		}
 		condition.IsNullOp = true
 	case inStr, notinStr:
-		if len(parts) < 3 {
-			return nil, httpio.NewBadRequestMessagef("operator '%s' requires a value part in condition '%s'	", condition.Operator, p.current.Value)
-		}
-		valPart := strings.TrimSpace(parts[2])
-		if !strings.HasPrefix(valPart, "(") || !strings.HasSuffix(valPart, ")") {
-			return nil, httpio.NewBadRequestMessagef("value for '%s' must be in parentheses, e.g., (v1,v2), got '%s' in condition '%s'", condition.Operator, valPart, p.current.Value)
-		}
-		valPart = valPart[1 : len(valPart)-1] // Remove parentheses
-		if valPart == "" {                    // e.g. name:in:()
-			return nil, httpio.NewBadRequestMessagef("value list for '%s' cannot be empty in condition '%s'", condition.Operator, p.current.Value)
-		}
-		values := strings.Split(valPart, ",")
-		condition.Values = make([]any, 0, len(values))
-		for _, v := range values {
-			trimmed := strings.TrimSpace(v)
-			if trimmed == "" { // e.g. name:in:(v1,,v2)
-				return nil, httpio.NewBadRequestMessagef("empty value in list for operator '%s' in condition '%s'", condition.Operator, p.current.Value)
-			}
-
-			valueKind := fieldInfo.Kind
-			if valueKind == reflect.Slice || valueKind == reflect.Array {
-				if fieldInfo.FieldType == nil {
-					return nil, errors.Newf("FieldType not available in FieldInfo for slice/array field '%s' to determine element kind", field)
-				}
-				valueKind = fieldInfo.FieldType.Elem().Kind()
-			}
-
-			typedValue, err := p.convertValue(trimmed, valueKind)
+		condition.Values, err = p.parseValueList(parts, condition.Operator, convert)
+		if err != nil {
+			return nil, err
+		}
+	case hasStr, hasanyStr, hasallStr:
+		if hasPath || !fieldInfo.isArray() {
+			return nil, httpio.NewBadRequestMessagef("operator '%s' requires an array field in condition '%s'", condition.Operator, p.current.Value)
+		}
+		if condition.Operator != hasStr {
+			condition.Values, err = p.parseValueList(parts, condition.Operator, convert)
 			if err != nil {
 				return nil, err
 			}
-			condition.Values = append(condition.Values, typedValue)
+
+			break
+		}
+		if len(parts) < 3 {
+			return nil, httpio.NewBadRequestMessagef("operator '%s' requires a value in condition '%s'", condition.Operator, p.current.Value)
+		}
+		condition.Value, err = convert(strings.TrimSpace(parts[2]))
+		if err != nil {
+			return nil, err
 		}
 	case eqStr, neStr, gtStr, ltStr, gteStr, lteStr:
 		if len(parts) < 3 {
 			return nil, httpio.NewBadRequestMessagef("operator '%s' requires a value in condition '%s'", condition.Operator, p.current.Value)
 		}
 		strValue := strings.TrimSpace(parts[2])
-		typedValue, err := p.convertValue(strValue, fieldInfo.Kind)
+		if hasPath {
+			condition.Value, err = convertJSONValue(strValue)
+		} else {
+			condition.Value, err = p.convertFieldValue(strValue, fieldInfo.FieldType, fieldInfo.Kind)
+		}
+		if err != nil {
+			return nil, err
+		}
+	case betweenStr:
+		if !hasPath && fieldInfo.isArray() {
+			return nil, httpio.NewBadRequestMessagef("operator '%s' can not be used on an array field in condition '%s'", condition.Operator, p.current.Value)
+		}
+		condition.Values, err = p.parseValueList(parts, condition.Operator, convert)
 		if err != nil {
 			return nil, err
 		}
-		condition.Value = typedValue
+		if len(condition.Values) != 2 {
+			return nil, httpio.NewBadRequestMessagef("operator '%s' requires exactly two values, e.g., (low,high), got %d in condition '%s'", condition.Operator, len(condition.Values), p.current.Value)
+		}
 	default:
 		return nil, httpio.NewBadRequestMessagef("unknown operator '%s' in condition '%s'", condition.Operator, p.current.Value)
 	}
@@ -468,6 +615,75 @@ func (p *FilterParser) parseConditionToken(dbType DBType) (ExpressionNode, error
 	return &ConditionNode{Condition: condition}, nil
 }
 
+// parseValueList parses the parenthesized value list of a list operator, e.g. (v1,v2).
+func (p *FilterParser) parseValueList(parts []string, operator string, convert func(string) (any, error)) ([]any, error) {
+	if len(parts) < 3 {
+		return nil, httpio.NewBadRequestMessagef("operator '%s' requires a value part in condition '%s'	", operator, p.current.Value)
+	}
+	valPart := strings.TrimSpace(parts[2])
+	if !strings.HasPrefix(valPart, "(") || !strings.HasSuffix(valPart, ")") {
+		return nil, httpio.NewBadRequestMessagef("value for '%s' must be in parentheses, e.g., (v1,v2), got '%s' in condition '%s'", operator, valPart, p.current.Value)
+	}
+	valPart = valPart[1 : len(valPart)-1] // Remove parentheses
+	if valPart == "" {                    // e.g. name:in:()
+		return nil, httpio.NewBadRequestMessagef("value list for '%s' cannot be empty in condition '%s'", operator, p.current.Value)
+	}
+	values := strings.Split(valPart, ",")
+	typedValues := make([]any, 0, len(values))
+	for _, v := range values {
+		trimmed := strings.TrimSpace(v)
+		if trimmed == "" { // e.g. name:in:(v1,,v2)
+			return nil, httpio.NewBadRequestMessagef("empty value in list for operator '%s' in condition '%s'", operator, p.current.Value)
+		}
+
+		typedValue, err := convert(trimmed)
+		if err != nil {
+			return nil, err
+		}
+		typedValues = append(typedValues, typedValue)
+	}
+
+	return typedValues, nil
+}
+
+// jsonPathSegment matches a member name or array index of a JSON path. Paths are written into
+// the SQL, so no other characters are allowed.
+var jsonPathSegment = regexp.MustCompile(`^(?:[A-Za-z_][A-Za-z0-9_]*|[0-9]+)$`)
+
+// parseJSONPath splits a dotted JSON path (e.g. cargo.items.0.weight) into its segments.
+func (p *FilterParser) parseJSONPath(pathStr string) ([]string, error) {
+	path := strings.Split(pathStr, ".")
+	for _, segment := range path {
+		if !jsonPathSegment.MatchString(segment) {
+			return nil, httpio.NewBadRequestMessagef("invalid JSON path segment '%s' in condition '%s'", segment, p.current.Value)
+		}
+	}
+
+	return path, nil
+}
+
+// convertJSONValue types a value compared with a JSON path: true and false are booleans,
+// numbers are float64, and anything else is a string. A value in double quotes is the string
+// between them, so code:eq:"007" matches the JSON string "007" where code:eq:007 matches the
+// number 7.
+func convertJSONValue(strValue string) (any, error) {
+	if len(strValue) >= 2 && strings.HasPrefix(strValue, `"`) && strings.HasSuffix(strValue, `"`) {
+		return strValue[1 : len(strValue)-1], nil
+	}
+
+	switch strValue {
+	case trueStr:
+		return true, nil
+	case "false":
+		return false, nil
+	}
+	if f, err := strconv.ParseFloat(strValue, 64); err == nil {
+		return f, nil
+	}
+
+	return strValue, nil
+}
+
 // convertValue converts a string value to the specified reflect.Kind.
 func (p *FilterParser) convertValue(strValue string, kind reflect.Kind) (any, error) {
 	switch kind {
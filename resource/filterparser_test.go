@@ -4,8 +4,12 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
+	"cloud.google.com/go/civil"
+	"cloud.google.com/go/spanner"
 	"github.com/cccteam/httpio"
+	"github.com/google/go-cmp/cmp"
 )
 
 // defaultTestJSONToSQLNameMap provides a standard map for most test cases.
@@ -640,3 +644,284 @@ func TestParser_Parse_Successful(t *testing.T) {
 		})
 	}
 }
+
+func TestParser_Parse_time(t *testing.T) {
+	t.Parallel()
+
+	fields := map[jsonFieldName]FilterFieldInfo{
+		"updated_at": {dbColumnNames: map[DBType]string{SpannerDBType: "UpdatedAt"}, Kind: reflect.Struct, FieldType: reflect.TypeFor[time.Time](), Indexed: true},
+		"launched":   {dbColumnNames: map[DBType]string{SpannerDBType: "Launched"}, Kind: reflect.Struct, FieldType: reflect.TypeFor[spanner.NullDate](), Indexed: true},
+		"rank":       {dbColumnNames: map[DBType]string{SpannerDBType: "Rank"}, Kind: reflect.Int, Indexed: true},
+		"tags":       {dbColumnNames: map[DBType]string{SpannerDBType: "Tags"}, Kind: reflect.Slice, FieldType: reflect.TypeFor[[]string](), Indexed: true},
+	}
+	// Thursday, 2025-03-13 14:30 in New York is 18:30 UTC
+	now := time.Date(2025, time.March, 13, 14, 30, 0, 0, time.FixedZone("EDT", -4*60*60))
+
+	tests := []struct {
+		name         string
+		filterString string
+		want         Condition
+		wantErr      string
+	}{
+		{
+			name:         "RFC 3339 is converted to UTC",
+			filterString: "updated_at:gte:2025-03-01T08:00:00-05:00",
+			want:         Condition{Field: "UpdatedAt", Operator: gteStr, Value: time.Date(2025, time.March, 1, 13, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:         "date on a time field is midnight UTC",
+			filterString: "updated_at:lt:2025-03-01",
+			want:         Condition{Field: "UpdatedAt", Operator: ltStr, Value: time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:         "now with offset",
+			filterString: "updated_at:gte:now-7d",
+			want:         Condition{Field: "UpdatedAt", Operator: gteStr, Value: time.Date(2025, time.March, 6, 18, 30, 0, 0, time.UTC)},
+		},
+		{
+			name:         "today with offsets",
+			filterString: "updated_at:lt:today+1d-2h",
+			want:         Condition{Field: "UpdatedAt", Operator: ltStr, Value: time.Date(2025, time.March, 13, 22, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:         "startOfWeek",
+			filterString: "updated_at:gte:startOfWeek",
+			want:         Condition{Field: "UpdatedAt", Operator: gteStr, Value: time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:         "between startOfMonth",
+			filterString: "updated_at:between:(startOfMonth-1M,startOfMonth-1s)",
+			want: Condition{Field: "UpdatedAt", Operator: betweenStr, Values: []any{
+				time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2025, time.February, 28, 23, 59, 59, 0, time.UTC),
+			}},
+		},
+		{
+			name:         "date field",
+			filterString: "launched:between:(startOfYear,today)",
+			want:         Condition{Field: "Launched", Operator: betweenStr, Values: []any{civil.Date{Year: 2025, Month: time.January, Day: 1}, civil.Date{Year: 2025, Month: time.March, Day: 13}}},
+		},
+		{
+			name:         "between on an integer field",
+			filterString: "rank:between:(1,5)",
+			want:         Condition{Field: "Rank", Operator: betweenStr, Values: []any{1, 5}},
+		},
+		{
+			name:         "unknown anchor",
+			filterString: "updated_at:gte:yesterday",
+			wantErr:      "unknown time anchor 'yesterday'",
+		},
+		{
+			name:         "invalid time",
+			filterString: "updated_at:gte:now-7x",
+			wantErr:      "is not a valid RFC 3339 time, date or relative time expression",
+		},
+		{
+			name:         "between with one value",
+			filterString: "rank:between:(1)",
+			wantErr:      "requires exactly two values",
+		},
+		{
+			name:         "between on an array field",
+			filterString: "tags:between:(a,b)",
+			wantErr:      "can not be used on an array field",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			parser, err := NewFilterParser(NewFilterLexer(tt.filterString), fields)
+			if err != nil {
+				t.Fatalf("NewFilterParser() error = %v", err)
+			}
+			parser.now = func() time.Time { return now }
+
+			got, err := parser.Parse(SpannerDBType)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("FilterParser.Parse() error = %v, want %q", err, tt.wantErr)
+				}
+				if !httpio.HasBadRequest(err) {
+					t.Errorf("FilterParser.Parse() error = %v, want HTTP 400 Bad Request", err)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("FilterParser.Parse() error = %v", err)
+			}
+
+			node, ok := got.(*ConditionNode)
+			if !ok {
+				t.Fatalf("FilterParser.Parse() = %T, want *ConditionNode", got)
+			}
+			if diff := cmp.Diff(tt.want, node.Condition); diff != "" {
+				t.Errorf("FilterParser.Parse() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParser_Parse_arrayAndJSON(t *testing.T) {
+	t.Parallel()
+
+	fields := map[jsonFieldName]FilterFieldInfo{
+		"name":    {dbColumnNames: map[DBType]string{SpannerDBType: "Name"}, Kind: reflect.String, FieldType: reflect.TypeFor[string](), Indexed: true},
+		"tags":    {dbColumnNames: map[DBType]string{SpannerDBType: "Tags"}, Kind: reflect.Slice, FieldType: reflect.TypeFor[[]string]()},
+		"ranks":   {dbColumnNames: map[DBType]string{SpannerDBType: "Ranks"}, Kind: reflect.Slice, FieldType: reflect.TypeFor[[]int]()},
+		"details": {dbColumnNames: map[DBType]string{SpannerDBType: "Details"}, Kind: reflect.Struct, FieldType: reflect.TypeFor[spanner.NullJSON]()},
+	}
+
+	tests := []struct {
+		name         string
+		filterString string
+		want         Condition
+		wantErr      string
+	}{
+		{
+			name:         "has",
+			filterString: "tags:has:red",
+			want:         Condition{Field: "Tags", Operator: hasStr, Value: "red"},
+		},
+		{
+			name:         "hasany",
+			filterString: "tags:hasany:(red,blue)",
+			want:         Condition{Field: "Tags", Operator: hasanyStr, Values: []any{"red", "blue"}},
+		},
+		{
+			name:         "hasall typed by the element type",
+			filterString: "ranks:hasall:(1,2)",
+			want:         Condition{Field: "Ranks", Operator: hasallStr, Values: []any{1, 2}},
+		},
+		{
+			name:         "json path number",
+			filterString: "details.cargo.weight:gt:10",
+			want:         Condition{Field: "Details", Path: []string{"cargo", "weight"}, Operator: gtStr, Value: float64(10)},
+		},
+		{
+			name:         "json path array index",
+			filterString: "details.items.0.name:eq:fuel",
+			want:         Condition{Field: "Details", Path: []string{"items", "0", "name"}, Operator: eqStr, Value: "fuel"},
+		},
+		{
+			name:         "json path boolean",
+			filterString: "details.hazardous:eq:true",
+			want:         Condition{Field: "Details", Path: []string{"hazardous"}, Operator: eqStr, Value: true},
+		},
+		{
+			name:         "json path quoted string",
+			filterString: `details.code:eq:"007"`,
+			want:         Condition{Field: "Details", Path: []string{"code"}, Operator: eqStr, Value: "007"},
+		},
+		{
+			name:         "json path list",
+			filterString: `details.code:in:(7,"007")`,
+			want:         Condition{Field: "Details", Path: []string{"code"}, Operator: inStr, Values: []any{float64(7), "007"}},
+		},
+		{
+			name:         "json path null check",
+			filterString: "details.cargo:isnull",
+			want:         Condition{Field: "Details", Path: []string{"cargo"}, Operator: isnullStr, IsNullOp: true},
+		},
+		{
+			name:         "json path segment with a space",
+			filterString: "details.the cargo.weight:gt:10",
+			wantErr:      "invalid JSON path segment 'the cargo'",
+		},
+		{
+			name:         "json path segment with a quote",
+			filterString: "details.cargo'.weight:gt:10",
+			wantErr:      "invalid JSON path segment 'cargo''",
+		},
+		{
+			name:         "json path empty segment",
+			filterString: "details.cargo..weight:gt:10",
+			wantErr:      "invalid JSON path segment ''",
+		},
+		{
+			name:         "json path trailing dot",
+			filterString: "details.:eq:1",
+			wantErr:      "invalid JSON path segment ''",
+		},
+		{
+			name:         "path on a non-JSON field",
+			filterString: "name.first:eq:Vanta",
+			wantErr:      "'name' is not a JSON field and can not have a path",
+		},
+		{
+			name:         "path on an array field",
+			filterString: "tags.0:eq:red",
+			wantErr:      "'tags' is not a JSON field and can not have a path",
+		},
+		{
+			name:         "has on a non-array field",
+			filterString: "name:has:Vanta",
+			wantErr:      "operator 'has' requires an array field",
+		},
+		{
+			name:         "hasany on a non-array field",
+			filterString: "name:hasany:(a,b)",
+			wantErr:      "operator 'hasany' requires an array field",
+		},
+		{
+			name:         "hasall on a json path",
+			filterString: "details.tags:hasall:(a,b)",
+			wantErr:      "operator 'hasall' requires an array field",
+		},
+		{
+			name:         "has without a value",
+			filterString: "tags:has",
+			wantErr:      "operator 'has' requires a value",
+		},
+		{
+			name:         "hasany without parentheses",
+			filterString: "tags:hasany:red",
+			wantErr:      "must be in parentheses",
+		},
+		{
+			name:         "hasall with an invalid element",
+			filterString: "ranks:hasall:(1,x)",
+			wantErr:      "is not a valid integer",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			parser, err := NewFilterParser(NewFilterLexer("name:eq:Vanta,"+tt.filterString), fields)
+			if err != nil {
+				t.Fatalf("NewFilterParser() error = %v", err)
+			}
+
+			got, err := parser.Parse(SpannerDBType)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("FilterParser.Parse() error = %v, want %q", err, tt.wantErr)
+				}
+				if !httpio.HasBadRequest(err) {
+					t.Errorf("FilterParser.Parse() error = %v, want HTTP 400 Bad Request", err)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("FilterParser.Parse() error = %v", err)
+			}
+
+			node, ok := got.(*LogicalOpNode)
+			if !ok {
+				t.Fatalf("FilterParser.Parse() = %T, want *LogicalOpNode", got)
+			}
+			condition, ok := node.Right.(*ConditionNode)
+			if !ok {
+				t.Fatalf("FilterParser.Parse() right operand = %T, want *ConditionNode", node.Right)
+			}
+			if diff := cmp.Diff(tt.want, condition.Condition); diff != "" {
+				t.Errorf("FilterParser.Parse() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
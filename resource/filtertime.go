@@ -0,0 +1,112 @@
+package resource
+
+import (
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"cloud.google.com/go/spanner"
+	"github.com/cccteam/httpio"
+)
+
+// relativeTimeExpr matches a relative time expression: an anchor followed by any number of
+// offsets, e.g. now-7d or startOfMonth-1M+2d.
+var relativeTimeExpr = regexp.MustCompile(`^([A-Za-z]+)((?:[+-][0-9]+[smhdwMy])*)$`)
+
+// relativeTimeOffset matches a single offset of a relative time expression, e.g. -7d.
+var relativeTimeOffset = regexp.MustCompile(`([+-])([0-9]+)([smhdwMy])`)
+
+// isTimeType reports whether t is compared as a TIMESTAMP.
+func isTimeType(t reflect.Type) bool {
+	return t == reflect.TypeFor[time.Time]() || t == reflect.TypeFor[spanner.NullTime]()
+}
+
+// isDateType reports whether t is compared as a DATE.
+func isDateType(t reflect.Type) bool {
+	return t == reflect.TypeFor[civil.Date]() || t == reflect.TypeFor[spanner.NullDate]()
+}
+
+// convertFieldValue converts a string value to the type of a field. TIMESTAMP and DATE fields
+// are parsed by parseTime, anything else by its reflect.Kind.
+func (p *FilterParser) convertFieldValue(strValue string, fieldType reflect.Type, kind reflect.Kind) (any, error) {
+	switch {
+	case isTimeType(fieldType):
+		return p.parseTime(strValue)
+	case isDateType(fieldType):
+		t, err := p.parseTime(strValue)
+		if err != nil {
+			return nil, err
+		}
+
+		return civil.DateOf(t), nil
+	default:
+		return p.convertValue(strValue, kind)
+	}
+}
+
+// parseTime parses an RFC 3339 timestamp, a YYYY-MM-DD date, or a relative time expression
+// resolved against the time the filter is parsed. The result is always in UTC, so both SQL
+// dialects compare the same instant, and relative anchors start at midnight UTC.
+func (p *FilterParser) parseTime(strValue string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, strValue); err == nil {
+		return t.UTC(), nil
+	}
+	if d, err := civil.ParseDate(strValue); err == nil {
+		return d.In(time.UTC), nil
+	}
+
+	matches := relativeTimeExpr.FindStringSubmatch(strValue)
+	if matches == nil {
+		return time.Time{}, httpio.NewBadRequestMessagef("value '%s' in condition '%s' is not a valid RFC 3339 time, date or relative time expression", strValue, p.current.Value)
+	}
+
+	now := p.now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	var t time.Time
+	switch strings.ToLower(matches[1]) {
+	case "now":
+		t = now
+	case "today":
+		t = today
+	case "startofweek":
+		t = today.AddDate(0, 0, -(int(today.Weekday())+6)%7)
+	case "startofmonth":
+		t = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	case "startofyear":
+		t = time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return time.Time{}, httpio.NewBadRequestMessagef("unknown time anchor '%s' in condition '%s', must be one of now, today, startOfWeek, startOfMonth or startOfYear", matches[1], p.current.Value)
+	}
+
+	for _, offset := range relativeTimeOffset.FindAllStringSubmatch(matches[2], -1) {
+		n, err := strconv.Atoi(offset[2])
+		if err != nil {
+			return time.Time{}, httpio.NewBadRequestMessagef("offset '%s' in condition '%s' is not a valid integer: %v", offset[0], p.current.Value, err)
+		}
+		if offset[1] == "-" {
+			n = -n
+		}
+
+		switch offset[3] {
+		case "s":
+			t = t.Add(time.Duration(n) * time.Second)
+		case "m":
+			t = t.Add(time.Duration(n) * time.Minute)
+		case "h":
+			t = t.Add(time.Duration(n) * time.Hour)
+		case "d":
+			t = t.AddDate(0, 0, n)
+		case "w":
+			t = t.AddDate(0, 0, 7*n)
+		case "M":
+			t = t.AddDate(0, n, 0)
+		case "y":
+			t = t.AddDate(n, 0, 0)
+		}
+	}
+
+	return t, nil
+}
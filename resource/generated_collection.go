@@ -18,6 +18,7 @@ type FieldTags struct {
 	JSON      string // json tag name (first comma-separated part); "" or "-" is unregistered
 	Perm      string // raw perm tag value (comma-separated permissions)
 	Immutable bool   // immutable:"true"
+	Mask      string // mask strategy; a masked field registers the Reveal permission on its tag
 }
 
 // FieldTagsFromStructTag extracts the registration-relevant values from a struct tag: the
@@ -34,6 +35,7 @@ func FieldTagsFromStructTag(field accesstypes.Field, tag reflect.StructTag) Fiel
 		JSON:      jsonTag,
 		Perm:      tag.Get(permTagKey),
 		Immutable: immutableTag == trueStr,
+		Mask:      tag.Get(maskTagKey),
 	}
 }
 
@@ -405,6 +407,10 @@ func (g *GeneratedCollection) addResourceSet(scope accesstypes.PermissionScope,
 	for tag, tagPermissions := range tags {
 		for _, permission := range tagPermissions {
 			permissions := g.tagStore[scope][res][tag]
+			if permission == Reveal && slices.Contains(permissions, Reveal) {
+				// the list and read request structs of a resource both register Reveal on a masked field
+				continue
+			}
 			if slices.Contains(permissions, permission) {
 				return errors.Newf("found existing mapping between tag (%s) and permission (%s) under resource (%s)", tag, permission, res)
 			}
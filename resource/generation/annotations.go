@@ -6,28 +6,36 @@ package generation
 // sourceStructTagKeys below.
 const (
 	spannerTagKey            = "spanner"
+	postgresTagKey           = "postgres"
+	dbTagKey                 = "db"
 	permTagKey               = "perm"
 	conditionsTagKey         = "conditions"
 	defaultCreateFnTagKey    = "default_create_fn"
 	outputOnlyUpdateFnTagKey = "output_only_update_fn"
 	allowFilterTagKey        = "allow_filter"
+	allowSortTagKey          = "allow_sort"
 	indexTagKey              = "index"
 	uniqueIndexTagKey        = "uniqueindex"
 	enumeratedTagKey         = "enumerated"
+	maskTagKey               = "mask"
 )
 
 // sourceStructTagKeys registers every author-written struct-tag key for the
 // README.md completeness test. Add every new tag-key constant here.
 var sourceStructTagKeys = []string{
 	spannerTagKey,
+	postgresTagKey,
+	dbTagKey,
 	permTagKey,
 	conditionsTagKey,
 	defaultCreateFnTagKey,
 	outputOnlyUpdateFnTagKey,
 	allowFilterTagKey,
+	allowSortTagKey,
 	indexTagKey,
 	uniqueIndexTagKey,
 	enumeratedTagKey,
+	maskTagKey,
 }
 
 // Values recognized inside a conditions tag's comma-separated list — register new values
@@ -37,6 +45,7 @@ const (
 	piiCondition        = "pii"
 	inputOnlyCondition  = "input_only"
 	outputOnlyCondition = "output_only"
+	encryptedCondition  = "encrypted"
 )
 
 // conditionValues registers every recognized conditions value for the README.md
@@ -46,6 +55,7 @@ var conditionValues = []string{
 	piiCondition,
 	inputOnlyCondition,
 	outputOnlyCondition,
+	encryptedCondition,
 }
 
 // Struct-tag keys the generator writes into generated request structs, read back at
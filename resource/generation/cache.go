@@ -45,6 +45,22 @@ func hashFile(filePath string) ([]byte, error) {
 
 // Compute the sha256 checksum of each file in a directory
 func hashFilesInDir(path string) (map[string]struct{}, error) {
+	fileHashes, err := hashFilesByName(path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	hashMap := make(map[string]struct{}, len(fileHashes))
+	for _, hash := range fileHashes {
+		hashMap[hash] = struct{}{}
+	}
+
+	return hashMap, nil
+}
+
+// hashFilesByName computes the sha256 checksum of each file in a directory that include
+// accepts, or of every file when include is nil, keyed by file name.
+func hashFilesByName(path string, include func(fileName string) bool) (map[string]string, error) {
 	stat, err := os.Stat(path)
 	if err != nil {
 		return nil, errors.Wrapf(err, "could not stat %q: os.Stat()", path)
@@ -64,13 +80,17 @@ func hashFilesInDir(path string) (map[string]struct{}, error) {
 		return nil, errors.Wrap(err, "os.File.Readdirnames()")
 	}
 
-	hashMap := make(map[string]struct{}, len(fileNames))
+	hashMap := make(map[string]string, len(fileNames))
 	for _, fileName := range fileNames {
+		if include != nil && !include(fileName) {
+			continue
+		}
+
 		hash, err := hashFile(filepath.Join(path, fileName))
 		if err != nil {
 			return nil, err
 		}
-		hashMap[string(hash)] = struct{}{}
+		hashMap[fileName] = string(hash)
 	}
 
 	return hashMap, nil
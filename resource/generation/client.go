@@ -1,5 +1,5 @@
 // Package generation provides tools for generating resource-driven API boilerplate
-// in Go & TypeScript based on Go structures and a Spanner DB schema.
+// in Go & TypeScript based on Go structures and a Spanner or Postgres DB schema.
 //
 // The complete reference for the comment annotations (@resource, @suppress, …) and
 // struct tags the generator recognizes lives in the resource module's README.md
@@ -51,6 +51,10 @@ type client struct {
 	genComputedResources   bool
 	genVirtualResources    bool
 	spannerEmulatorVersion string
+	offlineSchema          bool
+	postgresConnString     string
+	// ledger records the files written by a watch mode run; nil outside watch mode.
+	ledger *fileLedger
 	FileWriter
 	genCache *cache.Cache
 }
@@ -77,6 +81,9 @@ func newClient(ctx context.Context, resourcePackageDir string, migrationSourceUR
 	if err := resolveOptions(c, opts); err != nil {
 		return nil, err
 	}
+	if c.offlineSchema && c.postgresConnString != "" {
+		return nil, errors.New("WithOfflineSchema() and WithPostgresSchema() cannot be used together")
+	}
 
 	c.loadPackages = append(c.loadPackages, resourcePackageDir)
 	c.resource = packageDir(resourcePackageDir)
@@ -98,24 +105,37 @@ func newClient(ctx context.Context, resourcePackageDir string, migrationSourceUR
 
 		fallthrough
 	default:
-		for _, migrationSource := range migrationSourceURL {
-			hashedMigrationSourceURL, err := hashString(migrationSource)
-			if err != nil {
-				return nil, err
-			}
-			migrationCachePath := filepath.Join("migrations", fmt.Sprintf("%x", hashedMigrationSourceURL))
+		if err := c.loadSchema(ctx); err != nil {
+			return nil, err
+		}
+	}
 
-			if err := c.genCache.DeleteSubpath(migrationCachePath); err != nil {
-				return nil, errors.Wrap(err, "cache.Cache.DeleteSubpath()")
-			}
+	return c, nil
+}
+
+// loadSchema reads the table lookup and enum values from the schema source, first dropping
+// the cached migration checksums, which the next populateCache stores again.
+func (c *client) loadSchema(ctx context.Context) error {
+	for _, migrationSource := range c.migrationSourceURLs {
+		hashedMigrationSourceURL, err := hashString(migrationSource)
+		if err != nil {
+			return err
 		}
+		migrationCachePath := filepath.Join("migrations", fmt.Sprintf("%x", hashedMigrationSourceURL))
 
-		if err := c.runSpanner(ctx, c.spannerEmulatorVersion, migrationSourceURL); err != nil {
-			return nil, err
+		if err := c.genCache.DeleteSubpath(migrationCachePath); err != nil {
+			return errors.Wrap(err, "cache.Cache.DeleteSubpath()")
 		}
 	}
 
-	return c, nil
+	switch {
+	case c.offlineSchema:
+		return c.parseSchema(c.migrationSourceURLs)
+	case c.postgresConnString != "":
+		return c.runPostgres(ctx, c.postgresConnString, c.migrationSourceURLs)
+	default:
+		return c.runSpanner(ctx, c.spannerEmulatorVersion, c.migrationSourceURLs)
+	}
 }
 
 func (c *client) Close() error {
@@ -235,9 +255,29 @@ func (t *tableMetadata) addSchemaResult(result *informationSchemaResult) {
 	column.IsUniqueIndex = result.IsUniqueIndex
 	column.HasDefault = result.HasDefault
 
+	if result.IsSearchIndex && result.SpannerType == "TOKENLIST" && result.GenerationExpression != nil {
+		for _, source := range tokenizedColumns(*result.GenerationExpression) {
+			if t.SearchTokens == nil {
+				t.SearchTokens = make(map[string]string)
+			}
+			t.SearchTokens[source] = result.ColumnName
+		}
+	}
+
 	t.Columns[result.ColumnName] = column
 }
 
+// columnTagKeys returns the struct-tag keys that map a field to its column, in lookup order:
+// the spanner tag for a Spanner schema, and the postgres tag, then the db tag, for a Postgres
+// schema, matching the tags the resource package reads for each database.
+func (c *client) columnTagKeys() []string {
+	if c.postgresConnString != "" {
+		return []string{postgresTagKey, dbTagKey}
+	}
+
+	return []string{spannerTagKey}
+}
+
 func (c *client) tableMetadataFor(resourceName string) (*tableMetadata, error) {
 	table, ok := c.tableMap[c.pluralize(resourceName)]
 	if !ok {
@@ -277,7 +317,7 @@ func (c *client) templateFuncs() map[string]any {
 	return templateFuncs
 }
 
-// permissionConstant renders a permission as its accesstypes constant when one exists,
+// permissionConstant renders a permission as its accesstypes (or resource) constant when one exists,
 // falling back to a typed conversion for permissions outside the standard set.
 func permissionConstant(p accesstypes.Permission) string {
 	switch p {
@@ -295,6 +335,8 @@ func permissionConstant(p accesstypes.Permission) string {
 		return "accesstypes.Execute"
 	case accesstypes.NullPermission:
 		return "accesstypes.NullPermission"
+	case resource.Reveal:
+		return "resource.Reveal"
 	default:
 		return fmt.Sprintf("accesstypes.Permission(%q)", string(p))
 	}
@@ -340,11 +382,7 @@ func (c *client) writeFormattedGoFile(destinationPath, templateName, fileTemplat
 		return err
 	}
 
-	if err := os.WriteFile(destinationPath, formattedOutput, 0o644); err != nil {
-		return errors.Wrapf(err, "os.WriteFile(): file: %s", destinationPath)
-	}
-
-	return nil
+	return c.writeGeneratedFile(destinationPath, formattedOutput)
 }
 
 // formatGoBytes formats rendered Go source. The fast path resolves the file's import
@@ -441,7 +479,10 @@ func isVowel(b byte) bool {
 	}
 }
 
-func removeGeneratedFiles(directory string, method generatedFileDeleteMethod) error {
+// removeGeneratedFiles removes the files in directory that method identifies as generated.
+// In watch mode they are only marked stale, and removed at the end of the run unless it
+// writes or keeps them.
+func (c *client) removeGeneratedFiles(directory string, method generatedFileDeleteMethod) error {
 	log.Printf("removing generated files in directory %q...", directory)
 	dir, err := os.Open(directory)
 	if err != nil {
@@ -463,24 +504,21 @@ func removeGeneratedFiles(directory string, method generatedFileDeleteMethod) er
 			continue
 		}
 
-		switch method {
-		case prefix:
-			if err := removeGeneratedFileByPrefix(directory, f); err != nil {
-				return errors.Wrap(err, "removeGeneratedFileByPrefix()")
-			}
-		case headerComment:
-			if err := removeGeneratedFileByHeaderComment(directory, f); err != nil {
-				return errors.Wrap(err, "removeGeneratedFileByHeaderComment()")
-			}
+		fp := filepath.Join(directory, f)
+		generated, err := isGeneratedFile(fp, method)
+		if err != nil {
+			return err
+		}
+		if !generated {
+			continue
 		}
-	}
 
-	return nil
-}
+		if c.ledger != nil {
+			c.ledger.markStale(fp)
+
+			continue
+		}
 
-func removeGeneratedFileByPrefix(directory, file string) error {
-	if strings.HasPrefix(file, genPrefix) {
-		fp := filepath.Join(directory, file)
 		if err := os.Remove(fp); err != nil {
 			return errors.Wrap(err, "os.Remove()")
 		}
@@ -489,20 +527,22 @@ func removeGeneratedFileByPrefix(directory, file string) error {
 	return nil
 }
 
-func removeGeneratedFileByHeaderComment(directory, file string) error {
-	fp := filepath.Join(directory, file)
-	content, err := os.ReadFile(fp)
-	if err != nil {
-		return errors.Wrap(err, "os.ReadFile()")
-	}
-
-	if bytes.HasPrefix(content, []byte(generationHeader)) {
-		if err := os.Remove(fp); err != nil {
-			return errors.Wrap(err, "os.Remove()")
+// isGeneratedFile reports whether the file at path is generated: by its genPrefix file name,
+// or by its "// Code generated by resourcegeneration. DO NOT EDIT." header comment.
+func isGeneratedFile(path string, method generatedFileDeleteMethod) (bool, error) {
+	switch method {
+	case prefix:
+		return strings.HasPrefix(filepath.Base(path), genPrefix), nil
+	case headerComment:
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return false, errors.Wrap(err, "os.ReadFile()")
 		}
+
+		return bytes.HasPrefix(content, []byte(generationHeader)), nil
 	}
 
-	return nil
+	return false, nil
 }
 
 func formatInterfaceTypes(types []string) string {
@@ -555,6 +595,7 @@ func formatRPCInterfaceTypes(rpcMethods []*rpcMethodInfo) string {
 // Every resource starts with a List handler.
 // Views do not have Read handlers.
 // Consolidated resources do not have Patch handlers.
+// Interleaved children also have their List and Patch handlers nested under the parent.
 // Ignored handler types are filtered out.
 func resourceEndpoints(res *resourceInfo) []HandlerType {
 	handlerTypes := []HandlerType{ListHandler}
@@ -571,6 +612,19 @@ func resourceEndpoints(res *resourceInfo) []HandlerType {
 		return slices.Contains(res.SuppressedHandlers, ht)
 	})
 
+	if res.HasBatchRead {
+		handlerTypes = append(handlerTypes, BatchReadHandler)
+	}
+
+	if res.HasNestedRoutes() {
+		if slices.Contains(handlerTypes, ListHandler) {
+			handlerTypes = append(handlerTypes, NestedListHandler)
+		}
+		if slices.Contains(handlerTypes, PatchHandler) {
+			handlerTypes = append(handlerTypes, NestedPatchHandler)
+		}
+	}
+
 	return handlerTypes
 }
 
@@ -669,3 +723,15 @@ func alphaFollowingNumber(result []byte, b byte) bool {
 func isAlphaNumeric(b byte) bool {
 	return ('a' <= b && b <= 'z') || ('A' <= b && b <= 'Z') || ('0' <= b && b <= '9')
 }
+
+// asyncRPCMethods returns the @async RPC methods with a generated handler.
+func (c *client) asyncRPCMethods() []*rpcMethodInfo {
+	var methods []*rpcMethodInfo
+	for _, method := range c.rpcMethods {
+		if method.Async && !method.SuppressHandler {
+			methods = append(methods, method)
+		}
+	}
+
+	return methods
+}
@@ -481,3 +481,41 @@ func Test_client_localPackageImports(t *testing.T) {
 		})
 	}
 }
+
+func Test_tableMetadata_addSchemaResult_searchTokens(t *testing.T) {
+	t.Parallel()
+
+	ptr := func(s string) *string { return &s }
+	tests := []struct {
+		name   string
+		result informationSchemaResult
+		want   map[string]string
+	}{
+		{
+			name:   "fulltext",
+			result: informationSchemaResult{ColumnName: "NameTokens", SpannerType: "TOKENLIST", IsSearchIndex: true, GenerationExpression: ptr("TOKENIZE_FULLTEXT(Name)")},
+			want:   map[string]string{"Name": "NameTokens"},
+		},
+		{
+			name:   "concatenated",
+			result: informationSchemaResult{ColumnName: "Tokens", SpannerType: "TOKENLIST", IsSearchIndex: true, GenerationExpression: ptr("TOKENLIST_CONCAT([TOKENIZE_FULLTEXT(`Name`), TOKENIZE_SUBSTRING(Details, ngram_size_max=>3)])")},
+			want:   map[string]string{"Name": "Tokens", "Details": "Tokens"},
+		},
+		{
+			name:   "not in a search index",
+			result: informationSchemaResult{ColumnName: "NameTokens", SpannerType: "TOKENLIST", GenerationExpression: ptr("TOKENIZE_FULLTEXT(Name)")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			table := &tableMetadata{Columns: make(map[string]columnMeta)}
+			table.addSchemaResult(&tt.result)
+			if diff := cmp.Diff(tt.want, table.SearchTokens); diff != "" {
+				t.Errorf("tableMetadata.SearchTokens mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -349,13 +349,13 @@ func handlerSetData(res *resourceInfo, handlerType HandlerType) (resource.SetDat
 		permissions = []accesstypes.Permission{accesstypes.List}
 		for _, field := range res.Fields {
 			fields = append(fields, fieldTagsFromTemplateTags(field.Name(),
-				field.JSONTag(), field.IndexTag(), field.AllowFilterTag(), field.ListPermTag(), field.PIITag()))
+				field.JSONTag(), field.IndexTag(), field.AllowFilterTag(), field.AllowSortTag(), field.ListPermTag(), field.PIITag(), field.MaskTag()))
 		}
 	case ReadHandler:
 		permissions = []accesstypes.Permission{accesstypes.Read}
 		for _, field := range res.Fields {
 			fields = append(fields, fieldTagsFromTemplateTags(field.Name(),
-				field.JSONTag(), field.UniqueIndexTag(), field.ReadPermTag(), field.PIITag()))
+				field.JSONTag(), field.UniqueIndexTag(), field.ReadPermTag(), field.PIITag(), field.MaskTag()))
 		}
 	case PatchHandler:
 		permissions = []accesstypes.Permission{accesstypes.Create, accesstypes.Update, accesstypes.Delete}
@@ -8,6 +8,7 @@ import (
 	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/cccteam/ccc/accesstypes"
 	"github.com/cccteam/ccc/resource"
@@ -246,6 +247,7 @@ func Test_computeCollectionData(t *testing.T) {
 					Scope:       accesstypes.GlobalPermissionScope,
 					Permissions: []accesstypes.Permission{accesstypes.Create, accesstypes.Delete, accesstypes.List, accesstypes.Read, accesstypes.Update},
 					Tags: []resource.TagData{
+						{Name: "badge", Permissions: []accesstypes.Permission{accesstypes.Read, resource.Reveal}},
 						{Name: "code", Permissions: []accesstypes.Permission{accesstypes.Update}},
 						{Name: "derived"},
 						{Name: "id"},
@@ -826,3 +828,416 @@ func Test_parsePermissionScopeAnnotation(t *testing.T) {
 		})
 	}
 }
+
+// Test_resolveRowPolicy pins @rowPolicy resolution, including the requirement that the
+// resource has a query clause for the policy function to return.
+func Test_resolveRowPolicy(t *testing.T) {
+	t.Parallel()
+
+	structs := fixtureStructs(loadCollectionFixture(t))
+
+	tests := []struct {
+		name       string
+		structName string
+		indexed    bool
+		want       string
+		wantErr    bool
+	}{
+		{
+			name:       "policy function on an indexed resource",
+			structName: "Beacon",
+			indexed:    true,
+			want:       "beaconRowPolicy",
+		},
+		{
+			name:       "policy function without a query clause errors",
+			structName: "Beacon",
+			wantErr:    true,
+		},
+		{
+			name:       "no annotation",
+			structName: "Fossil",
+			indexed:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			annotations, err := genlang.NewScanner(resourceKeywords()).ScanStruct(structs[tt.structName])
+			if err != nil {
+				t.Fatalf("ScanStruct() error = %v", err)
+			}
+
+			res := fixtureResource(t, structs, tt.structName, func(r *resourceInfo) {
+				for _, f := range r.Fields {
+					f.IsIndex = tt.indexed
+				}
+			})
+
+			err = resolveRowPolicy(res, annotations)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveRowPolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if res.RowPolicyFunc != tt.want {
+				t.Errorf("RowPolicyFunc = %q, want %q", res.RowPolicyFunc, tt.want)
+			}
+			if res.HasRowPolicy() != (tt.want != "") {
+				t.Errorf("HasRowPolicy() = %v, want %v", res.HasRowPolicy(), tt.want != "")
+			}
+		})
+	}
+}
+
+// Test_resolveAuditable pins the audit column validation and the default functions
+// @auditable registers for each column.
+func Test_resolveAuditable(t *testing.T) {
+	t.Parallel()
+
+	structs := fixtureStructs(loadCollectionFixture(t))
+
+	type funcs struct{ create, update string }
+
+	tests := []struct {
+		name       string
+		structName string
+		want       map[string]funcs
+		wantErr    []string
+	}{
+		{
+			name:       "audit columns",
+			structName: "Logbook",
+			want: map[string]funcs{
+				"ID":        {},
+				"CreatedAt": {create: "resource.CommitTimestamp"},
+				"CreatedBy": {create: "resource.CurrentUser"},
+				"UpdatedAt": {create: "resource.CommitTimestampPtr", update: "resource.CommitTimestampPtr"},
+				"UpdatedBy": {create: "resource.CurrentUserPtr", update: "resource.CurrentUserPtr"},
+			},
+		},
+		{
+			name:       "invalid audit columns",
+			structName: "Stardate",
+			wantErr: []string{
+				"audit column CreatedAt must be a time.Time or *time.Time, found string",
+				"audit column UpdatedAt cannot use the default_create_fn or output_only_update_fn tags",
+			},
+		},
+		{
+			name:       "no annotation",
+			structName: "Fossil",
+			want: map[string]funcs{
+				"ID":   {},
+				"Name": {},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			annotations, err := genlang.NewScanner(resourceKeywords()).ScanStruct(structs[tt.structName])
+			if err != nil {
+				t.Fatalf("ScanStruct() error = %v", err)
+			}
+
+			res := fixtureResource(t, structs, tt.structName, nil)
+
+			err = resolveAuditable(res, annotations)
+			if tt.wantErr != nil {
+				if err == nil {
+					t.Fatalf("resolveAuditable() error = nil, want %q", tt.wantErr)
+				}
+				for _, want := range tt.wantErr {
+					if !strings.Contains(err.Error(), want) {
+						t.Errorf("resolveAuditable() error = %v, want %q", err, want)
+					}
+				}
+				if res.IsAuditable {
+					t.Errorf("IsAuditable = true, want false")
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveAuditable() error = %v", err)
+			}
+
+			for _, f := range res.Fields {
+				want := tt.want[f.Name()]
+				if got := f.DefaultCreateFuncName(); got != want.create {
+					t.Errorf("%s.DefaultCreateFuncName() = %q, want %q", f.Name(), got, want.create)
+				}
+				if got := f.OutputOnlyUpdateFuncName(); got != want.update {
+					t.Errorf("%s.OutputOnlyUpdateFuncName() = %q, want %q", f.Name(), got, want.update)
+				}
+				if got := f.IsOutputOnly(); got != (want.create != "") {
+					t.Errorf("%s.IsOutputOnly() = %v, want %v", f.Name(), got, want.create != "")
+				}
+			}
+		})
+	}
+}
+
+func Test_checkMaskTag(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		field   string
+		wantErr string
+	}{
+		{
+			name:  "pii string pointer",
+			field: "Badge",
+		},
+		{
+			name:    "missing pii condition",
+			field:   "Email",
+			wantErr: "mask tag requires the pii condition",
+		},
+		{
+			name:    "non-string field",
+			field:   "Pin",
+			wantErr: "mask tag is only supported on string and *string fields",
+		},
+		{
+			name:    "unknown strategy",
+			field:   "Passcode",
+			wantErr: `unknown mask strategy "first4"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// field errors accumulate on the parsed struct, so every case parses its own copy
+			structs := fixtureStructs(loadCollectionFixture(t))
+			res := fixtureResource(t, structs, "Credential", nil)
+			for _, f := range res.Fields {
+				if f.Name() == tt.field {
+					checkMaskTag(f)
+				}
+			}
+
+			s := structs["Credential"]
+			if tt.wantErr == "" {
+				if s.HasErrors() {
+					t.Errorf("checkMaskTag() errors = %s, want none", s.PrintErrors())
+				}
+
+				return
+			}
+			if !strings.Contains(s.PrintErrors(), tt.wantErr) {
+				t.Errorf("checkMaskTag() errors = %s, want %q", s.PrintErrors(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_checkEncryptedCondition(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		field   string
+		indexed bool
+		wantErr string
+	}{
+		{
+			name:  "string pointer",
+			field: "Notes",
+		},
+		{
+			name:    "non-string field",
+			field:   "Vitals",
+			wantErr: "encrypted condition is only supported on string and *string fields",
+		},
+		{
+			name:    "filterable field",
+			field:   "Callsign",
+			wantErr: "encrypted condition cannot be used with the allow_filter tag",
+		},
+		{
+			name:    "sortable field",
+			field:   "Sigil",
+			wantErr: "encrypted condition cannot be used with the allow_sort tag",
+		},
+		{
+			name:    "indexed field",
+			field:   "Notes",
+			indexed: true,
+			wantErr: "encrypted condition cannot be used on a primary key or indexed field",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			structs := fixtureStructs(loadCollectionFixture(t))
+			res := fixtureResource(t, structs, "Credential", nil)
+			for _, f := range res.Fields {
+				if f.Name() == tt.field {
+					f.IsIndex = tt.indexed
+					checkEncryptedCondition(f)
+				}
+			}
+
+			s := structs["Credential"]
+			if tt.wantErr == "" {
+				if s.HasErrors() {
+					t.Errorf("checkEncryptedCondition() errors = %s, want none", s.PrintErrors())
+				}
+
+				return
+			}
+			if !strings.Contains(s.PrintErrors(), tt.wantErr) {
+				t.Errorf("checkEncryptedCondition() errors = %s, want %q", s.PrintErrors(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_resolveBatchRead(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		structName string
+		mutate     func(*resourceInfo)
+		want       bool
+		wantErr    string
+	}{
+		{
+			name:       "batch read",
+			structName: "Manifest",
+			want:       true,
+		},
+		{
+			name:       "compound primary key",
+			structName: "Manifest",
+			mutate:     func(res *resourceInfo) { res.PkCount = 2 },
+			wantErr:    "not supported for a compound primary key",
+		},
+		{
+			name:       "suppressed read handler",
+			structName: "Manifest",
+			mutate:     func(res *resourceInfo) { res.SuppressedHandlers = []HandlerType{ReadHandler} },
+			wantErr:    "requires the readHandler",
+		},
+		{
+			name:       "no annotation",
+			structName: "Fossil",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			structs := fixtureStructs(loadCollectionFixture(t))
+			annotations, err := genlang.NewScanner(resourceKeywords()).ScanStruct(structs[tt.structName])
+			if err != nil {
+				t.Fatalf("ScanStruct() error = %v", err)
+			}
+
+			res := fixtureResource(t, structs, tt.structName, tt.mutate)
+
+			err = resolveBatchRead(res, annotations)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("resolveBatchRead() error = %v, want %q", err, tt.wantErr)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveBatchRead() error = %v", err)
+			}
+			if res.HasBatchRead != tt.want {
+				t.Errorf("HasBatchRead = %v, want %v", res.HasBatchRead, tt.want)
+			}
+			if got := slices.Contains(resourceEndpoints(res), BatchReadHandler); got != tt.want {
+				t.Errorf("resourceEndpoints() contains %s = %v, want %v", BatchReadHandler, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_resolveQueryPolicy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		structName string
+		indexed    bool
+		want       queryPolicy
+		wantConfig string
+		wantErr    []string
+	}{
+		{
+			name:       "every option",
+			structName: "Sensor",
+			indexed:    true,
+			want:       queryPolicy{MaxLimit: 500, MaxOffset: 10000, Timeout: 1500 * time.Millisecond, RequireIndexedFilter: true, RequireIndexedSort: true},
+			wantConfig: ".SetMaxLimit(500).SetMaxOffset(10000).SetQueryTimeout(1500 * time.Millisecond).SetRequireIndexedFilter(true).SetRequireIndexedSort(true)",
+		},
+		{
+			name:       "indexed filter required without an indexed field",
+			structName: "Sensor",
+			wantErr:    []string{"requires at least one indexed field"},
+		},
+		{
+			name:       "invalid options",
+			structName: "Probe",
+			indexed:    true,
+			wantErr:    []string{`maxLimit must be a positive integer, got "0"`, `timeout must be a positive duration such as 5s, got "soon"`, `unknown option "pageSize"`},
+		},
+		{
+			name:       "no annotation",
+			structName: "Fossil",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			structs := fixtureStructs(loadCollectionFixture(t))
+			annotations, err := genlang.NewScanner(resourceKeywords()).ScanStruct(structs[tt.structName])
+			if err != nil {
+				t.Fatalf("ScanStruct() error = %v", err)
+			}
+
+			res := fixtureResource(t, structs, tt.structName, func(r *resourceInfo) {
+				for _, f := range r.Fields {
+					f.IsIndex = tt.indexed
+				}
+			})
+
+			err = resolveQueryPolicy(res, annotations)
+			if len(tt.wantErr) != 0 {
+				for _, want := range tt.wantErr {
+					if err == nil || !strings.Contains(err.Error(), want) {
+						t.Errorf("resolveQueryPolicy() error = %v, want %q", err, want)
+					}
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveQueryPolicy() error = %v", err)
+			}
+			if res.QueryPolicy != tt.want {
+				t.Errorf("QueryPolicy = %+v, want %+v", res.QueryPolicy, tt.want)
+			}
+			if got := res.QueryPolicyConfig(); got != tt.wantConfig {
+				t.Errorf("QueryPolicyConfig() = %q, want %q", got, tt.wantConfig)
+			}
+		})
+	}
+}
@@ -14,6 +14,11 @@ func (r *resourceGenerator) generateComputedResourceHandler(res *computedResourc
 	begin := time.Now()
 	fileName := generatedGoFileName(strings.ToLower(caser.ToSnake(res.Name())))
 	destinationFilePath := filepath.Join(r.handler.Dir(), fileName)
+	if !r.scope.includesComputed() {
+		r.keepGeneratedFile(destinationFilePath)
+
+		return nil
+	}
 
 	if err := r.writeFormattedGoFile(destinationFilePath, fmt.Sprintf("computedResourceHandlerTemplate:%q", res.Name()), computedResourceHandlerTemplate, &computedHandlerData{
 		Source:              r.computed.Dir(),
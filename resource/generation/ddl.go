@@ -0,0 +1,725 @@
+package generation
+
+import (
+	"cmp"
+	"log"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/cloudspannerecosystem/memefish"
+	"github.com/cloudspannerecosystem/memefish/ast"
+	"github.com/go-playground/errors/v5"
+)
+
+// upMigrationFile matches the name of a golang-migrate up migration, capturing its version.
+var upMigrationFile = regexp.MustCompile(`^(\d+)_.*\.up\.sql$`)
+
+// parseSchema builds the table lookup and enum values by parsing the migrations, without
+// starting the Spanner emulator.
+func (c *client) parseSchema(migrationSourceURLs []string) error {
+	log.Println("Parsing Spanner migrations...")
+
+	schema, err := parseMigrations(migrationSourceURLs)
+	if err != nil {
+		return err
+	}
+
+	enumValues, err := schema.enumValues()
+	if err != nil {
+		return err
+	}
+
+	c.tableMap = newTableMap(schema.informationSchema())
+	c.enumValues = enumValues
+
+	return nil
+}
+
+// parseMigrations applies the up migrations of each source in version order, the order the
+// emulator migrates them in.
+func parseMigrations(migrationSourceURLs []string) (*ddlSchema, error) {
+	schema := newDDLSchema()
+	for _, migrationSource := range migrationSourceURLs {
+		dir, ok := strings.CutPrefix(migrationSource, "file://")
+		if !ok {
+			return nil, errors.Newf("migration source %q is not a file:// URL, which parsing the migrations requires", migrationSource)
+		}
+
+		files, err := upMigrationFiles(dir)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, file := range files {
+			b, err := os.ReadFile(filepath.Join(dir, file))
+			if err != nil {
+				return nil, errors.Wrap(err, "os.ReadFile()")
+			}
+
+			stmts, err := memefish.ParseStatements(file, string(b))
+			if err != nil {
+				return nil, errors.Wrapf(err, "memefish.ParseStatements(): file: %s", file)
+			}
+
+			for _, stmt := range stmts {
+				if err := schema.apply(stmt); err != nil {
+					return nil, errors.Wrapf(err, "file: %s", file)
+				}
+			}
+		}
+	}
+
+	return schema, nil
+}
+
+// upMigrationFiles returns the up migrations in dir, sorted by version.
+func upMigrationFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "os.ReadDir()")
+	}
+
+	type migration struct {
+		version uint64
+		file    string
+	}
+	var migrations []migration
+	for _, entry := range entries {
+		match := upMigrationFile.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "strconv.ParseUint(): file: %s", entry.Name())
+		}
+		migrations = append(migrations, migration{version: version, file: entry.Name()})
+	}
+	slices.SortFunc(migrations, func(a, b migration) int { return cmp.Compare(a.version, b.version) })
+
+	files := make([]string, 0, len(migrations))
+	for _, m := range migrations {
+		files = append(files, m.file)
+	}
+
+	return files, nil
+}
+
+// ddlSchema is the schema the migrations declare. Spanner names are case-insensitive, so
+// tables, columns and indexes are looked up by their lower-case name, and reported by the
+// name they were declared with.
+type ddlSchema struct {
+	tables  map[string]*ddlTable
+	indexes map[string]*ddlIndex
+	// rows are the rows the migrations insert, used for the enum values.
+	rows map[string][]ddlRow
+	// changed are the tables the migrations update or delete rows of, whose rows are unknown.
+	changed map[string]bool
+}
+
+type ddlTable struct {
+	name        string
+	columns     []*ddlColumn
+	primaryKey  []string
+	parent      string
+	foreignKeys []*ddlForeignKey
+}
+
+type ddlColumn struct {
+	name                 string
+	spannerType          string
+	notNull              bool
+	hasDefault           bool
+	generationExpression *string
+}
+
+type ddlForeignKey struct {
+	name              string
+	columns           []string
+	referencedTable   string
+	referencedColumns []string
+}
+
+type ddlIndex struct {
+	name   string
+	table  string
+	unique bool
+	search bool
+	keys   []string
+	stored []string
+}
+
+// ddlRow is an inserted row: the value of each column, by lower-case column name.
+type ddlRow map[string]ast.Expr
+
+func newDDLSchema() *ddlSchema {
+	return &ddlSchema{
+		tables:  make(map[string]*ddlTable),
+		indexes: make(map[string]*ddlIndex),
+		rows:    make(map[string][]ddlRow),
+		changed: make(map[string]bool),
+	}
+}
+
+// apply applies a statement to the schema. Views are left out, as the information schema
+// query leaves them out, and so are statements that don't change the table lookup or the
+// enum values, e.g. change streams.
+func (s *ddlSchema) apply(stmt ast.Statement) error {
+	switch stmt := stmt.(type) {
+	case *ast.CreateTable:
+		return s.createTable(stmt)
+	case *ast.AlterTable:
+		return s.alterTable(stmt)
+	case *ast.DropTable:
+		s.dropTable(pathName(stmt.Name))
+	case *ast.RenameTable:
+		for _, to := range stmt.Tos {
+			if err := s.renameTable(to.Old.Name, to.New.Name); err != nil {
+				return err
+			}
+		}
+	case *ast.CreateIndex:
+		return s.createIndex(&ddlIndex{
+			name:   pathName(stmt.Name),
+			table:  pathName(stmt.TableName),
+			unique: stmt.Unique,
+			keys:   indexKeyNames(stmt.Keys),
+			stored: storingNames(stmt.Storing),
+		}, stmt.IfNotExists)
+	case *ast.CreateSearchIndex:
+		var stored []string
+		stored = append(stored, identNames(stmt.TokenListPart)...)
+		stored = append(stored, storingNames(stmt.Storing)...)
+		stored = append(stored, identNames(stmt.PartitionColumns)...)
+
+		return s.createIndex(&ddlIndex{name: pathName(stmt.Name), table: pathName(stmt.TableName), search: true, stored: stored}, false)
+	case *ast.AlterIndex:
+		return s.alterIndex(stmt)
+	case *ast.DropIndex:
+		delete(s.indexes, strings.ToLower(pathName(stmt.Name)))
+	case *ast.DropSearchIndex:
+		delete(s.indexes, strings.ToLower(pathName(stmt.Name)))
+	case *ast.Insert:
+		return s.insert(stmt)
+	case *ast.Update:
+		s.changed[strings.ToLower(pathName(stmt.TableName))] = true
+	case *ast.Delete:
+		s.changed[strings.ToLower(pathName(stmt.TableName))] = true
+	}
+
+	return nil
+}
+
+func (s *ddlSchema) table(name string) (*ddlTable, error) {
+	table, ok := s.tables[strings.ToLower(name)]
+	if !ok {
+		return nil, errors.Newf("table %s not found", name)
+	}
+
+	return table, nil
+}
+
+func (s *ddlSchema) createTable(stmt *ast.CreateTable) error {
+	name := pathName(stmt.Name)
+	if _, ok := s.tables[strings.ToLower(name)]; ok {
+		if stmt.IfNotExists {
+			return nil
+		}
+
+		return errors.Newf("CREATE TABLE %s: table already exists", name)
+	}
+
+	table := &ddlTable{name: name, primaryKey: indexKeyNames(stmt.PrimaryKeys)}
+	for _, def := range stmt.Columns {
+		table.columns = append(table.columns, newDDLColumn(def))
+		if def.PrimaryKey {
+			table.primaryKey = append(table.primaryKey, def.Name.Name)
+		}
+	}
+	for _, constraint := range stmt.TableConstraints {
+		table.addConstraint(constraint)
+	}
+	if stmt.Cluster != nil {
+		table.parent = pathName(stmt.Cluster.TableName)
+	}
+
+	s.tables[strings.ToLower(name)] = table
+
+	return nil
+}
+
+func (s *ddlSchema) alterTable(stmt *ast.AlterTable) error {
+	table, err := s.table(pathName(stmt.Name))
+	if err != nil {
+		return errors.Wrap(err, "ALTER TABLE")
+	}
+
+	switch alteration := stmt.TableAlteration.(type) {
+	case *ast.AddColumn:
+		if table.column(alteration.Column.Name.Name) != nil {
+			if alteration.IfNotExists {
+				return nil
+			}
+
+			return errors.Newf("ALTER TABLE %s: column %s already exists", table.name, alteration.Column.Name.Name)
+		}
+		table.columns = append(table.columns, newDDLColumn(alteration.Column))
+	case *ast.DropColumn:
+		table.columns = slices.DeleteFunc(table.columns, func(c *ddlColumn) bool { return strings.EqualFold(c.name, alteration.Name.Name) })
+	case *ast.AlterColumn:
+		column := table.column(alteration.Name.Name)
+		if column == nil {
+			return errors.Newf("ALTER TABLE %s: column %s not found", table.name, alteration.Name.Name)
+		}
+		column.alter(alteration.Alteration)
+	case *ast.AddTableConstraint:
+		table.addConstraint(alteration.TableConstraint)
+	case *ast.DropConstraint:
+		table.foreignKeys = slices.DeleteFunc(table.foreignKeys, func(fk *ddlForeignKey) bool { return strings.EqualFold(fk.name, alteration.Name.Name) })
+	case *ast.SetInterleaveIn:
+		table.parent = pathName(alteration.TableName)
+	case *ast.RenameTo:
+		return s.renameTable(table.name, alteration.Name.Name)
+	}
+
+	return nil
+}
+
+// dropTable removes a table, its indexes and its rows.
+func (s *ddlSchema) dropTable(name string) {
+	key := strings.ToLower(name)
+	delete(s.tables, key)
+	delete(s.rows, key)
+	delete(s.changed, key)
+	maps.DeleteFunc(s.indexes, func(_ string, idx *ddlIndex) bool { return strings.EqualFold(idx.table, name) })
+}
+
+// renameTable renames a table, and the references to it of interleaved tables, foreign keys
+// and indexes.
+func (s *ddlSchema) renameTable(oldName, newName string) error {
+	table, err := s.table(oldName)
+	if err != nil {
+		return errors.Wrap(err, "RENAME TABLE")
+	}
+
+	oldKey, newKey := strings.ToLower(oldName), strings.ToLower(newName)
+	delete(s.tables, oldKey)
+	table.name = newName
+	s.tables[newKey] = table
+
+	for _, t := range s.tables {
+		if strings.EqualFold(t.parent, oldName) {
+			t.parent = newName
+		}
+		for _, fk := range t.foreignKeys {
+			if strings.EqualFold(fk.referencedTable, oldName) {
+				fk.referencedTable = newName
+			}
+		}
+	}
+	for _, idx := range s.indexes {
+		if strings.EqualFold(idx.table, oldName) {
+			idx.table = newName
+		}
+	}
+	if rows, ok := s.rows[oldKey]; ok {
+		delete(s.rows, oldKey)
+		s.rows[newKey] = rows
+	}
+	if s.changed[oldKey] {
+		delete(s.changed, oldKey)
+		s.changed[newKey] = true
+	}
+
+	return nil
+}
+
+func (s *ddlSchema) createIndex(idx *ddlIndex, ifNotExists bool) error {
+	if _, ok := s.indexes[strings.ToLower(idx.name)]; ok {
+		if ifNotExists {
+			return nil
+		}
+
+		return errors.Newf("CREATE INDEX %s: index already exists", idx.name)
+	}
+	if _, err := s.table(idx.table); err != nil {
+		return errors.Wrapf(err, "CREATE INDEX %s", idx.name)
+	}
+
+	s.indexes[strings.ToLower(idx.name)] = idx
+
+	return nil
+}
+
+func (s *ddlSchema) alterIndex(stmt *ast.AlterIndex) error {
+	idx, ok := s.indexes[strings.ToLower(pathName(stmt.Name))]
+	if !ok {
+		return errors.Newf("ALTER INDEX %s: index not found", pathName(stmt.Name))
+	}
+
+	switch alteration := stmt.IndexAlteration.(type) {
+	case *ast.AddStoredColumn:
+		idx.stored = append(idx.stored, alteration.Name.Name)
+	case *ast.DropStoredColumn:
+		idx.stored = slices.DeleteFunc(idx.stored, func(c string) bool { return strings.EqualFold(c, alteration.Name.Name) })
+	}
+
+	return nil
+}
+
+// insert records the rows of an INSERT. INSERT OR UPDATE replaces, and INSERT OR IGNORE
+// keeps, a row with the same primary key.
+func (s *ddlSchema) insert(stmt *ast.Insert) error {
+	table, err := s.table(pathName(stmt.TableName))
+	if err != nil {
+		return errors.Wrap(err, "INSERT")
+	}
+	key := strings.ToLower(table.name)
+
+	values, ok := stmt.Input.(*ast.ValuesInput)
+	if !ok {
+		// The rows of an INSERT ... SELECT are unknown.
+		s.changed[key] = true
+
+		return nil
+	}
+
+	for _, valuesRow := range values.Rows {
+		if len(valuesRow.Exprs) != len(stmt.Columns) {
+			return errors.Newf("INSERT INTO %s: %d values for %d columns", table.name, len(valuesRow.Exprs), len(stmt.Columns))
+		}
+
+		row := make(ddlRow, len(stmt.Columns))
+		for i, column := range stmt.Columns {
+			if !valuesRow.Exprs[i].Default {
+				row[strings.ToLower(column.Name)] = valuesRow.Exprs[i].Expr
+			}
+		}
+
+		existing := slices.IndexFunc(s.rows[key], func(r ddlRow) bool { return table.sameKey(r, row) })
+		switch {
+		case existing == -1:
+			s.rows[key] = append(s.rows[key], row)
+		case stmt.InsertOrType == ast.InsertOrTypeUpdate:
+			s.rows[key][existing] = row
+		case stmt.InsertOrType == ast.InsertOrTypeIgnore:
+		default:
+			return errors.Newf("INSERT INTO %s: a row with the same primary key already exists", table.name)
+		}
+	}
+
+	return nil
+}
+
+// enumValues returns the Id and Description of the rows inserted into each table with a
+// Description column, as the emulator's SELECT DISTINCT Id, Description ... ORDER BY Id
+// would.
+func (s *ddlSchema) enumValues() (map[string][]*enumData, error) {
+	enumValues := make(map[string][]*enumData)
+	for key, table := range s.tables {
+		if !slices.ContainsFunc(table.columns, func(c *ddlColumn) bool { return c.name == "Description" }) {
+			continue
+		}
+		if s.changed[key] {
+			return nil, errors.Newf("enum table %s: its rows are changed by a statement other than INSERT ... VALUES, which parsing the migrations can't follow", table.name)
+		}
+
+		values := make([]*enumData, 0, len(s.rows[key]))
+		for _, row := range s.rows[key] {
+			id, ok := stringLiteral(row["id"])
+			if !ok {
+				return nil, errors.Newf("enum table %s: Id %s is not a string literal", table.name, exprSQL(row["id"]))
+			}
+			description, ok := stringLiteral(row["description"])
+			if !ok {
+				return nil, errors.Newf("enum table %s: Description %s is not a string literal", table.name, exprSQL(row["description"]))
+			}
+
+			if !slices.ContainsFunc(values, func(e *enumData) bool { return e.ID == id && e.Description == description }) {
+				values = append(values, &enumData{ID: id, Description: description})
+			}
+		}
+		slices.SortStableFunc(values, func(a, b *enumData) int { return strings.Compare(a.ID, b.ID) })
+
+		enumValues[table.name] = values
+	}
+
+	return enumValues, nil
+}
+
+// informationSchema returns the rows the information schema query returns for the schema:
+// one per column of each table, except the hidden columns the query leaves out.
+func (s *ddlSchema) informationSchema() []informationSchemaResult {
+	var results []informationSchemaResult
+	for _, table := range s.tables {
+		for i, column := range table.columns {
+			// The query's NOT LIKE '%_HIDDEN', where _ matches any character.
+			if len(column.name) > len("HIDDEN") && strings.HasSuffix(column.name, "HIDDEN") {
+				continue
+			}
+
+			result := informationSchemaResult{
+				TableName:            table.name,
+				ColumnName:           column.name,
+				SpannerType:          column.spannerType,
+				IsNullable:           !column.notNull,
+				GenerationExpression: column.generationExpression,
+				OrdinalPosition:      int64(i + 1),
+				KeyOrdinalPosition:   1,
+				HasDefault:           column.hasDefault,
+				IsInterleaved:        table.parent != "",
+			}
+			if table.parent != "" {
+				result.ParentTableName = &table.parent
+			}
+			if pos := slices.IndexFunc(table.primaryKey, func(c string) bool { return strings.EqualFold(c, column.name) }); pos != -1 {
+				result.IsPrimaryKey = true
+				result.KeyOrdinalPosition = int64(pos + 1)
+			}
+			s.addReference(&result, table, column.name)
+			s.addIndexes(&result, table, column.name)
+
+			results = append(results, result)
+		}
+	}
+
+	return results
+}
+
+// addReference sets the foreign key columns of a result. Like the query, a referenced column
+// that is itself a foreign key is followed one step, and of several foreign keys the greatest
+// table and column names are reported.
+func (s *ddlSchema) addReference(result *informationSchemaResult, table *ddlTable, column string) {
+	var direct, followed []ddlReference
+	for _, fk := range table.foreignKeys {
+		pos := slices.IndexFunc(fk.columns, func(c string) bool { return strings.EqualFold(c, column) })
+		if pos == -1 {
+			continue
+		}
+		result.IsForeignKey = true
+		if pos >= len(fk.referencedColumns) {
+			continue
+		}
+		direct = append(direct, ddlReference{table: fk.referencedTable, column: fk.referencedColumns[pos]})
+
+		referenced, err := s.table(fk.referencedTable)
+		if err != nil {
+			continue
+		}
+		for _, next := range referenced.foreignKeys {
+			if slices.ContainsFunc(next.columns, func(c string) bool { return strings.EqualFold(c, fk.referencedColumns[pos]) }) && pos < len(next.referencedColumns) {
+				followed = append(followed, ddlReference{table: next.referencedTable, column: next.referencedColumns[pos]})
+			}
+		}
+	}
+
+	references := direct
+	if len(followed) != 0 {
+		references = followed
+	}
+	if len(references) == 0 {
+		return
+	}
+
+	referencedTable := slices.MaxFunc(references, func(a, b ddlReference) int { return strings.Compare(a.table, b.table) }).table
+	referencedColumn := slices.MaxFunc(references, func(a, b ddlReference) int { return strings.Compare(a.column, b.column) }).column
+	result.ReferencedTable, result.ReferencedColumn = &referencedTable, &referencedColumn
+}
+
+type ddlReference struct {
+	table  string
+	column string
+}
+
+// addIndexes sets the index columns of a result: the columns of the primary key, a unique
+// index, of the table's indexes, keys and stored columns alike, and of the indexes Spanner
+// manages for foreign keys. Those are on the referencing columns, and a unique index on
+// the referenced columns unless the primary key or a unique index already covers them.
+func (s *ddlSchema) addIndexes(result *informationSchemaResult, table *ddlTable, column string) {
+	has := func(columns []string) bool {
+		return slices.ContainsFunc(columns, func(c string) bool { return strings.EqualFold(c, column) })
+	}
+
+	if has(table.primaryKey) {
+		result.IsIndex, result.IsUniqueIndex = true, true
+	}
+	for _, idx := range s.indexes {
+		if !strings.EqualFold(idx.table, table.name) || (!has(idx.keys) && !has(idx.stored)) {
+			continue
+		}
+		result.IsIndex = true
+		result.IsUniqueIndex = result.IsUniqueIndex || idx.unique
+		result.IsSearchIndex = result.IsSearchIndex || idx.search
+	}
+
+	for _, fk := range table.foreignKeys {
+		if has(fk.columns) {
+			result.IsIndex = true
+		}
+	}
+	for _, referencing := range s.tables {
+		for _, fk := range referencing.foreignKeys {
+			if strings.EqualFold(fk.referencedTable, table.name) && has(fk.referencedColumns) && !s.hasUniqueKey(table, fk.referencedColumns) {
+				result.IsIndex, result.IsUniqueIndex = true, true
+			}
+		}
+	}
+}
+
+// hasUniqueKey reports whether the table's primary key or one of its unique indexes is on
+// exactly the columns.
+func (s *ddlSchema) hasUniqueKey(table *ddlTable, columns []string) bool {
+	sameColumns := func(keys []string) bool {
+		return len(keys) == len(columns) && !slices.ContainsFunc(keys, func(k string) bool {
+			return !slices.ContainsFunc(columns, func(c string) bool { return strings.EqualFold(c, k) })
+		})
+	}
+
+	if sameColumns(table.primaryKey) {
+		return true
+	}
+	for _, idx := range s.indexes {
+		if idx.unique && strings.EqualFold(idx.table, table.name) && sameColumns(idx.keys) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (t *ddlTable) column(name string) *ddlColumn {
+	for _, c := range t.columns {
+		if strings.EqualFold(c.name, name) {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// addConstraint records a table constraint. Only foreign keys and the primary key are used;
+// check constraints are left out.
+func (t *ddlTable) addConstraint(constraint *ast.TableConstraint) {
+	switch c := constraint.Constraint.(type) {
+	case *ast.ForeignKey:
+		fk := &ddlForeignKey{
+			columns:           identNames(c.Columns),
+			referencedTable:   pathName(c.ReferenceTable),
+			referencedColumns: identNames(c.ReferenceColumns),
+		}
+		if constraint.Name != nil {
+			fk.name = constraint.Name.Name
+		}
+		t.foreignKeys = append(t.foreignKeys, fk)
+	case *ast.TablePrimaryKey:
+		t.primaryKey = indexKeyNames(c.Columns)
+	}
+}
+
+// sameKey reports whether two inserted rows have the same primary key.
+func (t *ddlTable) sameKey(a, b ddlRow) bool {
+	if len(t.primaryKey) == 0 {
+		return false
+	}
+
+	for _, key := range t.primaryKey {
+		if exprSQL(a[strings.ToLower(key)]) != exprSQL(b[strings.ToLower(key)]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func newDDLColumn(def *ast.ColumnDef) *ddlColumn {
+	column := &ddlColumn{name: def.Name.Name, spannerType: def.Type.SQL(), notNull: def.NotNull}
+	column.setDefaultSemantics(def.DefaultSemantics)
+
+	return column
+}
+
+// setDefaultSemantics sets the column's default or generation expression. Identity and
+// AUTO_INCREMENT columns have neither, as in the information schema.
+func (c *ddlColumn) setDefaultSemantics(semantics ast.ColumnDefaultSemantics) {
+	c.hasDefault, c.generationExpression = false, nil
+	switch semantics := semantics.(type) {
+	case *ast.ColumnDefaultExpr:
+		c.hasDefault = true
+	case *ast.GeneratedColumnExpr:
+		expression := semantics.Expr.SQL()
+		c.generationExpression = &expression
+	}
+}
+
+func (c *ddlColumn) alter(alteration ast.ColumnAlteration) {
+	switch alteration := alteration.(type) {
+	case *ast.AlterColumnType:
+		c.spannerType, c.notNull = alteration.Type.SQL(), alteration.NotNull
+		switch {
+		case alteration.DefaultExpr != nil:
+			c.setDefaultSemantics(alteration.DefaultExpr)
+		case alteration.GeneratedExpr != nil:
+			c.setDefaultSemantics(alteration.GeneratedExpr)
+		default:
+			c.setDefaultSemantics(nil)
+		}
+	case *ast.AlterColumnSetDefault:
+		c.hasDefault = true
+	case *ast.AlterColumnDropDefault:
+		c.hasDefault = false
+	}
+}
+
+// pathName returns the name of a table or index, without its schema.
+func pathName(path *ast.Path) string {
+	return path.Idents[len(path.Idents)-1].Name
+}
+
+func identNames(idents []*ast.Ident) []string {
+	names := make([]string, 0, len(idents))
+	for _, ident := range idents {
+		names = append(names, ident.Name)
+	}
+
+	return names
+}
+
+func indexKeyNames(keys []*ast.IndexKey) []string {
+	names := make([]string, 0, len(keys))
+	for _, key := range keys {
+		names = append(names, key.Name.Name)
+	}
+
+	return names
+}
+
+func storingNames(storing *ast.Storing) []string {
+	if storing == nil {
+		return nil
+	}
+
+	return identNames(storing.Columns)
+}
+
+func stringLiteral(expr ast.Expr) (string, bool) {
+	literal, ok := expr.(*ast.StringLiteral)
+	if !ok {
+		return "", false
+	}
+
+	return literal.Value, true
+}
+
+func exprSQL(expr ast.Expr) string {
+	if expr == nil {
+		return "NULL"
+	}
+
+	return expr.SQL()
+}
@@ -0,0 +1,238 @@
+package generation
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cloudspannerecosystem/memefish"
+	"github.com/google/go-cmp/cmp"
+)
+
+func applyDDL(t *testing.T, sql string) *ddlSchema {
+	t.Helper()
+
+	stmts, err := memefish.ParseStatements("", sql)
+	if err != nil {
+		t.Fatalf("memefish.ParseStatements() error = %v", err)
+	}
+
+	schema := newDDLSchema()
+	for _, stmt := range stmts {
+		if err := schema.apply(stmt); err != nil {
+			t.Fatalf("ddlSchema.apply() error = %v", err)
+		}
+	}
+
+	return schema
+}
+
+func Test_ddlSchema_tableMap(t *testing.T) {
+	t.Parallel()
+
+	schema := applyDDL(t, `
+CREATE TABLE DockingBays (
+  Id STRING(36) NOT NULL,
+  Name STRING(MAX) NOT NULL,
+) PRIMARY KEY (Id);
+
+CREATE UNIQUE INDEX DockingBaysByName ON DockingBays(Name);
+
+CREATE TABLE Ships (
+  Id STRING(36) NOT NULL,
+  Name STRING(MAX) NOT NULL,
+  DockingBayId STRING(36),
+  Status STRING(MAX) NOT NULL DEFAULT ('docked'),
+  Retired BOOL,
+  NameTokens TOKENLIST AS (TOKENIZE_FULLTEXT(Name)) HIDDEN,
+  CONSTRAINT FK_Ships_DockingBayId FOREIGN KEY (DockingBayId) REFERENCES DockingBays(Id),
+) PRIMARY KEY (Id);
+
+CREATE SEARCH INDEX ShipsSearch ON Ships(NameTokens);
+
+CREATE TABLE CargoManifests (
+  ShipId STRING(36) NOT NULL,
+  LineNumber INT64 NOT NULL,
+  Details STRING(MAX),
+) PRIMARY KEY (ShipId, LineNumber),
+  INTERLEAVE IN PARENT Ships ON DELETE CASCADE;
+
+ALTER TABLE Ships DROP COLUMN Retired;
+ALTER TABLE CargoManifests ADD COLUMN Details_HIDDEN STRING(MAX);
+ALTER TABLE CargoManifests ALTER COLUMN Details STRING(MAX) NOT NULL;
+
+CREATE VIEW ShipNames SQL SECURITY INVOKER AS SELECT Ships.Name FROM Ships;
+`)
+
+	want := map[string]*tableMetadata{
+		"DockingBays": {
+			Columns: map[string]columnMeta{
+				"Id":   {IsPrimaryKey: true, IsIndex: true, IsUniqueIndex: true},
+				"Name": {IsIndex: true, IsUniqueIndex: true, OrdinalPosition: 1},
+			},
+			PkCount: 1,
+		},
+		"Ships": {
+			Columns: map[string]columnMeta{
+				"Id":           {IsPrimaryKey: true, IsIndex: true, IsUniqueIndex: true},
+				"Name":         {OrdinalPosition: 1},
+				"DockingBayId": {IsForeignKey: true, IsNullable: true, IsIndex: true, OrdinalPosition: 2, ReferencedTable: "DockingBays", ReferencedColumn: "Id"},
+				"Status":       {HasDefault: true, OrdinalPosition: 3},
+				"NameTokens":   {IsNullable: true, IsIndex: true, OrdinalPosition: 4},
+			},
+			PkCount:      1,
+			SearchTokens: map[string]string{"Name": "NameTokens"},
+		},
+		"CargoManifests": {
+			Columns: map[string]columnMeta{
+				"ShipId":     {IsPrimaryKey: true, IsIndex: true, IsUniqueIndex: true},
+				"LineNumber": {IsPrimaryKey: true, IsIndex: true, IsUniqueIndex: true, OrdinalPosition: 1, KeyOrdinalPosition: 1},
+				"Details":    {OrdinalPosition: 2},
+			},
+			PkCount:       2,
+			IsInterleaved: true,
+			ParentTable:   "Ships",
+		},
+	}
+	if diff := cmp.Diff(want, newTableMap(schema.informationSchema())); diff != "" {
+		t.Errorf("newTableMap() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func Test_ddlSchema_foreignKeys(t *testing.T) {
+	t.Parallel()
+
+	schema := applyDDL(t, `
+CREATE TABLE Ships (
+  Id STRING(36) NOT NULL,
+  RegistryCode STRING(MAX) NOT NULL,
+) PRIMARY KEY (Id);
+
+CREATE TABLE Berths (
+  Id STRING(36) NOT NULL,
+  ShipId STRING(36) NOT NULL,
+  CONSTRAINT FK_Berths_ShipId FOREIGN KEY (ShipId) REFERENCES Ships(Id),
+) PRIMARY KEY (Id);
+
+CREATE TABLE Tugs (
+  Id STRING(36) NOT NULL,
+  BerthShipId STRING(36),
+  ShipRegistryCode STRING(MAX),
+) PRIMARY KEY (Id);
+
+ALTER TABLE Tugs ADD CONSTRAINT FK_Tugs_BerthShipId FOREIGN KEY (BerthShipId) REFERENCES Berths(ShipId);
+ALTER TABLE Tugs ADD CONSTRAINT FK_Tugs_ShipRegistryCode FOREIGN KEY (ShipRegistryCode) REFERENCES Ships(RegistryCode);
+ALTER TABLE Tugs DROP CONSTRAINT FK_Tugs_ShipRegistryCode;
+ALTER TABLE Tugs ADD CONSTRAINT FK_Tugs_RegistryCode FOREIGN KEY (ShipRegistryCode) REFERENCES Ships(RegistryCode);
+`)
+
+	tableMap := newTableMap(schema.informationSchema())
+
+	// A reference to a foreign key column is followed to the column it references.
+	if got := tableMap["Tugs"].Columns["BerthShipId"]; got.ReferencedTable != "Ships" || got.ReferencedColumn != "Id" {
+		t.Errorf("Tugs.BerthShipId references %s.%s, want Ships.Id", got.ReferencedTable, got.ReferencedColumn)
+	}
+	// Spanner backs a reference to columns without a unique index with a managed unique index.
+	if got := tableMap["Ships"].Columns["RegistryCode"]; !got.IsIndex || !got.IsUniqueIndex {
+		t.Errorf("Ships.RegistryCode IsIndex = %v, IsUniqueIndex = %v, want true, true", got.IsIndex, got.IsUniqueIndex)
+	}
+	if got := tableMap["Tugs"].Columns["ShipRegistryCode"]; !got.IsForeignKey || got.ReferencedColumn != "RegistryCode" {
+		t.Errorf("Tugs.ShipRegistryCode IsForeignKey = %v, ReferencedColumn = %q, want true, RegistryCode", got.IsForeignKey, got.ReferencedColumn)
+	}
+}
+
+func Test_ddlSchema_enumValues(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		sql     string
+		want    map[string][]*enumData
+		wantErr bool
+	}{
+		{
+			name: "inserted rows",
+			sql: `
+CREATE TABLE ShipStatuses (Id STRING(MAX) NOT NULL, Description STRING(MAX) NOT NULL) PRIMARY KEY (Id);
+CREATE TABLE Ships (Id STRING(36) NOT NULL, Name STRING(MAX)) PRIMARY KEY (Id);
+INSERT INTO ShipStatuses (Id, Description) VALUES ('launched', 'Launched'), ('docked', 'Docked');
+INSERT OR UPDATE INTO ShipStatuses (Id, Description) VALUES ('docked', 'In dock');
+INSERT OR IGNORE INTO ShipStatuses (Id, Description) VALUES ('launched', 'Ignored');
+INSERT INTO Ships (Id, Name) VALUES (GENERATE_UUID(), 'Vanta');
+`,
+			want: map[string][]*enumData{"ShipStatuses": {
+				{ID: "docked", Description: "In dock"},
+				{ID: "launched", Description: "Launched"},
+			}},
+		},
+		{
+			name: "enum table without rows",
+			sql:  `CREATE TABLE ShipStatuses (Id STRING(MAX) NOT NULL, Description STRING(MAX) NOT NULL) PRIMARY KEY (Id);`,
+			want: map[string][]*enumData{"ShipStatuses": {}},
+		},
+		{
+			name: "rows changed by DELETE",
+			sql: `
+CREATE TABLE ShipStatuses (Id STRING(MAX) NOT NULL, Description STRING(MAX) NOT NULL) PRIMARY KEY (Id);
+INSERT INTO ShipStatuses (Id, Description) VALUES ('docked', 'Docked');
+DELETE FROM ShipStatuses WHERE Id = 'docked';
+`,
+			wantErr: true,
+		},
+		{
+			name: "value that is not a literal",
+			sql: `
+CREATE TABLE ShipStatuses (Id STRING(MAX) NOT NULL, Description STRING(MAX) NOT NULL) PRIMARY KEY (Id);
+INSERT INTO ShipStatuses (Id, Description) VALUES (GENERATE_UUID(), 'Docked');
+`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := applyDDL(t, tt.sql).enumValues()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ddlSchema.enumValues() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ddlSchema.enumValues() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_parseMigrations(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"2_ships.up.sql":       "CREATE TABLE Ships (Id STRING(36) NOT NULL) PRIMARY KEY (Id);",
+		"2_ships.down.sql":     "DROP TABLE Ships;",
+		"10_ship_names.up.sql": "ALTER TABLE Ships ADD COLUMN Name STRING(MAX);",
+	}
+	for name, sql := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(sql), 0o600); err != nil {
+			t.Fatalf("os.WriteFile() error = %v", err)
+		}
+	}
+
+	schema, err := parseMigrations([]string{"file://" + dir})
+	if err != nil {
+		t.Fatalf("parseMigrations() error = %v", err)
+	}
+
+	ships := newTableMap(schema.informationSchema())["Ships"]
+	if ships == nil {
+		t.Fatal("parseMigrations() has no Ships table")
+	}
+	if _, ok := ships.Columns["Name"]; !ok {
+		t.Error("parseMigrations() did not apply version 10 after version 2")
+	}
+
+	if _, err := parseMigrations([]string{"gcs://bucket/migrations"}); err == nil {
+		t.Error("parseMigrations() error = nil for a gcs:// source, want an error")
+	}
+}
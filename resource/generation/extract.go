@@ -3,11 +3,15 @@ package generation
 import (
 	"fmt"
 	"iter"
+	"maps"
 	"slices"
+	"strconv"
 	"strings"
+	"time"
 	"unicode"
 
 	"github.com/cccteam/ccc/accesstypes"
+	"github.com/cccteam/ccc/resource"
 	"github.com/cccteam/ccc/resource/generation/parser"
 	"github.com/cccteam/ccc/resource/generation/parser/genlang"
 	"github.com/go-playground/errors/v5"
@@ -45,9 +49,10 @@ func (c *client) structsToResources(structs []*parser.Struct, validators ...stru
 			IsConsolidated: c.IsConsolidated(pStruct.Name()),
 			PkCount:        table.PkCount,
 			IsInterleaved:  table.IsInterleaved,
+			ParentTable:    table.ParentTable,
 		}
 
-		fields, err := newResourceFields(resource, pStruct, table)
+		fields, err := newResourceFields(resource, pStruct, table, c.columnTagKeys())
 		if err != nil {
 			resourceErrors = append(resourceErrors, err)
 
@@ -55,7 +60,7 @@ func (c *client) structsToResources(structs []*parser.Struct, validators ...stru
 		}
 		resource.Fields = fields
 
-		if err := validateNullability(pStruct, table); err != nil {
+		if err := validateNullability(pStruct, table, c.columnTagKeys()); err != nil {
 			resourceErrors = append(resourceErrors, err)
 
 			continue
@@ -74,9 +79,33 @@ func (c *client) structsToResources(structs []*parser.Struct, validators ...stru
 		return nil, errors.Wrapf(errors.Join(resourceErrors...), "encountered %d errors converting structs to resources", len(resourceErrors))
 	}
 
+	c.linkInterleavedResources(resources)
+
 	return resources, nil
 }
 
+// linkInterleavedResources links each interleaved resource to the resource generated for its
+// parent table. A parent table without a resource leaves the child unlinked.
+func (c *client) linkInterleavedResources(resources []*resourceInfo) {
+	byTable := make(map[string]*resourceInfo, len(resources))
+	for _, res := range resources {
+		byTable[c.pluralize(res.Name())] = res
+	}
+
+	for _, res := range resources {
+		if res.ParentTable == "" {
+			continue
+		}
+
+		parent, ok := byTable[res.ParentTable]
+		if !ok {
+			continue
+		}
+		res.Parent = parent
+		parent.Children = append(parent.Children, res)
+	}
+}
+
 // parsePermissionScopeAnnotation resolves a @permissionScope argument to one of the two
 // valid scopes.
 func parsePermissionScopeAnnotation(arg genlang.Arg) (accesstypes.PermissionScope, error) {
@@ -137,6 +166,155 @@ func resolveResourceAnnotations(res *resourceInfo, annotations genlang.StructAnn
 		res.ValidateUpdateType = string(annotations.Struct.Get(validateUpdateTypeKeyword))
 	}
 
+	if err := resolveRowPolicy(res, annotations); err != nil {
+		return err
+	}
+
+	if err := resolveQueryPolicy(res, annotations); err != nil {
+		return err
+	}
+
+	if err := resolveAuditable(res, annotations); err != nil {
+		return err
+	}
+
+	if err := resolveBatchRead(res, annotations); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// resolveRowPolicy applies a @rowPolicy annotation. The policy function returns the resource's
+// generated query clause, so the resource must have at least one field eligible for query clauses.
+func resolveRowPolicy(res *resourceInfo, annotations genlang.StructAnnotations) error {
+	if !annotations.Struct.Has(rowPolicyKeyword) {
+		return nil
+	}
+
+	if !res.IsQueryClauseEligible() {
+		return errors.Newf("@%s on %s requires at least one indexed or allow_filter field to build the policy's query clause", rowPolicyKeyword, res.Name())
+	}
+
+	res.RowPolicyFunc = string(annotations.Struct.Get(rowPolicyKeyword))
+
+	return nil
+}
+
+// resolveQueryPolicy applies a @queryPolicy annotation, a comma separated list of maxLimit=N,
+// maxOffset=N, timeout=<duration>, requireIndexedFilter and requireIndexedSort.
+func resolveQueryPolicy(res *resourceInfo, annotations genlang.StructAnnotations) error {
+	if !annotations.Struct.Has(queryPolicyKeyword) {
+		return nil
+	}
+
+	var errs []error
+	for option := range strings.SplitSeq(string(annotations.Struct.Get(queryPolicyKeyword)), ",") {
+		name, value, hasValue := strings.Cut(option, "=")
+		name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+
+		var err error
+		switch name {
+		case "maxLimit":
+			res.QueryPolicy.MaxLimit, err = parseQueryPolicyCount(name, value)
+		case "maxOffset":
+			res.QueryPolicy.MaxOffset, err = parseQueryPolicyCount(name, value)
+		case "timeout":
+			if res.QueryPolicy.Timeout, err = time.ParseDuration(value); err != nil || res.QueryPolicy.Timeout <= 0 {
+				err = errors.Newf("timeout must be a positive duration such as 5s, got %q", value)
+			}
+		case "requireIndexedFilter":
+			if hasValue {
+				err = errors.Newf("requireIndexedFilter does not take a value, got %q", value)
+			} else if !slices.ContainsFunc(res.Fields, func(f *resourceField) bool { return f.IsIndex || f.IsUniqueIndex }) {
+				err = errors.New("requireIndexedFilter requires at least one indexed field")
+			}
+			res.QueryPolicy.RequireIndexedFilter = true
+		case "requireIndexedSort":
+			if hasValue {
+				err = errors.Newf("requireIndexedSort does not take a value, got %q", value)
+			}
+			res.QueryPolicy.RequireIndexedSort = true
+		default:
+			err = errors.Newf("unknown option %q, must be one of maxLimit, maxOffset, timeout, requireIndexedFilter or requireIndexedSort", name)
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.Wrapf(errors.Join(errs...), "@%s on %s", queryPolicyKeyword, res.Name())
+	}
+
+	return nil
+}
+
+// parseQueryPolicyCount parses the positive integer value of a @queryPolicy option.
+func parseQueryPolicyCount(name, value string) (uint64, error) {
+	n, err := strconv.ParseUint(value, 10, 64)
+	if err != nil || n == 0 {
+		return 0, errors.Newf("%s must be a positive integer, got %q", name, value)
+	}
+
+	return n, nil
+}
+
+// resolveAuditable applies an @auditable annotation. The resource's audit columns are populated
+// through the default-func machinery, so they must have the expected types and must not declare
+// their own default functions.
+func resolveAuditable(res *resourceInfo, annotations genlang.StructAnnotations) error {
+	if !annotations.Struct.Has(auditableKeyword) {
+		return nil
+	}
+
+	var found bool
+	var errs []error
+	for _, field := range res.Fields {
+		col, ok := auditColumns[field.Name()]
+		if !ok {
+			continue
+		}
+		found = true
+
+		if field.DerefType() != col.derefType {
+			errs = append(errs, errors.Newf("audit column %s must be a %s or *%s, found %s", field.Name(), col.derefType, col.derefType, field.Type()))
+		}
+		if field.HasTag(defaultCreateFnTagKey) || field.HasTag(outputOnlyUpdateFnTagKey) {
+			errs = append(errs, errors.Newf("audit column %s cannot use the %s or %s tags", field.Name(), defaultCreateFnTagKey, outputOnlyUpdateFnTagKey))
+		}
+	}
+
+	if !found {
+		errs = append(errs, errors.Newf("requires at least one of the audit columns %s", strings.Join(slices.Sorted(maps.Keys(auditColumns)), ", ")))
+	}
+
+	if len(errs) > 0 {
+		return errors.Wrapf(errors.Join(errs...), "@%s on %s", auditableKeyword, res.Name())
+	}
+
+	res.IsAuditable = true
+
+	return nil
+}
+
+// resolveBatchRead applies a @batchRead annotation. The batch-read handler takes a list of IDs
+// and authorizes with the read handler's permission Set, so the resource must have a single
+// column primary key and a generated read handler.
+func resolveBatchRead(res *resourceInfo, annotations genlang.StructAnnotations) error {
+	if !annotations.Struct.Has(batchReadKeyword) {
+		return nil
+	}
+
+	if res.HasCompoundPrimaryKey() {
+		return errors.Newf("@%s on %s is not supported for a compound primary key", batchReadKeyword, res.Name())
+	}
+	if !slices.Contains(resourceEndpoints(res), ReadHandler) {
+		return errors.Newf("@%s on %s requires the %s", batchReadKeyword, res.Name(), ReadHandler)
+	}
+
+	res.HasBatchRead = true
+
 	return nil
 }
 
@@ -219,7 +397,7 @@ func (c *client) structsToVirtualResources(structs []*parser.Struct, validators
 			IsVirtual: true,
 		}
 
-		fields, err := newVirtualFields(resource, pStruct)
+		fields, err := newVirtualFields(resource, pStruct, c.columnTagKeys())
 		if err != nil {
 			errs = append(errs, err)
 
@@ -227,7 +405,7 @@ func (c *client) structsToVirtualResources(structs []*parser.Struct, validators
 		}
 		resource.Fields = fields
 
-		nullableFields, err := fieldNullability(pStruct)
+		nullableFields, err := fieldNullability(pStruct, c.columnTagKeys())
 		if err != nil {
 			errs = append(errs, err)
 
@@ -235,8 +413,8 @@ func (c *client) structsToVirtualResources(structs []*parser.Struct, validators
 		}
 
 		for _, field := range resource.Fields {
-			spannerTag, _ := field.LookupTag(spannerTagKey)
-			nullability, ok := nullableFields[spannerTag]
+			column, _ := columnTag(field.Field, c.columnTagKeys())
+			nullability, ok := nullableFields[column]
 			if !ok {
 				continue
 			}
@@ -266,6 +444,18 @@ func (c *client) structsToVirtualResources(structs []*parser.Struct, validators
 			continue
 		}
 
+		if err := resolveRowPolicy(resource, annotations); err != nil {
+			errs = append(errs, err)
+
+			continue
+		}
+
+		if err := resolveQueryPolicy(resource, annotations); err != nil {
+			errs = append(errs, err)
+
+			continue
+		}
+
 		resources = append(resources, resource)
 	}
 
@@ -276,21 +466,21 @@ func (c *client) structsToVirtualResources(structs []*parser.Struct, validators
 	return resources, nil
 }
 
-func newResourceFields(parent *resourceInfo, pStruct *parser.Struct, table *tableMetadata) ([]*resourceField, error) {
+func newResourceFields(parent *resourceInfo, pStruct *parser.Struct, table *tableMetadata, columnTagKeys []string) ([]*resourceField, error) {
 	if parent.IsVirtual {
 		panic("newResourceFields cannot be used with virtual resources")
 	}
 	fields := make([]*resourceField, 0, len(pStruct.Fields()))
 	for _, field := range pStruct.Fields() {
-		spannerTag, ok := field.LookupTag(spannerTagKey)
+		column, ok := columnTag(field, columnTagKeys)
 		if !ok {
-			field.AddError("missing spanner tag")
+			field.AddError(fmt.Sprintf("missing %s tag", columnTagNames(columnTagKeys)))
 
 			continue
 		}
-		tableColumn, ok := table.Columns[spannerTag]
+		tableColumn, ok := table.Columns[column]
 		if !ok {
-			field.AddError("spanner tag does not match any table columns")
+			field.AddError(fmt.Sprintf("%s tag does not match any table columns", columnTagNames(columnTagKeys)))
 
 			continue
 		}
@@ -300,7 +490,7 @@ func newResourceFields(parent *resourceInfo, pStruct *parser.Struct, table *tabl
 			continue
 		}
 
-		fields = append(fields, &resourceField{
+		rf := &resourceField{
 			Field:              field,
 			Parent:             parent,
 			IsPrimaryKey:       tableColumn.IsPrimaryKey,
@@ -313,7 +503,12 @@ func newResourceFields(parent *resourceInfo, pStruct *parser.Struct, table *tabl
 			ReferencedResource: tableColumn.ReferencedTable,
 			ReferencedField:    tableColumn.ReferencedColumn,
 			HasDefault:         tableColumn.HasDefault,
-		})
+			SearchToken:        table.SearchTokens[column],
+		}
+		checkMaskTag(rf)
+		checkEncryptedCondition(rf)
+
+		fields = append(fields, rf)
 	}
 
 	if pStruct.HasErrors() {
@@ -323,25 +518,29 @@ func newResourceFields(parent *resourceInfo, pStruct *parser.Struct, table *tabl
 	return fields, nil
 }
 
-func newVirtualFields(parent *resourceInfo, pStruct *parser.Struct) ([]*resourceField, error) {
+func newVirtualFields(parent *resourceInfo, pStruct *parser.Struct, columnTagKeys []string) ([]*resourceField, error) {
 	if !parent.IsVirtual {
 		panic("newVirtualFields cannot be used with concrete resources")
 	}
 	fields := make([]*resourceField, 0, len(pStruct.Fields()))
 	for _, field := range pStruct.Fields() {
-		_, ok := field.LookupTag(spannerTagKey)
+		_, ok := columnTag(field, columnTagKeys)
 		if !ok {
-			field.AddError("missing spanner tag")
+			field.AddError(fmt.Sprintf("missing %s tag", columnTagNames(columnTagKeys)))
 
 			continue
 		}
 
-		fields = append(fields, &resourceField{
+		rf := &resourceField{
 			Field:         field,
 			Parent:        parent,
 			IsIndex:       field.HasTag(indexTagKey) || field.HasTag(uniqueIndexTagKey),
 			IsUniqueIndex: field.HasTag(uniqueIndexTagKey),
-		})
+		}
+		checkMaskTag(rf)
+		checkEncryptedCondition(rf)
+
+		fields = append(fields, rf)
 	}
 
 	if pStruct.HasErrors() {
@@ -351,6 +550,49 @@ func newVirtualFields(parent *resourceInfo, pStruct *parser.Struct) ([]*resource
 	return fields, nil
 }
 
+// checkMaskTag records a field error when a mask tag does not name a known strategy or is
+// not on a pii string field.
+func checkMaskTag(field *resourceField) {
+	strategy, ok := field.LookupTag(maskTagKey)
+	if !ok {
+		return
+	}
+
+	if _, err := resource.ParseMaskStrategy(strategy); err != nil {
+		field.AddError(err.Error())
+	}
+	if !field.IsPII() {
+		field.AddError(fmt.Sprintf("mask tag requires the %s condition", piiCondition))
+	}
+	if field.DerefType() != "string" {
+		field.AddError("mask tag is only supported on string and *string fields")
+	}
+}
+
+// checkEncryptedCondition records a field error when an encrypted field is not a string,
+// or is a key, filterable or sortable: encrypted values can't be compared in the database.
+func checkEncryptedCondition(field *resourceField) {
+	if !field.IsEncrypted() {
+		return
+	}
+
+	if field.DerefType() != "string" {
+		field.AddError("encrypted condition is only supported on string and *string fields")
+	}
+	if field.IsPrimaryKey || field.IsIndex || field.IsUniqueIndex {
+		field.AddError("encrypted condition cannot be used on a primary key or indexed field")
+	}
+	if field.HasTag(allowFilterTagKey) {
+		field.AddError("encrypted condition cannot be used with the allow_filter tag")
+	}
+	if field.HasTag(allowSortTagKey) {
+		field.AddError("encrypted condition cannot be used with the allow_sort tag")
+	}
+	if field.SearchToken != "" {
+		field.AddError("encrypted condition cannot be used on a field tokenized for a search index")
+	}
+}
+
 func (c *client) structsToRPCMethods(structs []*parser.Struct, validators ...structValidator) ([]*rpcMethodInfo, error) {
 	rpcMethods := make([]*rpcMethodInfo, 0, len(structs))
 	var errs []error
@@ -385,6 +627,13 @@ func (c *client) structsToRPCMethods(structs []*parser.Struct, validators ...str
 				}
 				field.enumeratedResource = &enumeratedResource
 			}
+			if conditions, hasConditions := field.LookupTag(conditionsTagKey); hasConditions {
+				for condition := range strings.SplitSeq(conditions, ",") {
+					if condition != outputOnlyCondition {
+						field.AddError(fmt.Sprintf("condition %q is not supported on an RPC field: only %q marks a response field", condition, outputOnlyCondition))
+					}
+				}
+			}
 
 			rpcMethod.Fields = append(rpcMethod.Fields, &field)
 		}
@@ -396,6 +645,7 @@ func (c *client) structsToRPCMethods(structs []*parser.Struct, validators ...str
 		}
 
 		rpcMethod.SuppressHandler = annotations.Struct.Has(suppressKeyword)
+		rpcMethod.Async = annotations.Struct.Has(asyncKeyword)
 
 		if err := resolvePermissionScope(annotations, &rpcMethod.PermissionScope); err != nil {
 			errs = append(errs, errors.Wrapf(err, "on %s", s.Name()))
@@ -478,17 +728,17 @@ func structsToCompResources(structs []*parser.Struct, validators ...structValida
 	return compResources, nil
 }
 
-func validateNullability(pStruct *parser.Struct, table *tableMetadata) error {
-	nullableFields, err := fieldNullability(pStruct)
+func validateNullability(pStruct *parser.Struct, table *tableMetadata, columnTagKeys []string) error {
+	nullableFields, err := fieldNullability(pStruct, columnTagKeys)
 	if err != nil {
 		return err
 	}
 
 	var errRows []string
 	for _, field := range pStruct.Fields() {
-		spannerTag, _ := field.LookupTag(spannerTagKey)
-		if nullableFields[spannerTag] != table.Columns[spannerTag].IsNullable {
-			errRow := fmt.Sprintf("| %-32s | %13t | %15t |", spannerTag, nullableFields[spannerTag], table.Columns[spannerTag].IsNullable)
+		column, _ := columnTag(field, columnTagKeys)
+		if nullableFields[column] != table.Columns[column].IsNullable {
+			errRow := fmt.Sprintf("| %-32s | %13t | %15t |", column, nullableFields[column], table.Columns[column].IsNullable)
 			errRows = append(errRows, errRow)
 		}
 	}
@@ -512,11 +762,11 @@ func validateNullability(pStruct *parser.Struct, table *tableMetadata) error {
 	return nil
 }
 
-func fieldNullability(pStruct *parser.Struct) (map[string]bool, error) {
+func fieldNullability(pStruct *parser.Struct, columnTagKeys []string) (map[string]bool, error) {
 	nullableFields := make(map[string]bool)
 	var missingTags []string
 	for _, field := range pStruct.Fields() {
-		spannerTag, ok := field.LookupTag(spannerTagKey)
+		column, ok := columnTag(field, columnTagKeys)
 		if !ok {
 			missingTags = append(missingTags, field.Name())
 		}
@@ -534,19 +784,26 @@ func fieldNullability(pStruct *parser.Struct) (map[string]bool, error) {
 			"spanner.NullBool", "spanner.NullDate", "spanner.NullFloat32", "spanner.NullFloat64", "spanner.NullInt64", "spanner.NullJSON", "spanner.NullNumeric", "spanner.NullString", "spanner.NullTime",
 			"*civil.Date",
 		}, field.Type()) {
-			nullableFields[spannerTag] = true
+			nullableFields[column] = true
 
 			continue
 		}
 
 		if field.IsPointer() {
-			nullableFields[spannerTag] = true
+			nullableFields[column] = true
 
 			continue
 		}
 
 		if name := field.DerefUnqualifiedType(); strings.HasPrefix(name, "Null") && unicode.IsUpper(rune(name[4])) {
-			nullableFields[spannerTag] = true
+			nullableFields[column] = true
+
+			continue
+		}
+
+		// Every pgtype type carries a Valid flag, so all of them hold NULL.
+		if strings.HasPrefix(field.Type(), "pgtype.") {
+			nullableFields[column] = true
 
 			continue
 		}
@@ -561,8 +818,25 @@ func fieldNullability(pStruct *parser.Struct) (map[string]bool, error) {
 			msg.WriteString(missingTags[i])
 		}
 
-		return nil, errors.Newf("struct %s fields missing spanner tags: [%s]", pStruct.Name(), msg.String())
+		return nil, errors.Newf("struct %s fields missing %s tags: [%s]", pStruct.Name(), columnTagNames(columnTagKeys), msg.String())
 	}
 
 	return nullableFields, nil
 }
+
+// columnTag returns the column a field maps to: the value of the first of columnTagKeys the
+// field is tagged with.
+func columnTag(field *parser.Field, columnTagKeys []string) (string, bool) {
+	for _, key := range columnTagKeys {
+		if column, ok := field.LookupTag(key); ok {
+			return column, true
+		}
+	}
+
+	return "", false
+}
+
+// columnTagNames names the column tag keys for error messages, e.g. "postgres or db".
+func columnTagNames(columnTagKeys []string) string {
+	return strings.Join(columnTagKeys, " or ")
+}
@@ -0,0 +1,172 @@
+package generation
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ettle/strcase"
+	"github.com/go-playground/errors/v5"
+)
+
+// runGoClientGeneration writes the Go client of the generated routes: a file with the Client
+// and the plumbing its requests share, a file per routed resource and computed resource, and
+// a file per RPC method.
+func (r *resourceGenerator) runGoClientGeneration() error {
+	begin := time.Now()
+
+	if err := os.MkdirAll(r.goClient.Dir(), 0o755); err != nil {
+		return errors.Wrapf(err, "os.MkdirAll(): dir: %s", r.goClient.Dir())
+	}
+	if err := r.removeGeneratedFiles(r.goClient.Dir(), prefix); err != nil {
+		return err
+	}
+
+	data := r.goClientData()
+	destinationFile := filepath.Join(r.goClient.Dir(), generatedGoFileName(goClientOutputName))
+	if err := r.writeFormattedGoFile(destinationFile, "goClientTemplate", goClientTemplate, data); err != nil {
+		return errors.Wrap(err, "writeFormattedGoFile()")
+	}
+
+	for _, res := range data.Resources {
+		fileName := generatedGoFileName(strings.ToLower(caser.ToSnake(res.Plural)))
+		if err := r.writeFormattedGoFile(filepath.Join(r.goClient.Dir(), fileName), "goClientResourceTemplate:"+res.Name, goClientResourceTemplate, &goClientResourceData{
+			Source:   r.resource.Dir(),
+			Package:  r.goClient.Package(),
+			Resource: res,
+		}); err != nil {
+			return errors.Wrap(err, "writeFormattedGoFile()")
+		}
+	}
+
+	for _, method := range data.RPCMethods {
+		fileName := generatedGoFileName(strings.ToLower(caser.ToSnake(method.Name())))
+		if err := r.writeFormattedGoFile(filepath.Join(r.goClient.Dir(), fileName), "goClientRPCTemplate:"+method.Name(), goClientRPCTemplate, &goClientRPCData{
+			Source:    r.rpc.Dir(),
+			Package:   r.goClient.Package(),
+			RPCMethod: method,
+		}); err != nil {
+			return errors.Wrap(err, "writeFormattedGoFile()")
+		}
+	}
+
+	log.Printf("Generated Go client in %s: %s\n", time.Since(begin), r.goClient.Dir())
+
+	return nil
+}
+
+// goClientData returns the resources, computed resources and RPC methods the generated
+// routes serve, in the shape of the client's functions.
+func (r *resourceGenerator) goClientData() *goClientData {
+	data := &goClientData{
+		Source:            r.resource.Dir(),
+		Package:           r.goClient.Package(),
+		ConsolidatedRoute: "/" + r.ConsolidatedRoute,
+	}
+
+	// A consolidated resource is patched through PatchResources even when its own routes are
+	// disabled, so it still gets a file for its row and patch types.
+	hasConsolidatedRoute := slices.ContainsFunc(r.resources, func(res *resourceInfo) bool { return !res.RoutingDisabled() && hasConsolidatedHandler(res) })
+	for _, res := range r.resources {
+		var handlers []HandlerType
+		if !res.RoutingDisabled() {
+			handlers = resourceEndpoints(res)
+		}
+		consolidated := hasConsolidatedRoute && res.IsConsolidated
+		if len(handlers) == 0 && !consolidated {
+			continue
+		}
+
+		c := r.goClientResource(res, handlers)
+		c.Consolidated = consolidated
+		data.Resources = append(data.Resources, c)
+		if consolidated {
+			data.Consolidated = append(data.Consolidated, c)
+		}
+	}
+
+	for _, res := range r.computedResources {
+		if res.RoutingDisabled() {
+			continue
+		}
+
+		data.Resources = append(data.Resources, r.goClientComputedResource(res))
+	}
+
+	if r.genRPCMethods {
+		for _, method := range r.rpcMethods {
+			if !method.SuppressHandler {
+				data.RPCMethods = append(data.RPCMethods, method)
+			}
+		}
+	}
+
+	return data
+}
+
+func (r *resourceGenerator) goClientResource(res *resourceInfo, handlers []HandlerType) *goClientResource {
+	plural := r.pluralize(res.Name())
+	c := &goClientResource{
+		Name:       res.Name(),
+		Plural:     plural,
+		Route:      "/" + strcase.ToKebab(plural),
+		Handlers:   handlers,
+		ReturnsIDs: res.PrimaryKeyIsGeneratedUUID(),
+		Query:      res,
+		imports:    resourceTypeImports(nil, res),
+	}
+
+	for _, field := range res.Fields {
+		f := goClientField{Name: field.Name(), JSONName: strcase.ToCamel(field.Name()), Type: field.Type(), Immutable: field.IsImmutable()}
+		if !field.IsInputOnly() {
+			c.Fields = append(c.Fields, f)
+		}
+		if !field.IsPrimaryKey && !field.IsOutputOnly() {
+			c.PatchFields = append(c.PatchFields, f)
+		}
+	}
+
+	for _, field := range res.PrimaryKeyFields() {
+		c.Keys = append(c.Keys, goClientField{Name: field.Name(), Param: strcase.ToGoCamel(field.Name()), Type: field.Type()})
+	}
+
+	if res.Parent != nil {
+		c.ParentName = res.Parent.Name()
+		c.ParentRoute = "/" + strcase.ToKebab(r.pluralize(res.Parent.Name()))
+		for _, field := range res.Parent.PrimaryKeyFields() {
+			c.ParentKeys = append(c.ParentKeys, goClientField{Name: field.Name(), Param: strcase.ToGoCamel(res.Parent.Name() + field.Name()), Type: field.Type()})
+		}
+		c.ChildKeys = c.Keys[len(c.ParentKeys):]
+	}
+
+	return c
+}
+
+func (r *resourceGenerator) goClientComputedResource(res *computedResource) *goClientResource {
+	plural := r.pluralize(res.Name())
+	c := &goClientResource{
+		Name:    res.Name(),
+		Plural:  plural,
+		Route:   "/" + strcase.ToKebab(plural),
+		imports: appendTypeImports(nil, res.Imports()),
+	}
+	if !res.SuppressListHandler {
+		c.Handlers = append(c.Handlers, ListHandler)
+	}
+	if !res.SuppressReadHandler {
+		c.Handlers = append(c.Handlers, ReadHandler)
+	}
+
+	for _, field := range res.Fields {
+		c.Fields = append(c.Fields, goClientField{Name: field.Name(), JSONName: strcase.ToCamel(field.Name()), Type: field.Type()})
+		c.imports = appendTypeImports(c.imports, field.Imports())
+	}
+	for _, field := range res.PrimaryKeys() {
+		c.Keys = append(c.Keys, goClientField{Name: field.Name(), Param: strcase.ToGoCamel(field.Name()), Type: field.Type()})
+	}
+
+	return c
+}
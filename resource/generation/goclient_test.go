@@ -0,0 +1,90 @@
+package generation
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_goClientData(t *testing.T) {
+	t.Parallel()
+
+	r := collectionFixtureGenerator(t)
+	r.ConsolidatedRoute = "resources"
+	data := r.goClientData()
+
+	handlers := make(map[string][]HandlerType)
+	for _, res := range data.Resources {
+		handlers[res.Name] = res.Handlers
+	}
+	wantHandlers := map[string][]HandlerType{
+		"Fossil":   nil, // routing disabled, but patched through the consolidated route
+		"Gadget":   {ListHandler},
+		"Sprocket": {ListHandler, ReadHandler},
+		"Widget":   {ListHandler, ReadHandler, PatchHandler},
+		"Summary":  {ListHandler, ReadHandler},
+	}
+	if diff := cmp.Diff(wantHandlers, handlers); diff != "" {
+		t.Errorf("goClientData() resources mismatch (-want +got):\n%s", diff)
+	}
+
+	var consolidated []string
+	for _, res := range data.Consolidated {
+		consolidated = append(consolidated, res.Name)
+	}
+	if diff := cmp.Diff([]string{"Fossil", "Sprocket"}, consolidated); diff != "" {
+		t.Errorf("goClientData() consolidated mismatch (-want +got):\n%s", diff)
+	}
+	if data.ConsolidatedRoute != "/resources" {
+		t.Errorf("goClientData() ConsolidatedRoute = %q, want %q", data.ConsolidatedRoute, "/resources")
+	}
+
+	var methods []string
+	for _, method := range data.RPCMethods {
+		methods = append(methods, method.Name())
+	}
+	if diff := cmp.Diff([]string{"DoSomething"}, methods); diff != "" {
+		t.Errorf("goClientData() RPC methods mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func Test_goClientResource_fields(t *testing.T) {
+	t.Parallel()
+
+	r := collectionFixtureGenerator(t)
+	var widget *goClientResource
+	for _, res := range r.goClientData().Resources {
+		if res.Name == "Widget" {
+			widget = res
+		}
+	}
+	if widget == nil {
+		t.Fatal("goClientData() has no Widget")
+	}
+
+	names := func(fields []goClientField) []string {
+		var names []string
+		for _, field := range fields {
+			names = append(names, field.JSONName)
+		}
+
+		return names
+	}
+	// Secret is input_only: sent in a patch, never returned.
+	if diff := cmp.Diff([]string{"id", "name", "listedName", "code", "derived", "badge"}, names(widget.Fields)); diff != "" {
+		t.Errorf("Fields mismatch (-want +got):\n%s", diff)
+	}
+	// Derived is output_only and ID is the key: neither is set by a patch.
+	if diff := cmp.Diff([]string{"name", "listedName", "code", "secret", "badge"}, names(widget.PatchFields)); diff != "" {
+		t.Errorf("PatchFields mismatch (-want +got):\n%s", diff)
+	}
+	if got, want := widget.Route, "/widgets"; got != want {
+		t.Errorf("Route = %q, want %q", got, want)
+	}
+	if got, want := widget.KeyParams(), "id ccc.UUID"; got != want {
+		t.Errorf("KeyParams() = %q, want %q", got, want)
+	}
+	if !widget.Patchable() {
+		t.Error("Patchable() = false, want true")
+	}
+}
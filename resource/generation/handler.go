@@ -12,7 +12,7 @@ import (
 )
 
 func (r *resourceGenerator) runHandlerGeneration() error {
-	if err := removeGeneratedFiles(r.handler.Dir(), prefix); err != nil {
+	if err := r.removeGeneratedFiles(r.handler.Dir(), prefix); err != nil {
 		return errors.Wrap(err, "removeGeneratedFiles()")
 	}
 
@@ -35,6 +35,12 @@ func (r *resourceGenerator) runHandlerGeneration() error {
 		if err := forEachGo(rpcMethods, r.generateRPCHandler); err != nil {
 			return err
 		}
+
+		if asyncMethods := r.asyncRPCMethods(); len(asyncMethods) > 0 {
+			if err := r.generateRPCOperationHandler(asyncMethods); err != nil {
+				return errors.Wrap(err, "generateRPCOperationHandler()")
+			}
+		}
 	}
 
 	if r.genComputedResources {
@@ -60,6 +66,14 @@ func (r *resourceGenerator) runHandlerGeneration() error {
 }
 
 func (r *resourceGenerator) generateHandlers(res *resourceInfo) error {
+	fileName := generatedGoFileName(strings.ToLower(caser.ToSnake(r.pluralize(res.Name()))))
+	destinationFilePath := filepath.Join(r.handler.Dir(), fileName)
+	if !r.scope.includesResource(r.client, res) {
+		r.keepGeneratedFile(destinationFilePath)
+
+		return nil
+	}
+
 	handlerTypes := resourceEndpoints(res)
 
 	handlerData := make([][]byte, 0, len(handlerTypes))
@@ -74,9 +88,6 @@ func (r *resourceGenerator) generateHandlers(res *resourceInfo) error {
 
 	if len(handlerData) > 0 {
 		begin := time.Now()
-		fileName := generatedGoFileName(strings.ToLower(caser.ToSnake(r.pluralize(res.Name()))))
-		destinationFilePath := filepath.Join(r.handler.Dir(), fileName)
-
 		if err := r.writeFormattedGoFile(destinationFilePath, "handlers", handlerHeaderTemplate, &handlersFileData{
 			Source:              r.resource.Dir(),
 			LocalPackageImports: r.localPackageImports(),
@@ -138,9 +149,27 @@ func (c *client) handlerName(structName string, handlerType HandlerType) string
 		functionName = structName
 	case PatchHandler:
 		functionName = "Patch" + c.pluralize(structName)
+	case BatchReadHandler:
+		functionName = "BatchRead" + c.pluralize(structName)
 	default:
 		panic(fmt.Sprintf("unexpected HandlerType: %q", handlerType))
 	}
 
 	return functionName
 }
+
+// nestedHandlerName returns the name of an interleaved child's handler routed under its parent,
+// prefixed with the parent's name (e.g. ShipCargoManifests).
+func (c *client) nestedHandlerName(res *resourceInfo, handlerType HandlerType) string {
+	var functionName string
+	switch handlerType {
+	case NestedListHandler:
+		functionName = res.Parent.Name() + c.pluralize(res.Name())
+	case NestedPatchHandler:
+		functionName = "Patch" + res.Parent.Name() + c.pluralize(res.Name())
+	default:
+		panic(fmt.Sprintf("unexpected nested HandlerType: %q", handlerType))
+	}
+
+	return functionName
+}
@@ -0,0 +1,838 @@
+package generation
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"cloud.google.com/go/spanner"
+	"github.com/cccteam/ccc"
+	"github.com/cccteam/ccc/accesstypes"
+	"github.com/cccteam/ccc/resource"
+	"github.com/cccteam/ccc/resource/generation/parser"
+	"github.com/ettle/strcase"
+	"github.com/go-playground/errors/v5"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	openAPIVersion   = "3.1.0"
+	jsonMediaType    = "application/json"
+	linkSchemaName   = "Link"
+	componentsSchema = "#/components/schemas/"
+
+	rpcOperationSchemaName         = "RPCOperation"
+	rpcOperationAcceptedSchemaName = "RPCOperationAccepted"
+)
+
+// openAPIDocument is the part of an OpenAPI 3.1 document the generator writes. Maps are
+// marshaled with sorted keys, so the document is the same from one run to the next.
+type openAPIDocument struct {
+	OpenAPI    string                     `json:"openapi"`
+	Info       openAPIInfo                `json:"info"`
+	Paths      map[string]openAPIPathItem `json:"paths"`
+	Components openAPIComponents          `json:"components"`
+}
+
+type openAPIInfo struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Version     string `json:"version"`
+}
+
+// openAPIPathItem maps a lower case HTTP method to the operation on a path.
+type openAPIPathItem map[string]*openAPIOperation
+
+type openAPIOperation struct {
+	OperationID string                     `json:"operationId"`
+	Tags        []string                   `json:"tags"`
+	Summary     string                     `json:"summary"`
+	Parameters  []*openAPIParameter        `json:"parameters,omitempty"`
+	RequestBody *openAPIRequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]openAPIResponse `json:"responses"`
+	Permissions []openAPIPermission        `json:"x-permissions"`
+}
+
+// openAPIPermission is a permission an operation requires, written in the x-permissions
+// extension. A patch requires Create, Update or Delete depending on each operation's op.
+type openAPIPermission struct {
+	Resource    accesstypes.Resource        `json:"resource"`
+	Scope       accesstypes.PermissionScope `json:"scope"`
+	Permissions []accesstypes.Permission    `json:"permissions"`
+}
+
+type openAPIParameter struct {
+	Name        string      `json:"name"`
+	In          string      `json:"in"`
+	Description string      `json:"description,omitempty"`
+	Required    bool        `json:"required,omitempty"`
+	Schema      *jsonSchema `json:"schema"`
+}
+
+type openAPIRequestBody struct {
+	Required bool                        `json:"required,omitempty"`
+	Content  map[string]openAPIMediaType `json:"content"`
+}
+
+type openAPIMediaType struct {
+	Schema *jsonSchema `json:"schema"`
+}
+
+type openAPIResponse struct {
+	Description string                      `json:"description"`
+	Content     map[string]openAPIMediaType `json:"content,omitempty"`
+}
+
+type openAPIComponents struct {
+	Schemas map[string]*jsonSchema `json:"schemas"`
+}
+
+// schemaTypes is the JSON Schema type keyword, marshaled as a string when it holds one type.
+type schemaTypes []string
+
+func (t schemaTypes) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+
+	return json.Marshal([]string(t))
+}
+
+// jsonSchema is the part of JSON Schema the generator writes. The x- extensions carry the
+// field's permissions, pii condition, mask strategy and immutability.
+type jsonSchema struct {
+	Ref                  string                   `json:"$ref,omitempty"`
+	Type                 schemaTypes              `json:"type,omitempty"`
+	Format               string                   `json:"format,omitempty"`
+	Description          string                   `json:"description,omitempty"`
+	Const                string                   `json:"const,omitempty"`
+	Default              *uint64                  `json:"default,omitempty"`
+	Maximum              *uint64                  `json:"maximum,omitempty"`
+	Items                *jsonSchema              `json:"items,omitempty"`
+	Properties           map[string]*jsonSchema   `json:"properties,omitempty"`
+	AdditionalProperties *jsonSchema              `json:"additionalProperties,omitempty"`
+	Required             []string                 `json:"required,omitempty"`
+	OneOf                []*jsonSchema            `json:"oneOf,omitempty"`
+	ReadOnly             bool                     `json:"readOnly,omitempty"`
+	Permissions          []accesstypes.Permission `json:"x-permissions,omitempty"`
+	PII                  bool                     `json:"x-pii,omitempty"`
+	Mask                 string                   `json:"x-mask,omitempty"`
+	Immutable            bool                     `json:"x-immutable,omitempty"`
+}
+
+func refSchema(name string) *jsonSchema {
+	return &jsonSchema{Ref: componentsSchema + name}
+}
+
+func arraySchema(items *jsonSchema) *jsonSchema {
+	return &jsonSchema{Type: schemaTypes{"array"}, Items: items}
+}
+
+func stringSchema(description string) *jsonSchema {
+	return &jsonSchema{Type: schemaTypes{"string"}, Description: description}
+}
+
+// openAPITypeSchemas maps a Go type name, without any pointer or slice, to the JSON Schema of
+// its encoding. Any other type is described as a string, as in the TypeScript output.
+func openAPITypeSchemas() map[string]jsonSchema {
+	str := func(format string) jsonSchema { return jsonSchema{Type: schemaTypes{"string"}, Format: format} }
+	integer := func(format string) jsonSchema { return jsonSchema{Type: schemaTypes{"integer"}, Format: format} }
+	number := func(format string) jsonSchema { return jsonSchema{Type: schemaTypes{"number"}, Format: format} }
+	boolean := jsonSchema{Type: schemaTypes{booleanStr}}
+	link := jsonSchema{Ref: componentsSchema + linkSchemaName}
+
+	return map[string]jsonSchema{
+		stringGoType:                             str(""),
+		boolGoType:                               boolean,
+		intGoType:                                integer("int64"),
+		int8GoType:                               integer("int32"),
+		int16GoType:                              integer("int32"),
+		int32GoType:                              integer("int32"),
+		int64GoType:                              integer("int64"),
+		uintGoType:                               integer("int64"),
+		uint8GoType:                              integer("int32"),
+		uint16GoType:                             integer("int32"),
+		uint32GoType:                             integer("int64"),
+		uint64GoType:                             integer("int64"),
+		float32GoType:                            number("float"),
+		float64GoType:                            number("double"),
+		reflect.TypeFor[ccc.UUID]().String():     str("uuid"),
+		reflect.TypeFor[ccc.NullUUID]().String(): str("uuid"),
+		reflect.TypeFor[time.Time]().String():    str("date-time"),
+		reflect.TypeFor[civil.Date]().String():   str("date"),
+		reflect.TypeFor[decimal.Decimal]().String():     number(""),
+		reflect.TypeFor[decimal.NullDecimal]().String(): number(""),
+		reflect.TypeFor[resource.Link]().String():       link,
+		reflect.TypeFor[resource.NullLink]().String():   link,
+		reflect.TypeFor[spanner.NullString]().String():  str(""),
+		reflect.TypeFor[spanner.NullInt64]().String():   integer("int64"),
+		reflect.TypeFor[spanner.NullFloat64]().String(): number("double"),
+		reflect.TypeFor[spanner.NullBool]().String():    boolean,
+		reflect.TypeFor[spanner.NullNumeric]().String(): str("decimal"),
+		reflect.TypeFor[spanner.NullTime]().String():    str("date-time"),
+		reflect.TypeFor[spanner.NullDate]().String():    str("date"),
+		reflect.TypeFor[spanner.NullJSON]().String():    {},
+	}
+}
+
+// fieldSchema returns the JSON Schema of a field's Go type. A slice other than []byte is an
+// array of its elements, and a nullable or pointer field also accepts null.
+func fieldSchema(field *parser.Field, nullable bool) *jsonSchema {
+	schema := &jsonSchema{Type: schemaTypes{"string"}}
+	switch {
+	case field.DerefType() == "[]byte":
+		schema.Format = "byte"
+	case field.IsLocalType() && field.AsStruct() != nil:
+		schema = &jsonSchema{Type: schemaTypes{"object"}, Properties: make(map[string]*jsonSchema)}
+		for _, f := range field.AsStruct().Fields() {
+			schema.Properties[strcase.ToCamel(f.Name())] = fieldSchema(f, false)
+		}
+	default:
+		if s, ok := openAPITypeSchemas()[field.TypeName()]; ok {
+			schema = &s
+		}
+		if field.IsIterable() {
+			schema = arraySchema(schema)
+		}
+	}
+
+	if nullable || field.IsPointer() {
+		switch {
+		case schema.Ref != "":
+			schema = &jsonSchema{OneOf: []*jsonSchema{schema, {Type: schemaTypes{"null"}}}}
+		case len(schema.Type) != 0:
+			schema.Type = append(schema.Type, "null")
+		}
+	}
+
+	return schema
+}
+
+// fieldPermissions returns the permissions a field's perm tag requires, among those allowed.
+func fieldPermissions(field *parser.Field, allowed ...accesstypes.Permission) []accesstypes.Permission {
+	tag, ok := field.LookupTag(permTagKey)
+	if !ok {
+		return nil
+	}
+
+	var permissions []accesstypes.Permission
+	for perm := range strings.SplitSeq(tag, ",") {
+		if p := accesstypes.Permission(strings.TrimSpace(perm)); slices.Contains(allowed, p) {
+			permissions = append(permissions, p)
+		}
+	}
+
+	return permissions
+}
+
+// runOpenAPIGeneration writes the OpenAPI document of the generated routes.
+func (r *resourceGenerator) runOpenAPIGeneration() error {
+	begin := time.Now()
+
+	doc := r.openAPIDocument()
+
+	output, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "json.MarshalIndent()")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.openAPIPath), 0o755); err != nil {
+		return errors.Wrapf(err, "os.MkdirAll(): dir: %s", filepath.Dir(r.openAPIPath))
+	}
+	if err := r.writeGeneratedFile(r.openAPIPath, append(output, '\n')); err != nil {
+		return err
+	}
+
+	log.Printf("Generated OpenAPI document in %s: %s\n", time.Since(begin), r.openAPIPath)
+
+	return nil
+}
+
+// openAPIDocument describes the routes runRouteGeneration writes.
+func (r *resourceGenerator) openAPIDocument() *openAPIDocument {
+	doc := &openAPIDocument{
+		OpenAPI: openAPIVersion,
+		Info: openAPIInfo{
+			Title:       r.applicationName,
+			Description: "Code generated by resourcegeneration. DO NOT EDIT.",
+			Version:     "1.0.0",
+		},
+		Paths: make(map[string]openAPIPathItem),
+		Components: openAPIComponents{Schemas: map[string]*jsonSchema{
+			linkSchemaName: {
+				Type: schemaTypes{"object"},
+				Properties: map[string]*jsonSchema{
+					"id":       {Type: schemaTypes{"string"}, Format: "uuid"},
+					"resource": {Type: schemaTypes{"string"}},
+					"text":     {Type: schemaTypes{"string"}},
+				},
+				Required: []string{"id", "resource", "text"},
+			},
+		}},
+	}
+
+	var consolidated []*resourceInfo
+	for _, res := range r.resources {
+		if res.IsConsolidated {
+			consolidated = append(consolidated, res)
+		}
+		if res.RoutingDisabled() {
+			continue
+		}
+
+		r.addResourceSchemas(doc, res)
+		for _, route := range r.resourceRoutes(res) {
+			r.addResourceRoute(doc, res, route)
+		}
+	}
+
+	if slices.ContainsFunc(r.resources, func(res *resourceInfo) bool { return !res.RoutingDisabled() && hasConsolidatedHandler(res) }) {
+		r.addConsolidatedRoute(doc, consolidated)
+	}
+
+	for _, res := range r.computedResources {
+		if res.RoutingDisabled() {
+			continue
+		}
+
+		r.addComputedResource(doc, res)
+	}
+
+	if r.genRPCMethods {
+		for _, method := range r.rpcMethods {
+			if method.SuppressHandler {
+				continue
+			}
+
+			r.addRPCMethod(doc, method)
+		}
+
+		if asyncMethods := r.asyncRPCMethods(); len(asyncMethods) > 0 {
+			r.addRPCOperationRoute(doc, asyncMethods)
+		}
+	}
+
+	return doc
+}
+
+// addOperation adds an operation on a route's path. The list and read handlers also answer
+// POST, which takes the filter and search in the body so pii values stay out of the URL.
+func addOperation(doc *openAPIDocument, path, method string, op *openAPIOperation) {
+	item, ok := doc.Paths[path]
+	if !ok {
+		item = make(openAPIPathItem)
+		doc.Paths[path] = item
+	}
+	item[strings.ToLower(method)] = op
+}
+
+// addResourceSchemas adds a resource's row schema and the values of its create and update
+// patch operations.
+func (r *resourceGenerator) addResourceSchemas(doc *openAPIDocument, res *resourceInfo) {
+	row := &jsonSchema{Type: schemaTypes{"object"}, Properties: make(map[string]*jsonSchema)}
+	for _, field := range res.Fields {
+		if field.IsInputOnly() {
+			continue
+		}
+
+		schema := fieldSchema(field.Field, field.IsNullable)
+		schema.ReadOnly = field.IsOutputOnly()
+		schema.Permissions = fieldPermissions(field.Field, accesstypes.Read, accesstypes.List)
+		schema.PII = field.IsPII()
+		schema.Mask, _ = field.LookupTag(maskTagKey)
+		row.Properties[strcase.ToCamel(field.Name())] = schema
+	}
+	doc.Components.Schemas[res.Name()] = row
+
+	if res.IsVirtual {
+		return
+	}
+
+	create := &jsonSchema{Type: schemaTypes{"object"}, Properties: make(map[string]*jsonSchema)}
+	update := &jsonSchema{Type: schemaTypes{"object"}, Properties: make(map[string]*jsonSchema)}
+	for _, field := range res.Fields {
+		if field.IsPrimaryKey || field.IsOutputOnly() {
+			continue
+		}
+
+		name := strcase.ToCamel(field.Name())
+		schema := fieldSchema(field.Field, field.IsNullable)
+		schema.Permissions = fieldPermissions(field.Field, accesstypes.Create, accesstypes.Update, accesstypes.Delete)
+		schema.Immutable = field.IsImmutable()
+		create.Properties[name] = schema
+		if field.IsRequired() {
+			create.Required = append(create.Required, name)
+		}
+		if !field.IsImmutable() {
+			update.Properties[name] = schema
+		}
+	}
+	doc.Components.Schemas[res.Name()+"Create"] = create
+	doc.Components.Schemas[res.Name()+"Update"] = update
+}
+
+// addResourceRoute adds the operations of one of a resource's generated routes.
+func (r *resourceGenerator) addResourceRoute(doc *openAPIDocument, res *resourceInfo, route *generatedRoute) {
+	plural := r.pluralize(res.Name())
+	permission := func(permissions ...accesstypes.Permission) []openAPIPermission {
+		return []openAPIPermission{{Resource: accesstypes.Resource(plural), Scope: scopeOrGlobal(res.PermissionScope), Permissions: permissions}}
+	}
+
+	var pathParams []*openAPIParameter
+	switch route.HandlerType {
+	case ReadHandler:
+		pathParams = pathParameters(route, resourceKeys(res))
+	case NestedListHandler, NestedPatchHandler:
+		pathParams = pathParameters(route, resourceKeys(res.Parent))
+	}
+
+	switch route.HandlerType {
+	case ListHandler, NestedListHandler:
+		summary := fmt.Sprintf("Lists %s", plural)
+		if route.HandlerType == NestedListHandler {
+			summary = fmt.Sprintf("Lists the %s of a %s", plural, res.Parent.Name())
+		}
+		params := append(pathParams, resourceListParameters(res)...)
+		rows := responseContent(arraySchema(refSchema(res.Name())))
+		r.addQueryOperations(doc, route, plural, summary, params, rows, permission(accesstypes.List), true)
+	case ReadHandler:
+		params := append(pathParams, columnsParameter(resourceColumns(res)))
+		r.addQueryOperations(doc, route, plural, fmt.Sprintf("Reads a %s", res.Name()), params, responseContent(refSchema(res.Name())), permission(accesstypes.Read), false)
+	case BatchReadHandler:
+		addOperation(doc, route.Path, route.Method, &openAPIOperation{
+			OperationID: route.HandlerFunc,
+			Tags:        []string{plural},
+			Summary:     fmt.Sprintf("Reads %s by primary key", plural),
+			Parameters: []*openAPIParameter{
+				{
+					Name:        "ids",
+					In:          "query",
+					Description: fmt.Sprintf("Comma-separated primary keys to read, at most %d. Rows are returned in the order of the IDs, and IDs without a row are skipped.", resource.MaxBatchReadIDs),
+					Required:    true,
+					Schema:      stringSchema(""),
+				},
+				columnsParameter(resourceColumns(res)),
+			},
+			Responses:   okResponses(responseContent(arraySchema(refSchema(res.Name())))),
+			Permissions: permission(accesstypes.Read),
+		})
+	case PatchHandler, NestedPatchHandler:
+		pattern, createPath := res.OperationPathPattern(), !res.PrimaryKeyIsGeneratedUUID()
+		summary := fmt.Sprintf("Creates, updates and deletes %s", plural)
+		if route.HandlerType == NestedPatchHandler {
+			pattern, createPath = res.NestedOperationPathPattern(), true
+			summary = fmt.Sprintf("Creates, updates and deletes the %s of a %s", plural, res.Parent.Name())
+		}
+
+		var content map[string]openAPIMediaType
+		if route.HandlerType == PatchHandler && res.PrimaryKeyIsGeneratedUUID() {
+			content = responseContent(&jsonSchema{
+				Type:       schemaTypes{"object"},
+				Properties: map[string]*jsonSchema{"iDs": arraySchema(&jsonSchema{Type: schemaTypes{"string"}, Format: "uuid"})},
+			})
+		}
+		addOperation(doc, route.Path, route.Method, &openAPIOperation{
+			OperationID: route.HandlerFunc,
+			Tags:        []string{plural},
+			Summary:     summary,
+			Parameters:  pathParams,
+			RequestBody: &openAPIRequestBody{
+				Required: true,
+				Content:  map[string]openAPIMediaType{jsonMediaType: {Schema: arraySchema(patchOperationSchema(res, "", pattern, createPath))}},
+			},
+			Responses:   okResponses(content),
+			Permissions: permission(accesstypes.Create, accesstypes.Update, accesstypes.Delete),
+		})
+	}
+}
+
+// addConsolidatedRoute adds the PatchResources operation, which patches every consolidated
+// resource in one transaction. Each operation's path starts with the resource's route name.
+func (r *resourceGenerator) addConsolidatedRoute(doc *openAPIDocument, resources []*resourceInfo) {
+	operations := &jsonSchema{}
+	permissions := make([]openAPIPermission, 0, len(resources))
+	ids := &jsonSchema{Type: schemaTypes{"object"}, Properties: make(map[string]*jsonSchema)}
+	for _, res := range resources {
+		plural := r.pluralize(res.Name())
+		prefix := "/" + strcase.ToKebab(plural)
+		operations.OneOf = append(operations.OneOf, patchOperationSchema(res, prefix, res.OperationPathPattern(), !res.PrimaryKeyIsGeneratedUUID()).OneOf...)
+		permissions = append(permissions, openAPIPermission{
+			Resource:    accesstypes.Resource(plural),
+			Scope:       scopeOrGlobal(res.PermissionScope),
+			Permissions: []accesstypes.Permission{accesstypes.Create, accesstypes.Update, accesstypes.Delete},
+		})
+		if res.PrimaryKeyIsGeneratedUUID() {
+			ids.Properties[strcase.ToGoCamel(plural)] = arraySchema(&jsonSchema{Type: schemaTypes{"string"}, Format: "uuid"})
+		}
+	}
+
+	addOperation(doc, r.consolidatedRoute(), http.MethodPatch, &openAPIOperation{
+		OperationID: "PatchResources",
+		Tags:        []string{"Resources"},
+		Summary:     "Creates, updates and deletes rows of several resources in one transaction",
+		RequestBody: &openAPIRequestBody{
+			Required: true,
+			Content:  map[string]openAPIMediaType{jsonMediaType: {Schema: arraySchema(operations)}},
+		},
+		Responses:   okResponses(responseContent(ids)),
+		Permissions: permissions,
+	})
+}
+
+// patchOperationSchema returns the schemas of a resource's add, patch and remove JSON Patch
+// operations, wrapped in a oneOf. The operation path is prefix followed by pattern, and an
+// add without createPath leaves the primary key to be generated.
+func patchOperationSchema(res *resourceInfo, prefix, pattern string, createPath bool) *jsonSchema {
+	operation := func(op, path string, pathRequired bool, value *jsonSchema) *jsonSchema {
+		schema := &jsonSchema{
+			Type: schemaTypes{"object"},
+			Properties: map[string]*jsonSchema{
+				"op":   {Type: schemaTypes{"string"}, Const: op},
+				"path": stringSchema(fmt.Sprintf("Operation path: %s", path)),
+			},
+			Required: []string{"op"},
+		}
+		if pathRequired {
+			schema.Required = append(schema.Required, "path")
+		}
+		if value != nil {
+			schema.Properties["value"] = value
+			schema.Required = append(schema.Required, "value")
+		}
+
+		return schema
+	}
+
+	createPattern := prefix + pattern
+	if !createPath {
+		createPattern = prefix
+	}
+
+	return &jsonSchema{OneOf: []*jsonSchema{
+		operation(string(resource.OperationCreate), createPattern, createPath || prefix != "", refSchema(res.Name()+"Create")),
+		operation(string(resource.OperationUpdate), prefix+pattern, true, refSchema(res.Name()+"Update")),
+		operation(string(resource.OperationDelete), prefix+pattern, true, nil),
+	}}
+}
+
+// addComputedResource adds the read and list operations of a computed resource.
+func (r *resourceGenerator) addComputedResource(doc *openAPIDocument, res *computedResource) {
+	plural := r.pluralize(res.Name())
+	row := &jsonSchema{Type: schemaTypes{"object"}, Properties: make(map[string]*jsonSchema)}
+	columns := make([]string, 0, len(res.Fields))
+	var keys []*parser.Field
+	for _, field := range res.Fields {
+		schema := fieldSchema(field.Field, false)
+		schema.PII = field.IsPII()
+		row.Properties[strcase.ToCamel(field.Name())] = schema
+		columns = append(columns, strcase.ToCamel(field.Name()))
+	}
+	for _, field := range res.PrimaryKeys() {
+		keys = append(keys, field.Field)
+	}
+	doc.Components.Schemas[res.Name()] = row
+
+	permission := func(permission accesstypes.Permission) []openAPIPermission {
+		return []openAPIPermission{{Resource: accesstypes.Resource(plural), Scope: scopeOrGlobal(res.PermissionScope), Permissions: []accesstypes.Permission{permission}}}
+	}
+
+	for _, route := range r.computedRoutes(res) {
+		switch route.HandlerType {
+		case ReadHandler:
+			params := append(pathParameters(route, keys), columnsParameter(columns))
+			r.addQueryOperations(doc, route, plural, fmt.Sprintf("Reads a %s", res.Name()), params, responseContent(refSchema(res.Name())), permission(accesstypes.Read), false)
+		case ListHandler:
+			params := listParameters(columns, nil, keyNames(keys), false, queryPolicy{})
+			r.addQueryOperations(doc, route, plural, fmt.Sprintf("Lists %s", plural), params, responseContent(arraySchema(refSchema(res.Name()))), permission(accesstypes.List), true)
+		}
+	}
+}
+
+// addRPCMethod adds the operation of an RPC method, which takes its request fields as the
+// request body and responds with its output_only fields.
+func (r *resourceGenerator) addRPCMethod(doc *openAPIDocument, method *rpcMethodInfo) {
+	body := &jsonSchema{Type: schemaTypes{"object"}, Properties: make(map[string]*jsonSchema)}
+	for _, field := range method.RequestFields() {
+		body.Properties[strcase.ToCamel(field.Name())] = fieldSchema(field.Field, false)
+	}
+	doc.Components.Schemas[method.Name()] = body
+
+	var content map[string]openAPIMediaType
+	if method.HasResponse() {
+		resp := &jsonSchema{Type: schemaTypes{"object"}, Properties: make(map[string]*jsonSchema)}
+		for _, field := range method.ResponseFields() {
+			resp.Properties[strcase.ToCamel(field.Name())] = fieldSchema(field.Field, false)
+		}
+		doc.Components.Schemas[method.Name()+"Response"] = resp
+		content = responseContent(refSchema(method.Name() + "Response"))
+	}
+
+	responses := okResponses(content)
+	if method.Async {
+		responses = acceptedResponses(responseContent(refSchema(rpcOperationAcceptedSchemaName)))
+	}
+
+	route := r.rpcRoute(method)
+	addOperation(doc, route.Path, route.Method, &openAPIOperation{
+		OperationID: route.HandlerFunc,
+		Tags:        []string{"Methods"},
+		Summary:     fmt.Sprintf("Executes %s", method.Name()),
+		RequestBody: &openAPIRequestBody{
+			Required: true,
+			Content:  map[string]openAPIMediaType{jsonMediaType: {Schema: refSchema(method.Name())}},
+		},
+		Responses: responses,
+		Permissions: []openAPIPermission{{
+			Resource:    accesstypes.Resource(method.Name()),
+			Scope:       scopeOrGlobal(method.PermissionScope),
+			Permissions: []accesstypes.Permission{accesstypes.Execute},
+		}},
+	})
+}
+
+// addRPCOperationRoute adds the status route of the @async methods' operations and its schemas.
+// An operation's result is the response of its method.
+func (r *resourceGenerator) addRPCOperationRoute(doc *openAPIDocument, asyncMethods []*rpcMethodInfo) {
+	uuid := &jsonSchema{Type: schemaTypes{"string"}, Format: "uuid"}
+	dateTime := &jsonSchema{Type: schemaTypes{"string"}, Format: "date-time"}
+	result := &jsonSchema{Description: "The method's response, set once the operation has succeeded"}
+	methods := make([]string, 0, len(asyncMethods))
+	permissions := make([]openAPIPermission, 0, len(asyncMethods))
+	for _, method := range asyncMethods {
+		methods = append(methods, method.Name())
+		permissions = append(permissions, openAPIPermission{
+			Resource:    accesstypes.Resource(method.Name()),
+			Scope:       scopeOrGlobal(method.PermissionScope),
+			Permissions: []accesstypes.Permission{accesstypes.Execute},
+		})
+		if method.HasResponse() {
+			result.OneOf = append(result.OneOf, refSchema(method.Name()+"Response"))
+		}
+	}
+
+	doc.Components.Schemas[rpcOperationAcceptedSchemaName] = &jsonSchema{
+		Type:       schemaTypes{"object"},
+		Properties: map[string]*jsonSchema{"operationId": uuid},
+		Required:   []string{"operationId"},
+	}
+	doc.Components.Schemas[rpcOperationSchemaName] = &jsonSchema{
+		Type: schemaTypes{"object"},
+		Properties: map[string]*jsonSchema{
+			"id":        uuid,
+			"method":    stringSchema("One of " + strings.Join(methods, ", ")),
+			"status":    stringSchema("pending, running, succeeded or failed"),
+			"progress":  {Type: schemaTypes{"integer"}, Format: "int64", Description: "Percent complete, as reported by the method"},
+			"result":    result,
+			"error":     {Type: schemaTypes{"string", "null"}, Description: "The error of a failed operation"},
+			"attempts":  {Type: schemaTypes{"integer"}, Format: "int64"},
+			"createdAt": dateTime,
+			"updatedAt": dateTime,
+		},
+		Required: []string{"id", "method", "status", "progress", "result", "error", "attempts", "createdAt", "updatedAt"},
+	}
+
+	route := r.rpcOperationRoute()
+	addOperation(doc, route.Path, route.Method, &openAPIOperation{
+		OperationID: route.HandlerFunc,
+		Tags:        []string{"Methods"},
+		Summary:     "Reads the status of an asynchronous method's operation. Requires Execute on the operation's method.",
+		Parameters: []*openAPIParameter{{
+			Name: rpcOperationParam, In: "path", Required: true, Schema: uuid,
+		}},
+		Responses:   okResponses(responseContent(refSchema(rpcOperationSchemaName))),
+		Permissions: permissions,
+	})
+}
+
+// addQueryOperations adds the operation of a list or read route, and the POST operation its
+// shared handler also answers. A list's POST takes the filter and search in the body.
+func (r *resourceGenerator) addQueryOperations(
+	doc *openAPIDocument, route *generatedRoute, tag, summary string, params []*openAPIParameter,
+	content map[string]openAPIMediaType, permissions []openAPIPermission, isList bool,
+) {
+	addOperation(doc, route.Path, route.Method, &openAPIOperation{
+		OperationID: route.HandlerFunc,
+		Tags:        []string{tag},
+		Summary:     summary,
+		Parameters:  params,
+		Responses:   okResponses(content),
+		Permissions: permissions,
+	})
+
+	if !route.SharedHandler() {
+		return
+	}
+
+	post := &openAPIOperation{
+		OperationID: "Query" + route.HandlerFunc,
+		Tags:        []string{tag},
+		Summary:     summary,
+		Parameters:  params,
+		Responses:   okResponses(content),
+		Permissions: permissions,
+	}
+	if isList {
+		post.Summary += ", with the filter and search in the body"
+		post.RequestBody = &openAPIRequestBody{Content: map[string]openAPIMediaType{jsonMediaType: {Schema: &jsonSchema{
+			Type: schemaTypes{"object"},
+			Properties: map[string]*jsonSchema{
+				"filter": stringSchema("Filter expression, used instead of the filter parameter. Required to filter on pii fields."),
+				"search": stringSchema("Full-text search, used instead of the search parameter. Required to search pii fields."),
+			},
+		}}}}
+	}
+	addOperation(doc, route.Path, http.MethodPost, post)
+}
+
+// pathParameters returns the parameters of a route's path, one per key field in order.
+func pathParameters(route *generatedRoute, keys []*parser.Field) []*openAPIParameter {
+	params := make([]*openAPIParameter, 0, len(route.TestParams))
+	for i, p := range route.TestParams {
+		schema := stringSchema("")
+		if i < len(keys) {
+			schema = fieldSchema(keys[i], false)
+		}
+		params = append(params, &openAPIParameter{Name: p.Key, In: "path", Required: true, Schema: schema})
+	}
+
+	return params
+}
+
+// resourceKeys returns a resource's primary key fields in key order.
+func resourceKeys(res *resourceInfo) []*parser.Field {
+	fields := res.PrimaryKeyFields()
+	keys := make([]*parser.Field, 0, len(fields))
+	for _, field := range fields {
+		keys = append(keys, field.Field)
+	}
+
+	return keys
+}
+
+func keyNames(keys []*parser.Field) []string {
+	names := make([]string, 0, len(keys))
+	for _, key := range keys {
+		names = append(names, strcase.ToCamel(key.Name()))
+	}
+
+	return names
+}
+
+// resourceColumns returns the JSON names of the fields a resource's list and read handlers return.
+func resourceColumns(res *resourceInfo) []string {
+	var columns []string
+	for _, field := range res.Fields {
+		if !field.IsInputOnly() {
+			columns = append(columns, strcase.ToCamel(field.Name()))
+		}
+	}
+
+	return columns
+}
+
+// resourceListParameters returns the query parameters of a resource's list handler. Filter
+// and sort fields are the ones the generated list request struct tags.
+func resourceListParameters(res *resourceInfo) []*openAPIParameter {
+	var filterable, sortable []string
+	for _, field := range res.Fields {
+		name := strcase.ToCamel(field.Name())
+		if field.IndexTag() != "" || field.AllowFilterTag() != "" {
+			filterable = append(filterable, name)
+		}
+		if field.IsSortable() {
+			sortable = append(sortable, name)
+		}
+	}
+
+	return listParameters(resourceColumns(res), filterable, sortable, len(res.SearchFields()) != 0, res.QueryPolicy)
+}
+
+// listParameters returns the reserved query parameters of a list handler.
+func listParameters(columns, filterable, sortable []string, searchable bool, policy queryPolicy) []*openAPIParameter {
+	params := []*openAPIParameter{columnsParameter(columns)}
+	if len(filterable) != 0 {
+		params = append(params, &openAPIParameter{
+			Name:        "filter",
+			In:          "query",
+			Description: fmt.Sprintf("Filter expression, e.g. field:eq:value. Filterable fields: %s. A filter on a pii field must be sent in the POST body.", strings.Join(filterable, ", ")),
+			Schema:      stringSchema(""),
+		})
+	}
+	if len(sortable) != 0 {
+		params = append(params, &openAPIParameter{
+			Name:        "sort",
+			In:          "query",
+			Description: fmt.Sprintf("Comma-separated field[:asc|desc[:nullsfirst|nullslast]] entries. Sortable fields: %s.", strings.Join(sortable, ", ")),
+			Schema:      stringSchema(""),
+		})
+	}
+
+	limit := &jsonSchema{Type: schemaTypes{"integer"}, Default: new(uint64(50))}
+	if policy.MaxLimit != 0 {
+		limit.Maximum = new(policy.MaxLimit)
+		limit.Default = new(min(50, policy.MaxLimit))
+	}
+	offset := &jsonSchema{Type: schemaTypes{"integer"}}
+	if policy.MaxOffset != 0 {
+		offset.Maximum = new(policy.MaxOffset)
+	}
+	params = append(params,
+		&openAPIParameter{Name: "limit", In: "query", Description: "Maximum rows returned.", Schema: limit},
+		&openAPIParameter{Name: "offset", In: "query", Description: "Rows to skip before returning results.", Schema: offset},
+	)
+
+	if searchable {
+		params = append(params, &openAPIParameter{
+			Name:        "search",
+			In:          "query",
+			Description: "Full-text search over the searchable fields. A search including a pii field must be sent in the POST body.",
+			Schema:      stringSchema(""),
+		})
+	}
+
+	return params
+}
+
+func columnsParameter(columns []string) *openAPIParameter {
+	return &openAPIParameter{
+		Name:        "columns",
+		In:          "query",
+		Description: fmt.Sprintf("Comma-separated fields to return, all accessible fields when omitted. Fields: %s.", strings.Join(columns, ", ")),
+		Schema:      stringSchema(""),
+	}
+}
+
+func responseContent(schema *jsonSchema) map[string]openAPIMediaType {
+	return map[string]openAPIMediaType{jsonMediaType: {Schema: schema}}
+}
+
+// okResponses returns the responses of an operation: its result, and the client errors every
+// generated handler can return.
+// acceptedResponses returns the responses of an @async method, which enqueues an operation whose
+// status route is in the Location header.
+func acceptedResponses(content map[string]openAPIMediaType) map[string]openAPIResponse {
+	return map[string]openAPIResponse{
+		"202": {Description: "The operation is enqueued; its status route is in the Location header", Content: content},
+		"400": {Description: "The request is invalid"},
+		"401": {Description: "The user is not authenticated"},
+		"403": {Description: "The user lacks a required permission"},
+	}
+}
+
+func okResponses(content map[string]openAPIMediaType) map[string]openAPIResponse {
+	return map[string]openAPIResponse{
+		"200": {Description: "OK", Content: content},
+		"400": {Description: "The request is invalid"},
+		"401": {Description: "The user is not authenticated"},
+		"403": {Description: "The user lacks a required permission"},
+		"404": {Description: "The row does not exist"},
+	}
+}
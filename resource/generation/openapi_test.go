@@ -0,0 +1,197 @@
+package generation
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/cccteam/ccc/accesstypes"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_openAPIDocument(t *testing.T) {
+	t.Parallel()
+
+	r := collectionFixtureGenerator(t)
+	r.routePrefix = "api"
+	r.ConsolidatedRoute = "resources"
+	doc := r.openAPIDocument()
+
+	tests := []struct {
+		name            string
+		path            string
+		method          string
+		wantOperationID string
+		wantPermissions []openAPIPermission
+		wantParameters  []string
+	}{
+		{
+			name:            "list",
+			path:            "/api/widgets",
+			method:          "get",
+			wantOperationID: "Widgets",
+			wantPermissions: []openAPIPermission{{Resource: "Widgets", Scope: accesstypes.GlobalPermissionScope, Permissions: []accesstypes.Permission{accesstypes.List}}},
+			wantParameters:  []string{"columns", "sort", "limit", "offset"},
+		},
+		{
+			name:            "list with the filter in the body",
+			path:            "/api/widgets",
+			method:          "post",
+			wantOperationID: "QueryWidgets",
+			wantPermissions: []openAPIPermission{{Resource: "Widgets", Scope: accesstypes.GlobalPermissionScope, Permissions: []accesstypes.Permission{accesstypes.List}}},
+			wantParameters:  []string{"columns", "sort", "limit", "offset"},
+		},
+		{
+			name:            "read",
+			path:            "/api/widgets/{widgetID}",
+			method:          "get",
+			wantOperationID: "Widget",
+			wantPermissions: []openAPIPermission{{Resource: "Widgets", Scope: accesstypes.GlobalPermissionScope, Permissions: []accesstypes.Permission{accesstypes.Read}}},
+			wantParameters:  []string{"widgetID", "columns"},
+		},
+		{
+			name:            "patch",
+			path:            "/api/widgets",
+			method:          "patch",
+			wantOperationID: "PatchWidgets",
+			wantPermissions: []openAPIPermission{{Resource: "Widgets", Scope: accesstypes.GlobalPermissionScope, Permissions: []accesstypes.Permission{accesstypes.Create, accesstypes.Update, accesstypes.Delete}}},
+		},
+		{
+			name:            "virtual resource list",
+			path:            "/api/gadgets",
+			method:          "get",
+			wantOperationID: "Gadgets",
+			wantPermissions: []openAPIPermission{{Resource: "Gadgets", Scope: accesstypes.DomainPermissionScope, Permissions: []accesstypes.Permission{accesstypes.List}}},
+			wantParameters:  []string{"columns", "sort", "limit", "offset"},
+		},
+		{
+			name:            "consolidated patch covers routing disabled resources",
+			path:            "/api/resources",
+			method:          "patch",
+			wantOperationID: "PatchResources",
+			wantPermissions: []openAPIPermission{
+				{Resource: "Fossils", Scope: accesstypes.GlobalPermissionScope, Permissions: []accesstypes.Permission{accesstypes.Create, accesstypes.Update, accesstypes.Delete}},
+				{Resource: "Sprockets", Scope: accesstypes.GlobalPermissionScope, Permissions: []accesstypes.Permission{accesstypes.Create, accesstypes.Update, accesstypes.Delete}},
+			},
+		},
+		{
+			name:            "computed resource read",
+			path:            "/api/summaries/{summaryID}",
+			method:          "get",
+			wantOperationID: "Summary",
+			wantPermissions: []openAPIPermission{{Resource: "Summaries", Scope: accesstypes.GlobalPermissionScope, Permissions: []accesstypes.Permission{accesstypes.Read}}},
+			wantParameters:  []string{"summaryID", "columns"},
+		},
+		{
+			name:            "rpc method",
+			path:            "/api/do-something",
+			method:          "post",
+			wantOperationID: "DoSomething",
+			wantPermissions: []openAPIPermission{{Resource: "DoSomething", Scope: accesstypes.DomainPermissionScope, Permissions: []accesstypes.Permission{accesstypes.Execute}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			op := doc.Paths[tt.path][tt.method]
+			if op == nil {
+				t.Fatalf("openAPIDocument() has no %s %s operation", tt.method, tt.path)
+			}
+			if op.OperationID != tt.wantOperationID {
+				t.Errorf("operationId = %q, want %q", op.OperationID, tt.wantOperationID)
+			}
+			if diff := cmp.Diff(tt.wantPermissions, op.Permissions); diff != "" {
+				t.Errorf("x-permissions mismatch (-want +got):\n%s", diff)
+			}
+			var params []string
+			for _, p := range op.Parameters {
+				params = append(params, p.Name)
+			}
+			if diff := cmp.Diff(tt.wantParameters, params); diff != "" {
+				t.Errorf("parameters mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	for _, path := range []string{"/api/relics", "/api/ledgers", "/api/hidden-method"} {
+		if _, ok := doc.Paths[path]; ok {
+			t.Errorf("openAPIDocument() has path %s, want it left out", path)
+		}
+	}
+}
+
+func Test_openAPIDocument_schemas(t *testing.T) {
+	t.Parallel()
+
+	doc := collectionFixtureGenerator(t).openAPIDocument()
+
+	widget := doc.Components.Schemas["Widget"]
+	if _, ok := widget.Properties["secret"]; ok {
+		t.Error("Widget has input only field secret")
+	}
+	if !widget.Properties["derived"].ReadOnly {
+		t.Error("Widget.derived is not readOnly")
+	}
+	badge, err := json.Marshal(widget.Properties["badge"])
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if want := `{"type":["string","null"],"x-permissions":["Read"],"x-pii":true,"x-mask":"last4"}`; string(badge) != want {
+		t.Errorf("Widget.badge = %s, want %s", badge, want)
+	}
+
+	update := doc.Components.Schemas["WidgetUpdate"]
+	var fields []string
+	for name := range update.Properties {
+		fields = append(fields, name)
+	}
+	slices.Sort(fields)
+	if diff := cmp.Diff([]string{"badge", "listedName", "name", "secret"}, fields); diff != "" {
+		t.Errorf("WidgetUpdate properties mismatch (-want +got):\n%s", diff)
+	}
+	if !doc.Components.Schemas["WidgetCreate"].Properties["code"].Immutable {
+		t.Error("WidgetCreate.code is not x-immutable")
+	}
+	if _, ok := doc.Components.Schemas["GadgetCreate"]; ok {
+		t.Error("virtual resource Gadget has a create schema")
+	}
+}
+
+func Test_openAPIDocument_asyncRPCMethod(t *testing.T) {
+	t.Parallel()
+
+	r := collectionFixtureGenerator(t)
+	r.routePrefix = "api"
+	r.rpcMethods[0].Async = true
+	doc := r.openAPIDocument()
+
+	op := doc.Paths["/api/do-something"]["post"]
+	if op == nil {
+		t.Fatal("openAPIDocument() has no post /api/do-something operation")
+	}
+	if _, ok := op.Responses["202"]; !ok {
+		t.Error("async method has no 202 response")
+	}
+	if _, ok := op.Responses["200"]; ok {
+		t.Error("async method has a 200 response")
+	}
+
+	status := doc.Paths["/api/rpc-operations/{rpcOperationID}"]["get"]
+	if status == nil {
+		t.Fatal("openAPIDocument() has no operation status route")
+	}
+	if status.OperationID != rpcOperationHandlerName {
+		t.Errorf("operationId = %q, want %q", status.OperationID, rpcOperationHandlerName)
+	}
+	wantPermissions := []openAPIPermission{{Resource: "DoSomething", Scope: accesstypes.DomainPermissionScope, Permissions: []accesstypes.Permission{accesstypes.Execute}}}
+	if diff := cmp.Diff(wantPermissions, status.Permissions); diff != "" {
+		t.Errorf("x-permissions mismatch (-want +got):\n%s", diff)
+	}
+	for _, name := range []string{rpcOperationSchemaName, rpcOperationAcceptedSchemaName} {
+		if _, ok := doc.Components.Schemas[name]; !ok {
+			t.Errorf("openAPIDocument() has no %s schema", name)
+		}
+	}
+}
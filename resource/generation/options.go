@@ -82,6 +82,32 @@ func GenerateRoutes(targetDir, routePrefix string) ResourceOption {
 	})
 }
 
+// GenerateOpenAPI enables writing an OpenAPI 3.1 document to targetPath describing the
+// generated routes: the list, read, batch read, patch, consolidated patch and RPC endpoints,
+// their filter, sort and columns parameters, their JSON Patch request bodies, and the
+// permission each operation requires. It requires GenerateRoutes, whose routes it describes.
+func GenerateOpenAPI(targetPath string) ResourceOption {
+	return resourceOption(func(r *resourceGenerator) error {
+		r.genOpenAPI = true
+		r.openAPIPath = targetPath
+
+		return nil
+	})
+}
+
+// GenerateGoClient enables writing a Go client of the generated routes into targetDir, whose
+// base name is its package name: a typed List, Read, BatchRead and Patch per resource, typed
+// filter clauses, a PatchResources for the consolidated route, and a function per RPC method.
+// It requires GenerateRoutes, whose routes the client calls.
+func GenerateGoClient(targetDir string) ResourceOption {
+	return resourceOption(func(r *resourceGenerator) error {
+		r.genGoClient = true
+		r.goClient = packageDir(targetDir)
+
+		return nil
+	})
+}
+
 // GenerateTypescript enables TypeScript generation as part of the resource generator run.
 // The permission data is computed statically from the parsed resources, so the run needs
 // no compiled application router.
@@ -91,10 +117,12 @@ func GenerateRoutes(targetDir, routePrefix string) ResourceOption {
 // into every consuming application this way. Target directories must be distinct.
 //
 // It accepts only TypeScript-specific options: GenerateMetadata, GeneratePermissions,
-// GenerateEnums, and WithTypescriptOverrides. Everything else — package locations
-// (WithVirtualResources, WithComputedResources, WithRPC), the Spanner emulator version,
-// plural overrides, and consolidated handlers — is a ResourceOption inherited from the
-// enclosing NewResourceGenerator options, so nesting one here fails to compile.
+// GenerateEnums, GenerateClient, GenerateAngularServices, GenerateSchemas, and
+// WithTypescriptOverrides. Everything else — package locations (WithVirtualResources,
+// WithComputedResources, WithRPC), the schema source (the Spanner emulator version,
+// WithOfflineSchema or WithPostgresSchema), plural overrides, and consolidated handlers —
+// is a ResourceOption inherited from the enclosing NewResourceGenerator options, so
+// nesting one here fails to compile.
 // GeneratePermissions and GenerateMetadata render from the permission collection, so
 // they additionally require GenerateRoutes or manual declarations (@manualAddResource,
 // @manualAddResourceSet, WithManualResources); enum output reads only the schema and
@@ -128,6 +156,13 @@ func (target typescriptTarget) resolve() (*typescriptGenerator, error) {
 		return nil, err
 	}
 
+	if t.genClient && !t.genMetadata {
+		return nil, errors.Newf("GenerateTypescript(%q): GenerateClient requires GenerateMetadata: the client is typed with the generated interfaces", target.destination)
+	}
+	if t.genAngular && (!t.genMetadata || !t.genPermission) {
+		return nil, errors.Newf("GenerateTypescript(%q): GenerateAngularServices requires GenerateMetadata and GeneratePermissions: the services are typed with the generated interfaces and constants", target.destination)
+	}
+
 	return t, nil
 }
 
@@ -182,6 +217,42 @@ func GenerateMetadata() TSOption {
 	})
 }
 
+// GenerateClient enables generating a typed API client: a function for each generated route
+// and RPC method, with typed filter and sort builders, calling the routes with fetch. Its
+// requests and responses are typed with the generated interfaces, so it requires
+// GenerateMetadata.
+func GenerateClient() TSOption {
+	return tsOption(func(t *typescriptGenerator) error {
+		t.genClient = true
+
+		return nil
+	})
+}
+
+// GenerateAngularServices enables generating an injectable Angular service for each
+// generated resource and RPC method, calling the routes with HttpClient. List and read have
+// signal helpers, and ResourcePatchService sends a ResourcePatchBatch to PatchResources. The
+// services are typed with the generated interfaces and reference the Resources and Methods
+// constants, so it requires GenerateMetadata and GeneratePermissions.
+func GenerateAngularServices() TSOption {
+	return tsOption(func(t *typescriptGenerator) error {
+		t.genAngular = true
+
+		return nil
+	})
+}
+
+// GenerateSchemas enables generating zod schemas validating the JSON of each resource,
+// computed resource and RPC method at runtime: a row schema, create and update schemas for
+// patch values, and an RPC request schema. Date fields are coerced from their JSON strings.
+func GenerateSchemas() TSOption {
+	return tsOption(func(t *typescriptGenerator) error {
+		t.genSchemas = true
+
+		return nil
+	})
+}
+
 // GenerateEnums enables generating constants for resources that have been tagged with `@enumerate`
 // and have Id and Description values in the schema migrations directory.
 func GenerateEnums() TSOption {
@@ -207,6 +278,46 @@ func WithSpannerEmulatorVersion(version string) ResourceOption {
 	})
 }
 
+// WithOfflineSchema reads the schema by parsing the migrations' .up.sql files, in version
+// order, instead of migrating a Spanner emulator container, so generation needs no container
+// runtime. Enum values come from the INSERT statements in the migrations. Only file://
+// migration sources can be parsed.
+func WithOfflineSchema() ResourceOption {
+	return Option(func(g any) error {
+		switch t := g.(type) {
+		case *client:
+			t.offlineSchema = true
+		case *resourceGenerator, *typescriptGenerator: // no-op
+		default:
+			panic(fmt.Sprintf("unexpected generator type in WithOfflineSchema(): %T", t))
+		}
+
+		return nil
+	})
+}
+
+// WithPostgresSchema reads the schema from the Postgres database at connString instead of a
+// Spanner emulator container. The migrations' .up.sql files are applied, in version order, to
+// a scratch schema that is dropped afterwards, and the tables are read from the pg_catalog.
+// Table names are matched to resources in PascalCase, so a docking_bays table backs a
+// DockingBay resource, and fields map to columns with postgres or db struct tags instead of
+// spanner tags. Only file:// migration sources can be applied, each file in a transaction, and
+// a file naming a schema is rejected. connString must be a database the migrations may be run
+// against, like a local development database.
+func WithPostgresSchema(connString string) ResourceOption {
+	return Option(func(g any) error {
+		switch t := g.(type) {
+		case *client:
+			t.postgresConnString = connString
+		case *resourceGenerator, *typescriptGenerator: // no-op
+		default:
+			panic(fmt.Sprintf("unexpected generator type in WithPostgresSchema(): %T", t))
+		}
+
+		return nil
+	})
+}
+
 // WithPluralOverrides sets the pluralization for any resource names that are not
 // handled correctly by the default pluralization rules.
 func WithPluralOverrides(overrides map[string]string) ResourceOption {
@@ -385,6 +496,13 @@ func applyResourceGeneratorDefaults(g *resourceGenerator) error {
 	}
 	g.receiverName = strings.ToLower(string(g.applicationName[0]))
 
+	if g.genOpenAPI && !g.genRoutes {
+		return errors.New("GenerateOpenAPI requires GenerateRoutes: the document describes the generated routes")
+	}
+	if g.genGoClient && !g.genRoutes {
+		return errors.New("GenerateGoClient requires GenerateRoutes: the client calls the generated routes")
+	}
+
 	// Each GenerateTypescript call owns one directory; two calls writing the same files
 	// to the same place is always a configuration mistake.
 	seen := make(map[string]struct{}, len(g.typescriptTargets))
@@ -0,0 +1,385 @@
+package generation
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/ettle/strcase"
+	"github.com/go-playground/errors/v5"
+	"github.com/jackc/pgx/v5"
+)
+
+// runPostgres builds the table lookup and enum values by applying the migrations to a scratch
+// schema in the Postgres database at connString and reading the schema from its pg_catalog.
+// The database must be one the migrations may be run against, like a local development
+// database: a migration can only be kept out of the other schemas by rejecting DDL that names one.
+func (c *client) runPostgres(ctx context.Context, connString string, migrationSourceURLs []string) error {
+	log.Println("Connecting to Postgres...")
+	conn, err := pgx.Connect(ctx, connString)
+	if err != nil {
+		return errors.Wrap(err, "pgx.Connect()")
+	}
+	defer func() {
+		if err := conn.Close(context.Background()); err != nil {
+			log.Print(errors.Wrap(err, "pgx.Conn.Close()"))
+		}
+	}()
+
+	schema, err := createPostgresSchema(ctx, conn)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if _, err := conn.Exec(context.Background(), "DROP SCHEMA "+pgx.Identifier{schema}.Sanitize()+" CASCADE"); err != nil {
+			log.Print(errors.Wrap(err, "pgx.Conn.Exec(): DROP SCHEMA"))
+		}
+	}()
+
+	log.Println("Starting Postgres Migration...")
+	if err := migratePostgres(ctx, conn, migrationSourceURLs); err != nil {
+		return err
+	}
+
+	log.Println("Creating postgres table lookup...")
+	results, err := queryPostgresCatalog(ctx, conn, schema)
+	if err != nil {
+		return err
+	}
+
+	enumValues, err := fetchPostgresEnumValues(ctx, conn, schema)
+	if err != nil {
+		return errors.Wrap(err, "fetchPostgresEnumValues()")
+	}
+
+	c.tableMap = newTableMap(results)
+	c.enumValues = enumValues
+
+	return nil
+}
+
+// createPostgresSchema creates a uniquely named schema and puts it first on the connection's
+// search_path, so the migrations create their tables in it.
+func createPostgresSchema(ctx context.Context, conn *pgx.Conn) (string, error) {
+	suffix := make([]byte, 8)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", errors.Wrap(err, "rand.Read()")
+	}
+	schema := "resourcegeneration_" + hex.EncodeToString(suffix)
+
+	if _, err := conn.Exec(ctx, "CREATE SCHEMA "+pgx.Identifier{schema}.Sanitize()); err != nil {
+		return "", errors.Wrap(err, "pgx.Conn.Exec(): CREATE SCHEMA")
+	}
+
+	if _, err := conn.Exec(ctx, "SET search_path TO "+pgx.Identifier{schema}.Sanitize()); err != nil {
+		return "", errors.Wrap(err, "pgx.Conn.Exec(): SET search_path")
+	}
+
+	return schema, nil
+}
+
+// migratePostgres applies the up migrations of each source in version order, each file as a
+// single multi-statement script in its own transaction. A file that names a schema is rejected
+// before it runs, so the migrations can only change the scratch schema.
+func migratePostgres(ctx context.Context, conn *pgx.Conn, migrationSourceURLs []string) error {
+	for _, migrationSource := range migrationSourceURLs {
+		dir, ok := strings.CutPrefix(migrationSource, "file://")
+		if !ok {
+			return errors.Newf("migration source %q is not a file:// URL, which migrating Postgres requires", migrationSource)
+		}
+
+		files, err := upMigrationFiles(dir)
+		if err != nil {
+			return err
+		}
+
+		for _, file := range files {
+			b, err := os.ReadFile(filepath.Join(dir, file))
+			if err != nil {
+				return errors.Wrap(err, "os.ReadFile()")
+			}
+
+			if stmt := schemaQualifiedDDL(string(b)); stmt != "" {
+				return errors.Newf("migration %s names a schema in %q, which would change the database outside the scratch schema", file, stmt)
+			}
+
+			if err := pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+				_, err := tx.Exec(ctx, string(b))
+
+				return err
+			}); err != nil {
+				return errors.Wrapf(err, "pgx.BeginFunc(): file: %s", file)
+			}
+		}
+	}
+
+	return nil
+}
+
+var (
+	sqlLineComment = regexp.MustCompile(`--[^\n]*`)
+
+	// schemaQualifiedDDLPatterns match the statements that reach outside the search_path: those
+	// switching or creating schemas, and those naming a schema-qualified object.
+	schemaQualifiedDDLPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`(?is)\bSET\s+(?:LOCAL\s+|SESSION\s+)?search_path\b`),
+		regexp.MustCompile(`(?is)\b(?:CREATE|ALTER|DROP)\s+SCHEMA\b`),
+		regexp.MustCompile(`(?is)\b(?:TABLE|INDEX|VIEW|SEQUENCE|TYPE|DOMAIN|FUNCTION|PROCEDURE|TRIGGER|REFERENCES|INTO|UPDATE|FROM)\s+(?:IF\s+(?:NOT\s+)?EXISTS\s+)?(?:ONLY\s+)?(?:"[^"]+"|\w+)\s*\.\s*(?:"|\w)`),
+		regexp.MustCompile(`(?is)\bINDEX\b[^;]*?\bON\s+(?:ONLY\s+)?(?:"[^"]+"|\w+)\s*\.\s*(?:"|\w)`),
+	}
+)
+
+// schemaQualifiedDDL returns the first statement of a migration that names a schema, or switches
+// the search_path, and an empty string when there is none.
+func schemaQualifiedDDL(migration string) string {
+	migration = sqlLineComment.ReplaceAllString(migration, "")
+	for _, stmt := range strings.Split(migration, ";") {
+		for _, pattern := range schemaQualifiedDDLPatterns {
+			if pattern.MatchString(stmt) {
+				return strings.Join(strings.Fields(stmt), " ")
+			}
+		}
+	}
+
+	return ""
+}
+
+// postgresTableMapQuery returns a row per column of the tables in a schema, with the same
+// columns as tableMapQuery. References are reported as declared; postgresSchemaResults
+// follows them the way tableMapQuery does.
+const postgresTableMapQuery string = `WITH key_columns AS (
+		SELECT
+			con.conrelid,
+			k.attnum,
+			bool_or(con.contype = 'p') AS is_primary_key,
+			bool_or(con.contype = 'f') AS is_foreign_key,
+			MAX(k.ordinality) FILTER (WHERE con.contype = 'p') AS key_ordinal_position,
+			MAX(ref.relname::text) FILTER (WHERE con.contype = 'f') AS referenced_table,
+			MAX(refattr.attname::text) FILTER (WHERE con.contype = 'f') AS referenced_column
+		FROM pg_constraint con
+			CROSS JOIN LATERAL unnest(con.conkey) WITH ORDINALITY AS k(attnum, ordinality)
+			LEFT JOIN pg_class ref ON ref.oid = con.confrelid
+			LEFT JOIN pg_attribute refattr ON refattr.attrelid = con.confrelid
+				AND refattr.attnum = con.confkey[k.ordinality::int]
+		WHERE con.contype IN ('p', 'f')
+		GROUP BY con.conrelid, k.attnum
+	),
+	index_columns AS (
+		SELECT
+			i.indrelid,
+			k.attnum,
+			bool_or(i.indisunique) AS is_unique
+		FROM pg_index i
+			CROSS JOIN LATERAL unnest(i.indkey::int2[]) AS k(attnum)
+		GROUP BY i.indrelid, k.attnum
+	)
+	SELECT
+		c.relname::text AS table_name,
+		a.attname::text AS column_name,
+		NOT a.attnotnull AS is_nullable,
+		format_type(a.atttypid, a.atttypmod) AS column_type,
+		COALESCE(kc.is_primary_key, false) AS is_primary_key,
+		COALESCE(kc.is_foreign_key, false) AS is_foreign_key,
+		kc.referenced_table,
+		kc.referenced_column,
+		ic.attnum IS NOT NULL AS is_index,
+		COALESCE(ic.is_unique, false) AS is_unique_index,
+		CASE WHEN a.attgenerated <> '' THEN pg_get_expr(d.adbin, d.adrelid) END AS generation_expression,
+		row_number() OVER (PARTITION BY c.oid ORDER BY a.attnum) AS ordinal_position,
+		COALESCE(kc.key_ordinal_position, 1) AS key_ordinal_position,
+		a.atthasdef AND a.attgenerated = '' AS has_default
+	FROM pg_class c
+		JOIN pg_attribute a ON a.attrelid = c.oid
+			AND a.attnum > 0
+			AND NOT a.attisdropped
+		LEFT JOIN pg_attrdef d ON d.adrelid = c.oid AND d.adnum = a.attnum
+		LEFT JOIN key_columns kc ON kc.conrelid = c.oid AND kc.attnum = a.attnum
+		LEFT JOIN index_columns ic ON ic.indrelid = c.oid AND ic.attnum = a.attnum
+	WHERE
+		c.relnamespace = $1::text::regnamespace
+		AND c.relkind IN ('r', 'p')
+		AND NOT c.relispartition
+	ORDER BY c.relname, a.attnum`
+
+func queryPostgresCatalog(ctx context.Context, conn *pgx.Conn, schema string) ([]informationSchemaResult, error) {
+	rows, err := conn.Query(ctx, postgresTableMapQuery, schema)
+	if err != nil {
+		return nil, errors.Wrap(err, "pgx.Conn.Query()")
+	}
+
+	results, err := pgx.CollectRows(rows, scanPostgresColumn)
+	if err != nil {
+		return nil, errors.Wrap(err, "pgx.CollectRows()")
+	}
+
+	return postgresSchemaResults(results), nil
+}
+
+// scanPostgresColumn reads a row of postgresTableMapQuery, naming the column type the way
+// tableMapQuery does.
+func scanPostgresColumn(row pgx.CollectableRow) (informationSchemaResult, error) {
+	var r informationSchemaResult
+	var columnType string
+	if err := row.Scan(
+		&r.TableName, &r.ColumnName, &r.IsNullable, &columnType, &r.IsPrimaryKey, &r.IsForeignKey,
+		&r.ReferencedTable, &r.ReferencedColumn, &r.IsIndex, &r.IsUniqueIndex, &r.GenerationExpression,
+		&r.OrdinalPosition, &r.KeyOrdinalPosition, &r.HasDefault,
+	); err != nil {
+		return r, err
+	}
+	r.SpannerType = spannerTypeOfPostgres(columnType)
+
+	return r, nil
+}
+
+var postgresStringType = regexp.MustCompile(`^(?:character varying|character)\((\d+)\)$`)
+
+// spannerTypeOfPostgres returns the Spanner type of a column with the Postgres type t, as
+// written by format_type(), e.g. STRING(MAX) for text and ARRAY<INT64> for bigint[]. A type
+// Spanner has no counterpart for is returned unchanged.
+func spannerTypeOfPostgres(t string) string {
+	if elem, ok := strings.CutSuffix(t, "[]"); ok {
+		return "ARRAY<" + spannerTypeOfPostgres(elem) + ">"
+	}
+
+	if m := postgresStringType.FindStringSubmatch(t); m != nil {
+		return "STRING(" + m[1] + ")"
+	}
+
+	switch {
+	case strings.HasPrefix(t, "numeric"):
+		return "NUMERIC"
+	case strings.HasPrefix(t, "timestamp"):
+		return "TIMESTAMP"
+	}
+
+	switch t {
+	case "text", "character varying", "character", "citext":
+		return "STRING(MAX)"
+	case "uuid":
+		return "STRING(36)"
+	case "smallint", "integer", "bigint":
+		return "INT64"
+	case "real":
+		return "FLOAT32"
+	case "double precision":
+		return "FLOAT64"
+	case "boolean":
+		return "BOOL"
+	case "date":
+		return "DATE"
+	case "json", "jsonb":
+		return "JSON"
+	case "bytea":
+		return "BYTES(MAX)"
+	default:
+		return t
+	}
+}
+
+// postgresSchemaResults follows each foreign key that references another foreign key column
+// one step, to the column that one references, as tableMapQuery does, and names the tables in
+// PascalCase, the form resources are looked up by, so a docking_bays table becomes DockingBays.
+func postgresSchemaResults(results []informationSchemaResult) []informationSchemaResult {
+	type column struct{ table, column string }
+	references := make(map[column]column)
+	for i := range results {
+		if results[i].IsForeignKey && results[i].ReferencedTable != nil && results[i].ReferencedColumn != nil {
+			references[column{results[i].TableName, results[i].ColumnName}] = column{*results[i].ReferencedTable, *results[i].ReferencedColumn}
+		}
+	}
+
+	for i := range results {
+		ref, ok := references[column{results[i].TableName, results[i].ColumnName}]
+		if !ok {
+			continue
+		}
+		if next, ok := references[ref]; ok {
+			ref = next
+		}
+
+		referencedTable := strcase.ToPascal(ref.table)
+		results[i].ReferencedTable, results[i].ReferencedColumn = &referencedTable, &ref.column
+	}
+
+	for i := range results {
+		results[i].TableName = strcase.ToPascal(results[i].TableName)
+	}
+
+	return results
+}
+
+// fetchPostgresEnumValues reads the Id and Description of each table with a column named
+// Description in any case, keyed like the table lookup.
+func fetchPostgresEnumValues(ctx context.Context, conn *pgx.Conn, schema string) (map[string][]*enumData, error) {
+	qry := `
+	SELECT
+		c.table_name::text,
+		MAX(c.column_name::text) FILTER (WHERE lower(c.column_name) = 'id'),
+		MAX(c.column_name::text) FILTER (WHERE lower(c.column_name) = 'description')
+	FROM information_schema.columns c
+	JOIN information_schema.tables t ON t.table_schema = c.table_schema
+		AND t.table_name = c.table_name
+		AND t.table_type = 'BASE TABLE'
+	WHERE c.table_schema = $1
+	GROUP BY c.table_name
+	HAVING bool_or(lower(c.column_name) = 'description')
+	`
+
+	type enumTable struct {
+		name, idColumn, descriptionColumn string
+	}
+
+	rows, err := conn.Query(ctx, qry, schema)
+	if err != nil {
+		return nil, errors.Wrap(err, "pgx.Conn.Query()")
+	}
+
+	tables, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (enumTable, error) {
+		var t enumTable
+		var idColumn *string
+		if err := row.Scan(&t.name, &idColumn, &t.descriptionColumn); err != nil {
+			return t, err
+		}
+		if idColumn == nil {
+			return t, errors.Newf("table %q has a %s column but no id column", t.name, t.descriptionColumn)
+		}
+		t.idColumn = *idColumn
+
+		return t, nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "pgx.CollectRows()")
+	}
+
+	enumResults := make(map[string][]*enumData, len(tables))
+	for _, table := range tables {
+		qry := fmt.Sprintf("SELECT DISTINCT %[1]s::text, %[2]s::text FROM %[3]s ORDER BY %[1]s::text",
+			pgx.Identifier{table.idColumn}.Sanitize(), pgx.Identifier{table.descriptionColumn}.Sanitize(), pgx.Identifier{schema, table.name}.Sanitize())
+
+		rows, err := conn.Query(ctx, qry)
+		if err != nil {
+			return nil, errors.Wrap(err, "pgx.Conn.Query()")
+		}
+
+		results, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*enumData, error) {
+			e := &enumData{}
+			err := row.Scan(&e.ID, &e.Description)
+
+			return e, err
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "pgx.CollectRows()")
+		}
+
+		enumResults[strcase.ToPascal(table.name)] = results
+	}
+
+	return enumResults, nil
+}
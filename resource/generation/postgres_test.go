@@ -0,0 +1,205 @@
+package generation
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func Test_postgresSchemaResults(t *testing.T) {
+	t.Parallel()
+
+	ptr := func(s string) *string { return &s }
+	results := []informationSchemaResult{
+		{TableName: "ships", ColumnName: "id", IsPrimaryKey: true, IsIndex: true, IsUniqueIndex: true, OrdinalPosition: 1, KeyOrdinalPosition: 1},
+		{TableName: "berths", ColumnName: "id", IsPrimaryKey: true, IsIndex: true, IsUniqueIndex: true, OrdinalPosition: 1, KeyOrdinalPosition: 1},
+		{TableName: "berths", ColumnName: "ship_id", IsForeignKey: true, ReferencedTable: ptr("ships"), ReferencedColumn: ptr("id"), OrdinalPosition: 2, KeyOrdinalPosition: 1},
+		{TableName: "tug_boats", ColumnName: "id", IsPrimaryKey: true, IsIndex: true, IsUniqueIndex: true, OrdinalPosition: 1, KeyOrdinalPosition: 1},
+		{TableName: "tug_boats", ColumnName: "berth_ship_id", IsForeignKey: true, IsNullable: true, ReferencedTable: ptr("berths"), ReferencedColumn: ptr("ship_id"), OrdinalPosition: 2, KeyOrdinalPosition: 1},
+	}
+
+	want := map[string]*tableMetadata{
+		"Ships": {
+			Columns: map[string]columnMeta{"id": {IsPrimaryKey: true, IsIndex: true, IsUniqueIndex: true}},
+			PkCount: 1,
+		},
+		"Berths": {
+			Columns: map[string]columnMeta{
+				"id":      {IsPrimaryKey: true, IsIndex: true, IsUniqueIndex: true},
+				"ship_id": {IsForeignKey: true, OrdinalPosition: 1, ReferencedTable: "Ships", ReferencedColumn: "id"},
+			},
+			PkCount: 1,
+		},
+		// A reference to a foreign key column is followed to the column it references.
+		"TugBoats": {
+			Columns: map[string]columnMeta{
+				"id":            {IsPrimaryKey: true, IsIndex: true, IsUniqueIndex: true},
+				"berth_ship_id": {IsForeignKey: true, IsNullable: true, OrdinalPosition: 1, ReferencedTable: "Ships", ReferencedColumn: "id"},
+			},
+			PkCount: 1,
+		},
+	}
+	if diff := cmp.Diff(want, newTableMap(postgresSchemaResults(results))); diff != "" {
+		t.Errorf("newTableMap(postgresSchemaResults()) mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// catalogRow is a row of postgresTableMapQuery, scanned into its destinations in order.
+type catalogRow []any
+
+func (r catalogRow) FieldDescriptions() []pgconn.FieldDescription { return nil }
+
+func (r catalogRow) Values() ([]any, error) { return r, nil }
+
+func (r catalogRow) RawValues() [][]byte { return nil }
+
+func (r catalogRow) Scan(dest ...any) error {
+	for i := range dest {
+		reflect.ValueOf(dest[i]).Elem().Set(reflect.ValueOf(r[i]))
+	}
+
+	return nil
+}
+
+func Test_scanPostgresColumn(t *testing.T) {
+	t.Parallel()
+
+	ptr := func(s string) *string { return &s }
+	tests := []struct {
+		name string
+		row  catalogRow
+		want informationSchemaResult
+	}{
+		{
+			name: "primary key",
+			row:  catalogRow{"docking_bays", "id", false, "uuid", true, false, (*string)(nil), (*string)(nil), true, true, (*string)(nil), int64(1), int64(1), true},
+			want: informationSchemaResult{
+				TableName: "docking_bays", ColumnName: "id", SpannerType: "STRING(36)", IsPrimaryKey: true,
+				IsIndex: true, IsUniqueIndex: true, OrdinalPosition: 1, KeyOrdinalPosition: 1, HasDefault: true,
+			},
+		},
+		{
+			name: "nullable foreign key",
+			row:  catalogRow{"docking_bays", "ship_id", true, "uuid", false, true, ptr("ships"), ptr("id"), false, false, (*string)(nil), int64(2), int64(1), false},
+			want: informationSchemaResult{
+				TableName: "docking_bays", ColumnName: "ship_id", SpannerType: "STRING(36)", IsNullable: true, IsForeignKey: true,
+				ReferencedTable: ptr("ships"), ReferencedColumn: ptr("id"), OrdinalPosition: 2, KeyOrdinalPosition: 1,
+			},
+		},
+		{
+			name: "generated column",
+			row:  catalogRow{"docking_bays", "label", false, "character varying(64)", false, false, (*string)(nil), (*string)(nil), false, false, ptr("upper(name)"), int64(3), int64(1), false},
+			want: informationSchemaResult{
+				TableName: "docking_bays", ColumnName: "label", SpannerType: "STRING(64)", GenerationExpression: ptr("upper(name)"),
+				OrdinalPosition: 3, KeyOrdinalPosition: 1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := scanPostgresColumn(tt.row)
+			if err != nil {
+				t.Fatalf("scanPostgresColumn() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("scanPostgresColumn() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_spannerTypeOfPostgres(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		pgType string
+		want   string
+	}{
+		{pgType: "text", want: "STRING(MAX)"},
+		{pgType: "character varying(255)", want: "STRING(255)"},
+		{pgType: "character varying", want: "STRING(MAX)"},
+		{pgType: "uuid", want: "STRING(36)"},
+		{pgType: "bigint", want: "INT64"},
+		{pgType: "double precision", want: "FLOAT64"},
+		{pgType: "numeric(12,2)", want: "NUMERIC"},
+		{pgType: "boolean", want: "BOOL"},
+		{pgType: "timestamp with time zone", want: "TIMESTAMP"},
+		{pgType: "date", want: "DATE"},
+		{pgType: "jsonb", want: "JSON"},
+		{pgType: "bytea", want: "BYTES(MAX)"},
+		{pgType: "text[]", want: "ARRAY<STRING(MAX)>"},
+		{pgType: "tsvector", want: "tsvector"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pgType, func(t *testing.T) {
+			t.Parallel()
+
+			if got := spannerTypeOfPostgres(tt.pgType); got != tt.want {
+				t.Errorf("spannerTypeOfPostgres(%q) = %q, want %q", tt.pgType, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_schemaQualifiedDDL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		migration string
+		want      string
+	}{
+		{
+			name: "unqualified",
+			migration: `CREATE TABLE docking_bays (
+	id uuid PRIMARY KEY,
+	ship_id uuid REFERENCES ships (id) ON DELETE CASCADE
+);
+-- public.docking_bays is created on the search_path
+CREATE INDEX docking_bays_ship_id ON docking_bays (ship_id);`,
+		},
+		{
+			name:      "qualified table",
+			migration: "CREATE TABLE public.docking_bays (id uuid PRIMARY KEY);",
+			want:      "CREATE TABLE public.docking_bays (id uuid PRIMARY KEY)",
+		},
+		{
+			name:      "qualified and quoted drop",
+			migration: `DROP TABLE IF EXISTS "public"."ships";`,
+			want:      `DROP TABLE IF EXISTS "public"."ships"`,
+		},
+		{
+			name:      "qualified reference",
+			migration: "ALTER TABLE docking_bays ADD CONSTRAINT fk FOREIGN KEY (ship_id) REFERENCES public.ships (id);",
+			want:      "ALTER TABLE docking_bays ADD CONSTRAINT fk FOREIGN KEY (ship_id) REFERENCES public.ships (id)",
+		},
+		{
+			name:      "qualified index table",
+			migration: "CREATE UNIQUE INDEX ships_name ON public.ships (name);",
+			want:      "CREATE UNIQUE INDEX ships_name ON public.ships (name)",
+		},
+		{
+			name:      "search path",
+			migration: "SET search_path TO public;\nCREATE TABLE ships (id uuid);",
+			want:      "SET search_path TO public",
+		},
+		{
+			name:      "schema",
+			migration: "CREATE SCHEMA audit;",
+			want:      "CREATE SCHEMA audit",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := schemaQualifiedDDL(tt.migration); got != tt.want {
+				t.Errorf("schemaQualifiedDDL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
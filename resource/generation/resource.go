@@ -18,13 +18,19 @@ type resourceGenerator struct {
 	*client
 	genHandlers         bool
 	genRoutes           bool
+	genOpenAPI          bool
+	genGoClient         bool
 	handler             packageDir
 	router              packageDir
+	goClient            packageDir
 	routePrefix         string
+	openAPIPath         string
 	applicationName     string
 	receiverName        string
 	typescriptTargets   []typescriptTarget
 	manualRegistrations []ManualRegistration
+	// scope limits a watch mode run to the outputs its changes affect; nil regenerates all.
+	scope *regenerationScope
 }
 
 // NewResourceGenerator constructs a new Generator for generating a resource-driven API.
@@ -147,6 +153,16 @@ func (r *resourceGenerator) Generate() error {
 			return err
 		}
 	}
+	if r.genOpenAPI {
+		if err := r.runOpenAPIGeneration(); err != nil {
+			return err
+		}
+	}
+	if r.genGoClient {
+		if err := r.runGoClientGeneration(); err != nil {
+			return err
+		}
+	}
 
 	if err := r.populateCache(); err != nil {
 		return err
@@ -237,6 +253,7 @@ func (r *resourceGenerator) buildUnifiedTypescriptGenerator(gc *resource.Generat
 	t.client = r.client
 	t.rc = gc
 	t.routerResources = routerResources
+	t.routePrefix = r.routePrefix
 
 	return t, nil
 }
@@ -284,12 +301,12 @@ func (r *resourceGenerator) validateTypescriptTargets() error {
 }
 
 func (r *resourceGenerator) runResourcesGeneration() error {
-	if err := removeGeneratedFiles(r.resource.Dir(), prefix); err != nil {
+	if err := r.removeGeneratedFiles(r.resource.Dir(), prefix); err != nil {
 		return err
 	}
 
 	if r.genVirtualResources {
-		if err := removeGeneratedFiles(r.virtual.Dir(), prefix); err != nil {
+		if err := r.removeGeneratedFiles(r.virtual.Dir(), prefix); err != nil {
 			return err
 		}
 	}
@@ -335,6 +352,12 @@ func (r *resourceGenerator) generateResources(res *resourceInfo) error {
 		destinationFilePath = filepath.Join(r.virtual.Dir(), fileName)
 	}
 
+	if !r.scope.includesResource(r.client, res) {
+		r.keepGeneratedFile(destinationFilePath)
+
+		return nil
+	}
+
 	if err := r.writeFormattedGoFile(destinationFilePath, "resourceFileTemplate", resourceFileTemplate, &resourceFileData{
 		Source:   r.resource.Dir(),
 		Package:  packageName,
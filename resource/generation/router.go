@@ -14,7 +14,7 @@ import (
 
 func (r *resourceGenerator) runRouteGeneration() error {
 	begin := time.Now()
-	if err := removeGeneratedFiles(r.router.Dir(), prefix); err != nil {
+	if err := r.removeGeneratedFiles(r.router.Dir(), prefix); err != nil {
 		return err
 	}
 
@@ -23,9 +23,7 @@ func (r *resourceGenerator) runRouteGeneration() error {
 	routerTestRoutes := make([]*generatedRoute, 0, len(r.resources)+len(r.computedResources))
 	generatedRoutesMap := make(map[string][]*generatedRoute)
 	for _, res := range r.resources {
-		handlerTypes := resourceEndpoints(res)
-
-		if slices.Contains(handlerTypes, ReadHandler) {
+		if slices.Contains(resourceEndpoints(res), ReadHandler) {
 			constResources = append(constResources, res)
 		}
 
@@ -37,34 +35,9 @@ func (r *resourceGenerator) runRouteGeneration() error {
 			hasConsolidatedHandlers = true
 		}
 
-		for _, ht := range handlerTypes {
-			basePath := fmt.Sprintf("/%s/%s", r.routePrefix, strcase.ToKebab(r.pluralize(res.Name())))
-			route := &generatedRoute{
-				Method:      ht.method(),
-				Path:        basePath,
-				HandlerFunc: r.handlerName(res.Name(), ht),
-				HandlerType: ht,
-				TestURL:     basePath,
-			}
-			if ht == ReadHandler {
-				if res.HasCompoundPrimaryKey() {
-					var pkNames []string
-					for _, field := range res.PrimaryKeys() {
-						pkNames = append(pkNames, field.Name())
-					}
-					route.TestParams = readRouteTestParams(res.Name(), pkNames)
-				} else {
-					route.TestParams = []routeTestParam{{
-						Key:   strcase.ToGoCamel(res.Name() + "ID"),
-						Value: strcase.ToGoCamel(fmt.Sprintf("test%sID", caser.ToPascal(res.Name()))),
-					}}
-				}
-				route.appendParamsToPaths()
-			}
-
-			generatedRoutesMap[res.Name()] = append(generatedRoutesMap[res.Name()], route)
-			routerTestRoutes = append(routerTestRoutes, route)
-		}
+		routes := r.resourceRoutes(res)
+		generatedRoutesMap[res.Name()] = append(generatedRoutesMap[res.Name()], routes...)
+		routerTestRoutes = append(routerTestRoutes, routes...)
 	}
 
 	constComputedResources := make([]*computedResource, 0, len(r.computedResources))
@@ -77,39 +50,9 @@ func (r *resourceGenerator) runRouteGeneration() error {
 			continue
 		}
 
-		basePath := fmt.Sprintf("/%s/%s", r.routePrefix, strcase.ToKebab(r.pluralize(res.Name())))
-		if !res.SuppressReadHandler {
-			var pkNames []string
-			for _, field := range res.PrimaryKeys() {
-				pkNames = append(pkNames, field.Name())
-			}
-
-			route := &generatedRoute{
-				Method:      ReadHandler.method(),
-				Path:        basePath,
-				HandlerFunc: r.handlerName(res.Name(), ReadHandler),
-				HandlerType: ReadHandler,
-				TestURL:     basePath,
-				TestParams:  readRouteTestParams(res.Name(), pkNames),
-			}
-			route.appendParamsToPaths()
-
-			generatedRoutesMap[res.Name()] = append(generatedRoutesMap[res.Name()], route)
-			routerTestRoutes = append(routerTestRoutes, route)
-		}
-
-		if !res.SuppressListHandler {
-			route := &generatedRoute{
-				Method:      ListHandler.method(),
-				Path:        basePath,
-				HandlerFunc: r.handlerName(res.Name(), ListHandler),
-				HandlerType: ListHandler,
-				TestURL:     basePath,
-			}
-
-			generatedRoutesMap[res.Name()] = append(generatedRoutesMap[res.Name()], route)
-			routerTestRoutes = append(routerTestRoutes, route)
-		}
+		routes := r.computedRoutes(res)
+		generatedRoutesMap[res.Name()] = append(generatedRoutesMap[res.Name()], routes...)
+		routerTestRoutes = append(routerTestRoutes, routes...)
 	}
 
 	if r.genRPCMethods {
@@ -118,11 +61,11 @@ func (r *resourceGenerator) runRouteGeneration() error {
 				continue
 			}
 
-			generatedRoutesMap[rpcStruct.Name()] = []*generatedRoute{{
-				Method:      http.MethodPost,
-				Path:        fmt.Sprintf("/%s/%s", r.routePrefix, strcase.ToKebab(rpcStruct.Name())),
-				HandlerFunc: rpcStruct.Name(),
-			}}
+			generatedRoutesMap[rpcStruct.Name()] = []*generatedRoute{r.rpcRoute(rpcStruct)}
+		}
+
+		if len(r.asyncRPCMethods()) > 0 {
+			generatedRoutesMap[rpcOperationHandlerName] = []*generatedRoute{r.rpcOperationRoute()}
 		}
 	}
 
@@ -135,6 +78,7 @@ func (r *resourceGenerator) runRouteGeneration() error {
 		ConstComputedResources: constComputedResources,
 		RouterTestRoutes:       routerTestRoutes,
 		HasConsolidatedHandler: hasConsolidatedHandlers,
+		HasRPCOperations:       r.genRPCMethods && len(r.asyncRPCMethods()) > 0,
 		RoutePrefix:            r.routePrefix,
 		ConsolidatedRoute:      r.ConsolidatedRoute,
 	}
@@ -155,6 +99,144 @@ func (r *resourceGenerator) runRouteGeneration() error {
 	return nil
 }
 
+// resourceRoutes returns the routes of a resource's generated handlers, in handler order.
+func (r *resourceGenerator) resourceRoutes(res *resourceInfo) []*generatedRoute {
+	handlerTypes := resourceEndpoints(res)
+	routes := make([]*generatedRoute, 0, len(handlerTypes))
+	for _, ht := range handlerTypes {
+		if ht == NestedListHandler || ht == NestedPatchHandler {
+			routes = append(routes, r.nestedRoute(res, ht))
+
+			continue
+		}
+
+		basePath := fmt.Sprintf("/%s/%s", r.routePrefix, strcase.ToKebab(r.pluralize(res.Name())))
+		route := &generatedRoute{
+			Method:      ht.method(),
+			Path:        basePath,
+			HandlerFunc: r.handlerName(res.Name(), ht),
+			HandlerType: ht,
+			TestURL:     basePath,
+		}
+		if ht == ReadHandler {
+			if res.HasCompoundPrimaryKey() {
+				var pkNames []string
+				for _, field := range res.PrimaryKeys() {
+					pkNames = append(pkNames, field.Name())
+				}
+				route.TestParams = readRouteTestParams(res.Name(), pkNames)
+			} else {
+				route.TestParams = []routeTestParam{{
+					Key:   strcase.ToGoCamel(res.Name() + "ID"),
+					Value: strcase.ToGoCamel(fmt.Sprintf("test%sID", caser.ToPascal(res.Name()))),
+				}}
+			}
+			route.appendParamsToPaths()
+		}
+		if ht == BatchReadHandler {
+			route.Path += ":batchGet"
+			route.TestURL += ":batchGet"
+		}
+
+		routes = append(routes, route)
+	}
+
+	return routes
+}
+
+// computedRoutes returns the routes of a computed resource's generated read and list handlers.
+func (r *resourceGenerator) computedRoutes(res *computedResource) []*generatedRoute {
+	var routes []*generatedRoute
+	basePath := fmt.Sprintf("/%s/%s", r.routePrefix, strcase.ToKebab(r.pluralize(res.Name())))
+	if !res.SuppressReadHandler {
+		var pkNames []string
+		for _, field := range res.PrimaryKeys() {
+			pkNames = append(pkNames, field.Name())
+		}
+
+		route := &generatedRoute{
+			Method:      ReadHandler.method(),
+			Path:        basePath,
+			HandlerFunc: r.handlerName(res.Name(), ReadHandler),
+			HandlerType: ReadHandler,
+			TestURL:     basePath,
+			TestParams:  readRouteTestParams(res.Name(), pkNames),
+		}
+		route.appendParamsToPaths()
+
+		routes = append(routes, route)
+	}
+
+	if !res.SuppressListHandler {
+		routes = append(routes, &generatedRoute{
+			Method:      ListHandler.method(),
+			Path:        basePath,
+			HandlerFunc: r.handlerName(res.Name(), ListHandler),
+			HandlerType: ListHandler,
+			TestURL:     basePath,
+		})
+	}
+
+	return routes
+}
+
+// rpcRoute returns the route of an RPC method's generated handler.
+func (r *resourceGenerator) rpcRoute(method *rpcMethodInfo) *generatedRoute {
+	return &generatedRoute{
+		Method:      http.MethodPost,
+		Path:        fmt.Sprintf("/%s/%s", r.routePrefix, strcase.ToKebab(method.Name())),
+		HandlerFunc: method.Name(),
+	}
+}
+
+// rpcOperationRoute returns the route of the generated handler reporting the status of an
+// @async RPC method's operation.
+func (r *resourceGenerator) rpcOperationRoute() *generatedRoute {
+	return &generatedRoute{
+		Method:      http.MethodGet,
+		Path:        fmt.Sprintf("%s/{%s}", r.rpcOperationsRoute(), rpcOperationParam),
+		HandlerFunc: rpcOperationHandlerName,
+	}
+}
+
+// rpcOperationsRoute returns the path operations are addressed under: the generated status
+// route is <path>/{rpcOperationID}.
+func (r *resourceGenerator) rpcOperationsRoute() string {
+	return fmt.Sprintf("/%s/rpc-operations", r.routePrefix)
+}
+
+// consolidatedRoute returns the path of the generated PatchResources handler.
+func (r *resourceGenerator) consolidatedRoute() string {
+	return fmt.Sprintf("/%s/%s", r.routePrefix, r.ConsolidatedRoute)
+}
+
+// nestedRoute builds the route of an interleaved child's handler under its parent row, for
+// example /api/ships/{shipID}/cargo-manifests. The parent key parameters are the ones the
+// parent's read route declares.
+func (r *resourceGenerator) nestedRoute(res *resourceInfo, ht HandlerType) *generatedRoute {
+	basePath := fmt.Sprintf("/%s/%s", r.routePrefix, strcase.ToKebab(r.pluralize(res.Parent.Name())))
+	var pkNames []string
+	for _, field := range res.Parent.PrimaryKeys() {
+		pkNames = append(pkNames, field.Name())
+	}
+
+	route := &generatedRoute{
+		Method:      ht.method(),
+		Path:        basePath,
+		HandlerFunc: r.nestedHandlerName(res, ht),
+		HandlerType: ht,
+		TestURL:     basePath,
+		TestParams:  readRouteTestParams(res.Parent.Name(), pkNames),
+	}
+	route.appendParamsToPaths()
+
+	childPath := "/" + strcase.ToKebab(r.pluralize(res.Name()))
+	route.Path += childPath
+	route.TestURL += childPath
+
+	return route
+}
+
 // readRouteTestParams returns one route parameter per primary-key field for
 // addressing a read route in the generated router tests.
 func readRouteTestParams(resourceName string, pkNames []string) []routeTestParam {
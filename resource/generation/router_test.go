@@ -49,3 +49,60 @@ func Test_readRouteTestParams(t *testing.T) {
 		})
 	}
 }
+
+func Test_resourceGenerator_nestedRoute(t *testing.T) {
+	t.Parallel()
+
+	structs := fixtureStructs(loadCollectionFixture(t))
+	parent := fixtureResource(t, structs, "Manifest", nil)
+	child := fixtureResource(t, structs, "ManifestLine", func(res *resourceInfo) {
+		res.PkCount = 2
+		for i, field := range res.Fields {
+			field.IsPrimaryKey = field.Name() != "Cargo"
+			field.KeyOrdinalPosition = int64(i + 1)
+		}
+		res.Parent = parent
+	})
+
+	r := &resourceGenerator{client: &client{}, routePrefix: "api"}
+
+	tests := []struct {
+		name        string
+		handlerType HandlerType
+		want        *generatedRoute
+	}{
+		{
+			name:        "nested list",
+			handlerType: NestedListHandler,
+			want: &generatedRoute{
+				Method:      "GET",
+				Path:        "/api/manifests/{manifestID}/manifest-lines",
+				HandlerFunc: "ManifestManifestLines",
+				HandlerType: NestedListHandler,
+				TestURL:     "/api/manifests/testManifestID/manifest-lines",
+				TestParams:  []routeTestParam{{Key: "manifestID", Value: "testManifestID"}},
+			},
+		},
+		{
+			name:        "nested patch",
+			handlerType: NestedPatchHandler,
+			want: &generatedRoute{
+				Method:      "PATCH",
+				Path:        "/api/manifests/{manifestID}/manifest-lines",
+				HandlerFunc: "PatchManifestManifestLines",
+				HandlerType: NestedPatchHandler,
+				TestURL:     "/api/manifests/testManifestID/manifest-lines",
+				TestParams:  []routeTestParam{{Key: "manifestID", Value: "testManifestID"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := r.nestedRoute(child, tt.handlerType)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("resourceGenerator.nestedRoute() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
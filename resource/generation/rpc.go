@@ -11,7 +11,7 @@ import (
 )
 
 func (r *resourceGenerator) runRPCGeneration() error {
-	if err := removeGeneratedFiles(r.rpc.Dir(), prefix); err != nil {
+	if err := r.removeGeneratedFiles(r.rpc.Dir(), prefix); err != nil {
 		return err
 	}
 
@@ -33,6 +33,11 @@ func (r *resourceGenerator) runRPCGeneration() error {
 func (r *resourceGenerator) generateRPCMethod(rpc *rpcMethodInfo) error {
 	fileName := generatedGoFileName(strings.ToLower(caser.ToSnake(rpc.Name())))
 	destinationFilePath := filepath.Join(r.rpc.Dir(), fileName)
+	if !r.scope.includesRPC() {
+		r.keepGeneratedFile(destinationFilePath)
+
+		return nil
+	}
 
 	if err := r.writeFormattedGoFile(destinationFilePath, fmt.Sprintf("rpcFileTemplate:%q", rpc.Name()), rpcFileTemplate, &rpcFileData{
 		Source:    r.rpc.Dir(),
@@ -49,6 +54,11 @@ func (r *resourceGenerator) generateRPCHandler(rpcMethod *rpcMethodInfo) error {
 	begin := time.Now()
 	fileName := generatedGoFileName(strings.ToLower(caser.ToSnake(rpcMethod.Name())))
 	destinationFilePath := filepath.Join(r.handler.Dir(), fileName)
+	if !r.scope.includesRPC() {
+		r.keepGeneratedFile(destinationFilePath)
+
+		return nil
+	}
 
 	if err := r.writeFormattedGoFile(destinationFilePath, fmt.Sprintf("rcpHandlerTemplate:%q", rpcMethod.Name()), rpcHandlerTemplate, &rpcHandlerData{
 		Source:              r.rpc.Dir(),
@@ -57,6 +67,7 @@ func (r *resourceGenerator) generateRPCHandler(rpcMethod *rpcMethodInfo) error {
 		Package:             r.handler.Package(),
 		ApplicationName:     r.applicationName,
 		ReceiverName:        r.receiverName,
+		OperationsRoute:     r.rpcOperationsRoute(),
 	}); err != nil {
 		return errors.Wrap(err, "writeFormattedGoFile()")
 	}
@@ -66,6 +77,28 @@ func (r *resourceGenerator) generateRPCHandler(rpcMethod *rpcMethodInfo) error {
 	return nil
 }
 
+// generateRPCOperationHandler generates the operation status handler of the @async RPC methods and
+// the registration of their runners with a worker.
+func (r *resourceGenerator) generateRPCOperationHandler(asyncMethods []*rpcMethodInfo) error {
+	begin := time.Now()
+	destinationFilePath := filepath.Join(r.handler.Dir(), generatedGoFileName(rpcOperationOutputName))
+
+	if err := r.writeFormattedGoFile(destinationFilePath, "rpcOperationHandlerTemplate", rpcOperationHandlerTemplate, &rpcOperationHandlerData{
+		Source:              r.rpc.Dir(),
+		LocalPackageImports: r.localPackageImports(),
+		RPCMethods:          asyncMethods,
+		Package:             r.handler.Package(),
+		ApplicationName:     r.applicationName,
+		ReceiverName:        r.receiverName,
+	}); err != nil {
+		return errors.Wrap(err, "writeFormattedGoFile()")
+	}
+
+	log.Printf("Generated RPC operation handler file in %s: %s", time.Since(begin), destinationFilePath)
+
+	return nil
+}
+
 func (r *resourceGenerator) generateRPCInterfaces() error {
 	destinationFile := filepath.Join(".", r.rpc.Dir(), generatedGoFileName("rpc_iface"))
 
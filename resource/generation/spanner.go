@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"regexp"
+	"slices"
 
 	"cloud.google.com/go/spanner"
 	initiator "github.com/cccteam/db-initiator"
@@ -63,6 +65,23 @@ func (c *client) runSpanner(ctx context.Context, emulatorVersion string, migrati
 	return nil
 }
 
+// tokenizeCall matches the column argument of a TOKENIZE_* function in a TOKENLIST column's
+// generation expression, e.g. TOKENIZE_FULLTEXT(Name).
+var tokenizeCall = regexp.MustCompile(`TOKENIZE_\w+\(\s*` + "`?" + `(\w+)`)
+
+// tokenizedColumns returns the columns a TOKENLIST generation expression tokenizes. A
+// TOKENLIST_CONCAT of several TOKENIZE_* calls returns each of their columns.
+func tokenizedColumns(expression string) []string {
+	var columns []string
+	for _, match := range tokenizeCall.FindAllStringSubmatch(expression, -1) {
+		if !slices.Contains(columns, match[1]) {
+			columns = append(columns, match[1])
+		}
+	}
+
+	return columns
+}
+
 func createTableMapUsingQuery(ctx context.Context, db *spanner.Client) (map[string]*tableMetadata, error) {
 	log.Println("Creating spanner table lookup...")
 
@@ -71,6 +90,12 @@ func createTableMapUsingQuery(ctx context.Context, db *spanner.Client) (map[stri
 		return nil, err
 	}
 
+	return newTableMap(results), nil
+}
+
+// newTableMap builds the table lookup from the rows of the information schema query, one per
+// column.
+func newTableMap(results []informationSchemaResult) map[string]*tableMetadata {
 	schemaMetadata := make(map[string]*tableMetadata)
 	for i := range results {
 		table, ok := schemaMetadata[results[i].TableName]
@@ -79,13 +104,16 @@ func createTableMapUsingQuery(ctx context.Context, db *spanner.Client) (map[stri
 				Columns:       make(map[string]columnMeta),
 				IsInterleaved: results[i].IsInterleaved,
 			}
+			if results[i].ParentTableName != nil {
+				table.ParentTable = *results[i].ParentTableName
+			}
 		}
 
 		table.addSchemaResult(&results[i])
 		schemaMetadata[results[i].TableName] = table
 	}
 
-	return schemaMetadata, nil
+	return schemaMetadata
 }
 
 const tableMapQuery string = `WITH DEPENDENCIES AS (
@@ -139,11 +167,13 @@ const tableMapQuery string = `WITH DEPENDENCIES AS (
 		d.REFERENCED_COLUMN,
 		ic.INDEX_NAME IS NOT NULL AS IS_INDEX,
 		MAX(COALESCE(i.IS_UNIQUE, false)) AS IS_UNIQUE_INDEX,
+		MAX(COALESCE(i.INDEX_TYPE = 'SEARCH', false)) AS IS_SEARCH_INDEX,
 		c.GENERATION_EXPRESSION,
 		c.ORDINAL_POSITION,
 		COALESCE(d.KEY_ORDINAL_POSITION, 1) AS KEY_ORDINAL_POSITION,
 		c.COLUMN_DEFAULT IS NOT NULL AS HAS_DEFAULT,
 		t.PARENT_TABLE_NAME IS NOT NULL AS IS_INTERLEAVED,
+		t.PARENT_TABLE_NAME,
 	FROM INFORMATION_SCHEMA.COLUMNS c
 		LEFT JOIN INFORMATION_SCHEMA.TABLES t ON c.TABLE_NAME = t.TABLE_NAME
 			AND t.TABLE_TYPE = 'BASE TABLE'
@@ -1,6 +1,9 @@
 package generation
 
 import (
+	"slices"
+	"strings"
+
 	"github.com/cccteam/ccc/accesstypes"
 	"github.com/cccteam/ccc/resource"
 	"github.com/cccteam/ccc/resource/generation/parser"
@@ -129,8 +132,10 @@ type routerFileData struct {
 	ConstComputedResources []*computedResource
 	RouterTestRoutes       []*generatedRoute
 	HasConsolidatedHandler bool
-	RoutePrefix            string
-	ConsolidatedRoute      string
+	// HasRPCOperations is set when an @async RPC method is routed, for the operation status route.
+	HasRPCOperations  bool
+	RoutePrefix       string
+	ConsolidatedRoute string
 }
 
 type rpcFileData struct {
@@ -150,12 +155,32 @@ type rpcHandlerData struct {
 	Package             string
 	ApplicationName     string
 	ReceiverName        string
+	// OperationsRoute is the path the operations of an @async method are addressed under.
+	OperationsRoute string
 }
 
 func (d *rpcHandlerData) typeImports() []fixerImport {
 	return rpcTypeImports(nil, d.RPCMethod)
 }
 
+type rpcOperationHandlerData struct {
+	Source              string
+	LocalPackageImports string
+	RPCMethods          []*rpcMethodInfo
+	Package             string
+	ApplicationName     string
+	ReceiverName        string
+}
+
+func (d *rpcOperationHandlerData) typeImports() []fixerImport {
+	var imports []fixerImport
+	for _, method := range d.RPCMethods {
+		imports = appendTypeImports(imports, method.Imports())
+	}
+
+	return imports
+}
+
 type rpcInterfacesData struct {
 	Source  string
 	Package string
@@ -172,10 +197,12 @@ func (d *rpcInterfacesData) typeImports() []fixerImport {
 }
 
 type tsConstantsData struct {
-	File       *typescriptGenerator
-	Data       *resource.TypescriptData
-	RPCMethods []*rpcMethodInfo
-	PIIMap     map[accesstypes.Resource]map[accesstypes.Tag]bool
+	File          *typescriptGenerator
+	Data          *resource.TypescriptData
+	RPCMethods    []*rpcMethodInfo
+	PIIMap        map[accesstypes.Resource]map[accesstypes.Tag]bool
+	MaskMap       map[accesstypes.Resource]map[accesstypes.Tag]string
+	OutputOnlyMap map[accesstypes.Resource]map[accesstypes.Tag]bool
 }
 
 type tsResourcesData struct {
@@ -192,6 +219,321 @@ type tsMethodsData struct {
 	GenPrefix  string
 }
 
+// HasAsync reports whether any method is @async, so the operation interfaces are declared.
+func (d tsMethodsData) HasAsync() bool {
+	return slices.ContainsFunc(d.RPCMethods, func(m *rpcMethodInfo) bool { return m.Async })
+}
+
+// tsClientData is the data of the TypeScript API client. Consolidated lists the resources
+// PatchResources patches, and is empty when no PatchResources route is generated.
+type tsClientData struct {
+	Interfaces        []string
+	Resources         []tsClientResource
+	Consolidated      []tsClientResource
+	ConsolidatedRoute string
+	RPCMethods        []*rpcMethodInfo
+	GenPrefix         string
+	APIBaseURL        string // the route prefix the generated routes are served under, e.g. /api
+}
+
+// tsClientResource is a resource or computed resource of the TypeScript API client, rendered
+// into the client's TypeScript: key parameter lists, route paths and field unions.
+type tsClientResource struct {
+	Interface       string // the generated row interface, e.g. Ships
+	Name            string
+	Route           string
+	Handlers        []HandlerType
+	KeyParams       string // e.g. id: string
+	KeyPath         string // e.g. keyPath(id)
+	KeyType         string
+	ParentName      string
+	ParentRoute     string
+	ParentKeyParams string
+	ParentKeyPath   string
+	FilterFields    string // a union of string literals, or never
+	SortFields      string
+	DateFields      string // an array literal, or empty when no field is a Date
+	ReturnsIDs      bool
+}
+
+// Has reports whether the client has a function for the handler type.
+func (r tsClientResource) Has(ht HandlerType) bool {
+	return slices.Contains(r.Handlers, ht)
+}
+
+// reads reports whether the client has a function returning the resource's rows.
+func (r tsClientResource) reads() bool {
+	return slices.ContainsFunc(r.Handlers, func(ht HandlerType) bool {
+		return ht == ListHandler || ht == ReadHandler || ht == BatchReadHandler || ht == NestedListHandler
+	})
+}
+
+// QueryImports returns the names the Angular services import from the query file, so they
+// don't import a name they don't use.
+func (d tsClientData) QueryImports() string {
+	return d.queryImports(false)
+}
+
+// ClientQueryImports returns the names the client imports from the query file. Its list
+// helper is also typed with ListQuery.
+func (d tsClientData) ClientQueryImports() string {
+	return d.queryImports(true)
+}
+
+func (d tsClientData) queryImports(client bool) string {
+	var names []string
+	for _, r := range d.Resources {
+		if r.Has(ListHandler) || r.Has(NestedListHandler) {
+			names = append(names, r.Interface+"Query", "listRequest")
+			if client {
+				names = append(names, "ListQuery")
+			}
+		}
+		if r.Has(ReadHandler) || r.Has(BatchReadHandler) {
+			names = append(names, r.Interface+"ReadOptions", "readParams")
+		}
+		if r.Has(BatchReadHandler) {
+			names = append(names, "formatValue")
+		}
+		if r.Has(ReadHandler) || r.Has(NestedListHandler) || r.Has(NestedPatchHandler) {
+			names = append(names, "keyPath")
+		}
+		if r.Has(PatchHandler) || r.Has(NestedPatchHandler) {
+			names = append(names, "PatchOperation")
+		}
+		if r.DateFields != "" && r.reads() {
+			names = append(names, "reviveDates")
+		}
+	}
+	if len(d.Consolidated) != 0 {
+		names = append(names, "ResourceOperation")
+	}
+	if d.hasResponseDates() || d.HasAsync() {
+		names = append(names, "reviveDates")
+	}
+	slices.Sort(names)
+
+	return strings.Join(slices.Compact(names), ", ")
+}
+
+// ResourceInterfaces returns the row interfaces of the routed resources. The interfaces of
+// resources only patched through PatchResources are used by the query file alone.
+func (d tsClientData) ResourceInterfaces() string {
+	names := make([]string, 0, len(d.Resources))
+	for _, r := range d.Resources {
+		names = append(names, r.Interface)
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// HasList reports whether any resource has a list or nested list function.
+func (d tsClientData) HasList() bool {
+	return slices.ContainsFunc(d.Resources, func(r tsClientResource) bool {
+		return r.Has(ListHandler) || r.Has(NestedListHandler)
+	})
+}
+
+// HasSignals reports whether any Angular service has a listSignal or getSignal helper.
+func (d tsClientData) HasSignals() bool {
+	return slices.ContainsFunc(d.Resources, func(r tsClientResource) bool {
+		return r.Has(ListHandler) || r.Has(ReadHandler)
+	})
+}
+
+// HasDates reports whether any function returns rows, an RPC response or an operation with
+// Date fields to revive.
+func (d tsClientData) HasDates() bool {
+	return d.hasResponseDates() || d.HasAsync() || slices.ContainsFunc(d.Resources, func(r tsClientResource) bool {
+		return r.DateFields != "" && r.reads()
+	})
+}
+
+func (d tsClientData) hasResponseDates() bool {
+	return slices.ContainsFunc(d.RPCMethods, func(m *rpcMethodInfo) bool {
+		return !m.Async && m.ResponseDateFields() != ""
+	})
+}
+
+// HasAsync reports whether any method is @async, so the operation status function is
+// generated.
+func (d tsClientData) HasAsync() bool {
+	return slices.ContainsFunc(d.RPCMethods, func(m *rpcMethodInfo) bool { return m.Async })
+}
+
+// MethodImports returns the names the client and Angular services import from the methods
+// file: each method's request interface, and its response interface when it has one. An
+// @async method responds with its operation instead.
+func (d tsClientData) MethodImports() string {
+	names := make([]string, 0, len(d.RPCMethods))
+	for _, method := range d.RPCMethods {
+		names = append(names, method.Name())
+		if method.HasResponse() && !method.Async {
+			names = append(names, method.Name()+"Response")
+		}
+	}
+	if d.HasAsync() {
+		names = append(names, "RPCOperation", "RPCOperationAccepted")
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// tsSchemaData is the data of the zod schemas. Each field's schema is rendered by the
+// generator, so the template only lays out the objects.
+type tsSchemaData struct {
+	Resources  []tsSchemaResource
+	RPCMethods []tsSchemaResource
+	HasLink    bool
+}
+
+// tsSchemaResource is the schemas of a resource, computed resource or RPC method. Create and
+// Update are empty for a resource that can't be patched.
+type tsSchemaResource struct {
+	Name     string // the schema's name without the Schema suffix, e.g. ships
+	Row      []tsSchemaField
+	Create   []tsSchemaField
+	Update   []tsSchemaField
+	Response []tsSchemaField // an RPC method's response fields
+}
+
+type tsSchemaField struct {
+	Name   string
+	Schema string // e.g. z.string().nullable()
+}
+
+// goClientData is the data of the Go client's shared file. Resources and RPCMethods are the
+// client's other files. Consolidated lists the resources PatchResources patches, and is empty
+// when no PatchResources route is generated.
+type goClientData struct {
+	Source            string
+	Package           string
+	Resources         []*goClientResource
+	Consolidated      []*goClientResource
+	ConsolidatedRoute string
+	RPCMethods        []*rpcMethodInfo
+}
+
+// HasAsync reports whether any method is @async, so the client reads operations.
+func (d *goClientData) HasAsync() bool {
+	return slices.ContainsFunc(d.RPCMethods, func(m *rpcMethodInfo) bool { return m.Async })
+}
+
+// typeImports covers the consolidated resources, whose keys and patches the shared file renders.
+func (d *goClientData) typeImports() []fixerImport {
+	var imports []fixerImport
+	for _, res := range d.Consolidated {
+		imports = append(imports, res.imports...)
+	}
+
+	return imports
+}
+
+type goClientResourceData struct {
+	Source   string
+	Package  string
+	Resource *goClientResource
+}
+
+func (d *goClientResourceData) typeImports() []fixerImport {
+	return d.Resource.imports
+}
+
+// goClientResource is a resource or computed resource of the Go client. Query is nil for a
+// computed resource, which has no query clause.
+type goClientResource struct {
+	Name        string // e.g. Ship
+	Plural      string // e.g. Ships
+	Route       string // e.g. /ships
+	Handlers    []HandlerType
+	Fields      []goClientField // the fields of a row
+	PatchFields []goClientField // the fields a create or update sets
+	Keys        []goClientField
+	ParentName  string
+	ParentRoute string
+	ParentKeys  []goClientField
+	ChildKeys   []goClientField // the keys of a nested patch operation's path
+	ReturnsIDs  bool
+	// Consolidated reports whether PatchResources patches the resource.
+	Consolidated bool
+	Query        *resourceInfo
+
+	imports []fixerImport
+}
+
+// Has reports whether the client has a function for the handler type.
+func (r *goClientResource) Has(ht HandlerType) bool {
+	return slices.Contains(r.Handlers, ht)
+}
+
+// Patchable reports whether the client creates and updates the resource's rows, and so needs
+// its patch type.
+func (r *goClientResource) Patchable() bool {
+	return r.Consolidated || r.Has(PatchHandler) || r.Has(NestedPatchHandler)
+}
+
+// KeyParams returns the parameter list of the primary key, e.g. shipID ccc.UUID, lineNumber int64.
+func (r *goClientResource) KeyParams() string {
+	return goKeyParams(r.Keys)
+}
+
+// KeyArgs returns the arguments of the primary key, e.g. shipID, lineNumber.
+func (r *goClientResource) KeyArgs() string {
+	return goKeyArgs(r.Keys)
+}
+
+func (r *goClientResource) ParentKeyParams() string {
+	return goKeyParams(r.ParentKeys)
+}
+
+func (r *goClientResource) ParentKeyArgs() string {
+	return goKeyArgs(r.ParentKeys)
+}
+
+func (r *goClientResource) ChildKeyParams() string {
+	return goKeyParams(r.ChildKeys)
+}
+
+func (r *goClientResource) ChildKeyArgs() string {
+	return goKeyArgs(r.ChildKeys)
+}
+
+func goKeyParams(keys []goClientField) string {
+	params := make([]string, 0, len(keys))
+	for _, key := range keys {
+		params = append(params, key.Param+" "+key.Type)
+	}
+
+	return strings.Join(params, ", ")
+}
+
+func goKeyArgs(keys []goClientField) string {
+	args := make([]string, 0, len(keys))
+	for _, key := range keys {
+		args = append(args, key.Param)
+	}
+
+	return strings.Join(args, ", ")
+}
+
+type goClientField struct {
+	Name      string
+	JSONName  string
+	Param     string // the parameter name of a key, e.g. shipID
+	Type      string
+	Immutable bool
+}
+
+type goClientRPCData struct {
+	Source    string
+	Package   string
+	RPCMethod *rpcMethodInfo
+}
+
+func (d *goClientRPCData) typeImports() []fixerImport {
+	return rpcTypeImports(nil, d.RPCMethod)
+}
+
 type tsEnumsData struct {
 	Source     string
 	NamedTypes []*parser.NamedType
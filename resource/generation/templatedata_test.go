@@ -19,6 +19,7 @@ var (
 	_ typeImporter = (*rpcFileData)(nil)
 	_ typeImporter = (*rpcHandlerData)(nil)
 	_ typeImporter = (*rpcInterfacesData)(nil)
+	_ typeImporter = (*rpcOperationHandlerData)(nil)
 )
 
 // scopedPayload is a minimal typeImporter for exercising payload-scoped import
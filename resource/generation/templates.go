@@ -25,7 +25,9 @@ package {{ .Package }}
 import (
 	"context"
 	"iter"
+	"time"
 
+	"cloud.google.com/go/spanner"
 	"github.com/cccteam/ccc"
 	"github.com/cccteam/ccc/accesstypes"
 	"github.com/cccteam/ccc/resource"
@@ -39,11 +41,55 @@ func ({{ .Resource.Name }}) Resource() accesstypes.Resource {
 
 func ({{ .Resource.Name }}) DefaultConfig() resource.Config {
 	{{- if not .Resource.IsVirtual }}
-	return defaultConfig()
+	return defaultConfig(){{ .Resource.QueryPolicyConfig }}
 	{{- else }}
-	return resource.Config{}
+	return resource.Config{}{{ .Resource.QueryPolicyConfig }}
 	{{- end }}
 }
+{{- if .Resource.HasRowPolicy }}
+
+// RowPolicy restricts the rows a user can access to those matched by {{ .Resource.RowPolicyFunc }}.
+// A nil clause places no restriction.
+func ({{ .Resource.Name }}) RowPolicy(ctx context.Context, userPermissions resource.UserPermissions) (resource.ExpressionNode, error) {
+	qc, err := {{ .Resource.RowPolicyFunc }}(ctx, userPermissions)
+	if err != nil {
+		return nil, errors.Wrap(err, "{{ .Resource.RowPolicyFunc }}()")
+	}
+	if qc == nil {
+		return nil, nil
+	}
+	if err := qc.clause.Err(); err != nil {
+		return nil, errors.Wrap(err, "{{ .Resource.RowPolicyFunc }}()")
+	}
+
+	return qc.clause.Expression(), nil
+}
+{{- end }}
+{{- if not .Resource.IsVirtual }}
+
+// PrimaryKeyFields returns the primary key fields in key order.
+func ({{ .Resource.Name }}) PrimaryKeyFields() []accesstypes.Field {
+	return []accesstypes.Field{ {{- range $i, $field := .Resource.PrimaryKeyFields }}{{ if $i }}, {{ end }}"{{ $field.Name }}"{{ end -}} }
+}
+{{- end }}
+{{- with .Resource.EncryptedFields }}
+
+// EncryptedFields returns the fields encrypted before they are written to the database.
+func ({{ $.Resource.Name }}) EncryptedFields() []accesstypes.Field {
+	return []accesstypes.Field{ {{- range $i, $field := . }}{{ if $i }}, {{ end }}"{{ $field.Name }}"{{ end -}} }
+}
+{{- end }}
+{{- with .Resource.SearchFields }}
+
+// SearchTokens maps each searchable field to the search-indexed TOKENLIST column tokenized from it.
+func ({{ $.Resource.Name }}) SearchTokens() map[accesstypes.Field]string {
+	return map[accesstypes.Field]string{
+	{{- range . }}
+		"{{ .Name }}": "{{ .SearchToken }}",
+	{{- end }}
+	}
+}
+{{- end }}
 
 type {{ .Resource.Name }}Query struct {
 	qSet *resource.QuerySet[{{ .Resource.Name }}]
@@ -72,11 +118,50 @@ func (q *{{ $field.Parent.Name }}Query) {{ $field.Name }}() {{ $field.ResolvedTy
 }
 {{ end }}
 {{ end }}
+{{- if and (not .Resource.IsVirtual) .Resource.HasCompoundPrimaryKey }}
+{{- range $prefix := .Resource.KeyPrefixes }}
+{{- if lt (len $prefix) $.Resource.PkCount }}
+
+// SetKeyPrefix{{ $prefix.Name }} restricts the query to the rows whose primary key begins with {{ $prefix.Name }}.
+func (q *{{ $.Resource.Name }}Query) SetKeyPrefix{{ $prefix.Name }}({{ range $i, $field := $prefix }}{{ if $i }}, {{ end }}{{ GoCamel $field.Name }} {{ $field.ResolvedType }}{{ end }}) *{{ $.Resource.Name }}Query {
+	q.qSet.SetKeyPrefix(resource.KeySet{}{{ range $prefix }}.Add("{{ .Name }}", {{ GoCamel .Name }}){{ end }})
+
+	return q
+}
+{{- end }}
+
+// Set{{ $prefix.Last.Name }}Range restricts the query to the rows {{ with $prefix.Leading }}under the given {{ range $i, $field := . }}{{ if $i }} and {{ end }}{{ $field.Name }}{{ end }} {{ end }}whose {{ $prefix.Last.Name }} is between start and end.
+func (q *{{ $.Resource.Name }}Query) Set{{ $prefix.Last.Name }}Range({{ range $prefix.Leading }}{{ GoCamel .Name }} {{ .ResolvedType }}, {{ end }}start, end {{ $prefix.Last.ResolvedType }}, kind spanner.KeyRangeKind) *{{ $.Resource.Name }}Query {
+	q.qSet.SetKeyRange(resource.KeyRange{
+		Start: resource.KeySet{}{{ range $prefix.Leading }}.Add("{{ .Name }}", {{ GoCamel .Name }}){{ end }}.Add("{{ $prefix.Last.Name }}", start),
+		End:   resource.KeySet{}{{ range $prefix.Leading }}.Add("{{ .Name }}", {{ GoCamel .Name }}){{ end }}.Add("{{ $prefix.Last.Name }}", end),
+		Kind:  kind,
+	})
+
+	return q
+}
+{{- end }}
+{{- end }}
+
+{{- if .Resource.SearchFields }}
+
+// Search restricts the query to the rows matching the full-text query text, ordered by relevance
+// unless the query is sorted.
+func (q *{{ .Resource.Name }}Query) Search(text string) *{{ .Resource.Name }}Query {
+	q.qSet.SetSearch(text)
+
+	return q
+}
+{{- end }}
 
 func (q *{{ .Resource.Name }}Query) Read(ctx context.Context, txn resource.ReadOnlyTransaction) (*{{ .Resource.Name }}, error) {
 	return q.qSet.Read(ctx, txn)
 }
 
+func (q *{{ .Resource.Name }}Query) ReadMany(ctx context.Context, txn resource.ReadOnlyTransaction, keys []resource.KeySet) ([]*{{ .Resource.Name }}, error) {
+	return q.qSet.ReadMany(ctx, txn, keys)
+}
+
 func (q *{{ .Resource.Name }}Query) List(ctx context.Context, txn resource.ReadOnlyTransaction) iter.Seq2[*{{ .Resource.Name }}, error] {
 	return q.qSet.List(ctx, txn)
 }
@@ -84,6 +169,24 @@ func (q *{{ .Resource.Name }}Query) List(ctx context.Context, txn resource.ReadO
 func (q *{{ .Resource.Name }}Query) BatchList(ctx context.Context, client resource.Client, size int) iter.Seq[iter.Seq2[*{{ .Resource.Name }}, error]] {
 	return q.qSet.BatchList(ctx, client, size)
 }
+{{- if not .Resource.IsVirtual }}
+
+func (q *{{ .Resource.Name }}Query) UpdateWhere(ctx context.Context, txn resource.ReadWriteTransaction, patch *resource.PatchSet[{{ .Resource.Name }}], eventSource ...string) (int64, error) {
+	return q.qSet.UpdateWhere(ctx, txn, patch, eventSource...)
+}
+
+func (q *{{ .Resource.Name }}Query) DeleteWhere(ctx context.Context, txn resource.ReadWriteTransaction, eventSource ...string) (int64, error) {
+	return q.qSet.DeleteWhere(ctx, txn, eventSource...)
+}
+
+func (q *{{ .Resource.Name }}Query) PartitionedUpdateWhere(ctx context.Context, client resource.PartitionedExecutor, patch *resource.PatchSet[{{ .Resource.Name }}]) (int64, error) {
+	return q.qSet.PartitionedUpdateWhere(ctx, client, patch)
+}
+
+func (q *{{ .Resource.Name }}Query) PartitionedDeleteWhere(ctx context.Context, client resource.PartitionedExecutor) (int64, error) {
+	return q.qSet.PartitionedDeleteWhere(ctx, client)
+}
+{{- end }}
 
 func (q *{{ .Resource.Name }}Query) AddColumns(c *{{ .Resource.Name }}Columns) *{{ .Resource.Name }}Query {
 	for _, field := range c.fields {
@@ -168,7 +271,15 @@ func (p {{ .Resource.Name }}QueryPartialClause) Group(qc {{ .Resource.Name }}Que
 
 {{ range $field := .Resource.Fields }}
 {{ if $field.IsQueryClauseEligible -}}
-{{ if $unwrappedType := $field.UnwrappedNullType -}}
+{{ if $field.IsJSON -}}
+func (p {{ $field.Parent.Name }}QueryPartialClause) {{ $field.Name }}() {{ $field.Parent.Name }}QueryJSONIdent {
+	return {{ $field.Parent.Name }}QueryJSONIdent{JSONIdent: resource.NewJSONIdent("{{ $field.Name }}", p.partialClause, {{ $field.IsIndex }})}
+}
+{{- else if $field.IsArray -}}
+func (p {{ $field.Parent.Name }}QueryPartialClause) {{ $field.Name }}() {{ $field.Parent.Name }}QueryArrayIdent[{{ $field.ArrayElemType }}] {
+	return {{ $field.Parent.Name }}QueryArrayIdent[{{ $field.ArrayElemType }}]{ArrayIdent: resource.NewArrayIdent[{{ $field.ArrayElemType }}]("{{ $field.Name }}", p.partialClause, {{ $field.IsIndex }})}
+}
+{{- else if $unwrappedType := $field.UnwrappedNullType -}}
 func (p {{ $field.Parent.Name }}QueryPartialClause) {{ $field.Name }}() {{ $field.Parent.Name }}QueryIdent[{{ $unwrappedType }}] {
 	return {{ $field.Parent.Name }}QueryIdent[{{ $unwrappedType }}]{Ident: resource.NewIdent[{{ $unwrappedType }}]("{{ $field.Name }}", p.partialClause, {{ $field.IsIndex }})}
 }
@@ -220,6 +331,10 @@ func (i {{ .Resource.Name }}QueryIdent[T]) LessThanEq(v T) {{ .Resource.Name }}Q
 	return {{ .Resource.Name }}QueryClause{clause: i.Ident.LessThanEq(v)}
 }
 
+func (i {{ .Resource.Name }}QueryIdent[T]) Between(lo, hi T) {{ .Resource.Name }}QueryClause {
+	return {{ .Resource.Name }}QueryClause{clause: i.Ident.Between(lo, hi)}
+}
+
 func (i {{ .Resource.Name }}QueryIdent[T]) IsNull() {{ .Resource.Name }}QueryClause {
 	return {{ .Resource.Name }}QueryClause{clause: i.Ident.IsNull()}
 }
@@ -227,6 +342,50 @@ func (i {{ .Resource.Name }}QueryIdent[T]) IsNull() {{ .Resource.Name }}QueryCla
 func (i {{ .Resource.Name }}QueryIdent[T]) IsNotNull() {{ .Resource.Name }}QueryClause {
 	return {{ .Resource.Name }}QueryClause{clause: i.Ident.IsNotNull()}
 }
+{{- if .Resource.HasQueryClauseArrayFields }}
+
+type {{ .Resource.Name }}QueryArrayIdent[E comparable] struct {
+	resource.ArrayIdent[E]
+}
+
+func (i {{ .Resource.Name }}QueryArrayIdent[E]) Has(v E) {{ .Resource.Name }}QueryClause {
+	return {{ .Resource.Name }}QueryClause{clause: i.ArrayIdent.Has(v)}
+}
+
+func (i {{ .Resource.Name }}QueryArrayIdent[E]) HasAny(v ...E) {{ .Resource.Name }}QueryClause {
+	return {{ .Resource.Name }}QueryClause{clause: i.ArrayIdent.HasAny(v...)}
+}
+
+func (i {{ .Resource.Name }}QueryArrayIdent[E]) HasAll(v ...E) {{ .Resource.Name }}QueryClause {
+	return {{ .Resource.Name }}QueryClause{clause: i.ArrayIdent.HasAll(v...)}
+}
+
+func (i {{ .Resource.Name }}QueryArrayIdent[E]) IsNull() {{ .Resource.Name }}QueryClause {
+	return {{ .Resource.Name }}QueryClause{clause: i.ArrayIdent.IsNull()}
+}
+
+func (i {{ .Resource.Name }}QueryArrayIdent[E]) IsNotNull() {{ .Resource.Name }}QueryClause {
+	return {{ .Resource.Name }}QueryClause{clause: i.ArrayIdent.IsNotNull()}
+}
+{{- end }}
+{{- if .Resource.HasQueryClauseJSONFields }}
+
+type {{ .Resource.Name }}QueryJSONIdent struct {
+	resource.JSONIdent
+}
+
+func (i {{ .Resource.Name }}QueryJSONIdent) Path(path ...string) {{ .Resource.Name }}QueryIdent[any] {
+	return {{ .Resource.Name }}QueryIdent[any]{Ident: i.JSONIdent.Path(path...)}
+}
+
+func (i {{ .Resource.Name }}QueryJSONIdent) IsNull() {{ .Resource.Name }}QueryClause {
+	return {{ .Resource.Name }}QueryClause{clause: i.JSONIdent.IsNull()}
+}
+
+func (i {{ .Resource.Name }}QueryJSONIdent) IsNotNull() {{ .Resource.Name }}QueryClause {
+	return {{ .Resource.Name }}QueryClause{clause: i.JSONIdent.IsNotNull()}
+}
+{{- end }}
 
 type {{ .Resource.Name }}SortBuilder struct {
 	*{{ PrivateType .Resource.Name }}Sort
@@ -247,10 +406,12 @@ func (c *{{ PrivateType .Resource.Name }}Sort) addField(field string) *{{ .Resou
 }
 
 {{ range $field := .Resource.Fields }}
+{{- if $field.IsSortable }}
 func (c *{{ PrivateType $field.Parent.Name }}Sort) {{ $field.Name }}() *{{ $field.Parent.Name }}Sort {
 	return c.addField("{{ $field.Name }}")
 }
 {{ end }}
+{{- end }}
 
 type {{ .Resource.Name }}Sort struct {
 	*{{ PrivateType .Resource.Name }}Sort
@@ -267,6 +428,18 @@ func (s *{{ .Resource.Name }}Sort) Desc() *{{ .Resource.Name }}Sort {
 
 	return s
 }
+
+func (s *{{ .Resource.Name }}Sort) NullsFirst() *{{ .Resource.Name }}Sort {
+	s.sortFields[len(s.sortFields)-1].Nulls = resource.SortNullsFirst
+
+	return s
+}
+
+func (s *{{ .Resource.Name }}Sort) NullsLast() *{{ .Resource.Name }}Sort {
+	s.sortFields[len(s.sortFields)-1].Nulls = resource.SortNullsLast
+
+	return s
+}
 {{- end }}
 
 {{ if not .Resource.IsVirtual }}
@@ -554,7 +727,7 @@ import (
 	listTemplate = `func ({{ .ReceiverName }} *{{ .ApplicationName }}) {{ Pluralize .Resource.Name }}() http.HandlerFunc {
 	type {{ GoCamel .Resource.Name }} struct {
 		{{- range $field := .Resource.Fields }}
-		{{ $field.Name }} {{ $field.Type}} ` + "`{{ $field.JSONTag }} {{ $field.IndexTag }} {{ $field.AllowFilterTag }} {{ $field.ListPermTag }} {{ $field.PIITag }}`" + `
+		{{ $field.Name }} {{ $field.Type}} ` + "`{{ $field.JSONTag }} {{ $field.IndexTag }} {{ $field.AllowFilterTag }} {{ $field.AllowSortTag }} {{ $field.ListPermTag }} {{ $field.PIITag }} {{ $field.MaskTag }}`" + `
 		{{- end }}
 	}
 
@@ -600,7 +773,7 @@ import (
 	readTemplate = `func ({{ .ReceiverName }} *{{ .ApplicationName }}) {{ .Resource.Name }}() http.HandlerFunc {
 	type response struct {
 		{{- range $field := .Resource.Fields }}
-		{{ $field.Name }} {{ $field.Type}} ` + "`{{ $field.JSONTag }} {{ $field.UniqueIndexTag }} {{ $field.ReadPermTag }} {{ $field.PIITag }}`" + `
+		{{ $field.Name }} {{ $field.Type}} ` + "`{{ $field.JSONTag }} {{ $field.UniqueIndexTag }} {{ $field.ReadPermTag }} {{ $field.PIITag }} {{ $field.MaskTag }}`" + `
 		{{- end }}
 	}
 
@@ -649,6 +822,60 @@ import (
 	})
 }`
 
+	batchReadTemplate = `func ({{ .ReceiverName }} *{{ .ApplicationName }}) BatchRead{{ Pluralize .Resource.Name }}() http.HandlerFunc {
+	type response struct {
+		{{- range $field := .Resource.Fields }}
+		{{ $field.Name }} {{ $field.Type}} ` + "`{{ $field.JSONTag }} {{ $field.UniqueIndexTag }} {{ $field.ReadPermTag }} {{ $field.PIITag }} {{ $field.MaskTag }}`" + `
+		{{- end }}
+	}
+
+	decoder := NewQueryDecoder[{{ .ResourcePackage }}.{{ .Resource.Name }}, response]({{ .ReceiverName }}, accesstypes.Read)
+
+	return httpio.Log(func(w http.ResponseWriter, r *http.Request) error {
+		ctx, span := tracer.Start(r.Context())
+		defer span.End()
+
+		ids, withoutIDs, err := resource.DecodeIDs[{{ .Resource.PrimaryKeyType }}](r)
+		if err != nil {
+			return httpio.NewEncoder(w).ClientMessage(ctx, err)
+		}
+
+		querySet, err := decoder.Decode(withoutIDs, {{ .ReceiverName }}.UserPermissions(r))
+		if err != nil {
+			return httpio.NewEncoder(w).ClientMessage(ctx, err)
+		}
+
+		keys := make([]resource.KeySet, 0, len(ids))
+		for _, id := range ids {
+			keys = append(keys, resource.KeySet{}.Add("{{ .Resource.PrimaryKey.Name }}", id))
+		}
+
+		rows, err := {{ .ResourcePackage }}.New{{ .Resource.Name }}QueryFromQuerySet(querySet).ReadMany(ctx, {{ .ReceiverName }}.ResourceClient(), keys)
+		if err != nil {
+			return httpio.NewEncoder(w).ClientMessage(ctx, err)
+		}
+
+		resp := make([]map[string]any, 0, len(rows))
+		for _, row := range rows {
+			rec := (*response)(row)
+			rmap := make(map[string]any)
+			for _, field := range querySet.Fields() {
+				switch string(field) {
+				{{- range .Resource.Fields }}
+				{{- if not .IsInputOnly }}
+				case "{{ .Name }}":
+					rmap["{{ Camel .Name }}"] = rec.{{ .Name }}
+				{{- end }}
+				{{- end }}
+				}
+			}
+			resp = append(resp, rmap)
+		}
+
+		return httpio.NewEncoder(w).Ok(resp)
+	})
+}`
+
 	patchTemplate = `func ({{ .ReceiverName }} *{{ .ApplicationName }}) Patch{{ Pluralize .Resource.Name }}() http.HandlerFunc {
 	type request struct {
 		{{- range $field := .Resource.Fields }}
@@ -762,6 +989,123 @@ import (
 	})
 }`
 
+	nestedListTemplate = `func ({{ .ReceiverName }} *{{ .ApplicationName }}) {{ .Resource.Parent.Name }}{{ Pluralize .Resource.Name }}() http.HandlerFunc {
+	type {{ GoCamel .Resource.Name }} struct {
+		{{- range $field := .Resource.Fields }}
+		{{ $field.Name }} {{ $field.Type}} ` + "`{{ $field.JSONTag }} {{ $field.IndexTag }} {{ $field.AllowFilterTag }} {{ $field.AllowSortTag }} {{ $field.ListPermTag }} {{ $field.PIITag }} {{ $field.MaskTag }}`" + `
+		{{- end }}
+	}
+
+	type response []map[string]any
+
+	decoder := NewQueryDecoder[{{ .ResourcePackage }}.{{ .Resource.Name }}, {{ GoCamel .Resource.Name }}]({{ .ReceiverName }}, accesstypes.List)
+
+	return httpio.Log(func(w http.ResponseWriter, r *http.Request) error {
+		ctx, span := tracer.Start(r.Context())
+		defer span.End()
+
+		querySet, err := decoder.Decode(r, {{ .ReceiverName }}.UserPermissions(r))
+		if err != nil {
+			return httpio.NewEncoder(w).ClientMessage(ctx, err)
+		}
+		{{- range .Resource.NestedKeys }}
+		{{- if .IsParentKey }}
+		querySet.SetParentKey("{{ .Field.Name }}", httpio.Param[{{ .Field.Type }}](r, {{ .Param }}))
+		{{- end }}
+		{{- end }}
+
+		res := {{ .ResourcePackage }}.New{{ .Resource.Name }}QueryFromQuerySet(querySet)
+
+		resp := response{}
+		for row, err := range res.List(ctx, {{ .ReceiverName }}.ResourceClient()) {
+			if err != nil {
+				return httpio.NewEncoder(w).ClientMessage(ctx, err)
+			}
+			rec := (*{{ GoCamel .Resource.Name }})(row)
+			rmap := make(map[string]any)
+			for _, field := range querySet.Fields() {
+				switch string(field) {
+				{{- range .Resource.Fields }}
+				{{- if not .IsInputOnly }}
+				case "{{ .Name }}":
+					rmap["{{ Camel .Name }}"] = rec.{{ .Name }}
+				{{- end }}
+				{{- end }}
+				}
+			}
+			resp = append(resp, rmap)
+		}
+
+		return httpio.NewEncoder(w).Ok(resp)
+	})
+}`
+
+	nestedPatchTemplate = `func ({{ .ReceiverName }} *{{ .ApplicationName }}) Patch{{ .Resource.Parent.Name }}{{ Pluralize .Resource.Name }}() http.HandlerFunc {
+	type request struct {
+		{{- range $field := .Resource.Fields }}
+		{{ $field.Name }} {{ $field.Type}} ` + "`{{ $field.JSONTagForPatch }} {{ $field.ImmutableTag }} {{ $field.PatchPermTag }}`" + `
+		{{- end }}
+	}
+
+	decoder := NewDecoder[{{ .ResourcePackage }}.{{ .Resource.Name }}, request]({{ .ReceiverName }}, accesstypes.Create, accesstypes.Update, accesstypes.Delete)
+
+	return httpio.Log(func(w http.ResponseWriter, r *http.Request) error {
+		ctx, span := tracer.Start(r.Context())
+		defer span.End()
+
+		{{- range .Resource.NestedKeys }}
+		{{- if .IsParentKey }}
+		{{ GoCamel .Field.Name }} := httpio.Param[{{ .Field.Type }}](r, {{ .Param }})
+		{{- end }}
+		{{- end }}
+		eventSource := resource.UserEvent(ctx)
+
+		if err := {{ .ReceiverName }}.ResourceClient().ExecuteFunc(ctx, func(ctx context.Context, txn resource.ReadWriteTransaction) error {
+			r, err := resource.CloneRequest(r)
+			if err != nil {
+				return errors.Wrap(err, "resource.CloneRequest()")
+			}
+
+			for op, err := range resource.Operations(r, "{{ .Resource.NestedOperationPathPattern }}", resource.RequireCreatePath()) {
+				if err != nil {
+					return errors.Wrap(err, "resource.Operations()")
+				}
+
+				patchSet, err := decoder.DecodeOperation(op, {{ .ReceiverName }}.UserPermissions(r))
+				if err != nil {
+					return errors.Wrap(err, "decoder.DecodeOperation()")
+				}
+				{{- range .Resource.NestedKeys }}
+				{{- if not .IsParentKey }}
+				{{ GoCamel .Field.Name }} := httpio.Param[{{ .Field.Type }}](op.Req, {{ .Param }})
+				{{- end }}
+				{{- end }}
+
+				switch op.Type {
+				case resource.OperationCreate:
+					if err := {{ .ResourcePackage }}.New{{ .Resource.Name }}CreatePatchFromPatchSet({{ range .Resource.NestedKeys }}{{ GoCamel .Field.Name }}, {{ end }}patchSet).Buffer(ctx, txn, eventSource); err != nil {
+						return errors.Wrap(err, "{{ .ResourcePackage }}.{{ .Resource.Name }}CreatePatch.Buffer()")
+					}
+				case resource.OperationUpdate:
+					if err := {{ .ResourcePackage }}.New{{ .Resource.Name }}UpdatePatchFromPatchSet({{ range .Resource.NestedKeys }}{{ GoCamel .Field.Name }}, {{ end }}patchSet).Buffer(ctx, txn, eventSource); err != nil {
+						return errors.Wrap(err, "{{ .ResourcePackage }}.{{ .Resource.Name }}UpdatePatch.Buffer()")
+					}
+				case resource.OperationDelete:
+					if err := {{ .ResourcePackage }}.New{{ .Resource.Name }}DeletePatchFromPatchSet({{ range .Resource.NestedKeys }}{{ GoCamel .Field.Name }}, {{ end }}patchSet).Buffer(ctx, txn, eventSource); err != nil {
+						return errors.Wrap(err, "{{ .ResourcePackage }}.{{ .Resource.Name }}DeletePatch.Buffer()")
+					}
+				}
+			}
+
+			return nil
+		}); err != nil {
+			return httpio.NewEncoder(w).ClientMessage(ctx, handleError[{{ .ResourcePackage }}.{{ .Resource.Name }}](err))
+		}
+
+		return httpio.NewEncoder(w).Ok(nil)
+	})
+}`
+
 	consolidatedPatchTemplate = `// Code generated by resourcegeneration. DO NOT EDIT.
 // Source: {{ .Source }}
 
@@ -970,6 +1314,24 @@ export namespace {{ $resource }} {
   {{- end }}
   };
   {{- end }}
+  {{- if index $.MaskMap $resource }}
+  export const fieldMask = {
+  {{- range $_, $tag := $tags }}
+  {{- with index $.MaskMap $resource $tag }}
+    {{ $tag }}: '{{ . }}',
+  {{- end }}
+  {{- end }}
+  } as const;
+  {{- end }}
+  {{- if index $.OutputOnlyMap $resource }}
+  export const outputOnlyFieldName = {
+  {{- range $_, $tag := $tags }}
+  {{- if index $.OutputOnlyMap $resource $tag }}
+    {{ $tag }}: '{{ $tag }}' as FieldName,
+  {{- end }}
+  {{- end }}
+  };
+  {{- end }}
   export const resourceName = {
   {{- range $_, $tag := $tags }}
     {{ $tag }}: '{{ $resource.ResourceWithTag $tag }}' as Resource,
@@ -979,9 +1341,9 @@ export namespace {{ $resource }} {
 {{ end -}}
 {{ range $rpcMethod := $rpcMethods }}
 export namespace {{ $rpcMethod.Name }} {
-  {{- if $rpcMethod.Fields }}
+  {{- if $rpcMethod.RequestFields }}
   export const fieldName = {
-  {{- range $field := $rpcMethod.Fields }}
+  {{- range $field := $rpcMethod.RequestFields }}
     {{ Camel $field.Name }}: '{{ Camel $field.Name }}' as FieldName,
   {{- end }}
   };
@@ -1056,6 +1418,12 @@ const resourceMap: ResourceMap = {
     route: '{{ Kebab (Pluralize $resource.Name) }}',
     {{- if $resource.IsConsolidated }}
     consolidatedRoute: '{{ $consolidatedRoute }}',
+    {{- end }}
+    {{- if $resource.Parent }}
+    parent: { resource: Resources.{{ Pluralize $resource.Parent.Name }}, keys: [{{ range $i, $field := $resource.ParentKeyFields }}{{ if $i }}, {{ end }}'{{ Camel $field.Name }}'{{ end }}] },
+    {{- end }}
+    {{- if $resource.Children }}
+    children: [{{ range $i, $child := $resource.Children }}{{ if $i }}, {{ end }}Resources.{{ Pluralize $child.Name }}{{ end }}],
     {{- end }}
 	{{- if $resource.ListHandlerDisabled }}
     listDisabled: true,
@@ -1076,7 +1444,7 @@ const resourceMap: ResourceMap = {
       {{- range $field := $resource.Fields }}
       { fieldName: '{{ Camel $field.Name }}', 
        {{- if $field.IsPrimaryKey }} primaryKey: { ordinalPosition: {{ $field.KeyOrdinalPosition }} }, 
-       {{- end }} displayType: '{{ Lower $field.TypescriptDisplayType }}', required: {{ $field.IsRequired }}, isIndex: {{ $field.IsIndex -}}
+       {{- end }} displayType: '{{ Lower $field.TypescriptDisplayType }}', required: {{ $field.IsRequired }}, isIndex: {{ $field.IsIndex }}, isSortable: {{ $field.IsSortable -}}
       {{- if $field.IsEnumerated }}, enumeratedResource: Resources.{{ $field.ReferencedResource }}{{ end }} },
       {{- end }}
     ],
@@ -1099,7 +1467,7 @@ const resourceMap: ResourceMap = {
       {{- range $field := $resource.Fields }}
       { fieldName: '{{ Camel $field.Name }}', 
        {{- if $field.IsPrimaryKey }} primaryKey: { ordinalPosition: {{ $field.KeyOrdinalPosition }} }, 
-       {{- end }} displayType: '{{ Lower $field.TypescriptDataType }}', required: {{ $field.IsPrimaryKey }}, isIndex: false },
+       {{- end }} displayType: '{{ Lower $field.TypescriptDataType }}', required: {{ $field.IsPrimaryKey }}, isIndex: false, isSortable: false },
       {{- end }}
     ],
   },
@@ -1127,16 +1495,44 @@ export interface FieldPointer {
   field: FieldName;
 }
 {{ range $rpcMethod := .RPCMethods }}
-export interface {{ $rpcMethod.Name }}Config {{ if $rpcMethod.Fields }}{
-{{- range $field := $rpcMethod.Fields }}
+export interface {{ $rpcMethod.Name }}Config {{ if $rpcMethod.RequestFields }}{
+{{- range $field := $rpcMethod.RequestFields }}
   {{ Camel $field.Name }}: {{ $field.TypescriptDataType }} | FieldPointer;
 {{- end }}
 }{{ else }}{}{{ end }}
-export interface {{ $rpcMethod.Name }} {{ if $rpcMethod.Fields }}{
-{{- range $field := $rpcMethod.Fields }}
+export interface {{ $rpcMethod.Name }} {{ if $rpcMethod.RequestFields }}{
+{{- range $field := $rpcMethod.RequestFields }}
   {{ Camel $field.Name }}: {{ $field.TypescriptDataType }};
 {{- end }}
 }{{ else }}{}{{ end }}
+{{- if $rpcMethod.HasResponse }}
+export interface {{ $rpcMethod.Name }}Response {
+{{- range $field := $rpcMethod.ResponseFields }}
+  {{ Camel $field.Name }}: {{ $field.TypescriptDataType }};
+{{- end }}
+}
+{{- end }}
+{{ end }}
+{{- if .HasAsync }}
+// RPCOperationAccepted is the response of an asynchronous method: the ID of the operation
+// running it.
+export interface RPCOperationAccepted {
+  operationId: string;
+}
+
+// RPCOperation is the status of an asynchronous method's operation. Result is the method's
+// response interface.
+export interface RPCOperation<Result = unknown> {
+  id: string;
+  method: Method;
+  status: 'pending' | 'running' | 'succeeded' | 'failed';
+  progress: number;
+  result: Result | null;
+  error: string | null;
+  attempts: number;
+  createdAt: Date;
+  updatedAt: Date;
+}
 {{ end }}
 export interface RPCFieldMeta {
   fieldName: string;
@@ -1147,6 +1543,8 @@ export interface RPCFieldMeta {
 export interface MethodMeta {
   route: string;
   fields: RPCFieldMeta[];
+  responseFields?: RPCFieldMeta[];
+  async?: boolean;
 }
 
 export type MethodMap = Record<Method, MethodMeta>;
@@ -1155,15 +1553,25 @@ const methodMap: MethodMap = {
   {{- range $rpcMethod := .RPCMethods }}
   [Methods.{{ $rpcMethod.Name }}]: {
     route: '{{ Kebab ($rpcMethod.Name) }}',
-    {{- if $rpcMethod.Fields }}
+    {{- if $rpcMethod.RequestFields }}
     fields: [
-    {{- range $field := $rpcMethod.Fields }}
+    {{- range $field := $rpcMethod.RequestFields }}
       { fieldName: '{{ Camel $field.Name }}', displayType: '{{ Lower $field.TypescriptDisplayType }}'{{- if $field.IsEnumerated }}, enumeratedResource: Resources.{{ $field.EnumeratedResource }}{{ end }} },
     {{- end }}
     ],
     {{- else }}
     fields: [],
     {{- end }}
+    {{- if $rpcMethod.HasResponse }}
+    responseFields: [
+    {{- range $field := $rpcMethod.ResponseFields }}
+      { fieldName: '{{ Camel $field.Name }}', displayType: '{{ Lower $field.TypescriptDisplayType }}'{{- if $field.IsEnumerated }}, enumeratedResource: Resources.{{ $field.EnumeratedResource }}{{ end }} },
+    {{- end }}
+    ],
+    {{- end }}
+    {{- if $rpcMethod.Async }}
+    async: true,
+    {{- end }}
   },
 
   {{- end }}
@@ -1179,15 +1587,568 @@ export function methodMeta(method: Method): Meta {
 }
 `
 
-	typescriptEnumsTemplate = `// Code generated by resourcegeneration. DO NOT EDIT.
-// Source: {{ .Source }}
-{{ range $namedType := .NamedTypes -}}
-{{ if $enums := index $.EnumMap $namedType.Name }}
-export enum {{ $namedType.Name }} {
-{{ range $enum := $enums }}  {{ SanitizeIdentifier $enum.Description }} = {{ printf "'%s'" $enum.ID }},
-{{ end -}}
-}
-{{ end -}}
+	typescriptQueryTemplate = `// Code generated by resourcegeneration. DO NOT EDIT.
+{{- if .Interfaces }}
+import { {{ range $i, $name := .Interfaces }}{{ if $i }}, {{ end }}{{ $name }}{{ end }} } from './{{ .GenPrefix }}_resources';
+{{- end }}
+
+export type FilterValue = string | number | boolean | Date;
+
+export interface Filter<F extends string> {
+  readonly expression: string;
+  readonly compound: boolean;
+  readonly fields?: F;
+}
+
+export interface FieldFilter<F extends string> {
+  eq(value: FilterValue): Filter<F>;
+  ne(value: FilterValue): Filter<F>;
+  gt(value: FilterValue): Filter<F>;
+  gte(value: FilterValue): Filter<F>;
+  lt(value: FilterValue): Filter<F>;
+  lte(value: FilterValue): Filter<F>;
+  in(...values: FilterValue[]): Filter<F>;
+  notIn(...values: FilterValue[]): Filter<F>;
+  between(low: FilterValue, high: FilterValue): Filter<F>;
+  isNull(): Filter<F>;
+  isNotNull(): Filter<F>;
+  has(value: FilterValue): Filter<F>;
+  hasAny(...values: FilterValue[]): Filter<F>;
+  hasAll(...values: FilterValue[]): Filter<F>;
+}
+
+export interface FilterBuilder<F extends string> {
+  // where starts a condition on field, or on the value at path inside a JSON field.
+  where(field: F, ...path: string[]): FieldFilter<F>;
+  and(...filters: Filter<F>[]): Filter<F>;
+  or(...filters: Filter<F>[]): Filter<F>;
+}
+
+function valueString(value: FilterValue): string {
+  return value instanceof Date ? value.toISOString() : String(value);
+}
+
+// reservedFilterValue matches the values the filter syntax can't write: it has no escapes.
+const reservedFilterValue = /[,|()]|^\s|\s$/;
+
+// formatValue writes a value in the filter syntax. A value with a ',', '|', '(' or ')', or
+// with leading or trailing space, throws an Error.
+export function formatValue(value: FilterValue): string {
+  const formatted = valueString(value);
+  if (reservedFilterValue.test(formatted)) {
+    throw new Error('value "' + formatted + '" can not be written in a filter: it has a \',\', \'|\', \'(\' or \')\', or leading or trailing space');
+  }
+
+  return formatted;
+}
+
+function condition<F extends string>(expression: string): Filter<F> {
+  return { expression, compound: false };
+}
+
+function join<F extends string>(separator: string, filters: Filter<F>[]): Filter<F> {
+  const nonEmpty = filters.filter((filter) => filter.expression !== '');
+  if (nonEmpty.length === 1) {
+    return nonEmpty[0];
+  }
+
+  return {
+    expression: nonEmpty.map((filter) => (filter.compound ? '(' + filter.expression + ')' : filter.expression)).join(separator),
+    compound: nonEmpty.length > 1,
+  };
+}
+
+export function filterBuilder<F extends string>(): FilterBuilder<F> {
+  return {
+    where(field: F, ...path: string[]): FieldFilter<F> {
+      const name = [field, ...path].join('.');
+      const compare = (operator: string) => (value: FilterValue) => condition<F>(name + ':' + operator + ':' + formatValue(value));
+      const values = (operator: string) => (...operands: FilterValue[]) => condition<F>(name + ':' + operator + ':(' + operands.map(formatValue).join(',') + ')');
+
+      return {
+        eq: compare('eq'),
+        ne: compare('ne'),
+        gt: compare('gt'),
+        gte: compare('gte'),
+        lt: compare('lt'),
+        lte: compare('lte'),
+        in: values('in'),
+        notIn: values('notin'),
+        between: (low: FilterValue, high: FilterValue) => values('between')(low, high),
+        isNull: () => condition<F>(name + ':isnull'),
+        isNotNull: () => condition<F>(name + ':isnotnull'),
+        has: compare('has'),
+        hasAny: values('hasany'),
+        hasAll: values('hasall'),
+      };
+    },
+    and: (...filters: Filter<F>[]) => join(',', filters),
+    or: (...filters: Filter<F>[]) => join('|', filters),
+  };
+}
+
+export type SortDirection = 'asc' | 'desc';
+export type SortNulls = 'nullsfirst' | 'nullslast';
+export type Sort<F extends string> = F | { field: F; direction?: SortDirection; nulls?: SortNulls };
+
+function formatSort(sort: Sort<string>): string {
+  if (typeof sort === 'string') {
+    return sort;
+  }
+  if (sort.nulls) {
+    return sort.field + ':' + (sort.direction ?? 'asc') + ':' + sort.nulls;
+  }
+
+  return sort.direction ? sort.field + ':' + sort.direction : sort.field;
+}
+
+export interface ListQuery<F extends string, S extends string, C extends string> {
+  columns?: C[];
+  filter?: Filter<F>;
+  sort?: Sort<S>[];
+  limit?: number;
+  offset?: number;
+  search?: string;
+}
+
+export interface ReadOptions<C extends string> {
+  columns?: C[];
+}
+
+export interface ListRequest {
+  params: Record<string, string>;
+  // body holds the filter and search. A list with either is sent as a POST, which keeps pii
+  // values out of the URL.
+  body?: { filter?: string; search?: string };
+}
+
+export function readParams(options?: ReadOptions<string>): Record<string, string> {
+  return options?.columns?.length ? { columns: options.columns.join(',') } : {};
+}
+
+export function listRequest(query: ListQuery<string, string, string> = {}): ListRequest {
+  const params = readParams(query);
+  if (query.sort?.length) {
+    params['sort'] = query.sort.map(formatSort).join(',');
+  }
+  if (query.limit !== undefined) {
+    params['limit'] = String(query.limit);
+  }
+  if (query.offset !== undefined) {
+    params['offset'] = String(query.offset);
+  }
+  const filter = query.filter?.expression || undefined;
+  if (filter === undefined && !query.search) {
+    return { params };
+  }
+
+  return { params, body: { filter, search: query.search || undefined } };
+}
+
+export type PatchOperation<T> =
+  | { op: 'add'; path?: string; value: Partial<T> }
+  | { op: 'patch'; path: string; value: Partial<T> }
+  | { op: 'remove'; path: string };
+
+// keyPath returns the path segments addressing the row with the given primary key.
+export function keyPath(...keys: FilterValue[]): string {
+  return keys.map((value) => '/' + encodeURIComponent(valueString(value))).join('');
+}
+
+// reviveDates converts the JSON strings of a row's Date fields.
+export function reviveDates<T>(row: T, fields: string[]): T {
+  const record = row as Record<string, unknown>;
+  for (const field of fields) {
+    const value = record[field];
+    if (typeof value === 'string') {
+      record[field] = new Date(value);
+    }
+  }
+
+  return row;
+}
+{{ range $resource := .Resources }}
+export type {{ $resource.Interface }}Column = keyof {{ $resource.Interface }} & string;
+export type {{ $resource.Interface }}FilterField = {{ $resource.FilterFields }};
+export type {{ $resource.Interface }}SortField = {{ $resource.SortFields }};
+export type {{ $resource.Interface }}Query = ListQuery<{{ $resource.Interface }}FilterField, {{ $resource.Interface }}SortField, {{ $resource.Interface }}Column>;
+export type {{ $resource.Interface }}ReadOptions = ReadOptions<{{ $resource.Interface }}Column>;
+{{- if ne $resource.FilterFields "never" }}
+export const {{ Camel $resource.Interface }}Filter = filterBuilder<{{ $resource.Interface }}FilterField>();
+{{- end }}
+{{ end }}
+{{- if .Consolidated }}
+// ResourceOperation is an operation of a PatchResources request. Its path starts with the
+// resource's route, e.g. '/ships' + keyPath(id).
+export type ResourceOperation = {{ range $i, $resource := .Consolidated }}{{ if $i }} | {{ end }}PatchOperation<{{ $resource.Interface }}>{{ end }};
+
+// ResourcePatchBatch collects the operations of a PatchResources request, which applies them
+// in one transaction.
+export class ResourcePatchBatch {
+  readonly operations: ResourceOperation[] = [];
+{{- range $resource := .Consolidated }}
+
+  create{{ $resource.Name }}({{ if not $resource.ReturnsIDs }}{{ $resource.KeyParams }}, {{ end }}value: Partial<{{ $resource.Interface }}>): this {
+    this.operations.push({ op: 'add', path: '{{ $resource.Route }}'{{ if not $resource.ReturnsIDs }} + {{ $resource.KeyPath }}{{ end }}, value });
+
+    return this;
+  }
+
+  update{{ $resource.Name }}({{ $resource.KeyParams }}, value: Partial<{{ $resource.Interface }}>): this {
+    this.operations.push({ op: 'patch', path: '{{ $resource.Route }}' + {{ $resource.KeyPath }}, value });
+
+    return this;
+  }
+
+  delete{{ $resource.Name }}({{ $resource.KeyParams }}): this {
+    this.operations.push({ op: 'remove', path: '{{ $resource.Route }}' + {{ $resource.KeyPath }} });
+
+    return this;
+  }
+{{- end }}
+}
+{{ end -}}
+`
+
+	typescriptClientTemplate = `// Code generated by resourcegeneration. DO NOT EDIT.
+{{- if .ClientQueryImports }}
+import { {{ .ClientQueryImports }} } from './{{ .GenPrefix }}_query';
+{{- end }}
+{{- if .ResourceInterfaces }}
+import { {{ .ResourceInterfaces }} } from './{{ .GenPrefix }}_resources';
+{{- end }}
+{{- if .RPCMethods }}
+import { {{ .MethodImports }} } from './{{ .GenPrefix }}_methods';
+{{- end }}
+
+export interface ClientOptions {
+  // baseUrl is prepended to every route, e.g. '/api'.
+  baseUrl: string;
+  // fetch replaces the global fetch, e.g. to retry requests.
+  fetch?: typeof fetch;
+  // init returns the RequestInit each request starts from, e.g. with credentials or headers.
+  init?: () => RequestInit | Promise<RequestInit>;
+}
+
+let clientOptions: ClientOptions = { baseUrl: '' };
+
+export function configureClient(options: ClientOptions): void {
+  clientOptions = options;
+}
+
+export class ApiError extends Error {
+  constructor(
+    readonly status: number,
+    message: string,
+  ) {
+    super(message);
+    this.name = 'ApiError';
+  }
+}
+
+async function request<T>(method: string, path: string, params?: Record<string, string>, body?: unknown): Promise<T> {
+  const init = (await clientOptions.init?.()) ?? {};
+  const headers = new Headers(init.headers);
+  if (body !== undefined) {
+    headers.set('Content-Type', 'application/json');
+  }
+  const query = new URLSearchParams(params).toString();
+  const response = await (clientOptions.fetch ?? fetch)(clientOptions.baseUrl + path + (query ? '?' + query : ''), {
+    ...init,
+    method,
+    headers,
+    body: body === undefined ? undefined : JSON.stringify(body),
+  });
+  if (!response.ok) {
+    throw new ApiError(response.status, await response.text());
+  }
+  const text = await response.text();
+
+  return (text ? JSON.parse(text) : undefined) as T;
+}
+{{- if .HasList }}
+
+function list<T>(path: string, query: ListQuery<string, string, string> = {}): Promise<T[]> {
+  const { params, body } = listRequest(query);
+
+  return body ? request<T[]>('POST', path, params, body) : request<T[]>('GET', path, params);
+}
+{{- end }}
+{{ range $resource := .Resources }}
+{{- $interface := $resource.Interface }}
+{{- if $resource.Has "listHandler" }}
+export async function list{{ $interface }}(query?: {{ $interface }}Query): Promise<{{ $interface }}[]> {
+  const rows = await list<{{ $interface }}>('{{ $resource.Route }}', query);
+
+  return {{ if $resource.DateFields }}rows.map((row) => reviveDates(row, {{ $resource.DateFields }})){{ else }}rows{{ end }};
+}
+{{ end }}
+{{- if $resource.Has "readHandler" }}
+export async function get{{ $resource.Name }}({{ $resource.KeyParams }}, options?: {{ $interface }}ReadOptions): Promise<{{ $interface }}> {
+  const row = await request<{{ $interface }}>('GET', '{{ $resource.Route }}' + {{ $resource.KeyPath }}, readParams(options));
+
+  return {{ if $resource.DateFields }}reviveDates(row, {{ $resource.DateFields }}){{ else }}row{{ end }};
+}
+{{ end }}
+{{- if $resource.Has "batchReadHandler" }}
+export async function batchGet{{ $interface }}(ids: {{ $resource.KeyType }}[], options?: {{ $interface }}ReadOptions): Promise<{{ $interface }}[]> {
+  const params = { ...readParams(options), ids: ids.map(formatValue).join(',') };
+  const rows = await request<{{ $interface }}[]>('GET', '{{ $resource.Route }}:batchGet', params);
+
+  return {{ if $resource.DateFields }}rows.map((row) => reviveDates(row, {{ $resource.DateFields }})){{ else }}rows{{ end }};
+}
+{{ end }}
+{{- if $resource.Has "patchHandler" }}
+export function patch{{ $interface }}(operations: PatchOperation<{{ $interface }}>[]): Promise<{{ if $resource.ReturnsIDs }}{ iDs: string[] }{{ else }}void{{ end }}> {
+  return request<{{ if $resource.ReturnsIDs }}{ iDs: string[] }{{ else }}void{{ end }}>('PATCH', '{{ $resource.Route }}', undefined, operations);
+}
+{{ end }}
+{{- if $resource.Has "nestedListHandler" }}
+export async function list{{ $resource.ParentName }}{{ $interface }}({{ $resource.ParentKeyParams }}, query?: {{ $interface }}Query): Promise<{{ $interface }}[]> {
+  const rows = await list<{{ $interface }}>('{{ $resource.ParentRoute }}' + {{ $resource.ParentKeyPath }} + '{{ $resource.Route }}', query);
+
+  return {{ if $resource.DateFields }}rows.map((row) => reviveDates(row, {{ $resource.DateFields }})){{ else }}rows{{ end }};
+}
+{{ end }}
+{{- if $resource.Has "nestedPatchHandler" }}
+export function patch{{ $resource.ParentName }}{{ $interface }}({{ $resource.ParentKeyParams }}, operations: PatchOperation<{{ $interface }}>[]): Promise<void> {
+  return request<void>('PATCH', '{{ $resource.ParentRoute }}' + {{ $resource.ParentKeyPath }} + '{{ $resource.Route }}', undefined, operations);
+}
+{{ end }}
+{{- end }}
+{{- if .Consolidated }}
+// patchResources applies the operations in one transaction. Build them with a ResourcePatchBatch.
+export function patchResources(operations: ResourceOperation[]): Promise<Record<string, string[]>> {
+  return request<Record<string, string[]>>('PATCH', '/{{ .ConsolidatedRoute }}', undefined, operations);
+}
+{{ end }}
+{{- range $rpcMethod := .RPCMethods }}
+{{- if $rpcMethod.Async }}
+// {{ Camel $rpcMethod.Name }} enqueues the method: poll the operation with getRPCOperation.
+export function {{ Camel $rpcMethod.Name }}(req: {{ $rpcMethod.Name }}): Promise<RPCOperationAccepted> {
+  return request<RPCOperationAccepted>('POST', '/{{ Kebab $rpcMethod.Name }}', undefined, req);
+}
+{{- else if $rpcMethod.HasResponse }}
+export async function {{ Camel $rpcMethod.Name }}(req: {{ $rpcMethod.Name }}): Promise<{{ $rpcMethod.Name }}Response> {
+  const resp = await request<{{ $rpcMethod.Name }}Response>('POST', '/{{ Kebab $rpcMethod.Name }}', undefined, req);
+
+  return {{ with $rpcMethod.ResponseDateFields }}reviveDates(resp, {{ . }}){{ else }}resp{{ end }};
+}
+{{- else }}
+export function {{ Camel $rpcMethod.Name }}(req: {{ $rpcMethod.Name }}): Promise<void> {
+  return request<void>('POST', '/{{ Kebab $rpcMethod.Name }}', undefined, req);
+}
+{{- end }}
+{{ end -}}
+{{- if .HasAsync }}
+// getRPCOperation reads the status of an asynchronous method's operation. Pass the method's
+// response interface as Result; its Date fields are not revived.
+export async function getRPCOperation<Result = unknown>(operationId: string): Promise<RPCOperation<Result>> {
+  const op = await request<RPCOperation<Result>>('GET', '/rpc-operations/' + encodeURIComponent(operationId));
+
+  return reviveDates(op, ['createdAt', 'updatedAt']);
+}
+{{ end -}}
+`
+
+	typescriptAngularTemplate = `// Code generated by resourcegeneration. DO NOT EDIT.
+import { HttpClient } from '@angular/common/http';
+import { Injectable, InjectionToken{{ if .HasSignals }}, Signal{{ end }}, inject } from '@angular/core';
+{{- if .HasSignals }}
+import { toObservable, toSignal } from '@angular/core/rxjs-interop';
+{{- end }}
+import { Observable{{ if .HasDates }}, map{{ end }}{{ if .HasSignals }}, switchMap{{ end }} } from 'rxjs';
+{{- if or .RPCMethods .Resources }}
+import { {{ if .RPCMethods }}Methods{{ end }}{{ if and .RPCMethods .Resources }}, {{ end }}{{ if .Resources }}Resources{{ end }} } from './{{ .GenPrefix }}_constants';
+{{- end }}
+{{- if .QueryImports }}
+import { {{ .QueryImports }} } from './{{ .GenPrefix }}_query';
+{{- end }}
+{{- if .ResourceInterfaces }}
+import { {{ .ResourceInterfaces }} } from './{{ .GenPrefix }}_resources';
+{{- end }}
+{{- if .RPCMethods }}
+import { {{ .MethodImports }} } from './{{ .GenPrefix }}_methods';
+{{- end }}
+
+// API_BASE_URL is the URL the generated routes are served under, '{{ .APIBaseURL }}' unless provided.
+export const API_BASE_URL = new InjectionToken<string>('API_BASE_URL', { factory: () => '{{ .APIBaseURL }}' });
+{{ range $resource := .Resources }}
+{{- $interface := $resource.Interface }}
+{{- $revive := "" }}{{ if $resource.DateFields }}{{ $revive = printf ".pipe(map((rows) => rows.map((row) => reviveDates(row, %s))))" $resource.DateFields }}{{ end }}
+@Injectable({ providedIn: 'root' })
+export class {{ $interface }}Service {
+  private readonly http = inject(HttpClient);
+  private readonly baseUrl = inject(API_BASE_URL);
+  readonly resource = Resources.{{ $interface }};
+{{- if $resource.Has "listHandler" }}
+
+  list(query?: {{ $interface }}Query): Observable<{{ $interface }}[]> {
+    return this.query(this.baseUrl + '{{ $resource.Route }}', query);
+  }
+
+  // listSignal returns the rows matching query, reloaded when it changes. It must be called in
+  // an injection context, e.g. a field initializer.
+  listSignal(query: Signal<{{ $interface }}Query>): Signal<{{ $interface }}[] | undefined> {
+    return toSignal(toObservable(query).pipe(switchMap((q) => this.list(q))));
+  }
+{{- end }}
+{{- if $resource.Has "readHandler" }}
+
+  get({{ $resource.KeyParams }}, options?: {{ $interface }}ReadOptions): Observable<{{ $interface }}> {
+    return this.http.get<{{ $interface }}>(this.baseUrl + '{{ $resource.Route }}' + {{ $resource.KeyPath }}, { params: readParams(options) }){{ if $resource.DateFields }}.pipe(map((row) => reviveDates(row, {{ $resource.DateFields }}))){{ end }};
+  }
+
+  // getSignal returns the row with the primary key in key, reloaded when it changes. It must be
+  // called in an injection context, e.g. a field initializer.
+  getSignal(key: Signal<[{{ $resource.KeyParams }}]>, options?: {{ $interface }}ReadOptions): Signal<{{ $interface }} | undefined> {
+    return toSignal(toObservable(key).pipe(switchMap((k) => this.get(...k, options))));
+  }
+{{- end }}
+{{- if $resource.Has "batchReadHandler" }}
+
+  batchGet(ids: {{ $resource.KeyType }}[], options?: {{ $interface }}ReadOptions): Observable<{{ $interface }}[]> {
+    const params = { ...readParams(options), ids: ids.map(formatValue).join(',') };
+
+    return this.http.get<{{ $interface }}[]>(this.baseUrl + '{{ $resource.Route }}:batchGet', { params }){{ $revive }};
+  }
+{{- end }}
+{{- if $resource.Has "patchHandler" }}
+
+  patch(operations: PatchOperation<{{ $interface }}>[]): Observable<{{ if $resource.ReturnsIDs }}{ iDs: string[] }{{ else }}void{{ end }}> {
+    return this.http.patch<{{ if $resource.ReturnsIDs }}{ iDs: string[] }{{ else }}void{{ end }}>(this.baseUrl + '{{ $resource.Route }}', operations);
+  }
+{{- end }}
+{{- if $resource.Has "nestedListHandler" }}
+
+  listFor{{ $resource.ParentName }}({{ $resource.ParentKeyParams }}, query?: {{ $interface }}Query): Observable<{{ $interface }}[]> {
+    return this.query(this.baseUrl + '{{ $resource.ParentRoute }}' + {{ $resource.ParentKeyPath }} + '{{ $resource.Route }}', query);
+  }
+{{- end }}
+{{- if $resource.Has "nestedPatchHandler" }}
+
+  patchFor{{ $resource.ParentName }}({{ $resource.ParentKeyParams }}, operations: PatchOperation<{{ $interface }}>[]): Observable<void> {
+    return this.http.patch<void>(this.baseUrl + '{{ $resource.ParentRoute }}' + {{ $resource.ParentKeyPath }} + '{{ $resource.Route }}', operations);
+  }
+{{- end }}
+{{- if or ($resource.Has "listHandler") ($resource.Has "nestedListHandler") }}
+
+  private query(url: string, query?: {{ $interface }}Query): Observable<{{ $interface }}[]> {
+    const { params, body } = listRequest(query);
+    const response = body ? this.http.post<{{ $interface }}[]>(url, body, { params }) : this.http.get<{{ $interface }}[]>(url, { params });
+
+    return response{{ $revive }};
+  }
+{{- end }}
+}
+{{ end }}
+{{- if .Consolidated }}
+@Injectable({ providedIn: 'root' })
+export class ResourcePatchService {
+  private readonly http = inject(HttpClient);
+  private readonly baseUrl = inject(API_BASE_URL);
+
+  // patch applies the operations in one transaction. Build them with a ResourcePatchBatch.
+  patch(operations: ResourceOperation[]): Observable<Record<string, string[]>> {
+    return this.http.patch<Record<string, string[]>>(this.baseUrl + '/{{ .ConsolidatedRoute }}', operations);
+  }
+}
+{{ end }}
+{{- range $rpcMethod := .RPCMethods }}
+@Injectable({ providedIn: 'root' })
+export class {{ $rpcMethod.Name }}Service {
+  private readonly http = inject(HttpClient);
+  private readonly baseUrl = inject(API_BASE_URL);
+  readonly method = Methods.{{ $rpcMethod.Name }};
+{{ if $rpcMethod.Async }}
+  // execute enqueues the method: poll the operation with RPCOperationService.
+  execute(req: {{ $rpcMethod.Name }}): Observable<RPCOperationAccepted> {
+    return this.http.post<RPCOperationAccepted>(this.baseUrl + '/{{ Kebab $rpcMethod.Name }}', req);
+  }
+  {{- else if $rpcMethod.HasResponse }}
+  execute(req: {{ $rpcMethod.Name }}): Observable<{{ $rpcMethod.Name }}Response> {
+    return this.http.post<{{ $rpcMethod.Name }}Response>(this.baseUrl + '/{{ Kebab $rpcMethod.Name }}', req){{ with $rpcMethod.ResponseDateFields }}.pipe(map((resp) => reviveDates(resp, {{ . }}))){{ end }};
+  }
+  {{- else }}
+  execute(req: {{ $rpcMethod.Name }}): Observable<void> {
+    return this.http.post<void>(this.baseUrl + '/{{ Kebab $rpcMethod.Name }}', req);
+  }
+  {{- end }}
+}
+{{ end -}}
+{{- if .HasAsync }}
+@Injectable({ providedIn: 'root' })
+export class RPCOperationService {
+  private readonly http = inject(HttpClient);
+  private readonly baseUrl = inject(API_BASE_URL);
+
+  // get reads the status of an asynchronous method's operation. Pass the method's response
+  // interface as Result; its Date fields are not revived.
+  get<Result = unknown>(operationId: string): Observable<RPCOperation<Result>> {
+    return this.http.get<RPCOperation<Result>>(this.baseUrl + '/rpc-operations/' + encodeURIComponent(operationId)).pipe(map((op) => reviveDates(op, ['createdAt', 'updatedAt'])));
+  }
+}
+{{ end -}}
+`
+
+	typescriptSchemasTemplate = `// Code generated by resourcegeneration. DO NOT EDIT.
+import { z } from 'zod';
+{{- if .HasLink }}
+
+export const linkSchema = z.object({ id: z.string().uuid(), resource: z.string(), text: z.string() });
+{{- end }}
+
+// A row schema validates a whole row. A list or read with columns returns part of the row,
+// validated with the schema's partial().
+{{- range $resource := .Resources }}
+
+export const {{ $resource.Name }}Schema = z.object({
+{{- range $field := $resource.Row }}
+  {{ $field.Name }}: {{ $field.Schema }},
+{{- end }}
+});
+{{- if $resource.Create }}
+
+export const {{ $resource.Name }}CreateSchema = z.object({
+{{- range $field := $resource.Create }}
+  {{ $field.Name }}: {{ $field.Schema }},
+{{- end }}
+});
+
+export const {{ $resource.Name }}UpdateSchema = z.object({
+{{- range $field := $resource.Update }}
+  {{ $field.Name }}: {{ $field.Schema }},
+{{- end }}
+});
+{{- end }}
+{{- end }}
+{{- range $rpcMethod := .RPCMethods }}
+
+export const {{ $rpcMethod.Name }}Schema = z.object({
+{{- range $field := $rpcMethod.Row }}
+  {{ $field.Name }}: {{ $field.Schema }},
+{{- end }}
+});
+{{- if $rpcMethod.Response }}
+
+export const {{ $rpcMethod.Name }}ResponseSchema = z.object({
+{{- range $field := $rpcMethod.Response }}
+  {{ $field.Name }}: {{ $field.Schema }},
+{{- end }}
+});
+{{- end }}
+{{- end }}
+`
+
+	typescriptEnumsTemplate = `// Code generated by resourcegeneration. DO NOT EDIT.
+// Source: {{ .Source }}
+{{ range $namedType := .NamedTypes -}}
+{{ if $enums := index $.EnumMap $namedType.Name }}
+export enum {{ $namedType.Name }} {
+{{ range $enum := $enums }}  {{ SanitizeIdentifier $enum.Description }} = {{ printf "'%s'" $enum.ID }},
+{{ end -}}
+}
+{{ end -}}
 {{- end }}`
 
 	collectionTemplate = `// Code generated by resourcegeneration. DO NOT EDIT.
@@ -1242,7 +2203,7 @@ import (
 	"github.com/go-chi/chi/v5"
 )
 
-{{ if or (gt (len .ConstResources) 0) (gt (len .ConstComputedResources) 0) -}}
+{{ if or (gt (len .ConstResources) 0) (gt (len .ConstComputedResources) 0) .HasRPCOperations -}}
 const (
 {{- range $resource := .ConstResources }}
 {{- if $resource.HasCompoundPrimaryKey }}
@@ -1258,6 +2219,9 @@ const (
 	{{ $resource.Name }}{{ $field.Name }} httpio.ParamType = "{{ GoCamelConcat $resource.Name $field.Name }}"
 	{{- end }}
 {{- end }}
+{{- if .HasRPCOperations }}
+	RPCOperationID httpio.ParamType = "rpcOperationID"
+{{- end }}
 )
 {{- end }}
 
@@ -1406,9 +2370,17 @@ func ({{ .ReceiverName }} *{{ .ApplicationName }}) {{ .RPCMethod.Name }}() http.
 	{{ end }}
 	type request struct {
 		{{- range $field := .RPCMethod.Fields }}
-		{{ $field.Name }} {{ if $field.IsLocalType }}{{ Lower $field.UnqualifiedType }}{{ else }}{{ $field.Type }}{{ end }} ` + "`{{ $field.JSONTag }}`" + `
+		{{ $field.Name }} {{ if $field.IsLocalType }}{{ Lower $field.UnqualifiedType }}{{ else }}{{ $field.Type }}{{ end }} ` + "`{{ $field.RequestJSONTag }}`" + `
+		{{- end }}
+	}
+	{{- if and .RPCMethod.HasResponse (not .RPCMethod.Async) }}
+
+	type response struct {
+		{{- range $field := .RPCMethod.ResponseFields }}
+		{{ $field.Name }} {{ $field.Type }} ` + "`{{ $field.JSONTag }}`" + `
 		{{- end }}
 	}
+	{{- end }}
 
 	decoder := NewRPCDecoder[{{ .RPCMethod.Type }}, request]({{ .ReceiverName }}, accesstypes.Execute)
 
@@ -1440,6 +2412,15 @@ func ({{ .ReceiverName }} *{{ .ApplicationName }}) {{ .RPCMethod.Name }}() http.
 
 		p := (*{{ .RPCMethod.Type }})(params)
 		{{- end }}
+		{{- if .RPCMethod.Async }}
+
+		id, err := resource.EnqueueRPCOperation(ctx, {{ $.ReceiverName }}.ResourceClient(), {{ $.ReceiverName }}.UserPermissions(r), p.Method(), p)
+		if err != nil {
+			return httpio.NewEncoder(w).ClientMessage(ctx, err)
+		}
+
+		return resource.EncodeRPCOperationAccepted(w, id, "{{ .OperationsRoute }}")
+		{{- else }}
 		{{- if .RPCMethod.IsTxnRunner }}
 			if err := {{ $.ReceiverName }}.ResourceClient().ExecuteFunc(ctx, func(ctx context.Context, txn resource.ReadWriteTransaction) error {
 				if err := p.Execute(ctx, txn, {{ $.ReceiverName }}.RPCClient()); err != nil {
@@ -1455,25 +2436,69 @@ func ({{ .ReceiverName }} *{{ .ApplicationName }}) {{ .RPCMethod.Name }}() http.
 			return httpio.NewEncoder(w).ClientMessage(ctx, err)
 		}
 		{{- end }}
+		{{- if .RPCMethod.HasResponse }}
+
+		return httpio.NewEncoder(w).Ok(response{
+			{{- range $field := .RPCMethod.ResponseFields }}
+			{{ $field.Name }}: p.{{ $field.Name }},
+			{{- end }}
+		})
+		{{- else }}
 
 		return httpio.NewEncoder(w).Ok(nil)
+		{{- end }}
+		{{- end }}
 	})
 }
-`
+{{- if .RPCMethod.Async }}
 
-	rpcInterfacesTemplate = `// Code generated by resourcegeneration. DO NOT EDIT.
-// Source: {{ .Source }}
+// run{{ .RPCMethod.Name }} runs a {{ .RPCMethod.Name }} operation enqueued by its handler.
+func ({{ .ReceiverName }} *{{ .ApplicationName }}) run{{ .RPCMethod.Name }}(ctx context.Context, op *resource.RPCOperation) (any, error) {
+	{{- if .RPCMethod.HasResponse }}
+	type response struct {
+		{{- range $field := .RPCMethod.ResponseFields }}
+		{{ $field.Name }} {{ $field.Type }} ` + "`{{ $field.JSONTag }}`" + `
+		{{- end }}
+	}
 
-package {{ .Package }}
+	{{ end }}
+	p := &{{ .RPCMethod.Type }}{}
+	if err := op.DecodeRequest(p); err != nil {
+		return nil, errors.Wrap(err, "resource.RPCOperation.DecodeRequest()")
+	}
+	{{- if .RPCMethod.IsTxnRunner }}
 
-import "github.com/cccteam/ccc/accesstypes"
+	if err := {{ $.ReceiverName }}.ResourceClient().ExecuteFunc(ctx, func(ctx context.Context, txn resource.ReadWriteTransaction) error {
+		if err := p.Execute(ctx, txn, {{ $.ReceiverName }}.RPCClient()); err != nil {
+			return errors.Wrap(err, "Transaction.Execute()")
+		}
 
-type Method interface {
-	Method() accesstypes.Resource
-{{ FormatRPCInterfaceTypes .Types }}
-}`
+		return nil
+	}); err != nil {
+		return nil, errors.Wrap(err, "spanner.Client.ReadWriteTransaction()")
+	}
+	{{- else if .RPCMethod.IsDBRunner }}
 
-	computedResourceHandlerTemplate = `// Code generated by resourcegeneration. DO NOT EDIT.
+	if err := p.Execute(ctx, {{ $.ReceiverName }}.ResourceClient(), {{ $.ReceiverName }}.RPCClient()); err != nil {
+		return nil, err
+	}
+	{{- end }}
+	{{- if .RPCMethod.HasResponse }}
+
+	return response{
+		{{- range $field := .RPCMethod.ResponseFields }}
+		{{ $field.Name }}: p.{{ $field.Name }},
+		{{- end }}
+	}, nil
+	{{- else }}
+
+	return nil, nil
+	{{- end }}
+}
+{{- end }}
+`
+
+	rpcOperationHandlerTemplate = `// Code generated by resourcegeneration. DO NOT EDIT.
 // Source: {{ .Source }}
 
 package {{ .Package }}
@@ -1484,18 +2509,81 @@ import (
 	{{ .LocalPackageImports }}
 	"github.com/cccteam/ccc"
 	"github.com/cccteam/ccc/accesstypes"
+	"github.com/cccteam/ccc/resource"
 	"github.com/cccteam/ccc/tracer"
 	"github.com/cccteam/httpio"
+	"github.com/go-playground/errors/v5"
 )
 
-{{- if not .Resource.SuppressListHandler }}
-func ({{ .ReceiverName }} *{{ .ApplicationName }}) {{ Pluralize .Resource.Name }}() http.HandlerFunc {
-	type {{ GoCamel .Resource.Name }} struct {
-		{{- range $field := .Resource.Fields }}
-		{{ $field.Name }} {{ $field.Type}} ` + "`{{ $field.JSONTag }} {{ $field.PIITag }}`" + `
-		{{- end }}
-	}
-
+// RPCOperation reports the status of an @async RPC method's operation to the user who enqueued it,
+// in its domain, while they have the Execute permission on the method. Anyone else gets not found.
+func ({{ .ReceiverName }} *{{ .ApplicationName }}) RPCOperation() http.HandlerFunc {
+	return httpio.Log(func(w http.ResponseWriter, r *http.Request) error {
+		ctx, span := tracer.Start(r.Context())
+		defer span.End()
+
+		id := httpio.Param[ccc.UUID](r, router.RPCOperationID)
+
+		userPermissions := {{ .ReceiverName }}.UserPermissions(r)
+		op, err := resource.ReadRPCOperation(ctx, {{ .ReceiverName }}.ResourceClient(), userPermissions, id)
+		if err != nil {
+			return httpio.NewEncoder(w).ClientMessage(ctx, err)
+		}
+
+		if ok, missing, err := userPermissions.Check(ctx, accesstypes.Execute, op.Method); err != nil {
+			return httpio.NewEncoder(w).ClientMessage(ctx, errors.Wrap(err, "UserPermissions.Check()"))
+		} else if !ok {
+			return httpio.NewEncoder(w).ClientMessage(ctx, httpio.NewForbiddenMessagef("user %s, domain %s, does not have %s on %s", userPermissions.User(), userPermissions.Domain(), accesstypes.Execute, missing))
+		}
+
+		return httpio.NewEncoder(w).Ok(op)
+	})
+}
+
+// RegisterRPCOperations registers the runners of the @async RPC methods with a worker, which
+// runs the operations their handlers enqueue.
+func ({{ .ReceiverName }} *{{ .ApplicationName }}) RegisterRPCOperations(w *resource.RPCOperationWorker) {
+	{{- range $method := .RPCMethods }}
+	w.Register({{ $method.Type }}{}.Method(), {{ $.ReceiverName }}.run{{ $method.Name }})
+	{{- end }}
+}
+`
+
+	rpcInterfacesTemplate = `// Code generated by resourcegeneration. DO NOT EDIT.
+// Source: {{ .Source }}
+
+package {{ .Package }}
+
+import "github.com/cccteam/ccc/accesstypes"
+
+type Method interface {
+	Method() accesstypes.Resource
+{{ FormatRPCInterfaceTypes .Types }}
+}`
+
+	computedResourceHandlerTemplate = `// Code generated by resourcegeneration. DO NOT EDIT.
+// Source: {{ .Source }}
+
+package {{ .Package }}
+
+import (
+	"net/http"
+
+	{{ .LocalPackageImports }}
+	"github.com/cccteam/ccc"
+	"github.com/cccteam/ccc/accesstypes"
+	"github.com/cccteam/ccc/tracer"
+	"github.com/cccteam/httpio"
+)
+
+{{- if not .Resource.SuppressListHandler }}
+func ({{ .ReceiverName }} *{{ .ApplicationName }}) {{ Pluralize .Resource.Name }}() http.HandlerFunc {
+	type {{ GoCamel .Resource.Name }} struct {
+		{{- range $field := .Resource.Fields }}
+		{{ $field.Name }} {{ $field.Type}} ` + "`{{ $field.JSONTag }} {{ $field.PIITag }}`" + `
+		{{- end }}
+	}
+
 	type response []map[string]any
 
 	decoder := NewQueryDecoder[{{ .ComputedPackage }}.{{ .Resource.Name }}, {{ GoCamel .Resource.Name }}]({{ .ReceiverName }}, accesstypes.List)
@@ -1582,6 +2670,692 @@ func ({{ .ReceiverName }} *{{ .ApplicationName }}) {{ .Resource.Name }}() http.H
 	})
 }
 {{- end }}
+`
+
+	goClientTemplate = `// Code generated by resourcegeneration. DO NOT EDIT.
+// Source: {{ .Source }}
+
+package {{ .Package }}
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cccteam/ccc"
+	"github.com/cccteam/ccc/resource"
+	"github.com/go-playground/errors/v5"
+)
+
+// Client calls the generated routes of the API served at its base URL.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithRoundTripper sends the requests through rt, e.g. to set their Authorization header.
+// The default is http.DefaultTransport.
+func WithRoundTripper(rt http.RoundTripper) Option {
+	return func(c *Client) {
+		c.httpClient.Transport = rt
+	}
+}
+
+// New returns a Client of the API served at baseURL, e.g. https://example.com/api.
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{baseURL: strings.TrimSuffix(baseURL, "/"), httpClient: &http.Client{}}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Error is the error of a response with a status other than 2xx. Message is the response body.
+type Error struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+// Sort is a sort field of a list request. Direction defaults to ascending.
+type Sort struct {
+	Field     string
+	Direction resource.SortDirection
+	Nulls     resource.SortNulls
+}
+
+// ListQuery is the query of a list request. Filter is in the filter syntax of the list
+// handlers, e.g. the String of a resource's query clause. Columns limits the fields
+// returned, which default to every field the caller can read.
+type ListQuery struct {
+	Columns []string
+	Filter  string
+	Search  string
+	Sort    []Sort
+	Limit   int
+	Offset  int
+}
+
+func (q *ListQuery) params() url.Values {
+	params := columnParams(q.Columns)
+	if len(q.Sort) != 0 {
+		fields := make([]string, 0, len(q.Sort))
+		for _, s := range q.Sort {
+			field := s.Field
+			if s.Direction != "" || s.Nulls != "" {
+				direction := s.Direction
+				if direction == "" {
+					direction = resource.SortAscending
+				}
+				field += ":" + string(direction)
+			}
+			if s.Nulls != "" {
+				field += ":" + string(s.Nulls)
+			}
+			fields = append(fields, field)
+		}
+		params.Set("sort", strings.Join(fields, ","))
+	}
+	if q.Limit > 0 {
+		params.Set("limit", strconv.Itoa(q.Limit))
+	}
+	if q.Offset > 0 {
+		params.Set("offset", strconv.Itoa(q.Offset))
+	}
+
+	return params
+}
+
+type listBody struct {
+	Filter string ` + "`json:\"filter,omitempty\"`" + `
+	Search string ` + "`json:\"search,omitempty\"`" + `
+}
+
+// list sends a list request. A filter or search is sent in a POST body, the way one on a pii
+// field must be, so its values stay out of the URL.
+func list[T any](ctx context.Context, c *Client, path string, q *ListQuery) ([]T, error) {
+	if q == nil {
+		q = &ListQuery{}
+	}
+
+	method, body := http.MethodGet, any(nil)
+	if q.Filter != "" || q.Search != "" {
+		method, body = http.MethodPost, listBody{Filter: q.Filter, Search: q.Search}
+	}
+
+	var rows []T
+	if err := c.do(ctx, method, path, q.params(), body, &rows); err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+func columnParams(columns []string) url.Values {
+	params := make(url.Values)
+	if len(columns) != 0 {
+		params.Set("columns", strings.Join(columns, ","))
+	}
+
+	return params
+}
+
+// keyPath returns the URL path of a row's keys, e.g. /{id1}/{id2}.
+func keyPath(keys ...any) string {
+	var path strings.Builder
+	for _, key := range keys {
+		path.WriteString("/" + url.PathEscape(fmt.Sprint(key)))
+	}
+
+	return path.String()
+}
+
+// operationPath returns the path of a patch operation on a row, e.g. /{id1}/{id2}. Unlike a
+// URL path, an operation path is matched without unescaping.
+func operationPath(keys ...any) string {
+	var path strings.Builder
+	for _, key := range keys {
+		path.WriteString("/" + fmt.Sprint(key))
+	}
+
+	return path.String()
+}
+
+// do sends a request with body as its JSON body unless it is nil, and decodes the JSON
+// response into out unless it is nil.
+func (c *Client) do(ctx context.Context, method, path string, params url.Values, body, out any) error {
+	u := c.baseURL + path
+	if len(params) != 0 {
+		u += "?" + params.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return errors.Wrap(err, "json.Marshal()")
+		}
+		reader = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, reader)
+	if err != nil {
+		return errors.Wrap(err, "http.NewRequestWithContext()")
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "http.Client.Do()")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		message, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return errors.Wrap(err, "io.ReadAll()")
+		}
+
+		return &Error{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(message))}
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return errors.Wrap(err, "json.Decoder.Decode()")
+	}
+
+	return nil
+}
+{{- if .HasAsync }}
+
+// RPCOperation is the status of an @async method's operation. Result is set once the
+// operation has succeeded, and Error once it has failed.
+type RPCOperation struct {
+	ID        ccc.UUID                    ` + "`json:\"id\"`" + `
+	Method    string                      ` + "`json:\"method\"`" + `
+	Status    resource.RPCOperationStatus ` + "`json:\"status\"`" + `
+	Progress  int64                       ` + "`json:\"progress\"`" + `
+	Result    json.RawMessage             ` + "`json:\"result\"`" + `
+	Error     *string                     ` + "`json:\"error\"`" + `
+	Attempts  int64                       ` + "`json:\"attempts\"`" + `
+	CreatedAt time.Time                   ` + "`json:\"createdAt\"`" + `
+	UpdatedAt time.Time                   ` + "`json:\"updatedAt\"`" + `
+}
+
+// DecodeResult decodes the result of a succeeded operation into dst, the method's response.
+func (o *RPCOperation) DecodeResult(dst any) error {
+	if o.Status != resource.RPCOperationSucceeded {
+		return errors.Newf("operation %s is %s", o.ID, o.Status)
+	}
+	if err := json.Unmarshal(o.Result, dst); err != nil {
+		return errors.Wrap(err, "json.Unmarshal()")
+	}
+
+	return nil
+}
+
+// RPCOperation reads the status of an @async method's operation.
+func (c *Client) RPCOperation(ctx context.Context, id ccc.UUID) (*RPCOperation, error) {
+	op := &RPCOperation{}
+	if err := c.do(ctx, http.MethodGet, "/rpc-operations"+keyPath(id), nil, nil, op); err != nil {
+		return nil, err
+	}
+
+	return op, nil
+}
+{{- end }}
+{{- if .Consolidated }}
+
+// ResourceOperations collects the operations of a PatchResources request.
+type ResourceOperations struct {
+	operations []resource.PatchOperation
+}
+
+func NewResourceOperations() *ResourceOperations {
+	return &ResourceOperations{}
+}
+{{- range $resource := .Consolidated }}
+
+func (o *ResourceOperations) Create{{ $resource.Name }}({{ if not $resource.ReturnsIDs }}{{ $resource.KeyParams }}, {{ end }}p *{{ $resource.Name }}Patch) *ResourceOperations {
+	o.operations = append(o.operations, resource.PatchOperation{Op: resource.OperationCreate, Path: "{{ $resource.Route }}"{{ if not $resource.ReturnsIDs }} + operationPath({{ $resource.KeyArgs }}){{ end }}, Value: p.fields})
+
+	return o
+}
+
+func (o *ResourceOperations) Update{{ $resource.Name }}({{ $resource.KeyParams }}, p *{{ $resource.Name }}Patch) *ResourceOperations {
+	o.operations = append(o.operations, resource.PatchOperation{Op: resource.OperationUpdate, Path: "{{ $resource.Route }}" + operationPath({{ $resource.KeyArgs }}), Value: p.fields})
+
+	return o
+}
+
+func (o *ResourceOperations) Delete{{ $resource.Name }}({{ $resource.KeyParams }}) *ResourceOperations {
+	o.operations = append(o.operations, resource.PatchOperation{Op: resource.OperationDelete, Path: "{{ $resource.Route }}" + operationPath({{ $resource.KeyArgs }})})
+
+	return o
+}
+{{- end }}
+
+// PatchResources applies the operations in one transaction. It returns the IDs generated for
+// the created rows by resource, e.g. ids["ships"].
+func (c *Client) PatchResources(ctx context.Context, ops *ResourceOperations) (map[string][]ccc.UUID, error) {
+	var ids map[string][]ccc.UUID
+	if err := c.do(ctx, http.MethodPatch, "{{ .ConsolidatedRoute }}", nil, ops.operations, &ids); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+{{- end }}
+`
+
+	goClientResourceTemplate = `// Code generated by resourcegeneration. DO NOT EDIT.
+// Source: {{ .Source }}
+
+package {{ .Package }}
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cccteam/ccc"
+	"github.com/cccteam/ccc/resource"
+)
+{{- $r := .Resource }}
+
+// {{ $r.Name }} is a row of {{ $r.Plural }}. Fields left out of the request's columns are zero.
+type {{ $r.Name }} struct {
+	{{- range $field := $r.Fields }}
+	{{ $field.Name }} {{ $field.Type }} ` + "`json:\"{{ $field.JSONName }}\"`" + `
+	{{- end }}
+}
+{{- if $r.Handlers }}
+
+// {{ $r.Plural }}Client calls the {{ $r.Plural }} routes.
+type {{ $r.Plural }}Client struct {
+	client *Client
+}
+
+func (c *Client) {{ $r.Plural }}() *{{ $r.Plural }}Client {
+	return &{{ $r.Plural }}Client{client: c}
+}
+{{- end }}
+{{- if $r.Has "listHandler" }}
+
+// List lists {{ $r.Plural }}. A nil query lists them unfiltered.
+func (c *{{ $r.Plural }}Client) List(ctx context.Context, q *ListQuery) ([]{{ $r.Name }}, error) {
+	return list[{{ $r.Name }}](ctx, c.client, "{{ $r.Route }}", q)
+}
+{{- end }}
+{{- if $r.Has "readHandler" }}
+
+// Read reads a {{ $r.Name }}. Columns limits the fields returned, which default to every field
+// the caller can read.
+func (c *{{ $r.Plural }}Client) Read(ctx context.Context, {{ if $r.Keys }}{{ $r.KeyParams }}, {{ end }}columns ...string) (*{{ $r.Name }}, error) {
+	row := &{{ $r.Name }}{}
+	if err := c.client.do(ctx, http.MethodGet, "{{ $r.Route }}"+keyPath({{ $r.KeyArgs }}), columnParams(columns), nil, row); err != nil {
+		return nil, err
+	}
+
+	return row, nil
+}
+{{- end }}
+{{- if $r.Has "batchReadHandler" }}
+
+// BatchRead reads {{ $r.Plural }} by primary key, in the order of ids. IDs without a row are skipped.
+func (c *{{ $r.Plural }}Client) BatchRead(ctx context.Context, ids []{{ (index $r.Keys 0).Type }}, columns ...string) ([]{{ $r.Name }}, error) {
+	keys := make([]string, 0, len(ids))
+	for _, id := range ids {
+		keys = append(keys, fmt.Sprint(id))
+	}
+	params := columnParams(columns)
+	params.Set("ids", strings.Join(keys, ","))
+
+	var rows []{{ $r.Name }}
+	if err := c.client.do(ctx, http.MethodGet, "{{ $r.Route }}:batchGet", params, nil, &rows); err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+{{- end }}
+{{- if $r.Has "patchHandler" }}
+
+// Patch applies the operations in one transaction.{{ if $r.ReturnsIDs }} It returns the IDs generated for the created rows.{{ end }}
+func (c *{{ $r.Plural }}Client) Patch(ctx context.Context, ops *{{ $r.Name }}Operations) {{ if $r.ReturnsIDs }}([]ccc.UUID, error){{ else }}error{{ end }} {
+{{- if $r.ReturnsIDs }}
+	var resp struct {
+		IDs []ccc.UUID ` + "`json:\"iDs\"`" + `
+	}
+	if err := c.client.do(ctx, http.MethodPatch, "{{ $r.Route }}", nil, ops.operations, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.IDs, nil
+{{- else }}
+	return c.client.do(ctx, http.MethodPatch, "{{ $r.Route }}", nil, ops.operations, nil)
+{{- end }}
+}
+{{- end }}
+{{- if $r.Has "nestedListHandler" }}
+
+// ListFor{{ $r.ParentName }} lists the {{ $r.Plural }} of a {{ $r.ParentName }}. A nil query lists them unfiltered.
+func (c *{{ $r.Plural }}Client) ListFor{{ $r.ParentName }}(ctx context.Context, {{ $r.ParentKeyParams }}, q *ListQuery) ([]{{ $r.Name }}, error) {
+	return list[{{ $r.Name }}](ctx, c.client, "{{ $r.ParentRoute }}"+keyPath({{ $r.ParentKeyArgs }})+"{{ $r.Route }}", q)
+}
+{{- end }}
+{{- if $r.Has "nestedPatchHandler" }}
+
+// PatchFor{{ $r.ParentName }} applies the operations on the {{ $r.Plural }} of a {{ $r.ParentName }} in one transaction.
+func (c *{{ $r.Plural }}Client) PatchFor{{ $r.ParentName }}(ctx context.Context, {{ $r.ParentKeyParams }}, ops *{{ $r.ParentName }}{{ $r.Name }}Operations) error {
+	return c.client.do(ctx, http.MethodPatch, "{{ $r.ParentRoute }}"+keyPath({{ $r.ParentKeyArgs }})+"{{ $r.Route }}", nil, ops.operations, nil)
+}
+{{- end }}
+{{- if $r.Patchable }}
+
+// {{ $r.Name }}Patch holds the fields a {{ $r.Name }} create or update sets. Fields left unset
+// aren't sent.
+type {{ $r.Name }}Patch struct {
+	fields map[string]any
+}
+
+func New{{ $r.Name }}Patch() *{{ $r.Name }}Patch {
+	return &{{ $r.Name }}Patch{fields: make(map[string]any)}
+}
+{{- range $field := $r.PatchFields }}
+{{ if $field.Immutable }}
+// Set{{ $field.Name }} sets {{ $field.Name }}, which is immutable: only a create can set it.
+{{- end }}
+func (p *{{ $r.Name }}Patch) Set{{ $field.Name }}(v {{ $field.Type }}) *{{ $r.Name }}Patch {
+	p.fields["{{ $field.JSONName }}"] = v
+
+	return p
+}
+{{- end }}
+{{- end }}
+{{- if $r.Has "patchHandler" }}
+
+// {{ $r.Name }}Operations collects the operations of a {{ $r.Plural }} patch.
+type {{ $r.Name }}Operations struct {
+	operations []resource.PatchOperation
+}
+
+func New{{ $r.Name }}Operations() *{{ $r.Name }}Operations {
+	return &{{ $r.Name }}Operations{}
+}
+
+func (o *{{ $r.Name }}Operations) Create({{ if not $r.ReturnsIDs }}{{ $r.KeyParams }}, {{ end }}p *{{ $r.Name }}Patch) *{{ $r.Name }}Operations {
+	o.operations = append(o.operations, resource.PatchOperation{Op: resource.OperationCreate, {{ if not $r.ReturnsIDs }}Path: operationPath({{ $r.KeyArgs }}), {{ end }}Value: p.fields})
+
+	return o
+}
+
+func (o *{{ $r.Name }}Operations) Update({{ $r.KeyParams }}, p *{{ $r.Name }}Patch) *{{ $r.Name }}Operations {
+	o.operations = append(o.operations, resource.PatchOperation{Op: resource.OperationUpdate, Path: operationPath({{ $r.KeyArgs }}), Value: p.fields})
+
+	return o
+}
+
+func (o *{{ $r.Name }}Operations) Delete({{ $r.KeyParams }}) *{{ $r.Name }}Operations {
+	o.operations = append(o.operations, resource.PatchOperation{Op: resource.OperationDelete, Path: operationPath({{ $r.KeyArgs }})})
+
+	return o
+}
+{{- end }}
+{{- if $r.Has "nestedPatchHandler" }}
+
+// {{ $r.ParentName }}{{ $r.Name }}Operations collects the operations of a patch on the {{ $r.Plural }} of a
+// {{ $r.ParentName }}. Their paths hold the keys that follow the {{ $r.ParentName }}'s.
+type {{ $r.ParentName }}{{ $r.Name }}Operations struct {
+	operations []resource.PatchOperation
+}
+
+func New{{ $r.ParentName }}{{ $r.Name }}Operations() *{{ $r.ParentName }}{{ $r.Name }}Operations {
+	return &{{ $r.ParentName }}{{ $r.Name }}Operations{}
+}
+
+func (o *{{ $r.ParentName }}{{ $r.Name }}Operations) Create({{ $r.ChildKeyParams }}, p *{{ $r.Name }}Patch) *{{ $r.ParentName }}{{ $r.Name }}Operations {
+	o.operations = append(o.operations, resource.PatchOperation{Op: resource.OperationCreate, Path: operationPath({{ $r.ChildKeyArgs }}), Value: p.fields})
+
+	return o
+}
+
+func (o *{{ $r.ParentName }}{{ $r.Name }}Operations) Update({{ $r.ChildKeyParams }}, p *{{ $r.Name }}Patch) *{{ $r.ParentName }}{{ $r.Name }}Operations {
+	o.operations = append(o.operations, resource.PatchOperation{Op: resource.OperationUpdate, Path: operationPath({{ $r.ChildKeyArgs }}), Value: p.fields})
+
+	return o
+}
+
+func (o *{{ $r.ParentName }}{{ $r.Name }}Operations) Delete({{ $r.ChildKeyParams }}) *{{ $r.ParentName }}{{ $r.Name }}Operations {
+	o.operations = append(o.operations, resource.PatchOperation{Op: resource.OperationDelete, Path: operationPath({{ $r.ChildKeyArgs }})})
+
+	return o
+}
+{{- end }}
+{{- with $q := $r.Query }}{{ if $q.IsQueryClauseEligible }}
+
+// {{ $r.Name }}QueryPartialClause builds the filter of a {{ $r.Plural }} list, written into
+// ListQuery.Filter with the String of the finished clause.
+type {{ $r.Name }}QueryPartialClause struct {
+	partialClause resource.PartialQueryClause
+}
+
+func New{{ $r.Name }}QueryClause() {{ $r.Name }}QueryPartialClause {
+	return {{ $r.Name }}QueryPartialClause{partialClause: resource.NewPartialQueryClause()}
+}
+
+func (p {{ $r.Name }}QueryPartialClause) Group(qc {{ $r.Name }}QueryClause) {{ $r.Name }}QueryClause {
+	return {{ $r.Name }}QueryClause{clause: p.partialClause.Group(qc.clause)}
+}
+{{- range $field := $q.Fields }}
+{{- if $field.IsQueryClauseEligible }}
+{{ if $field.IsJSON }}
+func (p {{ $r.Name }}QueryPartialClause) {{ $field.Name }}() {{ $r.Name }}QueryJSONIdent {
+	return {{ $r.Name }}QueryJSONIdent{JSONIdent: resource.NewJSONIdent("{{ Camel $field.Name }}", p.partialClause, {{ $field.IsIndex }})}
+}
+{{- else if $field.IsArray }}
+func (p {{ $r.Name }}QueryPartialClause) {{ $field.Name }}() {{ $r.Name }}QueryArrayIdent[{{ slice $field.Type 2 }}] {
+	return {{ $r.Name }}QueryArrayIdent[{{ slice $field.Type 2 }}]{ArrayIdent: resource.NewArrayIdent[{{ slice $field.Type 2 }}]("{{ Camel $field.Name }}", p.partialClause, {{ $field.IsIndex }})}
+}
+{{- else if $unwrappedType := $field.UnwrappedNullType }}
+func (p {{ $r.Name }}QueryPartialClause) {{ $field.Name }}() {{ $r.Name }}QueryIdent[{{ $unwrappedType }}] {
+	return {{ $r.Name }}QueryIdent[{{ $unwrappedType }}]{Ident: resource.NewIdent[{{ $unwrappedType }}]("{{ Camel $field.Name }}", p.partialClause, {{ $field.IsIndex }})}
+}
+{{- else }}
+func (p {{ $r.Name }}QueryPartialClause) {{ $field.Name }}() {{ $r.Name }}QueryIdent[{{ $field.DerefType }}] {
+	return {{ $r.Name }}QueryIdent[{{ $field.DerefType }}]{Ident: resource.NewIdent[{{ $field.DerefType }}]("{{ Camel $field.Name }}", p.partialClause, {{ $field.IsIndex }})}
+}
+{{- end }}
+{{- end }}
+{{- end }}
+
+type {{ $r.Name }}QueryClause struct {
+	clause resource.QueryClause
+}
+
+func (qc {{ $r.Name }}QueryClause) And() {{ $r.Name }}QueryPartialClause {
+	return {{ $r.Name }}QueryPartialClause{partialClause: qc.clause.And()}
+}
+
+func (qc {{ $r.Name }}QueryClause) Or() {{ $r.Name }}QueryPartialClause {
+	return {{ $r.Name }}QueryPartialClause{partialClause: qc.clause.Or()}
+}
+
+// String returns the clause in the filter syntax of ListQuery.Filter. A value the syntax
+// can't represent, like one with a ',' or '|', is an error, as is an invalid JSON path.
+func (qc {{ $r.Name }}QueryClause) String() (string, error) {
+	if err := qc.clause.Err(); err != nil {
+		return "", err
+	}
+
+	return resource.FormatFilter(qc.clause.Expression())
+}
+
+type {{ $r.Name }}QueryIdent[T comparable] struct {
+	resource.Ident[T]
+}
+
+func (i {{ $r.Name }}QueryIdent[T]) Equal(v ...T) {{ $r.Name }}QueryClause {
+	return {{ $r.Name }}QueryClause{clause: i.Ident.Equal(v...)}
+}
+
+func (i {{ $r.Name }}QueryIdent[T]) NotEqual(v ...T) {{ $r.Name }}QueryClause {
+	return {{ $r.Name }}QueryClause{clause: i.Ident.NotEqual(v...)}
+}
+
+func (i {{ $r.Name }}QueryIdent[T]) GreaterThan(v T) {{ $r.Name }}QueryClause {
+	return {{ $r.Name }}QueryClause{clause: i.Ident.GreaterThan(v)}
+}
+
+func (i {{ $r.Name }}QueryIdent[T]) GreaterThanEq(v T) {{ $r.Name }}QueryClause {
+	return {{ $r.Name }}QueryClause{clause: i.Ident.GreaterThanEq(v)}
+}
+
+func (i {{ $r.Name }}QueryIdent[T]) LessThan(v T) {{ $r.Name }}QueryClause {
+	return {{ $r.Name }}QueryClause{clause: i.Ident.LessThan(v)}
+}
+
+func (i {{ $r.Name }}QueryIdent[T]) LessThanEq(v T) {{ $r.Name }}QueryClause {
+	return {{ $r.Name }}QueryClause{clause: i.Ident.LessThanEq(v)}
+}
+
+func (i {{ $r.Name }}QueryIdent[T]) Between(lo, hi T) {{ $r.Name }}QueryClause {
+	return {{ $r.Name }}QueryClause{clause: i.Ident.Between(lo, hi)}
+}
+
+func (i {{ $r.Name }}QueryIdent[T]) IsNull() {{ $r.Name }}QueryClause {
+	return {{ $r.Name }}QueryClause{clause: i.Ident.IsNull()}
+}
+
+func (i {{ $r.Name }}QueryIdent[T]) IsNotNull() {{ $r.Name }}QueryClause {
+	return {{ $r.Name }}QueryClause{clause: i.Ident.IsNotNull()}
+}
+{{- if $q.HasQueryClauseArrayFields }}
+
+type {{ $r.Name }}QueryArrayIdent[E comparable] struct {
+	resource.ArrayIdent[E]
+}
+
+func (i {{ $r.Name }}QueryArrayIdent[E]) Has(v E) {{ $r.Name }}QueryClause {
+	return {{ $r.Name }}QueryClause{clause: i.ArrayIdent.Has(v)}
+}
+
+func (i {{ $r.Name }}QueryArrayIdent[E]) HasAny(v ...E) {{ $r.Name }}QueryClause {
+	return {{ $r.Name }}QueryClause{clause: i.ArrayIdent.HasAny(v...)}
+}
+
+func (i {{ $r.Name }}QueryArrayIdent[E]) HasAll(v ...E) {{ $r.Name }}QueryClause {
+	return {{ $r.Name }}QueryClause{clause: i.ArrayIdent.HasAll(v...)}
+}
+
+func (i {{ $r.Name }}QueryArrayIdent[E]) IsNull() {{ $r.Name }}QueryClause {
+	return {{ $r.Name }}QueryClause{clause: i.ArrayIdent.IsNull()}
+}
+
+func (i {{ $r.Name }}QueryArrayIdent[E]) IsNotNull() {{ $r.Name }}QueryClause {
+	return {{ $r.Name }}QueryClause{clause: i.ArrayIdent.IsNotNull()}
+}
+{{- end }}
+{{- if $q.HasQueryClauseJSONFields }}
+
+type {{ $r.Name }}QueryJSONIdent struct {
+	resource.JSONIdent
+}
+
+func (i {{ $r.Name }}QueryJSONIdent) Path(path ...string) {{ $r.Name }}QueryIdent[any] {
+	return {{ $r.Name }}QueryIdent[any]{Ident: i.JSONIdent.Path(path...)}
+}
+
+func (i {{ $r.Name }}QueryJSONIdent) IsNull() {{ $r.Name }}QueryClause {
+	return {{ $r.Name }}QueryClause{clause: i.JSONIdent.IsNull()}
+}
+
+func (i {{ $r.Name }}QueryJSONIdent) IsNotNull() {{ $r.Name }}QueryClause {
+	return {{ $r.Name }}QueryClause{clause: i.JSONIdent.IsNotNull()}
+}
+{{- end }}
+{{- end }}{{ end }}
+`
+
+	goClientRPCTemplate = `// Code generated by resourcegeneration. DO NOT EDIT.
+// Source: {{ .Source }}
+
+package {{ .Package }}
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/cccteam/ccc"
+	"github.com/cccteam/ccc/resource"
+)
+
+// {{ .RPCMethod.Name }}Request is the request of the {{ .RPCMethod.Name }} method.
+type {{ .RPCMethod.Name }}Request struct {
+	{{- range $field := .RPCMethod.RequestFields }}
+	{{ $field.Name }} {{ $field.Type }} ` + "`{{ $field.JSONTag }}`" + `
+	{{- end }}
+}
+{{- if .RPCMethod.HasResponse }}
+
+// {{ .RPCMethod.Name }}Response is the response of the {{ .RPCMethod.Name }} method.
+type {{ .RPCMethod.Name }}Response struct {
+	{{- range $field := .RPCMethod.ResponseFields }}
+	{{ $field.Name }} {{ $field.Type }} ` + "`{{ $field.JSONTag }}`" + `
+	{{- end }}
+}
+{{- end }}
+{{- if .RPCMethod.Async }}
+
+// {{ .RPCMethod.Name }} enqueues the {{ .RPCMethod.Name }} method and returns the ID of its operation,
+// which RPCOperation reads.{{ if .RPCMethod.HasResponse }} The result of a succeeded operation decodes into a
+// {{ .RPCMethod.Name }}Response.{{ end }}
+func (c *Client) {{ .RPCMethod.Name }}(ctx context.Context, req *{{ .RPCMethod.Name }}Request) (ccc.UUID, error) {
+	accepted := &resource.RPCOperationAccepted{}
+	if err := c.do(ctx, http.MethodPost, "/{{ Kebab .RPCMethod.Name }}", nil, req, accepted); err != nil {
+		return ccc.UUID{}, err
+	}
+
+	return accepted.OperationID, nil
+}
+{{- else if .RPCMethod.HasResponse }}
+
+// {{ .RPCMethod.Name }} executes the {{ .RPCMethod.Name }} method.
+func (c *Client) {{ .RPCMethod.Name }}(ctx context.Context, req *{{ .RPCMethod.Name }}Request) (*{{ .RPCMethod.Name }}Response, error) {
+	resp := &{{ .RPCMethod.Name }}Response{}
+	if err := c.do(ctx, http.MethodPost, "/{{ Kebab .RPCMethod.Name }}", nil, req, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+{{- else }}
+
+// {{ .RPCMethod.Name }} executes the {{ .RPCMethod.Name }} method.
+func (c *Client) {{ .RPCMethod.Name }}(ctx context.Context, req *{{ .RPCMethod.Name }}Request) error {
+	return c.do(ctx, http.MethodPost, "/{{ Kebab .RPCMethod.Name }}", nil, req, nil)
+}
+{{- end }}
 `
 )
 
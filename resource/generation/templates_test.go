@@ -19,13 +19,21 @@ func fileTemplates() map[string]string {
 		"typescriptResourcesTemplate":     typescriptResourcesTemplate,
 		"typescriptMethodsTemplate":       typescriptMethodsTemplate,
 		"typescriptEnumsTemplate":         typescriptEnumsTemplate,
+		"typescriptQueryTemplate":         typescriptQueryTemplate,
+		"typescriptClientTemplate":        typescriptClientTemplate,
+		"typescriptAngularTemplate":       typescriptAngularTemplate,
+		"typescriptSchemasTemplate":       typescriptSchemasTemplate,
 		"collectionTemplate":              collectionTemplate,
 		"routesTemplate":                  routesTemplate,
 		"routerTestTemplate":              routerTestTemplate,
 		"rpcFileTemplate":                 rpcFileTemplate,
 		"rpcHandlerTemplate":              rpcHandlerTemplate,
 		"rpcInterfacesTemplate":           rpcInterfacesTemplate,
+		"rpcOperationHandlerTemplate":     rpcOperationHandlerTemplate,
 		"computedResourceHandlerTemplate": computedResourceHandlerTemplate,
+		"goClientTemplate":                goClientTemplate,
+		"goClientResourceTemplate":        goClientResourceTemplate,
+		"goClientRPCTemplate":             goClientRPCTemplate,
 	}
 }
 
@@ -37,7 +45,7 @@ func Test_fileTemplates_generationHeader(t *testing.T) {
 			t.Parallel()
 
 			if !strings.HasPrefix(tmpl, generationHeader+"\n") {
-				t.Errorf("%s must start with generationHeader %q so removeGeneratedFileByHeaderComment can clean up its output; got %q", name, generationHeader, firstLine(tmpl))
+				t.Errorf("%s must start with generationHeader %q so removeGeneratedFiles can clean up its output; got %q", name, generationHeader, firstLine(tmpl))
 			}
 		})
 	}
@@ -1,12 +1,15 @@
 // Package collectionfixture provides parsed-struct fixtures for the static permission
 // collection computation tests. The structs cover the registration-relevant tag shapes:
-// perm-tagged fields, untagged fields, immutable fields, and input-only/output-only
-// fields. The constants cover @manualAddResource annotation shapes: doc-comment and
-// line-comment placement, an explicit scope, and an unannotated (dormant) constant that
-// must contribute nothing.
+// perm-tagged fields, untagged fields, immutable fields, input-only/output-only fields,
+// masked fields, audit columns, batch reads, and JSON columns. The constants cover @manualAddResource
+// annotation shapes: doc-comment and line-comment placement, an explicit scope, and an
+// unannotated (dormant) constant that must contribute nothing.
 package collectionfixture
 
 import (
+	"time"
+
+	"cloud.google.com/go/spanner"
 	"github.com/cccteam/ccc"
 	"github.com/cccteam/ccc/accesstypes"
 )
@@ -32,6 +35,7 @@ type Widget struct {
 	Code       string   `spanner:"Code" conditions:"immutable"`
 	Secret     string   `spanner:"Secret" conditions:"input_only"`
 	Derived    string   `spanner:"Derived" conditions:"output_only"`
+	Badge      *string  `spanner:"Badge" conditions:"pii" mask:"last4" perm:"Read"`
 }
 
 type Gadget struct {
@@ -74,11 +78,99 @@ type (
 	}
 )
 
+type (
+	// Beacon rows are restricted per user by a row policy.
+	// @rowPolicy(beaconRowPolicy)
+	Beacon struct {
+		ID     ccc.UUID `spanner:"Id"`
+		Sector string   `spanner:"Sector"`
+	}
+)
+
 type Fossil struct {
 	ID   ccc.UUID `spanner:"Id"`
 	Name string   `spanner:"Name"`
 }
 
+// Credential covers the mask tag and encrypted condition shapes checkMaskTag and
+// checkEncryptedCondition accept and reject.
+type Credential struct {
+	ID       ccc.UUID `spanner:"Id"`
+	Badge    *string  `spanner:"Badge" conditions:"pii" mask:"last4"`
+	Email    string   `spanner:"Email" mask:"email"`
+	Pin      int64    `spanner:"Pin" conditions:"pii" mask:"full"`
+	Passcode string   `spanner:"Passcode" conditions:"pii" mask:"first4"`
+	Notes    *string  `spanner:"Notes" conditions:"pii,encrypted"`
+	Vitals   int64    `spanner:"Vitals" conditions:"encrypted"`
+	Callsign string   `spanner:"Callsign" conditions:"encrypted" allow_filter:"true"`
+	Sigil    string   `spanner:"Sigil" conditions:"encrypted" allow_sort:"true"`
+}
+
+type (
+	// Logbook has every audit column shape @auditable accepts.
+	// @auditable
+	Logbook struct {
+		ID        ccc.UUID   `spanner:"Id"`
+		CreatedAt time.Time  `spanner:"CreatedAt"`
+		CreatedBy string     `spanner:"CreatedBy"`
+		UpdatedAt *time.Time `spanner:"UpdatedAt"`
+		UpdatedBy *string    `spanner:"UpdatedBy"`
+	}
+)
+
+type (
+	// Stardate has audit columns @auditable rejects.
+	// @auditable
+	Stardate struct {
+		ID        ccc.UUID  `spanner:"Id"`
+		CreatedAt string    `spanner:"CreatedAt"`
+		UpdatedAt time.Time `spanner:"UpdatedAt" output_only_update_fn:"resource.CommitTimestamp"`
+	}
+)
+
+type (
+	// Manifest is read in batches by ID.
+	// @batchRead
+	Manifest struct {
+		ID   ccc.UUID `spanner:"Id"`
+		Name string   `spanner:"Name"`
+	}
+)
+
+// ManifestLine is interleaved in Manifest.
+type ManifestLine struct {
+	ManifestID ccc.UUID `spanner:"ManifestId"`
+	LineNumber int64    `spanner:"LineNumber"`
+	Cargo      string   `spanner:"Cargo"`
+}
+
+type (
+	// Sensor bounds its List queries.
+	// @queryPolicy(maxLimit=500, maxOffset=10000, timeout=1500ms, requireIndexedFilter, requireIndexedSort)
+	Sensor struct {
+		ID       ccc.UUID `spanner:"Id"`
+		Reading  int64    `spanner:"Reading"`
+		Station  string   `spanner:"Station" allow_sort:"true"`
+		Firmware string   `spanner:"Firmware"`
+	}
+)
+
+type (
+	// Probe has options @queryPolicy rejects.
+	// @queryPolicy(maxLimit=0, timeout=soon, pageSize=10)
+	Probe struct {
+		ID ccc.UUID `spanner:"Id"`
+	}
+)
+
+// Transmission has a Spanner JSON column and a Postgres JSONB column.
+type Transmission struct {
+	ID        ccc.UUID         `spanner:"Id"`
+	Payload   spanner.NullJSON `spanner:"Payload"`
+	Telemetry spanner.PGJsonB  `spanner:"Telemetry"`
+	Name      string           `spanner:"Name"`
+}
+
 type DoSomething struct {
 	Input string
 }
@@ -86,3 +178,10 @@ type DoSomething struct {
 type HiddenMethod struct {
 	Input string
 }
+
+// IssueClearance responds with the clearance Execute issues.
+type IssueClearance struct {
+	ShipID      ccc.UUID
+	ClearanceID ccc.UUID  `conditions:"output_only"`
+	ExpiresAt   time.Time `conditions:"output_only"`
+}
@@ -1,12 +1,14 @@
 package generation
 
 import (
+	"cmp"
 	"fmt"
 	"iter"
 	"net/http"
 	"path/filepath"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/cccteam/ccc/accesstypes"
 	"github.com/cccteam/ccc/resource/generation/parser"
@@ -29,7 +31,7 @@ const (
 	genPrefix = "zz_gen"
 
 	// generationHeader marks a file as owned by this generator. Every file-level template
-	// must start with it: removeGeneratedFileByHeaderComment only deletes files carrying
+	// must start with it: removeGeneratedFiles by headerComment only deletes files carrying
 	// this exact header, so a template using a different header silently escapes cleanup.
 	generationHeader = "// Code generated by resourcegeneration. DO NOT EDIT."
 )
@@ -46,6 +48,12 @@ const (
 	ReadHandler HandlerType = "readHandler"
 	// PatchHandler is the patch handler.
 	PatchHandler HandlerType = "patchHandler"
+	// BatchReadHandler is the batch-read handler, generated for resources annotated with @batchRead.
+	BatchReadHandler HandlerType = "batchReadHandler"
+	// NestedListHandler is the list handler of an interleaved child, routed under its parent row.
+	NestedListHandler HandlerType = "nestedListHandler"
+	// NestedPatchHandler is the patch handler of an interleaved child, routed under its parent row.
+	NestedPatchHandler HandlerType = "nestedPatchHandler"
 )
 
 // RouteType describes a route or set of routes for a resource-driven API.
@@ -126,6 +134,12 @@ func (h HandlerType) template() string {
 		return listTemplate
 	case PatchHandler:
 		return patchTemplate
+	case BatchReadHandler:
+		return batchReadTemplate
+	case NestedListHandler:
+		return nestedListTemplate
+	case NestedPatchHandler:
+		return nestedPatchTemplate
 	default:
 		panic(fmt.Sprintf("template(): unknown handler type: %s", h))
 	}
@@ -134,9 +148,9 @@ func (h HandlerType) template() string {
 // method returns the proper http method type for a HandlerType
 func (h HandlerType) method() string {
 	switch h {
-	case ReadHandler, ListHandler:
+	case ReadHandler, ListHandler, BatchReadHandler, NestedListHandler:
 		return http.MethodGet
-	case PatchHandler:
+	case PatchHandler, NestedPatchHandler:
 		return http.MethodPatch
 	default:
 		panic(fmt.Sprintf("Method(): unknown handler type: %s", h))
@@ -185,6 +199,15 @@ const (
 	routerTestOutputName          = "routes_test"
 	consolidatedHandlerOutputName = "consolidated_handler"
 	collectionOutputName          = "collection"
+	goClientOutputName            = "client"
+	rpcOperationOutputName        = "rpc_operation"
+)
+
+const (
+	// rpcOperationHandlerName is the generated handler reporting an @async RPC operation's status.
+	rpcOperationHandlerName = "RPCOperation"
+	// rpcOperationParam is the route parameter of the operation ID in the status route.
+	rpcOperationParam = "rpcOperationID"
 )
 
 type informationSchemaResult struct {
@@ -199,11 +222,13 @@ type informationSchemaResult struct {
 	IsNullable           bool    `spanner:"IS_NULLABLE"`
 	IsIndex              bool    `spanner:"IS_INDEX"`
 	IsUniqueIndex        bool    `spanner:"IS_UNIQUE_INDEX"`
+	IsSearchIndex        bool    `spanner:"IS_SEARCH_INDEX"`
 	GenerationExpression *string `spanner:"GENERATION_EXPRESSION"`
 	OrdinalPosition      int64   `spanner:"ORDINAL_POSITION"`
 	KeyOrdinalPosition   int64   `spanner:"KEY_ORDINAL_POSITION"`
 	HasDefault           bool    `spanner:"HAS_DEFAULT"`
 	IsInterleaved        bool    `spanner:"IS_INTERLEAVED"`
+	ParentTableName      *string `spanner:"PARENT_TABLE_NAME"`
 }
 
 type enumData struct {
@@ -215,6 +240,10 @@ type tableMetadata struct {
 	Columns       map[string]columnMeta
 	PkCount       int
 	IsInterleaved bool
+	// ParentTable is the table an interleaved table is interleaved in.
+	ParentTable string
+	// SearchTokens maps each column tokenized into a search-indexed TOKENLIST column to that column.
+	SearchTokens map[string]string
 }
 
 type columnMeta struct {
@@ -243,7 +272,7 @@ type generatedRoute struct {
 // SharedHandler reports whether the route's handler is additionally registered
 // for POST requests at the same path (read and list handlers accept POST bodies).
 func (g *generatedRoute) SharedHandler() bool {
-	return g.HandlerType == ReadHandler || g.HandlerType == ListHandler
+	return g.HandlerType == ReadHandler || g.HandlerType == ListHandler || g.HandlerType == NestedListHandler
 }
 
 // appendParamsToPaths appends each test parameter to the route's paths: the
@@ -280,6 +309,8 @@ type rpcMethodInfo struct {
 	// PermissionScope is the scope the method's registration uses
 	// (@permissionScope); empty means accesstypes.GlobalPermissionScope.
 	PermissionScope accesstypes.PermissionScope
+	// Async is set by @async: the handler enqueues an RPC operation for a worker to run.
+	Async bool
 }
 
 func (r *rpcMethodInfo) IsTxnRunner() bool {
@@ -310,6 +341,49 @@ func (r *rpcMethodInfo) HasLocalType() bool {
 	return false
 }
 
+// RequestFields returns the fields the method's request body sets.
+func (r *rpcMethodInfo) RequestFields() []*rpcField {
+	fields := make([]*rpcField, 0, len(r.Fields))
+	for _, field := range r.Fields {
+		if !field.IsResponse() {
+			fields = append(fields, field)
+		}
+	}
+
+	return fields
+}
+
+// ResponseFields returns the output_only fields Execute sets, which the handler encodes as the
+// response body.
+func (r *rpcMethodInfo) ResponseFields() []*rpcField {
+	fields := make([]*rpcField, 0, len(r.Fields))
+	for _, field := range r.Fields {
+		if field.IsResponse() {
+			fields = append(fields, field)
+		}
+	}
+
+	return fields
+}
+
+// HasResponse reports whether the method responds with a body.
+func (r *rpcMethodInfo) HasResponse() bool {
+	return slices.ContainsFunc(r.Fields, (*rpcField).IsResponse)
+}
+
+// ResponseDateFields returns the response fields the TypeScript client revives as a Date, as
+// an array literal, or empty when none is a Date.
+func (r *rpcMethodInfo) ResponseDateFields() string {
+	var dateFields []string
+	for _, field := range r.ResponseFields() {
+		if field.TypescriptDataType() == dateTSType {
+			dateFields = append(dateFields, caser.ToCamel(field.Name()))
+		}
+	}
+
+	return tsArray(dateFields)
+}
+
 type rpcField struct {
 	*parser.Field
 	typescriptType     string
@@ -323,6 +397,27 @@ func (r rpcField) JSONTag() string {
 	return fmt.Sprintf("%s:%q", jsonTagKey, camelCaseName)
 }
 
+// RequestJSONTag returns the field's tag in the generated request struct, which leaves out a
+// response field so a client can't set it.
+func (r rpcField) RequestJSONTag() string {
+	if r.IsResponse() {
+		return fmt.Sprintf("%s:%q", jsonTagKey, "-")
+	}
+
+	return r.JSONTag()
+}
+
+// IsResponse reports whether the field is output_only: set by Execute and encoded in the
+// response rather than decoded from the request.
+func (r rpcField) IsResponse() bool {
+	tag, ok := r.LookupTag(conditionsTagKey)
+	if !ok {
+		return false
+	}
+
+	return slices.Contains(strings.Split(tag, ","), outputOnlyCondition)
+}
+
 func (r *rpcField) TypescriptDataType() string {
 	switch r.typescriptType {
 	case uuidTSType:
@@ -448,6 +543,23 @@ func (c *computedField) TypescriptDataType() string {
 	return c.typescriptType
 }
 
+// auditColumn describes a column populated on an @auditable resource. The Ptr variant of each
+// FieldDefaultFunc is used for pointer fields.
+type auditColumn struct {
+	derefType  string // required field type, with or without a pointer
+	createFunc string
+	updateFunc string // empty for columns only written on create
+}
+
+// auditColumns are the column names recognized on @auditable resources. UpdatedAt and UpdatedBy
+// are also written on create so a new row reads as its own last update.
+var auditColumns = map[string]auditColumn{
+	"CreatedAt": {derefType: "time.Time", createFunc: "resource.CommitTimestamp"},
+	"CreatedBy": {derefType: stringGoType, createFunc: "resource.CurrentUser"},
+	"UpdatedAt": {derefType: "time.Time", createFunc: "resource.CommitTimestamp", updateFunc: "resource.CommitTimestamp"},
+	"UpdatedBy": {derefType: stringGoType, createFunc: "resource.CurrentUser", updateFunc: "resource.CurrentUser"},
+}
+
 type resourceInfo struct {
 	*parser.TypeInfo
 	Fields             []*resourceField
@@ -468,6 +580,15 @@ type resourceInfo struct {
 	DefaultsUpdateType string
 	ValidateCreateType string
 	ValidateUpdateType string
+	RowPolicyFunc      string
+	QueryPolicy        queryPolicy
+	IsAuditable        bool
+	HasBatchRead       bool
+	// ParentTable is the table this resource is interleaved in, and Parent its resource when
+	// one is generated. Children are the generated resources interleaved in this one.
+	ParentTable string
+	Parent      *resourceInfo
+	Children    []*resourceInfo
 }
 
 func (r *resourceInfo) HasNullBool() bool {
@@ -504,6 +625,71 @@ func (r *resourceInfo) DeleteHandlerDisabled() bool {
 	return slices.Contains(r.SuppressedHandlers, PatchHandler)
 }
 
+// HasRowPolicy indicates if a row policy function has been registered
+func (r *resourceInfo) HasRowPolicy() bool {
+	return r.RowPolicyFunc != ""
+}
+
+// QueryPolicyConfig returns the Config setter calls applying the resource's @queryPolicy, to be
+// chained onto its default Config.
+func (r *resourceInfo) QueryPolicyConfig() string {
+	var setters strings.Builder
+	if r.QueryPolicy.MaxLimit != 0 {
+		fmt.Fprintf(&setters, ".SetMaxLimit(%d)", r.QueryPolicy.MaxLimit)
+	}
+	if r.QueryPolicy.MaxOffset != 0 {
+		fmt.Fprintf(&setters, ".SetMaxOffset(%d)", r.QueryPolicy.MaxOffset)
+	}
+	if r.QueryPolicy.Timeout != 0 {
+		fmt.Fprintf(&setters, ".SetQueryTimeout(%s)", durationLiteral(r.QueryPolicy.Timeout))
+	}
+	if r.QueryPolicy.RequireIndexedFilter {
+		setters.WriteString(".SetRequireIndexedFilter(true)")
+	}
+	if r.QueryPolicy.RequireIndexedSort {
+		setters.WriteString(".SetRequireIndexedSort(true)")
+	}
+
+	return setters.String()
+}
+
+// PrimaryKeyFields returns the primary key fields in key order
+func (r *resourceInfo) PrimaryKeyFields() []*resourceField {
+	var fields []*resourceField
+	for _, field := range r.PrimaryKeys() {
+		fields = append(fields, field)
+	}
+	slices.SortStableFunc(fields, func(a, b *resourceField) int {
+		return cmp.Compare(a.KeyOrdinalPosition, b.KeyOrdinalPosition)
+	})
+
+	return fields
+}
+
+// SearchFields returns the fields tokenized into a search-indexed TOKENLIST column.
+func (r *resourceInfo) SearchFields() []*resourceField {
+	var fields []*resourceField
+	for _, field := range r.Fields {
+		if field.SearchToken != "" {
+			fields = append(fields, field)
+		}
+	}
+
+	return fields
+}
+
+// EncryptedFields returns the fields with the encrypted condition
+func (r *resourceInfo) EncryptedFields() []*resourceField {
+	var fields []*resourceField
+	for _, field := range r.Fields {
+		if field.IsEncrypted() {
+			fields = append(fields, field)
+		}
+	}
+
+	return fields
+}
+
 // HasDefaultsCreateType indicates if a default create type has been registered
 func (r *resourceInfo) HasDefaultsCreateType() bool {
 	return r.DefaultsCreateType != ""
@@ -577,6 +763,113 @@ func (r *resourceInfo) OperationPathPattern() string {
 	return pattern.String()
 }
 
+// keyPrefix is a leading run of a compound primary key's fields, in key order.
+type keyPrefix []*resourceField
+
+// Name concatenates the names of the prefix fields.
+func (p keyPrefix) Name() string {
+	var name strings.Builder
+	for _, field := range p {
+		name.WriteString(field.Name())
+	}
+
+	return name.String()
+}
+
+// Leading returns the prefix fields before the last one.
+func (p keyPrefix) Leading() []*resourceField {
+	return p[:len(p)-1]
+}
+
+// Last returns the last field of the prefix.
+func (p keyPrefix) Last() *resourceField {
+	return p[len(p)-1]
+}
+
+// KeyPrefixes returns every leading run of the primary key, from its first field alone to all
+// of its fields. The generated query types get prefix and range helpers for each of them.
+func (r *resourceInfo) KeyPrefixes() []keyPrefix {
+	fields := r.PrimaryKeyFields()
+	prefixes := make([]keyPrefix, 0, len(fields))
+	for i := range fields {
+		prefixes = append(prefixes, keyPrefix(fields[:i+1]))
+	}
+
+	return prefixes
+}
+
+// HasNestedRoutes reports whether the resource is an interleaved child whose list and patch
+// handlers are also routed under its parent row. The parent's read route declares the route
+// parameters for its key, so the parent must have one.
+func (r *resourceInfo) HasNestedRoutes() bool {
+	return r.Parent != nil && r.PkCount > r.Parent.PkCount && slices.Contains(resourceEndpoints(r.Parent), ReadHandler)
+}
+
+// ParentKeyFields returns the leading primary key fields an interleaved child shares with
+// its parent, in key order.
+func (r *resourceInfo) ParentKeyFields() []*resourceField {
+	if r.Parent == nil {
+		return nil
+	}
+
+	return r.PrimaryKeyFields()[:r.Parent.PkCount]
+}
+
+// nestedKey describes where a nested route handler reads one primary key field of an
+// interleaved child from.
+type nestedKey struct {
+	Field *resourceField
+	// IsParentKey reports whether the value is a route parameter of the parent row rather
+	// than a parameter of the patch operation path.
+	IsParentKey bool
+	// Param is the Go expression naming the parameter: the parent's router constant, or the
+	// quoted operation path parameter.
+	Param string
+}
+
+// NestedKeys returns the primary key fields of an interleaved child in field order, which is
+// the argument order of the generated patch constructors.
+func (r *resourceInfo) NestedKeys() []nestedKey {
+	parentKeys := r.ParentKeyFields()
+	childKeyCount := r.PkCount - len(parentKeys)
+
+	keys := make([]nestedKey, 0, r.PkCount)
+	var childKey int
+	for _, field := range r.PrimaryKeys() {
+		if i := slices.Index(parentKeys, field); i >= 0 {
+			parentField := r.Parent.PrimaryKeyFields()[i]
+			keys = append(keys, nestedKey{Field: field, IsParentKey: true, Param: "router." + r.Parent.Name() + parentField.Name()})
+
+			continue
+		}
+
+		childKey++
+		param := `"id"`
+		if childKeyCount > 1 {
+			param = fmt.Sprintf(`"id%d"`, childKey)
+		}
+		keys = append(keys, nestedKey{Field: field, Param: param})
+	}
+
+	return keys
+}
+
+// NestedOperationPathPattern is the patch operation path of a nested route, which only
+// addresses the primary key fields not taken from the parent row.
+func (r *resourceInfo) NestedOperationPathPattern() string {
+	childKeyCount := r.PkCount - len(r.ParentKeyFields())
+	if childKeyCount == 1 {
+		return "/{id}"
+	}
+
+	var pattern strings.Builder
+	for i := range childKeyCount {
+		fmt.Fprintf(&pattern, "/{id%d}", i+1)
+	}
+
+	return pattern.String()
+}
+
 func (r *resourceInfo) PrimaryKeyType() string {
 	for _, f := range r.Fields {
 		if f.IsPrimaryKey {
@@ -597,6 +890,16 @@ func (r *resourceInfo) PrimaryKey() *resourceField {
 	return nil
 }
 
+// HasQueryClauseArrayFields reports whether any filterable field is an ARRAY column.
+func (r *resourceInfo) HasQueryClauseArrayFields() bool {
+	return slices.ContainsFunc(r.Fields, func(f *resourceField) bool { return f.IsQueryClauseEligible() && f.IsArray() })
+}
+
+// HasQueryClauseJSONFields reports whether any filterable field is a JSON column.
+func (r *resourceInfo) HasQueryClauseJSONFields() bool {
+	return slices.ContainsFunc(r.Fields, func(f *resourceField) bool { return f.IsQueryClauseEligible() && f.IsJSON() })
+}
+
 func (r *resourceInfo) IsQueryClauseEligible() bool {
 	for _, field := range r.Fields {
 		if field.IsQueryClauseEligible() {
@@ -607,6 +910,34 @@ func (r *resourceInfo) IsQueryClauseEligible() bool {
 	return false
 }
 
+// queryPolicy holds the arguments of a @queryPolicy annotation.
+type queryPolicy struct {
+	MaxLimit             uint64
+	MaxOffset            uint64
+	Timeout              time.Duration
+	RequireIndexedFilter bool
+	RequireIndexedSort   bool
+}
+
+// durationLiteral returns a Go expression for d in the largest time unit that divides it.
+func durationLiteral(d time.Duration) string {
+	for _, unit := range []struct {
+		name     string
+		duration time.Duration
+	}{
+		{"time.Hour", time.Hour},
+		{"time.Minute", time.Minute},
+		{"time.Second", time.Second},
+		{"time.Millisecond", time.Millisecond},
+	} {
+		if d%unit.duration == 0 {
+			return fmt.Sprintf("%d * %s", d/unit.duration, unit.name)
+		}
+	}
+
+	return fmt.Sprintf("time.Duration(%d)", d)
+}
+
 type resourceField struct {
 	*parser.Field
 	Parent         *resourceInfo
@@ -623,6 +954,7 @@ type resourceField struct {
 	ReferencedResource string
 	ReferencedField    string
 	HasDefault         bool
+	SearchToken        string // Search-indexed TOKENLIST column the field is tokenized into
 }
 
 // When generating QueryClauses for Null-style wrapper types we want to use the underlying type
@@ -735,6 +1067,17 @@ func (f *resourceField) PIITag() string {
 	return ""
 }
 
+// MaskTag renders the mask strategy of a masked field. The mask tag is written unchanged
+// into the generated list and read request structs, where it also registers the Reveal
+// permission on the field's tag.
+func (f *resourceField) MaskTag() string {
+	if strategy, ok := f.LookupTag(maskTagKey); ok {
+		return fmt.Sprintf("%s:%q", maskTagKey, strategy)
+	}
+
+	return ""
+}
+
 func (f *resourceField) UniqueIndexTag() string {
 	if f.IsUniqueIndex {
 		return indexTrue
@@ -751,6 +1094,14 @@ func (f *resourceField) AllowFilterTag() string {
 	return ""
 }
 
+func (f *resourceField) AllowSortTag() string {
+	if f.HasTag(allowSortTagKey) {
+		return allowSortTagKey + `:"true"`
+	}
+
+	return ""
+}
+
 func (f *resourceField) IsPII() bool {
 	tag, ok := f.LookupTag(conditionsTagKey)
 	if !ok {
@@ -762,6 +1113,17 @@ func (f *resourceField) IsPII() bool {
 	return slices.Contains(conditions, piiCondition)
 }
 
+func (f *resourceField) IsEncrypted() bool {
+	tag, ok := f.LookupTag(conditionsTagKey)
+	if !ok {
+		return false
+	}
+
+	conditions := strings.Split(tag, ",")
+
+	return slices.Contains(conditions, encryptedCondition)
+}
+
 func (f *resourceField) IsImmutable() bool {
 	tag, ok := f.LookupTag(conditionsTagKey)
 	if !ok {
@@ -776,12 +1138,12 @@ func (f *resourceField) IsImmutable() bool {
 func (f *resourceField) IsOutputOnly() bool {
 	tag, ok := f.LookupTag(conditionsTagKey)
 	if !ok {
-		return f.HasOutputOnlyUpdateFunc()
+		return f.HasOutputOnlyUpdateFunc() || f.IsAuditColumn()
 	}
 
 	conditions := strings.Split(tag, ",")
 
-	return slices.Contains(conditions, outputOnlyCondition) || f.HasOutputOnlyUpdateFunc()
+	return slices.Contains(conditions, outputOnlyCondition) || f.HasOutputOnlyUpdateFunc() || f.IsAuditColumn()
 }
 
 func (f *resourceField) IsInputOnly() bool {
@@ -798,6 +1160,10 @@ func (f *resourceField) IsInputOnly() bool {
 func (f *resourceField) DefaultCreateFuncName() string {
 	tag, ok := f.LookupTag(defaultCreateFnTagKey)
 	if !ok {
+		if col, ok := f.auditColumn(); ok {
+			return f.auditFuncName(col.createFunc)
+		}
+
 		return ""
 	}
 
@@ -811,6 +1177,10 @@ func (f *resourceField) HasDefaultCreateFunc() bool {
 func (f *resourceField) OutputOnlyUpdateFuncName() string {
 	tag, ok := f.LookupTag(outputOnlyUpdateFnTagKey)
 	if !ok {
+		if col, ok := f.auditColumn(); ok && col.updateFunc != "" {
+			return f.auditFuncName(col.updateFunc)
+		}
+
 		return ""
 	}
 
@@ -821,6 +1191,32 @@ func (f *resourceField) HasOutputOnlyUpdateFunc() bool {
 	return f.OutputOnlyUpdateFuncName() != ""
 }
 
+// IsAuditColumn indicates the field is one of the audit columns populated on an @auditable resource.
+func (f *resourceField) IsAuditColumn() bool {
+	_, ok := f.auditColumn()
+
+	return ok
+}
+
+func (f *resourceField) auditColumn() (auditColumn, bool) {
+	if f.Parent == nil || !f.Parent.IsAuditable {
+		return auditColumn{}, false
+	}
+
+	col, ok := auditColumns[f.Name()]
+
+	return col, ok
+}
+
+// auditFuncName picks the pointer variant of an audit column's FieldDefaultFunc for pointer fields.
+func (f *resourceField) auditFuncName(name string) string {
+	if f.IsPointer() {
+		return name + "Ptr"
+	}
+
+	return name
+}
+
 func (f *resourceField) ReadPermTag() string {
 	tag, ok := f.LookupTag(permTagKey)
 	if !ok {
@@ -909,6 +1305,45 @@ func (f *resourceField) IsQueryClauseEligible() bool {
 	return f.HasTag(allowFilterTagKey)
 }
 
+// IsSortable reports whether list requests can sort on the field: any field that isn't
+// encrypted, unless the resource's @queryPolicy requires an indexed sort, and then primary key
+// and indexed fields and fields with the allow_sort tag.
+func (f *resourceField) IsSortable() bool {
+	if f.IsEncrypted() {
+		return false
+	}
+	if f.Parent == nil || !f.Parent.QueryPolicy.RequireIndexedSort {
+		return true
+	}
+	if f.IsPrimaryKey || f.IsIndex || f.IsUniqueIndex {
+		return true
+	}
+
+	return f.HasTag(allowSortTagKey)
+}
+
+// IsArray reports whether the field is an ARRAY column, filtered with the has, hasany and
+// hasall operators. []byte is a BYTES column, not an array.
+func (f *resourceField) IsArray() bool {
+	return f.IsIterable() && f.Type() != "[]byte"
+}
+
+// ArrayElemType returns the element type of an ARRAY field.
+func (f *resourceField) ArrayElemType() string {
+	return strings.TrimPrefix(f.ResolvedType(), "[]")
+}
+
+// IsJSON reports whether the field is a JSON column, filtered by path. It agrees with the
+// runtime check of the filter parser: a Spanner JSON or Postgres JSONB column.
+func (f *resourceField) IsJSON() bool {
+	switch f.DerefType() {
+	case "spanner.NullJSON", "spanner.PGJsonB":
+		return true
+	default:
+		return false
+	}
+}
+
 func generatedGoFileName(name string) string {
 	return generatedFileName(name, "go")
 }
@@ -936,6 +1371,11 @@ const (
 	manualAddResourceKeyword    string = "manualAddResource"    // Declares a manual permission registration on an accesstypes.Resource constant
 	manualAddResourceSetKeyword string = "manualAddResourceSet" // Declares that hand-written handlers register this resource's permission Sets for the given handler types
 	permissionScopeKeyword      string = "permissionScope"      // Declares the permission scope (global or domain) all of a resource's registrations use
+	rowPolicyKeyword            string = "rowPolicy"            // Specifies a function returning the row-level access policy for a resource
+	queryPolicyKeyword          string = "queryPolicy"          // Sets the maximum limit, maximum offset, timeout and indexed filter requirement of a resource's List queries
+	auditableKeyword            string = "auditable"            // Populates the CreatedAt, CreatedBy, UpdatedAt and UpdatedBy audit columns of a resource
+	batchReadKeyword            string = "batchRead"            // Generates a handler reading several rows of a resource by primary key in one request
+	asyncKeyword                string = "async"                // Runs an RPC method in the background: the handler enqueues an operation and responds 202 Accepted
 )
 
 func resourceKeywords() map[string]genlang.KeywordOpts {
@@ -954,5 +1394,10 @@ func resourceKeywords() map[string]genlang.KeywordOpts {
 		manualAddResourceKeyword:    {genlang.ScanConstant: genlang.ArgsRequired},
 		manualAddResourceSetKeyword: {genlang.ScanStruct: genlang.ArgsRequired},
 		permissionScopeKeyword:      {genlang.ScanStruct: genlang.ArgsRequired | genlang.Exclusive},
+		rowPolicyKeyword:            {genlang.ScanStruct: genlang.ArgsRequired | genlang.Exclusive},
+		queryPolicyKeyword:          {genlang.ScanStruct: genlang.ArgsRequired | genlang.Exclusive},
+		auditableKeyword:            {genlang.ScanStruct: genlang.NoArgs | genlang.Exclusive},
+		batchReadKeyword:            {genlang.ScanStruct: genlang.NoArgs | genlang.Exclusive},
+		asyncKeyword:                {genlang.ScanStruct: genlang.NoArgs | genlang.Exclusive},
 	}
 }
@@ -70,6 +70,8 @@ func Test_generatedRoute_SharedHandler(t *testing.T) {
 		{name: "read handler is shared", handlerType: ReadHandler, want: true},
 		{name: "list handler is shared", handlerType: ListHandler, want: true},
 		{name: "patch handler is not shared", handlerType: PatchHandler, want: false},
+		{name: "nested list handler is shared", handlerType: NestedListHandler, want: true},
+		{name: "nested patch handler is not shared", handlerType: NestedPatchHandler, want: false},
 		{name: "no handler type is not shared", handlerType: "", want: false},
 	}
 	for _, tt := range tests {
@@ -167,3 +169,148 @@ func Test_generatedRoute_appendParamsToPaths(t *testing.T) {
 		})
 	}
 }
+
+func Test_resourceInfo_NestedKeys(t *testing.T) {
+	t.Parallel()
+
+	structs := fixtureStructs(loadCollectionFixture(t))
+	parent := fixtureResource(t, structs, "Manifest", nil)
+	child := fixtureResource(t, structs, "ManifestLine", func(res *resourceInfo) {
+		res.PkCount = 2
+		res.ParentTable = "Manifests"
+		for i, field := range res.Fields {
+			field.IsPrimaryKey = field.Name() != "Cargo"
+			field.KeyOrdinalPosition = int64(i + 1)
+		}
+	})
+
+	(&client{}).linkInterleavedResources([]*resourceInfo{parent, child})
+	if child.Parent != parent {
+		t.Fatalf("ManifestLine.Parent = %v, want Manifest", child.Parent)
+	}
+	if len(parent.Children) != 1 || parent.Children[0] != child {
+		t.Errorf("Manifest.Children = %v, want [ManifestLine]", parent.Children)
+	}
+
+	type key struct {
+		Name        string
+		IsParentKey bool
+		Param       string
+	}
+	var got []key
+	for _, k := range child.NestedKeys() {
+		got = append(got, key{Name: k.Field.Name(), IsParentKey: k.IsParentKey, Param: k.Param})
+	}
+	want := []key{
+		{Name: "ManifestID", IsParentKey: true, Param: "router.ManifestID"},
+		{Name: "LineNumber", Param: `"id"`},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("resourceInfo.NestedKeys() mismatch (-want +got):\n%s", diff)
+	}
+	if got, want := child.NestedOperationPathPattern(), "/{id}"; got != want {
+		t.Errorf("resourceInfo.NestedOperationPathPattern() = %q, want %q", got, want)
+	}
+}
+
+func Test_resourceField_IsJSON(t *testing.T) {
+	t.Parallel()
+
+	res := fixtureResource(t, fixtureStructs(loadCollectionFixture(t)), "Transmission", nil)
+	got := make(map[string]bool)
+	for _, field := range res.Fields {
+		got[field.Name()] = field.IsJSON()
+	}
+	want := map[string]bool{"ID": false, "Payload": true, "Telemetry": true, "Name": false}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("resourceField.IsJSON() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func Test_resourceField_IsSortable(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		structName  string
+		indexedSort bool
+		want        map[string]bool
+	}{
+		{
+			name:       "every field by default",
+			structName: "Sensor",
+			want:       map[string]bool{"ID": true, "Reading": true, "Station": true, "Firmware": true},
+		},
+		{
+			name:        "indexed sort required",
+			structName:  "Sensor",
+			indexedSort: true,
+			want:        map[string]bool{"ID": true, "Reading": true, "Station": true, "Firmware": false},
+		},
+		{
+			name:       "encrypted fields",
+			structName: "Credential",
+			want:       map[string]bool{"ID": true, "Badge": true, "Email": true, "Pin": true, "Passcode": true, "Notes": false, "Vitals": false, "Callsign": false, "Sigil": false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			res := fixtureResource(t, fixtureStructs(loadCollectionFixture(t)), tt.structName, func(r *resourceInfo) {
+				r.QueryPolicy.RequireIndexedSort = tt.indexedSort
+				for _, f := range r.Fields {
+					f.IsIndex = f.Name() == "Reading"
+				}
+			})
+			got := make(map[string]bool)
+			for _, field := range res.Fields {
+				got[field.Name()] = field.IsSortable()
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("resourceField.IsSortable() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_rpcMethodInfo_ResponseFields(t *testing.T) {
+	t.Parallel()
+
+	s := fixtureStructs(loadCollectionFixture(t))["IssueClearance"]
+	method := &rpcMethodInfo{Struct: s}
+	for _, f := range s.Fields() {
+		field := &rpcField{Field: f}
+		if f.Name() == "ExpiresAt" {
+			field.typescriptType = dateTSType
+		}
+		method.Fields = append(method.Fields, field)
+	}
+
+	names := func(fields []*rpcField) []string {
+		var names []string
+		for _, field := range fields {
+			names = append(names, field.Name())
+		}
+
+		return names
+	}
+	if diff := cmp.Diff([]string{"ShipID"}, names(method.RequestFields())); diff != "" {
+		t.Errorf("RequestFields() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"ClearanceID", "ExpiresAt"}, names(method.ResponseFields())); diff != "" {
+		t.Errorf("ResponseFields() mismatch (-want +got):\n%s", diff)
+	}
+	if !method.HasResponse() {
+		t.Error("HasResponse() = false, want true")
+	}
+	if got, want := method.ResponseDateFields(), "['expiresAt']"; got != want {
+		t.Errorf("ResponseDateFields() = %q, want %q", got, want)
+	}
+	if got, want := method.Fields[1].RequestJSONTag(), `json:"-"`; got != want {
+		t.Errorf("RequestJSONTag() = %q, want %q", got, want)
+	}
+	if got, want := method.Fields[0].RequestJSONTag(), `json:"shipId"`; got != want {
+		t.Errorf("RequestJSONTag() = %q, want %q", got, want)
+	}
+}
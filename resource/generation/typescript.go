@@ -3,15 +3,18 @@ package generation
 import (
 	"fmt"
 	"log"
-	"os"
 	"path/filepath"
+	"reflect"
 	"slices"
+	"strings"
 	"time"
 
 	"github.com/cccteam/ccc/accesstypes"
 	"github.com/cccteam/ccc/resource"
 	"github.com/cccteam/ccc/resource/generation/parser"
+	"github.com/ettle/strcase"
 	"github.com/go-playground/errors/v5"
+	"github.com/shopspring/decimal"
 	"golang.org/x/tools/go/packages"
 )
 
@@ -20,10 +23,14 @@ type typescriptGenerator struct {
 	genPermission          bool
 	genMetadata            bool
 	genEnums               bool
+	genClient              bool
+	genAngular             bool
+	genSchemas             bool
 	typescriptDestination  string
 	typescriptOverrides    map[string]string
 	rc                     *resource.GeneratedCollection
 	routerResources        []accesstypes.Resource
+	routePrefix            string
 	spannerEmulatorVersion string
 }
 
@@ -120,6 +127,14 @@ func (t *typescriptGenerator) Generate() error {
 		return err
 	}
 
+	if err := t.runTypescriptClientGeneration(); err != nil {
+		return err
+	}
+
+	if err := t.runTypescriptSchemaGeneration(); err != nil {
+		return err
+	}
+
 	log.Printf("Finished Typescript generation in %s\n", time.Since(begin))
 
 	return nil
@@ -131,7 +146,7 @@ func (t *typescriptGenerator) runTypescriptEnumGeneration(namedTypes []*parser.N
 	}
 
 	if !t.genMetadata && !t.genPermission {
-		if err := removeGeneratedFiles(t.typescriptDestination, headerComment); err != nil {
+		if err := t.removeGeneratedFiles(t.typescriptDestination, headerComment); err != nil {
 			return errors.Wrap(err, "RemoveGeneratedFiles()")
 		}
 	}
@@ -149,7 +164,7 @@ func (t *typescriptGenerator) runTypescriptPermissionGeneration() error {
 	}
 	begin := time.Now()
 	if !t.genMetadata {
-		if err := removeGeneratedFiles(t.typescriptDestination, headerComment); err != nil {
+		if err := t.removeGeneratedFiles(t.typescriptDestination, headerComment); err != nil {
 			return errors.Wrap(err, "RemoveGeneratedFiles()")
 		}
 	}
@@ -181,11 +196,37 @@ func (t *typescriptGenerator) runTypescriptPermissionGeneration() error {
 		}
 	}
 
+	maskResourceFields := make(map[accesstypes.Resource]map[accesstypes.Tag]string)
+	for _, res := range t.resources {
+		for _, field := range res.Fields {
+			if strategy, ok := field.LookupTag(maskTagKey); ok {
+				if _, ok := maskResourceFields[accesstypes.Resource(t.pluralize(res.Name()))]; !ok {
+					maskResourceFields[accesstypes.Resource(t.pluralize(res.Name()))] = make(map[accesstypes.Tag]string)
+				}
+				maskResourceFields[accesstypes.Resource(t.pluralize(res.Name()))][accesstypes.Tag(caser.ToCamel(field.Name()))] = strategy
+			}
+		}
+	}
+
+	outputOnlyResourceFields := make(map[accesstypes.Resource]map[accesstypes.Tag]bool)
+	for _, res := range t.resources {
+		for _, field := range res.Fields {
+			if field.IsOutputOnly() {
+				if _, ok := outputOnlyResourceFields[accesstypes.Resource(t.pluralize(res.Name()))]; !ok {
+					outputOnlyResourceFields[accesstypes.Resource(t.pluralize(res.Name()))] = make(map[accesstypes.Tag]bool)
+				}
+				outputOnlyResourceFields[accesstypes.Resource(t.pluralize(res.Name()))][accesstypes.Tag(caser.ToCamel(field.Name()))] = true
+			}
+		}
+	}
+
 	templateData := tsConstantsData{
-		File:       t,
-		Data:       routerData,
-		RPCMethods: t.rpcMethods,
-		PIIMap:     piiResourceFields,
+		File:          t,
+		Data:          routerData,
+		RPCMethods:    t.rpcMethods,
+		PIIMap:        piiResourceFields,
+		MaskMap:       maskResourceFields,
+		OutputOnlyMap: outputOnlyResourceFields,
 	}
 
 	output, err := t.generateTemplateOutput(typescriptConstantsTemplate, typescriptConstantsTemplate, templateData)
@@ -194,17 +235,11 @@ func (t *typescriptGenerator) runTypescriptPermissionGeneration() error {
 	}
 
 	destinationFilePath := filepath.Join(t.typescriptDestination, generatedTypescriptFileName("constants"))
-	file, err := os.Create(destinationFilePath)
-	if err != nil {
-		return errors.Wrap(err, "os.Create()")
-	}
-	defer file.Close()
-
-	if err := t.WriteBytesToFile(file, output); err != nil {
+	if err := t.writeGeneratedFile(destinationFilePath, output); err != nil {
 		return err
 	}
 
-	log.Printf("Generated Permissions in %s: %s\n", time.Since(begin), file.Name())
+	log.Printf("Generated Permissions in %s: %s\n", time.Since(begin), destinationFilePath)
 
 	return nil
 }
@@ -214,7 +249,7 @@ func (t *typescriptGenerator) runTypescriptMetadataGeneration() error {
 		return nil
 	}
 
-	if err := removeGeneratedFiles(t.typescriptDestination, headerComment); err != nil {
+	if err := t.removeGeneratedFiles(t.typescriptDestination, headerComment); err != nil {
 		return errors.Wrap(err, "removeGeneratedFiles()")
 	}
 
@@ -257,17 +292,11 @@ func (t *typescriptGenerator) generateResourceMetadata() error {
 	}
 
 	destinationFilePath := filepath.Join(t.typescriptDestination, generatedTypescriptFileName("resources"))
-	file, err := os.Create(destinationFilePath)
-	if err != nil {
-		return errors.Wrap(err, "os.Create()")
-	}
-	defer file.Close()
-
-	if err := t.WriteBytesToFile(file, output); err != nil {
+	if err := t.writeGeneratedFile(destinationFilePath, output); err != nil {
 		return err
 	}
 
-	log.Printf("Generated resource metadata in %s: %s\n", time.Since(begin), file.Name())
+	log.Printf("Generated resource metadata in %s: %s\n", time.Since(begin), destinationFilePath)
 
 	return nil
 }
@@ -286,21 +315,401 @@ func (t *typescriptGenerator) generateMethodMetadata() error {
 	}
 
 	destinationFilePath := filepath.Join(t.typescriptDestination, generatedTypescriptFileName("methods"))
-	file, err := os.Create(destinationFilePath)
+	if err := t.writeGeneratedFile(destinationFilePath, output); err != nil {
+		return err
+	}
+
+	log.Printf("Generated methods metadata in %s: %s\n", time.Since(begin), destinationFilePath)
+
+	return nil
+}
+
+// runTypescriptClientGeneration writes the typed API client and the Angular services, and
+// the query file they share. It runs after the metadata generation, which clears the
+// destination of generated files.
+func (t *typescriptGenerator) runTypescriptClientGeneration() error {
+	if !t.genClient && !t.genAngular {
+		return nil
+	}
+	begin := time.Now()
+	log.Println("Starting typescript client generation...")
+
+	data := t.clientData()
+	if err := t.writeTypescriptFile("query", "typescriptQueryTemplate", typescriptQueryTemplate, data); err != nil {
+		return err
+	}
+
+	if t.genClient {
+		if err := t.writeTypescriptFile("client", "typescriptClientTemplate", typescriptClientTemplate, data); err != nil {
+			return err
+		}
+	}
+
+	if t.genAngular {
+		if err := t.writeTypescriptFile("services", "typescriptAngularTemplate", typescriptAngularTemplate, data); err != nil {
+			return err
+		}
+	}
+
+	log.Printf("Generated typescript client in %s\n", time.Since(begin))
+
+	return nil
+}
+
+// writeTypescriptFile executes the template into the generated file with the given name.
+func (t *typescriptGenerator) writeTypescriptFile(name, templateName, fileTemplate string, data any) error {
+	output, err := t.generateTemplateOutput(templateName, fileTemplate, data)
 	if err != nil {
-		return errors.Wrap(err, "os.Create()")
+		return errors.Wrap(err, "generateTemplateOutput()")
+	}
+
+	return t.writeGeneratedFile(filepath.Join(t.typescriptDestination, generatedTypescriptFileName(name)), output)
+}
+
+// clientData returns the routes of the TypeScript API client and Angular services: the
+// generated routes of each resource and computed resource, PatchResources, and each RPC
+// method.
+func (t *typescriptGenerator) clientData() tsClientData {
+	data := tsClientData{ConsolidatedRoute: t.ConsolidatedRoute, GenPrefix: genPrefix}
+	if t.routePrefix != "" {
+		data.APIBaseURL = "/" + t.routePrefix
+	}
+
+	var hasConsolidatedRoute bool
+	for _, res := range t.resources {
+		if res.IsConsolidated {
+			data.Consolidated = append(data.Consolidated, t.clientResource(res, nil))
+		}
+		if res.RoutingDisabled() {
+			continue
+		}
+		if hasConsolidatedHandler(res) {
+			hasConsolidatedRoute = true
+		}
+
+		if handlers := resourceEndpoints(res); len(handlers) != 0 {
+			data.Resources = append(data.Resources, t.clientResource(res, handlers))
+		}
+	}
+	if !hasConsolidatedRoute {
+		data.Consolidated = nil
+	}
+
+	for _, res := range t.computedResources {
+		if res.RoutingDisabled() {
+			continue
+		}
+
+		data.Resources = append(data.Resources, t.clientComputedResource(res))
+	}
+
+	for _, method := range t.rpcMethods {
+		if !method.SuppressHandler {
+			data.RPCMethods = append(data.RPCMethods, method)
+		}
+	}
+
+	for _, res := range slices.Concat(data.Resources, data.Consolidated) {
+		data.Interfaces = append(data.Interfaces, res.Interface)
+	}
+	slices.Sort(data.Interfaces)
+	data.Interfaces = slices.Compact(data.Interfaces)
+
+	return data
+}
+
+func (t *typescriptGenerator) clientResource(res *resourceInfo, handlers []HandlerType) tsClientResource {
+	plural := t.pluralize(res.Name())
+	r := tsClientResource{
+		Interface:  plural,
+		Name:       res.Name(),
+		Route:      "/" + strcase.ToKebab(plural),
+		Handlers:   handlers,
+		ReturnsIDs: res.PrimaryKeyIsGeneratedUUID(),
+	}
+
+	var filterFields, sortFields, dateFields []string
+	for _, field := range res.Fields {
+		name := caser.ToCamel(field.Name())
+		if field.IsQueryClauseEligible() {
+			filterFields = append(filterFields, name)
+		}
+		if field.IsSortable() {
+			sortFields = append(sortFields, name)
+		}
+		if field.TypescriptDataType() == dateTSType {
+			dateFields = append(dateFields, name)
+		}
+	}
+	r.FilterFields, r.SortFields, r.DateFields = tsUnion(filterFields), tsUnion(sortFields), tsArray(dateFields)
+
+	keys := make([]tsKey, 0, res.PkCount)
+	for _, field := range res.PrimaryKeyFields() {
+		keys = append(keys, tsKey{name: field.Name(), typescriptType: field.TypescriptDataType()})
+	}
+	r.KeyParams, r.KeyPath = tsKeyParams("", keys)
+	if len(keys) == 1 {
+		r.KeyType = keys[0].dataType()
 	}
-	defer file.Close()
 
-	if err := t.WriteBytesToFile(file, output); err != nil {
+	if res.Parent != nil {
+		parentKeys := make([]tsKey, 0, res.Parent.PkCount)
+		for _, field := range res.Parent.PrimaryKeyFields() {
+			parentKeys = append(parentKeys, tsKey{name: field.Name(), typescriptType: field.TypescriptDataType()})
+		}
+		r.ParentName = res.Parent.Name()
+		r.ParentRoute = "/" + strcase.ToKebab(t.pluralize(res.Parent.Name()))
+		r.ParentKeyParams, r.ParentKeyPath = tsKeyParams(res.Parent.Name(), parentKeys)
+	}
+
+	return r
+}
+
+func (t *typescriptGenerator) clientComputedResource(res *computedResource) tsClientResource {
+	plural := t.pluralize(res.Name())
+	r := tsClientResource{
+		Interface:    plural,
+		Name:         res.Name(),
+		Route:        "/" + strcase.ToKebab(plural),
+		FilterFields: tsUnion(nil),
+		SortFields:   tsUnion(nil),
+	}
+	if !res.SuppressListHandler {
+		r.Handlers = append(r.Handlers, ListHandler)
+	}
+	if !res.SuppressReadHandler {
+		r.Handlers = append(r.Handlers, ReadHandler)
+	}
+
+	var dateFields []string
+	for _, field := range res.Fields {
+		if field.TypescriptDataType() == dateTSType {
+			dateFields = append(dateFields, caser.ToCamel(field.Name()))
+		}
+	}
+	r.DateFields = tsArray(dateFields)
+
+	keys := make([]tsKey, 0)
+	for _, field := range res.PrimaryKeys() {
+		keys = append(keys, tsKey{name: field.Name(), typescriptType: field.TypescriptDataType()})
+	}
+	r.KeyParams, r.KeyPath = tsKeyParams("", keys)
+
+	return r
+}
+
+// runTypescriptSchemaGeneration writes the zod schemas of the resources, computed resources
+// and RPC methods.
+func (t *typescriptGenerator) runTypescriptSchemaGeneration() error {
+	if !t.genSchemas {
+		return nil
+	}
+	begin := time.Now()
+	if !t.genMetadata && !t.genPermission && !t.genEnums {
+		if err := t.removeGeneratedFiles(t.typescriptDestination, headerComment); err != nil {
+			return errors.Wrap(err, "removeGeneratedFiles()")
+		}
+	}
+	log.Println("Starting typescript schema generation...")
+
+	if err := t.writeTypescriptFile("schemas", "typescriptSchemasTemplate", typescriptSchemasTemplate, t.schemaData()); err != nil {
 		return err
 	}
 
-	log.Printf("Generated methods metadata in %s: %s\n", time.Since(begin), file.Name())
+	log.Printf("Generated typescript schemas in %s\n", time.Since(begin))
 
 	return nil
 }
 
+// schemaData returns the zod schemas: a row schema for each resource and computed resource,
+// create and update schemas for each resource that isn't virtual, and a request schema for
+// each RPC method. They follow the generated JSON: input only fields are left out of rows,
+// and primary keys and output only fields out of create and update. An enumerated field
+// accepts only the Ids of its enumerated resource's rows.
+func (t *typescriptGenerator) schemaData() tsSchemaData {
+	var data tsSchemaData
+	field := func(name, goType, typescriptType, enumerated string, nullable, optional bool, notes ...string) tsSchemaField {
+		schema := zodSchema(goType, typescriptType)
+		if values := t.enumValues[enumerated]; len(values) != 0 {
+			schema = zodEnumSchema(typescriptType, values)
+		} else if enumerated != "" {
+			notes = append(notes, "enumerated "+enumerated)
+		}
+		if schema == linkSchemaConst {
+			data.HasLink = true
+		}
+		if nullable {
+			schema += ".nullable()"
+		}
+		if optional {
+			schema += ".optional()"
+		}
+		if notes = slices.DeleteFunc(notes, func(n string) bool { return n == "" }); len(notes) != 0 {
+			schema += fmt.Sprintf(".describe('%s')", strings.Join(notes, ", "))
+		}
+
+		return tsSchemaField{Name: caser.ToCamel(name), Schema: schema}
+	}
+
+	for _, res := range t.resources {
+		s := tsSchemaResource{Name: caser.ToCamel(t.pluralize(res.Name()))}
+		for _, f := range res.Fields {
+			var enumerated string
+			if f.IsEnumerated {
+				enumerated = f.ReferencedResource
+			}
+			note := piiNote(f.IsPII())
+			if !f.IsInputOnly() {
+				s.Row = append(s.Row, field(f.Name(), f.TypeName(), f.typescriptType, enumerated, f.IsNullable, false, note))
+			}
+			if res.IsVirtual || f.IsPrimaryKey || f.IsOutputOnly() {
+				continue
+			}
+			s.Create = append(s.Create, field(f.Name(), f.TypeName(), f.typescriptType, enumerated, f.IsNullable, !f.IsRequired(), note))
+			if !f.IsImmutable() {
+				s.Update = append(s.Update, field(f.Name(), f.TypeName(), f.typescriptType, enumerated, f.IsNullable, true, note))
+			}
+		}
+		data.Resources = append(data.Resources, s)
+	}
+
+	for _, res := range t.computedResources {
+		s := tsSchemaResource{Name: caser.ToCamel(t.pluralize(res.Name()))}
+		for _, f := range res.Fields {
+			s.Row = append(s.Row, field(f.Name(), f.TypeName(), f.typescriptType, "", f.IsPointer(), false, piiNote(f.IsPII())))
+		}
+		data.Resources = append(data.Resources, s)
+	}
+
+	for _, method := range t.rpcMethods {
+		s := tsSchemaResource{Name: caser.ToCamel(method.Name())}
+		for _, f := range method.Fields {
+			if f.IsResponse() {
+				s.Response = append(s.Response, field(f.Name(), f.TypeName(), f.typescriptType, f.EnumeratedResource(), f.IsPointer(), false))
+			} else {
+				s.Row = append(s.Row, field(f.Name(), f.TypeName(), f.typescriptType, f.EnumeratedResource(), f.IsPointer(), f.IsPointer()))
+			}
+		}
+		data.RPCMethods = append(data.RPCMethods, s)
+	}
+
+	return data
+}
+
+const linkSchemaConst = "linkSchema"
+
+// zodSchema returns the zod schema of a field's TypeScript type. Dates are coerced from
+// their JSON strings, as are decimals, which are encoded as strings. A type the generator
+// doesn't know, e.g. one of the CustomTypes, is left unchecked.
+func zodSchema(goType, typescriptType string) string {
+	if elem, ok := strings.CutSuffix(typescriptType, "[]"); ok {
+		return fmt.Sprintf("z.array(%s)", zodSchema(goType, elem))
+	}
+
+	switch typescriptType {
+	case stringTSType:
+		return "z.string()"
+	case uuidTSType:
+		return "z.string().uuid()"
+	case booleanStr:
+		return "z.boolean()"
+	case dateTSType, civilDateTSType:
+		return "z.coerce.date()"
+	case linkTSType:
+		return linkSchemaConst
+	case numberTSType:
+		if goType == reflect.TypeFor[decimal.Decimal]().String() || goType == reflect.TypeFor[decimal.NullDecimal]().String() {
+			return "z.coerce.number()"
+		}
+
+		return "z.number()"
+	default:
+		return "z.unknown()"
+	}
+}
+
+// zodEnumSchema returns the zod schema of an enumerated field, which accepts only the Ids
+// of its enumerated resource's rows.
+func zodEnumSchema(typescriptType string, values []*enumData) string {
+	if elem, ok := strings.CutSuffix(typescriptType, "[]"); ok {
+		return fmt.Sprintf("z.array(%s)", zodEnumSchema(elem, values))
+	}
+
+	ids := make([]string, 0, len(values))
+	for _, v := range values {
+		ids = append(ids, v.ID)
+	}
+
+	return fmt.Sprintf("z.enum(%s)", tsArray(ids))
+}
+
+func piiNote(isPII bool) string {
+	if isPII {
+		return piiCondition
+	}
+
+	return ""
+}
+
+// tsKey is a key field of a route, passed to a client function as a parameter.
+type tsKey struct {
+	name           string
+	typescriptType string
+}
+
+// dataType returns the key's TypeScript type. A key of a resource missing from the
+// metadata has no TypeScript type, and is passed as a string.
+func (k tsKey) dataType() string {
+	if k.typescriptType == "" {
+		return stringGoType
+	}
+
+	return k.typescriptType
+}
+
+// tsKeyParams returns the parameter list of a route's keys, e.g. "shipId: string", and the
+// expression of their path segments, e.g. "keyPath(shipId)". A parent's keys are prefixed
+// with the parent's name, so they don't collide with the child's.
+func tsKeyParams(prefix string, keys []tsKey) (params, path string) {
+	paramList := make([]string, 0, len(keys))
+	names := make([]string, 0, len(keys))
+	for _, k := range keys {
+		name := caser.ToCamel(prefix + k.name)
+		paramList = append(paramList, fmt.Sprintf("%s: %s", name, k.dataType()))
+		names = append(names, name)
+	}
+
+	return strings.Join(paramList, ", "), fmt.Sprintf("keyPath(%s)", strings.Join(names, ", "))
+}
+
+// tsUnion returns a union of string literals, or never when values is empty.
+func tsUnion(values []string) string {
+	if len(values) == 0 {
+		return "never"
+	}
+
+	quoted := make([]string, 0, len(values))
+	for _, v := range values {
+		quoted = append(quoted, fmt.Sprintf("'%s'", v))
+	}
+
+	return strings.Join(quoted, " | ")
+}
+
+// tsArray returns an array literal of strings, or an empty string when values is empty.
+func tsArray(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	quoted := make([]string, 0, len(values))
+	for _, v := range values {
+		quoted = append(quoted, fmt.Sprintf("'%s'", v))
+	}
+
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
 func (t *typescriptGenerator) generateEnums(namedTypes []*parser.NamedType) error {
 	begin := time.Now()
 	log.Println("Starting enum generation...")
@@ -319,17 +728,12 @@ func (t *typescriptGenerator) generateEnums(namedTypes []*parser.NamedType) erro
 		return errors.Wrap(err, "generateTemplateOutput()")
 	}
 
-	file, err := os.Create(filepath.Join(t.typescriptDestination, generatedTypescriptFileName("enums")))
-	if err != nil {
-		return errors.Wrap(err, "os.Create()")
-	}
-	defer file.Close()
-
-	if err := t.WriteBytesToFile(file, output); err != nil {
+	destinationFilePath := filepath.Join(t.typescriptDestination, generatedTypescriptFileName("enums"))
+	if err := t.writeGeneratedFile(destinationFilePath, output); err != nil {
 		return err
 	}
 
-	log.Printf("Generated enums in %s: %s\n", time.Since(begin), file.Name())
+	log.Printf("Generated enums in %s: %s\n", time.Since(begin), destinationFilePath)
 
 	return nil
 }
@@ -0,0 +1,335 @@
+package generation
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/cccteam/ccc/resource"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_typescriptGenerator_clientData(t *testing.T) {
+	t.Parallel()
+
+	r := collectionFixtureGenerator(t)
+	r.ConsolidatedRoute = "resources"
+	ts := &typescriptGenerator{client: r.client, genClient: true, routePrefix: "v1"}
+
+	data := ts.clientData()
+	if data.APIBaseURL != "/v1" {
+		t.Errorf("clientData() APIBaseURL = %q, want %q", data.APIBaseURL, "/v1")
+	}
+
+	handlers := make(map[string][]HandlerType)
+	for _, res := range data.Resources {
+		handlers[res.Interface] = res.Handlers
+	}
+	want := map[string][]HandlerType{
+		"Gadgets":   {ListHandler},
+		"Sprockets": {ListHandler, ReadHandler},
+		"Summaries": {ListHandler, ReadHandler},
+		"Widgets":   {ListHandler, ReadHandler, PatchHandler},
+	}
+	if diff := cmp.Diff(want, handlers); diff != "" {
+		t.Errorf("clientData() Resources mismatch (-want +got):\n%s", diff)
+	}
+
+	var consolidated []string
+	for _, res := range data.Consolidated {
+		consolidated = append(consolidated, res.Interface)
+	}
+	if diff := cmp.Diff([]string{"Fossils", "Sprockets"}, consolidated); diff != "" {
+		t.Errorf("clientData() Consolidated mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"Fossils", "Gadgets", "Sprockets", "Summaries", "Widgets"}, data.Interfaces); diff != "" {
+		t.Errorf("clientData() Interfaces mismatch (-want +got):\n%s", diff)
+	}
+	if len(data.RPCMethods) != 1 || data.RPCMethods[0].Name() != "DoSomething" {
+		t.Errorf("clientData() RPCMethods = %v, want DoSomething", data.RPCMethods)
+	}
+}
+
+// Test_typescriptQueryTemplate_reservedFilterValue checks that the generated formatValue throws
+// on the values resource.FormatFilter can't write, and only those.
+func Test_typescriptQueryTemplate_reservedFilterValue(t *testing.T) {
+	t.Parallel()
+
+	match := regexp.MustCompile(`(?m)^const reservedFilterValue = /(.+)/;$`).FindStringSubmatch(typescriptQueryTemplate)
+	if match == nil {
+		t.Fatal("typescriptQueryTemplate has no reservedFilterValue")
+	}
+	reserved := regexp.MustCompile(match[1])
+
+	tests := []struct {
+		value    string
+		reserved bool
+	}{
+		{value: "Vanta"},
+		{value: "Bay Alpha"},
+		{value: "2026-10-19T08:30:00Z"},
+		{value: "a:b"},
+		{value: "a,b", reserved: true},
+		{value: "a|b", reserved: true},
+		{value: "(a", reserved: true},
+		{value: "a)", reserved: true},
+		{value: " a", reserved: true},
+		{value: "a ", reserved: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Parallel()
+
+			if got := reserved.MatchString(tt.value); got != tt.reserved {
+				t.Errorf("reservedFilterValue.test(%q) = %v, want %v", tt.value, got, tt.reserved)
+			}
+			_, err := resource.FormatFilter(&resource.ConditionNode{Condition: resource.Condition{Field: "name", Operator: "eq", Value: tt.value}})
+			if got := err != nil; got != tt.reserved {
+				t.Errorf("resource.FormatFilter(%q) error = %v, want error %v", tt.value, err, tt.reserved)
+			}
+		})
+	}
+}
+
+func Test_tsKeyParams(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		prefix     string
+		keys       []tsKey
+		wantParams string
+		wantPath   string
+	}{
+		{
+			name:       "single key",
+			keys:       []tsKey{{name: "ID", typescriptType: "string"}},
+			wantParams: "id: string",
+			wantPath:   "keyPath(id)",
+		},
+		{
+			name:       "parent keys are prefixed",
+			prefix:     "Ship",
+			keys:       []tsKey{{name: "ID", typescriptType: "string"}, {name: "LineNumber", typescriptType: "number"}},
+			wantParams: "shipId: string, shipLineNumber: number",
+			wantPath:   "keyPath(shipId, shipLineNumber)",
+		},
+		{
+			name:       "key without a typescript type",
+			keys:       []tsKey{{name: "ID"}},
+			wantParams: "id: string",
+			wantPath:   "keyPath(id)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			params, path := tsKeyParams(tt.prefix, tt.keys)
+			if params != tt.wantParams {
+				t.Errorf("tsKeyParams() params = %q, want %q", params, tt.wantParams)
+			}
+			if path != tt.wantPath {
+				t.Errorf("tsKeyParams() path = %q, want %q", path, tt.wantPath)
+			}
+		})
+	}
+}
+
+func Test_typescriptTarget_resolve_clientRequiresMetadata(t *testing.T) {
+	t.Parallel()
+
+	if _, err := (typescriptTarget{destination: "ts", options: []TSOption{GenerateClient()}}).resolve(); err == nil {
+		t.Error("typescriptTarget.resolve() error = nil, want GenerateClient requires GenerateMetadata")
+	}
+	if _, err := (typescriptTarget{destination: "ts", options: []TSOption{GenerateMetadata(), GenerateClient()}}).resolve(); err != nil {
+		t.Errorf("typescriptTarget.resolve() error = %v", err)
+	}
+}
+
+func Test_typescriptTarget_resolve_angularRequiresConstants(t *testing.T) {
+	t.Parallel()
+
+	if _, err := (typescriptTarget{destination: "ts", options: []TSOption{GenerateMetadata(), GenerateAngularServices()}}).resolve(); err == nil {
+		t.Error("typescriptTarget.resolve() error = nil, want GenerateAngularServices requires GeneratePermissions")
+	}
+	if _, err := (typescriptTarget{destination: "ts", options: []TSOption{GenerateMetadata(), GeneratePermissions(), GenerateAngularServices()}}).resolve(); err != nil {
+		t.Errorf("typescriptTarget.resolve() error = %v", err)
+	}
+}
+
+func Test_tsClientData_imports(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name              string
+		data              tsClientData
+		wantImports       string
+		wantClientImports string
+		wantSignals       bool
+		wantDates         bool
+	}{
+		{
+			name: "list and read with dates",
+			data: tsClientData{Resources: []tsClientResource{
+				{Interface: "Ships", Handlers: []HandlerType{ListHandler, ReadHandler}, DateFields: "['launched']"},
+			}},
+			wantImports:       "ShipsQuery, ShipsReadOptions, keyPath, listRequest, readParams, reviveDates",
+			wantClientImports: "ListQuery, ShipsQuery, ShipsReadOptions, keyPath, listRequest, readParams, reviveDates",
+			wantSignals:       true,
+			wantDates:         true,
+		},
+		{
+			name: "patch only",
+			data: tsClientData{
+				Resources:    []tsClientResource{{Interface: "Ships", Handlers: []HandlerType{PatchHandler}, DateFields: "['launched']"}},
+				Consolidated: []tsClientResource{{Interface: "Ships"}},
+			},
+			wantImports:       "PatchOperation, ResourceOperation",
+			wantClientImports: "PatchOperation, ResourceOperation",
+		},
+		{
+			name: "nested list and batch read",
+			data: tsClientData{Resources: []tsClientResource{
+				{Interface: "ShipLines", Handlers: []HandlerType{BatchReadHandler, NestedListHandler}},
+			}},
+			wantImports:       "ShipLinesQuery, ShipLinesReadOptions, formatValue, keyPath, listRequest, readParams",
+			wantClientImports: "ListQuery, ShipLinesQuery, ShipLinesReadOptions, formatValue, keyPath, listRequest, readParams",
+		},
+		{
+			name:              "async method",
+			data:              tsClientData{RPCMethods: []*rpcMethodInfo{{Async: true}}},
+			wantImports:       "reviveDates",
+			wantClientImports: "reviveDates",
+			wantDates:         true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.data.QueryImports(); got != tt.wantImports {
+				t.Errorf("QueryImports() = %q, want %q", got, tt.wantImports)
+			}
+			if got := tt.data.ClientQueryImports(); got != tt.wantClientImports {
+				t.Errorf("ClientQueryImports() = %q, want %q", got, tt.wantClientImports)
+			}
+			if got := tt.data.HasSignals(); got != tt.wantSignals {
+				t.Errorf("HasSignals() = %v, want %v", got, tt.wantSignals)
+			}
+			if got := tt.data.HasDates(); got != tt.wantDates {
+				t.Errorf("HasDates() = %v, want %v", got, tt.wantDates)
+			}
+		})
+	}
+}
+
+func Test_typescriptGenerator_schemaData(t *testing.T) {
+	t.Parallel()
+
+	r := collectionFixtureGenerator(t)
+	ts := &typescriptGenerator{client: r.client, typescriptOverrides: defaultTypescriptOverrides()}
+	for _, res := range ts.resources {
+		res.Fields = ts.resourceFieldsTypescriptType(res.Fields)
+		for _, field := range res.Fields {
+			field.IsNullable = field.IsPointer()
+		}
+	}
+	for _, res := range ts.computedResources {
+		res.Fields = ts.computedFieldsTypescriptType(res.Fields)
+	}
+
+	// Code is enumerated by a resource with values, ListedName by one without.
+	ts.enumValues = map[string][]*enumData{"WidgetCodes": {{ID: "A", Description: "Alpha"}, {ID: "B", Description: "Beta"}}}
+	var codeField *resourceField
+	for _, res := range ts.resources {
+		for _, field := range res.Fields {
+			switch {
+			case res.Name() == "Widget" && field.Name() == "Code":
+				field.IsEnumerated, field.ReferencedResource = true, "WidgetCodes"
+				codeField = field
+			case res.Name() == "Widget" && field.Name() == "ListedName":
+				field.IsEnumerated, field.ReferencedResource = true, "Listings"
+			}
+		}
+	}
+	widgetCodes := "WidgetCodes"
+	ts.rpcMethods = []*rpcMethodInfo{{
+		Struct: ts.rpcMethods[0].Struct,
+		Fields: []*rpcField{{Field: codeField.Field, typescriptType: "string[]", enumeratedResource: &widgetCodes}},
+	}}
+
+	schemas := make(map[string]tsSchemaResource)
+	for _, res := range ts.schemaData().Resources {
+		schemas[res.Name] = res
+	}
+
+	widgets := schemas["widgets"]
+	wantRow := []tsSchemaField{
+		{Name: "id", Schema: "z.string().uuid()"},
+		{Name: "name", Schema: "z.string()"},
+		{Name: "listedName", Schema: "z.string().describe('enumerated Listings')"},
+		{Name: "code", Schema: "z.enum(['A', 'B'])"},
+		{Name: "derived", Schema: "z.string()"},
+		{Name: "badge", Schema: "z.string().nullable().describe('pii')"},
+	}
+	if diff := cmp.Diff(wantRow, widgets.Row); diff != "" {
+		t.Errorf("schemaData() widgets Row mismatch (-want +got):\n%s", diff)
+	}
+	wantCreate := []tsSchemaField{
+		{Name: "name", Schema: "z.string()"},
+		{Name: "listedName", Schema: "z.string().describe('enumerated Listings')"},
+		{Name: "code", Schema: "z.enum(['A', 'B'])"},
+		{Name: "secret", Schema: "z.string()"},
+		{Name: "badge", Schema: "z.string().nullable().optional().describe('pii')"},
+	}
+	if diff := cmp.Diff(wantCreate, widgets.Create); diff != "" {
+		t.Errorf("schemaData() widgets Create mismatch (-want +got):\n%s", diff)
+	}
+	wantUpdate := []tsSchemaField{
+		{Name: "name", Schema: "z.string().optional()"},
+		{Name: "listedName", Schema: "z.string().optional().describe('enumerated Listings')"},
+		{Name: "secret", Schema: "z.string().optional()"},
+		{Name: "badge", Schema: "z.string().nullable().optional().describe('pii')"},
+	}
+	if diff := cmp.Diff(wantUpdate, widgets.Update); diff != "" {
+		t.Errorf("schemaData() widgets Update mismatch (-want +got):\n%s", diff)
+	}
+
+	if gadgets := schemas["gadgets"]; gadgets.Create != nil || gadgets.Update != nil {
+		t.Errorf("schemaData() virtual resource gadgets has create or update schemas")
+	}
+	if diff := cmp.Diff([]tsSchemaField{{Name: "id", Schema: "z.string().uuid()"}, {Name: "total", Schema: "z.number()"}}, schemas["summaries"].Row); diff != "" {
+		t.Errorf("schemaData() summaries Row mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]tsSchemaField{{Name: "code", Schema: "z.array(z.enum(['A', 'B']))"}}, ts.schemaData().RPCMethods[0].Row); diff != "" {
+		t.Errorf("schemaData() RPC method Row mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func Test_zodSchema(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		goType         string
+		typescriptType string
+		want           string
+	}{
+		{goType: "time.Time", typescriptType: dateTSType, want: "z.coerce.date()"},
+		{goType: "civil.Date", typescriptType: civilDateTSType + "[]", want: "z.array(z.coerce.date())"},
+		{goType: "decimal.Decimal", typescriptType: numberTSType, want: "z.coerce.number()"},
+		{goType: "resource.Link", typescriptType: linkTSType, want: "linkSchema"},
+		{goType: "richtext.Doc", typescriptType: "CustomTypes.RichText", want: "z.unknown()"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.goType, func(t *testing.T) {
+			t.Parallel()
+
+			if got := zodSchema(tt.goType, tt.typescriptType); got != tt.want {
+				t.Errorf("zodSchema() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,468 @@
+package generation
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"log"
+	"maps"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-playground/errors/v5"
+)
+
+// defaultWatchInterval is how often Watch polls the sources when no interval is given.
+const defaultWatchInterval = time.Second
+
+// Watch runs generator, then polls its sources every interval until ctx is done: the resource
+// package, the virtual, computed and RPC packages when enabled, and the file:// migration
+// sources. Each change regenerates only the resource files and handlers of the resources it
+// affects, the RPC or computed outputs when those packages change, and the routes, TypeScript
+// and other outputs shared by all resources. Files whose content is unchanged are left
+// untouched, and a summary of the change is logged. A failed regeneration is logged and
+// the next change regenerates everything. generator must come from NewResourceGenerator.
+func Watch(ctx context.Context, generator Generator, interval time.Duration) error {
+	r, ok := generator.(*resourceGenerator)
+	if !ok {
+		return errors.Newf("Watch() requires a generator from NewResourceGenerator(), got %T", generator)
+	}
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+
+	// Generate appends the annotated registrations to the ones passed as options on every run.
+	registrations := slices.Clone(r.manualRegistrations)
+
+	if err := r.Generate(); err != nil {
+		return err
+	}
+
+	generated, err := r.sourceSnapshot()
+	if err != nil {
+		return err
+	}
+	log.Printf("Watching %d source files for changes...", len(generated))
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	latest := generated
+	var failed bool
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		snapshot, err := r.sourceSnapshot()
+		if err != nil {
+			log.Print(err)
+
+			continue
+		}
+
+		// Regenerate once the sources hold still for an interval, so an editor saving several
+		// files, or a file in several writes, triggers a single run.
+		if !maps.Equal(snapshot, latest) {
+			latest = snapshot
+
+			continue
+		}
+
+		changes := diffSnapshots(generated, latest)
+		if len(changes) == 0 {
+			continue
+		}
+		generated = latest
+
+		r.manualRegistrations = slices.Clone(registrations)
+		summary, err := r.regenerate(ctx, changes, failed)
+		failed = err != nil
+		if err != nil {
+			log.Printf("Regeneration failed, the next change regenerates everything: %v", err)
+
+			continue
+		}
+
+		log.Print(summary)
+	}
+}
+
+// regenerate runs the generation limited to the outputs changes affect, or all of them when
+// full is set, and returns its summary.
+func (r *resourceGenerator) regenerate(ctx context.Context, changes []sourceChange, full bool) (string, error) {
+	begin := time.Now()
+
+	scope := r.scopeFor(changes)
+	if full {
+		scope.all, scope.rpc, scope.computed = true, true, true
+	}
+
+	if scope.schema {
+		tableMap, enumValues := r.tableMap, r.enumValues
+		if err := r.loadSchema(ctx); err != nil {
+			return "", err
+		}
+
+		for table := range changedTables(tableMap, r.tableMap) {
+			scope.tables[table] = true
+		}
+		if !scope.all && len(scope.tables) == 0 && len(scope.files) == 0 && !scope.rpc && !scope.computed && reflect.DeepEqual(enumValues, r.enumValues) {
+			return fmt.Sprintf("%s: schema unchanged, nothing to regenerate", describeChanges(changes)), nil
+		}
+	}
+
+	r.scope = scope
+	r.ledger = &fileLedger{stale: make(map[string]struct{}), seen: make(map[string]struct{})}
+	defer func() {
+		r.scope = nil
+		r.ledger = nil
+	}()
+
+	if err := r.Generate(); err != nil {
+		return "", err
+	}
+
+	if err := r.ledger.removeStale(); err != nil {
+		return "", err
+	}
+
+	return r.ledger.summary(describeChanges(changes), time.Since(begin)), nil
+}
+
+// sourceSnapshot returns the content hash of each source file Watch polls, keyed by path.
+// Generated files in the source packages are skipped, as the generator writes them.
+func (r *resourceGenerator) sourceSnapshot() (map[string]string, error) {
+	snapshot := make(map[string]string)
+	for _, dir := range r.watchedPackageDirs() {
+		hashes, err := hashFilesByName(dir, isSourceFile)
+		if err != nil {
+			return nil, err
+		}
+
+		for fileName, hash := range hashes {
+			snapshot[filepath.Join(dir, fileName)] = hash
+		}
+	}
+
+	for _, dir := range r.migrationDirs() {
+		hashes, err := hashFilesByName(dir, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		for fileName, hash := range hashes {
+			snapshot[filepath.Join(dir, fileName)] = hash
+		}
+	}
+
+	return snapshot, nil
+}
+
+// watchedPackageDirs returns the directories of the source packages the generator parses.
+func (r *resourceGenerator) watchedPackageDirs() []string {
+	dirs := []string{r.resource.Dir()}
+	if r.genVirtualResources {
+		dirs = append(dirs, r.virtual.Dir())
+	}
+	if r.genComputedResources {
+		dirs = append(dirs, r.computed.Dir())
+	}
+	if r.genRPCMethods {
+		dirs = append(dirs, r.rpc.Dir())
+	}
+
+	for i := range dirs {
+		if dirs[i] == "" {
+			dirs[i] = "."
+		}
+	}
+	slices.Sort(dirs)
+
+	return slices.Compact(dirs)
+}
+
+// migrationDirs returns the directories of the file:// migration sources. Other sources
+// can't be polled.
+func (r *resourceGenerator) migrationDirs() []string {
+	var dirs []string
+	for _, migrationSource := range r.migrationSourceURLs {
+		if dir, ok := strings.CutPrefix(migrationSource, "file://"); ok {
+			dirs = append(dirs, dir)
+		}
+	}
+
+	return dirs
+}
+
+// isSourceFile reports whether a file in a source package is Go source the generator
+// parses: not a test, and not a file the generator wrote.
+func isSourceFile(fileName string) bool {
+	return strings.HasSuffix(fileName, ".go") && !strings.HasSuffix(fileName, "_test.go") && !strings.HasPrefix(fileName, genPrefix)
+}
+
+type changeKind string
+
+const (
+	fileAdded    changeKind = "added"
+	fileModified changeKind = "modified"
+	fileRemoved  changeKind = "removed"
+)
+
+// sourceChange is a source file whose content hash differs between two snapshots.
+type sourceChange struct {
+	path string
+	kind changeKind
+}
+
+// diffSnapshots returns the files added, modified and removed from old to current, by path.
+func diffSnapshots(old, current map[string]string) []sourceChange {
+	var changes []sourceChange
+	for path, hash := range current {
+		oldHash, ok := old[path]
+		switch {
+		case !ok:
+			changes = append(changes, sourceChange{path: path, kind: fileAdded})
+		case oldHash != hash:
+			changes = append(changes, sourceChange{path: path, kind: fileModified})
+		}
+	}
+	for path := range old {
+		if _, ok := current[path]; !ok {
+			changes = append(changes, sourceChange{path: path, kind: fileRemoved})
+		}
+	}
+	slices.SortFunc(changes, func(a, b sourceChange) int { return strings.Compare(a.path, b.path) })
+
+	return changes
+}
+
+// describeChanges names the changed files, e.g. "modified pkg/resources/ships.go".
+func describeChanges(changes []sourceChange) string {
+	descriptions := make([]string, 0, len(changes))
+	for _, change := range changes {
+		descriptions = append(descriptions, fmt.Sprintf("%s %s", change.kind, change.path))
+	}
+
+	return strings.Join(descriptions, ", ")
+}
+
+// regenerationScope is what a watch mode run regenerates. Outputs shared by all resources,
+// like the routes and the TypeScript, are always regenerated, and only written if changed.
+type regenerationScope struct {
+	// all regenerates every resource.
+	all bool
+	// files are the changed resource source files by name without .go, which validation ties
+	// to the snake_case plural of the resource they declare.
+	files map[string]bool
+	// tables are the tables whose schema changed.
+	tables map[string]bool
+	// schema is set when a migration changed.
+	schema bool
+	// rpc and computed are set when the RPC or computed resource package changed.
+	rpc, computed bool
+}
+
+// scopeFor classifies changes by the directory they are in. A changed resource source file
+// that declares no known resource, like one holding shared types, affects every resource; a
+// new one can only affect the resources it declares.
+func (r *resourceGenerator) scopeFor(changes []sourceChange) *regenerationScope {
+	scope := &regenerationScope{files: make(map[string]bool), tables: make(map[string]bool)}
+
+	resourceDirs := []string{filepath.Clean(r.resource.Dir())}
+	if r.genVirtualResources {
+		resourceDirs = append(resourceDirs, filepath.Clean(r.virtual.Dir()))
+	}
+
+	var migrationDirs []string
+	for _, dir := range r.migrationDirs() {
+		migrationDirs = append(migrationDirs, filepath.Clean(dir))
+	}
+
+	known := make(map[string]bool, len(r.resources))
+	for _, res := range r.resources {
+		known[caser.ToSnake(r.pluralize(res.Name()))] = true
+	}
+
+	for _, change := range changes {
+		dir := filepath.Dir(change.path)
+		if slices.Contains(migrationDirs, dir) {
+			scope.schema = true
+		}
+		if r.genRPCMethods && filepath.Clean(r.rpc.Dir()) == dir {
+			scope.rpc = true
+		}
+		if r.genComputedResources && filepath.Clean(r.computed.Dir()) == dir {
+			scope.computed = true
+		}
+		if slices.Contains(resourceDirs, dir) {
+			file := strings.TrimSuffix(filepath.Base(change.path), ".go")
+			if !known[file] && change.kind != fileAdded {
+				scope.all = true
+			}
+			scope.files[file] = true
+		}
+	}
+
+	return scope
+}
+
+// includesResource reports whether res is regenerated: its source file or table changed, or
+// those of its parent or children, whose keys and routes its handlers share.
+func (s *regenerationScope) includesResource(c *client, res *resourceInfo) bool {
+	if s == nil || s.all {
+		return true
+	}
+
+	changed := func(res *resourceInfo) bool {
+		plural := c.pluralize(res.Name())
+
+		return s.tables[plural] || s.files[caser.ToSnake(plural)]
+	}
+	if changed(res) || (res.Parent != nil && changed(res.Parent)) {
+		return true
+	}
+
+	return slices.ContainsFunc(res.Children, changed)
+}
+
+// includesRPC reports whether the RPC method files and handlers are regenerated.
+func (s *regenerationScope) includesRPC() bool {
+	return s == nil || s.rpc
+}
+
+// includesComputed reports whether the computed resource handlers are regenerated.
+func (s *regenerationScope) includesComputed() bool {
+	return s == nil || s.computed
+}
+
+// changedTables returns the tables added, changed or dropped from old to current.
+func changedTables(old, current map[string]*tableMetadata) map[string]bool {
+	changed := make(map[string]bool)
+	for table, metadata := range current {
+		if !reflect.DeepEqual(old[table], metadata) {
+			changed[table] = true
+		}
+	}
+	for table := range old {
+		if _, ok := current[table]; !ok {
+			changed[table] = true
+		}
+	}
+
+	return changed
+}
+
+// fileLedger records the files a watch mode run writes, keeps, and leaves stale.
+type fileLedger struct {
+	mu sync.Mutex
+	// stale are the generated files removeGeneratedFiles found, removed at the end of the run
+	// unless it writes or keeps them.
+	stale map[string]struct{}
+	// seen are the files the run wrote or kept.
+	seen      map[string]struct{}
+	written   []string
+	removed   []string
+	unchanged int
+}
+
+func (l *fileLedger) markStale(path string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, ok := l.seen[path]; !ok {
+		l.stale[path] = struct{}{}
+	}
+}
+
+func (l *fileLedger) keep(path string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.seen[path] = struct{}{}
+	delete(l.stale, path)
+}
+
+// write writes data to path unless the file already holds it, compared by content hash.
+func (l *fileLedger) write(path string, data []byte) error {
+	l.keep(path)
+
+	if existing, err := hashFile(path); err == nil {
+		if hash := sha256.Sum256(data); bytes.Equal(existing, hash[:]) {
+			l.mu.Lock()
+			l.unchanged++
+			l.mu.Unlock()
+
+			return nil
+		}
+	}
+
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return errors.Wrapf(err, "os.WriteFile(): file: %s", path)
+	}
+
+	l.mu.Lock()
+	l.written = append(l.written, path)
+	l.mu.Unlock()
+
+	return nil
+}
+
+// removeStale removes the generated files the run neither wrote nor kept, like the files of
+// a deleted resource.
+func (l *fileLedger) removeStale() error {
+	for _, path := range slices.Sorted(maps.Keys(l.stale)) {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return errors.Wrap(err, "os.Remove()")
+		}
+		l.removed = append(l.removed, path)
+	}
+	clear(l.stale)
+
+	return nil
+}
+
+// summary describes the run: the changes, the files written and removed, and how many files
+// were regenerated without a change.
+func (l *fileLedger) summary(changes string, elapsed time.Duration) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: wrote %d files, removed %d, %d unchanged in %s", changes, len(l.written), len(l.removed), l.unchanged, elapsed.Round(time.Millisecond))
+	for _, path := range slices.Sorted(slices.Values(l.written)) {
+		fmt.Fprintf(&b, "\n\twrote %s", path)
+	}
+	for _, path := range l.removed {
+		fmt.Fprintf(&b, "\n\tremoved %s", path)
+	}
+
+	return b.String()
+}
+
+// writeGeneratedFile writes a generated file. In watch mode, a file whose content is
+// unchanged is left untouched, so tools watching the generated files don't rebuild.
+func (c *client) writeGeneratedFile(path string, data []byte) error {
+	if c.ledger != nil {
+		return c.ledger.write(path, data)
+	}
+
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return errors.Wrapf(err, "os.WriteFile(): file: %s", path)
+	}
+
+	return nil
+}
+
+// keepGeneratedFile keeps the generated file of an output a watch mode run skips, so it isn't
+// removed as stale.
+func (c *client) keepGeneratedFile(path string) {
+	if c.ledger != nil {
+		c.ledger.keep(path)
+	}
+}
@@ -0,0 +1,249 @@
+package generation
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func Test_diffSnapshots(t *testing.T) {
+	t.Parallel()
+
+	old := map[string]string{"ships.go": "a", "berths.go": "b", "types.go": "c"}
+	current := map[string]string{"ships.go": "a", "berths.go": "B", "docks.go": "d"}
+
+	want := []sourceChange{
+		{path: "berths.go", kind: fileModified},
+		{path: "docks.go", kind: fileAdded},
+		{path: "types.go", kind: fileRemoved},
+	}
+	if diff := cmp.Diff(want, diffSnapshots(old, current), cmp.AllowUnexported(sourceChange{})); diff != "" {
+		t.Errorf("diffSnapshots() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func Test_changedTables(t *testing.T) {
+	t.Parallel()
+
+	old := map[string]*tableMetadata{
+		"Ships":  {Columns: map[string]columnMeta{"Id": {IsPrimaryKey: true}}, PkCount: 1},
+		"Berths": {Columns: map[string]columnMeta{"Id": {IsPrimaryKey: true}}, PkCount: 1},
+		"Docks":  {Columns: map[string]columnMeta{"Id": {IsPrimaryKey: true}}, PkCount: 1},
+	}
+	current := map[string]*tableMetadata{
+		"Ships":  {Columns: map[string]columnMeta{"Id": {IsPrimaryKey: true}}, PkCount: 1},
+		"Berths": {Columns: map[string]columnMeta{"Id": {IsPrimaryKey: true}, "Name": {IsNullable: true}}, PkCount: 1},
+		"Tugs":   {Columns: map[string]columnMeta{"Id": {IsPrimaryKey: true}}, PkCount: 1},
+	}
+
+	want := map[string]bool{"Berths": true, "Docks": true, "Tugs": true}
+	if diff := cmp.Diff(want, changedTables(old, current)); diff != "" {
+		t.Errorf("changedTables() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func Test_resourceGenerator_scopeFor(t *testing.T) {
+	t.Parallel()
+
+	structs := fixtureStructs(loadCollectionFixture(t))
+	r := &resourceGenerator{client: &client{
+		resource:             packageDir("app/resources"),
+		virtual:              packageDir("app/virtual"),
+		rpc:                  packageDir("app/rpc"),
+		computed:             packageDir("app/computed"),
+		migrationSourceURLs:  []string{"file://schema/migrations", "file://schema/seed"},
+		genVirtualResources:  true,
+		genRPCMethods:        true,
+		genComputedResources: true,
+	}}
+	r.resources = []*resourceInfo{fixtureResource(t, structs, "Widget", nil)}
+
+	tests := []struct {
+		name    string
+		changes []sourceChange
+		want    *regenerationScope
+	}{
+		{
+			name:    "changed resource file",
+			changes: []sourceChange{{path: "app/resources/widgets.go", kind: fileModified}},
+			want:    &regenerationScope{files: map[string]bool{"widgets": true}},
+		},
+		{
+			name:    "shared types file",
+			changes: []sourceChange{{path: "app/resources/types.go", kind: fileModified}},
+			want:    &regenerationScope{all: true, files: map[string]bool{"types": true}},
+		},
+		{
+			name:    "removed shared types file",
+			changes: []sourceChange{{path: "app/resources/types.go", kind: fileRemoved}},
+			want:    &regenerationScope{all: true, files: map[string]bool{"types": true}},
+		},
+		{
+			name:    "new file",
+			changes: []sourceChange{{path: "app/resources/gadgets.go", kind: fileAdded}},
+			want:    &regenerationScope{files: map[string]bool{"gadgets": true}},
+		},
+		{
+			name:    "new file in the virtual resource dir",
+			changes: []sourceChange{{path: "app/virtual/summaries.go", kind: fileAdded}},
+			want:    &regenerationScope{files: map[string]bool{"summaries": true}},
+		},
+		{
+			name:    "RPC dir",
+			changes: []sourceChange{{path: "app/rpc/do_something.go", kind: fileModified}},
+			want:    &regenerationScope{rpc: true},
+		},
+		{
+			name:    "computed dir",
+			changes: []sourceChange{{path: "app/computed/summary.go", kind: fileModified}},
+			want:    &regenerationScope{computed: true},
+		},
+		{
+			name:    "migration dir",
+			changes: []sourceChange{{path: "schema/migrations/0002_widgets.up.sql", kind: fileAdded}},
+			want:    &regenerationScope{schema: true},
+		},
+		{
+			name:    "second migration dir",
+			changes: []sourceChange{{path: "schema/seed/0001_widgets.up.sql", kind: fileModified}},
+			want:    &regenerationScope{schema: true},
+		},
+		{
+			name: "changes in several dirs",
+			changes: []sourceChange{
+				{path: "app/resources/widgets.go", kind: fileModified},
+				{path: "app/rpc/do_something.go", kind: fileModified},
+				{path: "schema/migrations/0002_widgets.up.sql", kind: fileAdded},
+			},
+			want: &regenerationScope{files: map[string]bool{"widgets": true}, schema: true, rpc: true},
+		},
+		{
+			name:    "file outside the watched dirs",
+			changes: []sourceChange{{path: "app/handlers/widgets.go", kind: fileModified}},
+			want:    &regenerationScope{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := r.scopeFor(tt.changes)
+			if diff := cmp.Diff(tt.want, got, cmp.AllowUnexported(regenerationScope{}), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("resourceGenerator.scopeFor() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_regenerationScope_includesResource(t *testing.T) {
+	t.Parallel()
+
+	structs := fixtureStructs(loadCollectionFixture(t))
+	manifest := fixtureResource(t, structs, "Manifest", nil)
+	line := fixtureResource(t, structs, "ManifestLine", func(res *resourceInfo) { res.Parent = manifest })
+	manifest.Children = []*resourceInfo{line}
+	widget := fixtureResource(t, structs, "Widget", nil)
+
+	c := &client{}
+	tests := []struct {
+		name  string
+		scope *regenerationScope
+		res   *resourceInfo
+		want  bool
+	}{
+		{name: "nil scope regenerates everything", scope: nil, res: widget, want: true},
+		{name: "all", scope: &regenerationScope{all: true}, res: widget, want: true},
+		{name: "changed source file", scope: &regenerationScope{files: map[string]bool{"widgets": true}}, res: widget, want: true},
+		{name: "changed table", scope: &regenerationScope{tables: map[string]bool{"Widgets": true}}, res: widget, want: true},
+		{name: "unrelated change", scope: &regenerationScope{files: map[string]bool{"manifests": true}}, res: widget, want: false},
+		{name: "changed parent", scope: &regenerationScope{tables: map[string]bool{"Manifests": true}}, res: line, want: true},
+		{name: "changed child", scope: &regenerationScope{files: map[string]bool{"manifest_lines": true}}, res: manifest, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.scope.includesResource(c, tt.res); got != tt.want {
+				t.Errorf("regenerationScope.includesResource() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_fileLedger(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	unchanged := filepath.Join(dir, "zz_gen_ships.go")
+	changed := filepath.Join(dir, "zz_gen_berths.go")
+	kept := filepath.Join(dir, "zz_gen_docks.go")
+	stale := filepath.Join(dir, "zz_gen_tugs.go")
+	for _, path := range []string{unchanged, changed, kept, stale} {
+		if err := os.WriteFile(path, []byte("package resources\n"), 0o644); err != nil {
+			t.Fatalf("os.WriteFile() error = %v", err)
+		}
+	}
+
+	l := &fileLedger{stale: make(map[string]struct{}), seen: make(map[string]struct{})}
+	for _, path := range []string{unchanged, changed, kept, stale} {
+		l.markStale(path)
+	}
+	if err := l.write(unchanged, []byte("package resources\n")); err != nil {
+		t.Fatalf("fileLedger.write() error = %v", err)
+	}
+	if err := l.write(changed, []byte("package resources // changed\n")); err != nil {
+		t.Fatalf("fileLedger.write() error = %v", err)
+	}
+	l.keep(kept)
+	if err := l.removeStale(); err != nil {
+		t.Fatalf("fileLedger.removeStale() error = %v", err)
+	}
+
+	if diff := cmp.Diff([]string{changed}, l.written); diff != "" {
+		t.Errorf("fileLedger.written mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{stale}, l.removed); diff != "" {
+		t.Errorf("fileLedger.removed mismatch (-want +got):\n%s", diff)
+	}
+	if l.unchanged != 1 {
+		t.Errorf("fileLedger.unchanged = %d, want 1", l.unchanged)
+	}
+	for _, path := range []string{unchanged, changed, kept} {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("os.Stat(%q) error = %v", path, err)
+		}
+	}
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Errorf("os.Stat(%q) error = %v, want not exist", stale, err)
+	}
+}
+
+func Test_hashFilesByName(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	for name, content := range map[string]string{"ships.go": "a", "ships_test.go": "a", "zz_gen_ships.go": "b", "README.md": "c"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("os.WriteFile() error = %v", err)
+		}
+	}
+
+	got, err := hashFilesByName(dir, isSourceFile)
+	if err != nil {
+		t.Fatalf("hashFilesByName() error = %v", err)
+	}
+	if len(got) != 1 || got["ships.go"] == "" {
+		t.Errorf("hashFilesByName() = %v, want only ships.go", got)
+	}
+
+	all, err := hashFilesByName(dir, nil)
+	if err != nil {
+		t.Fatalf("hashFilesByName() error = %v", err)
+	}
+	if len(all) != 4 {
+		t.Errorf("hashFilesByName() returned %d files, want 4", len(all))
+	}
+}
@@ -14,6 +14,12 @@ import (
 	guid "github.com/google/uuid"
 )
 
+// primaryKeyer is an interface for resources that declare their primary key fields, in key
+// order. QuerySet uses it to recognize reads by the full primary key.
+type primaryKeyer interface {
+	PrimaryKeyFields() []accesstypes.Field
+}
+
 // KeyPart represents a single component of a primary key, consisting of a field name and its value.
 type KeyPart struct {
 	Key   accesstypes.Field
@@ -53,6 +59,11 @@ func (p KeySet) RowID() string {
 
 // KeySet converts the resource KeySet into a `spanner.KeySet`, which can be used in Spanner read or delete operations.
 func (p KeySet) KeySet() spanner.KeySet {
+	return p.spannerKey()
+}
+
+// spannerKey converts the resource KeySet into a `spanner.Key`, with its parts in the order they were added.
+func (p KeySet) spannerKey() spanner.Key {
 	keys := make(spanner.Key, 0, len(p.keyParts))
 	for _, v := range p.keyParts {
 		switch v.Value.(type) {
@@ -119,3 +130,64 @@ func (p KeySet) keys() []accesstypes.Field {
 
 	return pKeys
 }
+
+// AsPrefix returns the KeyRange of every key that begins with the parts of the key set, which
+// must be a leading run of the primary key fields in key order.
+func (p KeySet) AsPrefix() KeyRange {
+	return KeyRange{Start: p, End: p, Kind: spanner.ClosedClosed}
+}
+
+// KeyRange represents a range of primary keys. Start and End may be partial keys: a partial key
+// bounds the range by the leading key fields it has, so a partial key at both closed ends
+// matches every key with that prefix. An empty Start or End leaves that end unbounded, whatever
+// the Kind, for SQL queries and Spanner reads alike.
+type KeyRange struct {
+	Start KeySet
+	End   KeySet
+	Kind  spanner.KeyRangeKind
+}
+
+// KeySet converts the KeyRange into a `spanner.KeySet`, which can be used in Spanner read or delete operations.
+func (r KeyRange) KeySet() spanner.KeySet {
+	return r.spannerKeyRange()
+}
+
+// spannerKeyRange closes the empty ends of the range. Spanner treats an empty key as a prefix of
+// every key, so it is unbounded when closed but matches no key when open.
+func (r KeyRange) spannerKeyRange() spanner.KeyRange {
+	startClosed := r.startInclusive() || r.Start.Len() == 0
+	endClosed := r.endInclusive() || r.End.Len() == 0
+
+	var kind spanner.KeyRangeKind
+	switch {
+	case startClosed && endClosed:
+		kind = spanner.ClosedClosed
+	case startClosed:
+		kind = spanner.ClosedOpen
+	case endClosed:
+		kind = spanner.OpenClosed
+	default:
+		kind = spanner.OpenOpen
+	}
+
+	return spanner.KeyRange{Start: r.Start.spannerKey(), End: r.End.spannerKey(), Kind: kind}
+}
+
+// unbounded reports whether the range has neither a Start nor an End, so it matches every key.
+func (r KeyRange) unbounded() bool {
+	return r.Start.Len() == 0 && r.End.Len() == 0
+}
+
+// isPrefix reports whether the range matches exactly the keys beginning with one partial key.
+func (r KeyRange) isPrefix() bool {
+	return r.Kind == spanner.ClosedClosed && r.Start.Len() > 0 && reflect.DeepEqual(r.Start.keyParts, r.End.keyParts)
+}
+
+// startInclusive and endInclusive report whether the bounds of the range are closed.
+func (r KeyRange) startInclusive() bool {
+	return r.Kind == spanner.ClosedClosed || r.Kind == spanner.ClosedOpen
+}
+
+func (r KeyRange) endInclusive() bool {
+	return r.Kind == spanner.ClosedClosed || r.Kind == spanner.OpenClosed
+}
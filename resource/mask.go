@@ -0,0 +1,92 @@
+package resource
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/cccteam/ccc/accesstypes"
+	"github.com/go-playground/errors/v5"
+)
+
+// Reveal is the field-level permission that exempts a user from a field's mask. It is
+// registered on every masked field's tag; users with Read (or List) but without Reveal
+// receive the masked value instead of the stored one.
+const Reveal accesstypes.Permission = "Reveal"
+
+const maskChars = "****"
+
+// MaskStrategy identifies how a masked field's value is rendered for users without the
+// Reveal permission.
+type MaskStrategy string
+
+const (
+	// MaskFull replaces the entire value ("****").
+	MaskFull MaskStrategy = "full"
+	// MaskLast4 keeps the last four characters ("****1234"). Values of four characters
+	// or fewer are fully masked.
+	MaskLast4 MaskStrategy = "last4"
+	// MaskEmail keeps the first character of the local part and the domain ("j****@example.com").
+	// Values that are not email addresses are fully masked.
+	MaskEmail MaskStrategy = "email"
+)
+
+// ParseMaskStrategy validates a mask strategy name.
+func ParseMaskStrategy(s string) (MaskStrategy, error) {
+	switch m := MaskStrategy(s); m {
+	case MaskFull, MaskLast4, MaskEmail:
+		return m, nil
+	default:
+		return "", errors.Newf("unknown mask strategy %q, must be one of %q, %q or %q", s, MaskFull, MaskLast4, MaskEmail)
+	}
+}
+
+// Mask returns the masked form of s. Empty values stay empty so a masked field still
+// distinguishes "not set" from "set".
+func (m MaskStrategy) Mask(s string) string {
+	if s == "" {
+		return ""
+	}
+
+	switch m {
+	case MaskLast4:
+		runes := []rune(s)
+		if len(runes) <= 4 {
+			return maskChars
+		}
+
+		return maskChars + string(runes[len(runes)-4:])
+	case MaskEmail:
+		local, domain, ok := strings.Cut(s, "@")
+		if !ok || local == "" || domain == "" {
+			return maskChars
+		}
+
+		return string([]rune(local)[:1]) + maskChars + "@" + domain
+	default:
+		return maskChars
+	}
+}
+
+// maskField replaces the value of a string or *string field of the struct v points to
+// with its masked form. A nil pointer is left nil; fields of any other type are zeroed,
+// since they have no meaningful partial representation.
+func maskField(v reflect.Value, field accesstypes.Field, m MaskStrategy) {
+	f := v.Elem().FieldByName(string(field))
+	if !f.IsValid() || !f.CanSet() {
+		return
+	}
+
+	switch {
+	case f.Kind() == reflect.String:
+		f.SetString(m.Mask(f.String()))
+	case f.Kind() == reflect.Pointer && f.Type().Elem().Kind() == reflect.String:
+		if f.IsNil() {
+			return
+		}
+		masked := reflect.New(f.Type().Elem())
+		masked.Elem().SetString(m.Mask(f.Elem().String()))
+		f.Set(masked)
+	default:
+		f.SetZero()
+	}
+}
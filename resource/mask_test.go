@@ -0,0 +1,48 @@
+package resource
+
+import (
+	"testing"
+)
+
+func TestMaskStrategy_Mask(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		strategy MaskStrategy
+		value    string
+		want     string
+	}{
+		{name: "full", strategy: MaskFull, value: "A-12345678", want: "****"},
+		{name: "last4", strategy: MaskLast4, value: "A-12345678", want: "****5678"},
+		{name: "last4 short value", strategy: MaskLast4, value: "1234", want: "****"},
+		{name: "last4 multibyte", strategy: MaskLast4, value: "ÄÖÜ-ßäöü", want: "****ßäöü"},
+		{name: "email", strategy: MaskEmail, value: "jane.doe@example.com", want: "j****@example.com"},
+		{name: "email not an address", strategy: MaskEmail, value: "jane.doe", want: "****"},
+		{name: "empty stays empty", strategy: MaskLast4, value: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.strategy.Mask(tt.value); got != tt.want {
+				t.Errorf("MaskStrategy(%q).Mask(%q) = %q, want %q", tt.strategy, tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseMaskStrategy(t *testing.T) {
+	t.Parallel()
+
+	for _, s := range []string{"full", "last4", "email"} {
+		if _, err := ParseMaskStrategy(s); err != nil {
+			t.Errorf("ParseMaskStrategy(%q) error = %v", s, err)
+		}
+	}
+
+	if _, err := ParseMaskStrategy("first4"); err == nil {
+		t.Errorf("ParseMaskStrategy(%q) error = nil, want error", "first4")
+	}
+}
@@ -57,6 +57,15 @@ func (o *Operation) ReqWithPattern(pattern string, opts ...Option) (*http.Reques
 	return o.Req.WithContext(ctx), nil
 }
 
+// PatchOperation is one operation of a batch JSON patch request body, in the format Operations
+// parses. Clients marshal a slice of them as the body of a patch request. Path is left empty for
+// an add whose primary key the server generates, and Value for a remove.
+type PatchOperation struct {
+	Op    OperationType `json:"op"`
+	Path  string        `json:"path,omitempty"`
+	Value any           `json:"value,omitempty"`
+}
+
 type patchOperation struct {
 	Op    string          `json:"op"`
 	Path  string          `json:"path"`
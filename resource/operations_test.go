@@ -477,3 +477,33 @@ func TestOperationsWithPrefix(t *testing.T) {
 		})
 	}
 }
+
+func TestPatchOperation(t *testing.T) {
+	t.Parallel()
+
+	body, err := json.Marshal([]PatchOperation{
+		{Op: OperationCreate, Value: map[string]any{"name": "Aurora"}},
+		{Op: OperationUpdate, Path: "/ships/10", Value: map[string]any{"name": "Borealis"}},
+		{Op: OperationDelete, Path: "/ships/11"},
+	})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	want := `[{"op":"add","value":{"name":"Aurora"}},{"op":"patch","path":"/ships/10","value":{"name":"Borealis"}},{"op":"remove","path":"/ships/11"}]`
+	if diff := cmp.Diff(want, string(body)); diff != "" {
+		t.Errorf("json.Marshal() mismatch (-want +got):\n%s", diff)
+	}
+
+	r := &http.Request{Method: http.MethodPatch, Body: io.NopCloser(bytes.NewReader(body))}
+	var gotMethods []string
+	for oper, err := range Operations(r, "/ships/{id}") {
+		if err != nil {
+			t.Fatalf("Operations() error = %v", err)
+		}
+		gotMethods = append(gotMethods, oper.Req.Method)
+	}
+	if diff := cmp.Diff([]string{http.MethodPost, http.MethodPatch, http.MethodDelete}, gotMethods); diff != "" {
+		t.Errorf("Operations() methods mismatch (-want +got):\n%s", diff)
+	}
+}
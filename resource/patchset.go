@@ -84,6 +84,44 @@ func (p *PatchSet[Resource]) checkPermissions(ctx context.Context, dbType DBType
 	return p.querySet.checkPermissions(ctx, dbType)
 }
 
+// checkRowPolicy verifies the existing row satisfies the resource's row policy before it is
+// modified. A row outside the policy is reported as not found so its existence is not disclosed.
+// An update can not set a field the policy reads, since it could move the row outside the policy.
+func (p *PatchSet[Resource]) checkRowPolicy(ctx context.Context, txn ReadWriteTransaction) error {
+	if err := p.querySet.applyRowPolicy(ctx); err != nil {
+		return err
+	}
+
+	if p.querySet.rowFilter == nil {
+		return nil
+	}
+
+	for _, field := range p.Fields() {
+		dbField, ok := p.querySet.rMeta.dbFieldMap(txn.DBType())[field]
+		if ok && expressionReferences(p.querySet.rowFilter, dbField.ColumnName) {
+			return httpio.NewForbiddenMessagef("domain (%s), user (%s) can not set %s, it is restricted by the row policy of %s", p.querySet.userPermissions.Domain(), p.querySet.userPermissions.User(), field, p.Resource())
+		}
+	}
+
+	qSet := NewQuerySet(p.querySet.rMeta)
+	qSet.rowFilter = p.querySet.rowFilter
+	for _, part := range p.PrimaryKey().Parts() {
+		qSet.SetKey(part.Key, part.Value)
+		qSet.AddField(part.Key)
+	}
+
+	stmt, err := qSet.stmt(txn.DBType())
+	if err != nil {
+		return errors.Wrap(err, "QuerySet.stmt()")
+	}
+
+	if _, err := newReader[Resource](txn).Read(ctx, stmt); err != nil {
+		return errors.Wrap(err, "Reader[Resource].Read()")
+	}
+
+	return nil
+}
+
 // Set adds or updates a field's value in the PatchSet.
 func (p *PatchSet[Resource]) Set(field accesstypes.Field, value any) *PatchSet[Resource] {
 	p.data.Set(field, value)
@@ -403,7 +441,7 @@ func (p *PatchSet[Resource]) bufferInsert(ctx context.Context, txn ReadWriteTran
 		}
 	}
 
-	patch, err := p.Resolve(txn.DBType())
+	patch, err := p.resolve(ctx, txn)
 	if err != nil {
 		return errors.Wrap(err, "Resolve()")
 	}
@@ -426,6 +464,10 @@ func (p *PatchSet[Resource]) bufferUpdate(ctx context.Context, txn ReadWriteTran
 		return err
 	}
 
+	if err := p.checkRowPolicy(ctx, txn); err != nil {
+		return err
+	}
+
 	event, err := p.validateEventSource(eventSource)
 	if err != nil {
 		return err
@@ -453,7 +495,7 @@ func (p *PatchSet[Resource]) bufferUpdate(ctx context.Context, txn ReadWriteTran
 		}
 	}
 
-	patch, err := p.Resolve(txn.DBType())
+	patch, err := p.resolve(ctx, txn)
 	if err != nil {
 		return errors.Wrap(err, "Resolve()")
 	}
@@ -482,7 +524,7 @@ func (p *PatchSet[Resource]) bufferInsertOrUpdate(ctx context.Context, txn ReadW
 		return err
 	}
 
-	patch, err := p.Resolve(txn.DBType())
+	patch, err := p.resolve(ctx, txn)
 	if err != nil {
 		return errors.Wrap(err, "Resolve()")
 	}
@@ -505,6 +547,10 @@ func (p *PatchSet[Resource]) bufferDelete(ctx context.Context, txn ReadWriteTran
 		return err
 	}
 
+	if err := p.checkRowPolicy(ctx, txn); err != nil {
+		return err
+	}
+
 	event, err := p.validateEventSource(eventSource)
 	if err != nil {
 		return err
@@ -636,6 +682,7 @@ func (p *PatchSet[Resource]) insertChangeSet() (map[accesstypes.Field]DiffElem,
 		v.Old = nil
 		changeSet[k] = v
 	}
+	redactChangeSet(p.querySet.rMeta.encryptedFields, changeSet)
 
 	return changeSet, nil
 }
@@ -651,6 +698,11 @@ func (p *PatchSet[Resource]) updateChangeSet(ctx context.Context, txn ReadWriteT
 		return nil, errors.Wrap(err, "Reader[Resource].Read()")
 	}
 
+	// decrypt so unchanged encrypted values compare equal to the patch
+	if err := decryptFields(ctx, txn, p.querySet.rMeta.encryptedFields, oldValues); err != nil {
+		return nil, errors.Wrap(err, "decryptFields()")
+	}
+
 	changeSet, err := p.Diff(oldValues)
 	if err != nil {
 		return nil, errors.Wrap(err, "Diff()")
@@ -659,6 +711,7 @@ func (p *PatchSet[Resource]) updateChangeSet(ctx context.Context, txn ReadWriteT
 	if len(changeSet) == 0 {
 		return nil, httpio.NewBadRequestMessagef("No changes to apply for %s (%s)", p.Resource(), stmt.resolvedWhereClause)
 	}
+	redactChangeSet(p.querySet.rMeta.encryptedFields, changeSet)
 
 	return changeSet, nil
 }
@@ -678,6 +731,7 @@ func (p *PatchSet[Resource]) jsonDeleteSet(ctx context.Context, txn ReadWriteTra
 	if err != nil {
 		return nil, errors.Wrap(err, "Diff()")
 	}
+	redactChangeSet(p.querySet.rMeta.encryptedFields, changeSet)
 
 	return changeSet, nil
 }
@@ -711,24 +765,55 @@ func (p *PatchSet[Resource]) deleteChangeSet(old any) (map[accesstypes.Field]Dif
 }
 
 // Resolve returns a map with the keys set to the database struct tags found on databaseType, and the values set to the values in patchSet.
+// A PatchSet of a resource with encrypted fields must be resolved with ResolveContext.
 func (p *PatchSet[Resource]) Resolve(dbType DBType) (map[string]any, error) {
+	return p.ResolveContext(context.Background(), dbType, nil)
+}
+
+// ResolveContext is Resolve encrypting the values of encrypted fields with kp. kp may be nil
+// when the resource has no encrypted fields.
+func (p *PatchSet[Resource]) ResolveContext(ctx context.Context, dbType DBType, kp KeyProvider) (map[string]any, error) {
 	keySet := p.PrimaryKey()
 	if keySet.Len() == 0 {
 		return nil, errors.New("PatchSet must include at least one primary key in call to Resolve")
 	}
 
+	if len(p.querySet.rMeta.encryptedFields) != 0 && kp == nil {
+		return nil, errors.Newf("%s has encrypted fields, resolve it with ResolveContext() and a KeyProvider", p.Resource())
+	}
+
 	newMap := make(map[string]any, p.Len()+keySet.Len())
 	for structField, value := range all(p.Data(), keySet.KeyMap()) {
 		f, ok := p.querySet.rMeta.dbFieldMap(dbType)[structField]
 		if !ok {
 			return nil, errors.Newf("field %s not found in struct", structField)
 		}
+		if p.querySet.rMeta.IsEncrypted(structField) {
+			encrypted, err := encryptFieldValue(ctx, kp, structField, value)
+			if err != nil {
+				return nil, err
+			}
+			value = encrypted
+		}
 		newMap[f.ColumnName] = value
 	}
 
 	return newMap, nil
 }
 
+// resolve resolves the PatchSet for txn, encrypting the values of encrypted fields with its KeyProvider.
+func (p *PatchSet[Resource]) resolve(ctx context.Context, txn ReadWriteTransaction) (map[string]any, error) {
+	var kp KeyProvider
+	if len(p.querySet.rMeta.encryptedFields) != 0 {
+		var err error
+		if kp, err = keyProviderOf(txn); err != nil {
+			return nil, err
+		}
+	}
+
+	return p.ResolveContext(ctx, txn.DBType(), kp)
+}
+
 // Diff returns a map of fields that have changed between old and patchSet.
 func (p *PatchSet[Resource]) Diff(old any) (map[accesstypes.Field]DiffElem, error) {
 	oldValue := reflect.ValueOf(old)
@@ -27,10 +27,12 @@ type parsedQueryParams struct {
 	FilterParser func(DBType) (ExpressionNode, error)
 	Limit        *uint64
 	Offset       *uint64
+	Search       string
 }
 
 type filterBody struct {
 	Filter string `json:"filter"`
+	Search string `json:"search"`
 }
 
 // QueryDecoder is a struct that returns columns that a given user has access to view
@@ -38,7 +40,9 @@ type QueryDecoder[Resource Resourcer, Request any] struct {
 	requestFieldMapper *RequestFieldMapper
 	resourceSet        *Set[Resource]
 	filterParserFields map[jsonFieldName]FilterFieldInfo
+	sortableFields     map[accesstypes.Field]struct{}
 	structDecoder      *StructDecoder[filterBody]
+	piiSearchFields    []string
 }
 
 // NewQueryDecoder creates a new QueryDecoder for a given Resource and Request type.
@@ -64,7 +68,9 @@ func NewQueryDecoder[Resource Resourcer, Request any](resSet *Set[Resource]) (*Q
 		requestFieldMapper: mapper,
 		resourceSet:        resSet,
 		filterParserFields: filterParserFields,
+		sortableFields:     sortableFields(reflect.TypeOf(req), resSet.ResourceMetadata()),
 		structDecoder:      structDecoder,
+		piiSearchFields:    piiSearchFields(reflect.TypeOf(req), resSet.ResourceMetadata()),
 	}, nil
 }
 
@@ -78,6 +84,10 @@ func (d *QueryDecoder[Resource, Request]) DecodeWithoutPermissions(request *http
 		}
 	}
 
+	if queryParams.Get(searchParam) != "" && len(d.piiSearchFields) != 0 {
+		return nil, httpio.NewBadRequestMessagef("cannot search sensitive fields in URL: %s", strings.Join(d.piiSearchFields, ", "))
+	}
+
 	if request.Method == http.MethodPost {
 		body, err := d.structDecoder.Decode(request)
 		if err != nil {
@@ -90,6 +100,13 @@ func (d *QueryDecoder[Resource, Request]) DecodeWithoutPermissions(request *http
 			}
 			queryParams.Add(filterParam, body.Filter)
 		}
+
+		if body.Search != "" {
+			if queryParams.Get(searchParam) != "" {
+				return nil, httpio.NewBadRequestMessagef("cannot have 'search' parameter in both query and body")
+			}
+			queryParams.Add(searchParam, body.Search)
+		}
 	}
 
 	parsedQuery, err := d.parseQuery(queryParams)
@@ -103,6 +120,7 @@ func (d *QueryDecoder[Resource, Request]) DecodeWithoutPermissions(request *http
 	qSet.SetSortFields(parsedQuery.SortFields)
 	qSet.SetLimit(parsedQuery.Limit)
 	qSet.SetOffset(parsedQuery.Offset)
+	qSet.SetSearch(parsedQuery.Search)
 	if len(parsedQuery.ColumnFields) == 0 {
 		qSet.ReturnAccessibleFields(true)
 	} else {
@@ -137,7 +155,10 @@ func (d *QueryDecoder[Resource, Request]) parseQuery(query url.Values) (*parsedQ
 	var filterParser func(DBType) (ExpressionNode, error)
 	var limit *uint64
 	var offset *uint64
+	var search string
 	var err error
+	var res Resource
+	policy := d.resourceSet.ResourceMetadata().queryPolicy
 
 	if sortParamValue := query.Get(sortParam); sortParamValue != "" {
 		sortFields, err = d.parseSortParam(sortParamValue)
@@ -156,8 +177,11 @@ func (d *QueryDecoder[Resource, Request]) parseQuery(query url.Values) (*parsedQ
 		limit = &limitVal
 		delete(query, limitParam)
 	} else {
-		defaultLimit := uint64(50)
-		limit = &defaultLimit
+		limitVal := defaultLimit
+		if policy.maxLimit != 0 {
+			limitVal = min(limitVal, policy.maxLimit)
+		}
+		limit = &limitVal
 	}
 
 	if offsetStr := query.Get(offsetParam); offsetStr != "" {
@@ -169,6 +193,10 @@ func (d *QueryDecoder[Resource, Request]) parseQuery(query url.Values) (*parsedQ
 		delete(query, offsetParam)
 	}
 
+	if err := policy.checkPaging(res.Resource(), limit, offset); err != nil {
+		return nil, err
+	}
+
 	if cols := query.Get(columnsParam); cols != "" {
 		// column names received in the query parameters are a comma separated list of json field names (ie: json tags on the request struct)
 		// we need to convert these to struct field names
@@ -192,6 +220,11 @@ func (d *QueryDecoder[Resource, Request]) parseQuery(query url.Values) (*parsedQ
 		delete(query, filterParam)
 	}
 
+	if searchStr := query.Get(searchParam); searchStr != "" {
+		search = strings.TrimSpace(searchStr)
+		delete(query, searchParam)
+	}
+
 	if len(query) > 0 {
 		return nil, httpio.NewBadRequestMessagef("unknown query parameters: %v", query)
 	}
@@ -202,6 +235,7 @@ func (d *QueryDecoder[Resource, Request]) parseQuery(query url.Values) (*parsedQ
 		FilterParser: filterParser,
 		Limit:        limit,
 		Offset:       offset,
+		Search:       search,
 	}, nil
 }
 
@@ -215,7 +249,7 @@ func (d *QueryDecoder[Resource, Request]) parseSortParam(sortParamValue string)
 			if trimmedPart == "" {
 				return nil, httpio.NewBadRequestMessagef("invalid sort field, found empty part in sort parameter: %s", sortParamValue)
 			}
-			fieldAndDir := strings.SplitN(trimmedPart, ":", 2)
+			fieldAndDir := strings.SplitN(trimmedPart, ":", 3)
 			jsonFieldName := strings.TrimSpace(fieldAndDir[0])
 
 			if jsonFieldName == "" {
@@ -226,9 +260,12 @@ func (d *QueryDecoder[Resource, Request]) parseSortParam(sortParamValue string)
 			if !found {
 				return nil, httpio.NewBadRequestMessagef("unknown sort field: %s", jsonFieldName)
 			}
+			if _, ok := d.sortableFields[goFieldName]; !ok && d.sortableFields != nil {
+				return nil, httpio.NewBadRequestMessagef("'%s' is not indexed and can not be sorted on", jsonFieldName)
+			}
 
 			direction := SortAscending // Default direction
-			if len(fieldAndDir) == 2 {
+			if len(fieldAndDir) >= 2 {
 				dirStr := strings.ToLower(strings.TrimSpace(fieldAndDir[1]))
 				switch dirStr {
 				case "asc":
@@ -239,7 +276,18 @@ func (d *QueryDecoder[Resource, Request]) parseSortParam(sortParamValue string)
 					return nil, httpio.NewBadRequestMessagef("invalid sort direction for field '%s': %s. Must be 'asc' or 'desc'", jsonFieldName, fieldAndDir[1])
 				}
 			}
-			sortFields = append(sortFields, SortField{Field: string(goFieldName), Direction: direction})
+
+			var nulls SortNulls
+			if len(fieldAndDir) == 3 {
+				nullsStr := strings.ToLower(strings.TrimSpace(fieldAndDir[2]))
+				switch SortNulls(nullsStr) {
+				case SortNullsFirst, SortNullsLast:
+					nulls = SortNulls(nullsStr)
+				default:
+					return nil, httpio.NewBadRequestMessagef("invalid nulls ordering for field '%s': %s. Must be 'nullsfirst' or 'nullslast'", jsonFieldName, fieldAndDir[2])
+				}
+			}
+			sortFields = append(sortFields, SortField{Field: string(goFieldName), Direction: direction, Nulls: nulls})
 		}
 	}
 
@@ -270,6 +318,7 @@ func (d *QueryDecoder[Resource, Request]) checkForPII(filterStr string) error {
 
 		if token.Type == TokenCondition {
 			jsonFieldNameStr := strings.SplitN(token.Value, ":", 2)[0]
+			jsonFieldNameStr, _, _ = strings.Cut(jsonFieldNameStr, ".")
 			if fieldInfo, found := d.filterParserFields[jsonFieldName(jsonFieldNameStr)]; found {
 				if fieldInfo.PII {
 					return httpio.NewBadRequestMessagef("cannot filter on sensitive field in URL: %s", jsonFieldNameStr)
@@ -281,6 +330,44 @@ func (d *QueryDecoder[Resource, Request]) checkForPII(filterStr string) error {
 	return nil
 }
 
+// piiSearchFields returns the JSON names of the searchable fields with pii:"true". A search
+// matches these fields, so its text is as sensitive as a filter on them.
+func piiSearchFields[Resource Resourcer](reqType reflect.Type, resourceMetadata *Metadata[Resource]) []string {
+	var fields []string
+	for structField := range reqType.Fields() {
+		if _, ok := resourceMetadata.searchTokens[accesstypes.Field(structField.Name)]; !ok {
+			continue
+		}
+		if structField.Tag.Get(piiTagKey) == trueStr {
+			jsonFieldNameStr, _, _ := strings.Cut(structField.Tag.Get(jsonTagKey), ",")
+			fields = append(fields, jsonFieldNameStr)
+		}
+	}
+
+	return fields
+}
+
+// sortableFields returns the fields a sort parameter may use when the resource's query policy
+// requires an indexed sort: the primary key, and fields with index:"true" or allow_sort:"true".
+// It returns nil when any field may be sorted on.
+func sortableFields[Resource Resourcer](reqType reflect.Type, resourceMetadata *Metadata[Resource]) map[accesstypes.Field]struct{} {
+	if !resourceMetadata.queryPolicy.requireIndexedSort {
+		return nil
+	}
+
+	fields := make(map[accesstypes.Field]struct{})
+	for _, field := range resourceMetadata.primaryKey {
+		fields[field] = struct{}{}
+	}
+	for structField := range reqType.Fields() {
+		if structField.Tag.Get(indexTagKey) == trueStr || structField.Tag.Get(allowSortTagKey) == trueStr {
+			fields[accesstypes.Field(structField.Name)] = struct{}{}
+		}
+	}
+
+	return fields
+}
+
 func newFilterParserFields[Resource Resourcer](reqType reflect.Type, resourceMetadata *Metadata[Resource]) (map[jsonFieldName]FilterFieldInfo, error) {
 	fields := make(map[jsonFieldName]FilterFieldInfo)
 
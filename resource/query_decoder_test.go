@@ -23,6 +23,7 @@ type TestResource struct {
 	Tags               []string `spanner:"tags_sql"`
 	LegacyIndexedField string   `spanner:"legacy_indexed_field_sql"`
 	Ssn                string   `spanner:"ssn_sql"`
+	Rank               *string  `spanner:"rank_sql"`
 }
 
 func (tr TestResource) Resource() accesstypes.Resource { return "testresources" }
@@ -44,6 +45,7 @@ type TestRequest struct {
 	Tags               []string `json:"tags"               index:"true"`
 	LegacyIndexedField string   `json:"legacyIndexedField" index:"true"`
 	Ssn                string   `json:"ssn"                index:"true" pii:"true"`
+	Rank               *string  `json:"rank"               allow_sort:"true"`
 }
 
 func TestQueryDecoder_parseQuery(t *testing.T) {
@@ -271,6 +273,51 @@ func TestQueryDecoder_parseQuery(t *testing.T) {
 				Limit: new(uint64(50)),
 			},
 		},
+		{
+			name:              "HAS on an array field",
+			queryValues:       url.Values{"filter": []string{"tags:has:red"}},
+			wantErr:           false,
+			expectedASTString: "tags_sql:has:red",
+			expectedResult: &parsedQueryParams{
+				Limit: new(uint64(50)),
+			},
+		},
+		{
+			name:              "HASANY on an array field",
+			queryValues:       url.Values{"filter": []string{"tags:hasany:(red,blue)"}},
+			wantErr:           false,
+			expectedASTString: "tags_sql:hasany:(red,blue)",
+			expectedResult: &parsedQueryParams{
+				Limit: new(uint64(50)),
+			},
+		},
+		{
+			name:              "HASALL on an integer array field",
+			queryValues:       url.Values{"filter": []string{"itemIDs:hasall:(1,2)"}},
+			wantErr:           false,
+			expectedASTString: "item_ids_sql:hasall:(1,2)",
+			expectedResult: &parsedQueryParams{
+				Limit: new(uint64(50)),
+			},
+		},
+		{
+			name:           "HAS on a non-array field",
+			queryValues:    url.Values{"filter": []string{"name:has:John"}},
+			wantErr:        true,
+			expectedErrMsg: "operator 'has' requires an array field in condition 'name:has:John'",
+		},
+		{
+			name:           "HASALL with an invalid element",
+			queryValues:    url.Values{"filter": []string{"itemIDs:hasall:(1,x)"}},
+			wantErr:        true,
+			expectedErrMsg: "value 'x' in condition 'itemIDs:hasall:(1,x)' is not a valid integer",
+		},
+		{
+			name:           "path on a non-JSON field",
+			queryValues:    url.Values{"filter": []string{"name.first:eq:John"}},
+			wantErr:        true,
+			expectedErrMsg: "'name' is not a JSON field and can not have a path in condition 'name.first:eq:John'",
+		},
 		{
 			name:              "ISNULL operator",
 			queryValues:       url.Values{"filter": []string{"email:isnull"}},
@@ -375,6 +422,39 @@ func TestQueryDecoder_parseQuery(t *testing.T) {
 				Limit:      new(uint64(50)),
 			},
 		},
+		{
+			name:        "sort nulls first",
+			queryValues: url.Values{"sort": []string{"email:desc:nullsfirst"}},
+			wantErr:     false,
+			expectedResult: &parsedQueryParams{
+				SortFields: []SortField{{Field: "Email", Direction: SortDescending, Nulls: SortNullsFirst}},
+				Limit:      new(uint64(50)),
+			},
+		},
+		{
+			name:        "sort allow_sort field",
+			queryValues: url.Values{"sort": []string{"rank:asc:NullsLast"}},
+			wantErr:     false,
+			expectedResult: &parsedQueryParams{
+				SortFields: []SortField{{Field: "Rank", Direction: SortAscending, Nulls: SortNullsLast}},
+				Limit:      new(uint64(50)),
+			},
+		},
+		{
+			name:        "sort field that is not indexed",
+			queryValues: url.Values{"sort": []string{"status:asc"}},
+			wantErr:     false,
+			expectedResult: &parsedQueryParams{
+				SortFields: []SortField{{Field: "Status", Direction: SortAscending}},
+				Limit:      new(uint64(50)),
+			},
+		},
+		{
+			name:           "sort invalid nulls ordering",
+			queryValues:    url.Values{"sort": []string{"email:asc:nullsmiddle"}},
+			wantErr:        true,
+			expectedErrMsg: "invalid nulls ordering for field 'email': nullsmiddle. Must be 'nullsfirst' or 'nullslast'",
+		},
 		{
 			name:           "sort invalid direction",
 			queryValues:    url.Values{"sort": []string{"name:invalid"}},
@@ -10,6 +10,7 @@ import (
 type PartialQueryClause struct {
 	tree            ExpressionNode
 	hasIndexedField bool
+	err             error
 }
 
 // NewPartialQueryClause creates an empty PartialQueryClause.
@@ -21,7 +22,7 @@ func NewPartialQueryClause() PartialQueryClause {
 func (p PartialQueryClause) Group(qc QueryClause) QueryClause {
 	groupedExpr := &GroupNode{Expression: qc.tree}
 	if p.tree == nil {
-		return QueryClause{tree: groupedExpr, hasIndexedField: qc.hasIndexedField}
+		return QueryClause{tree: groupedExpr, hasIndexedField: qc.hasIndexedField, err: qc.err}
 	}
 	logicalNode, ok := p.tree.(*LogicalOpNode)
 	if !ok {
@@ -30,17 +31,26 @@ func (p PartialQueryClause) Group(qc QueryClause) QueryClause {
 	logicalNode.Right = groupedExpr
 	finalHasIndexedField := p.hasIndexedField || qc.hasIndexedField
 
-	return QueryClause{tree: logicalNode, hasIndexedField: finalHasIndexedField}
+	return QueryClause{tree: logicalNode, hasIndexedField: finalHasIndexedField, err: firstErr(p.err, qc.err)}
 }
 
 // QueryClause represents a complete, valid query expression that can be part of a WHERE clause.
 type QueryClause struct {
 	tree            ExpressionNode
 	hasIndexedField bool
+	err             error
 }
 
-// Validate checks if the query clause has at least one indexed field.
+// Err returns the error of building the query clause, e.g. from an invalid JSON path.
+func (qc QueryClause) Err() error {
+	return qc.err
+}
+
+// Validate checks that the query clause was built without error and has at least one indexed field.
 func (qc QueryClause) Validate() error {
+	if qc.err != nil {
+		return qc.err
+	}
 	if !qc.hasIndexedField {
 		return stderr.New("invalid filter query, filter must contain at least one column that is indexed")
 	}
@@ -48,6 +58,11 @@ func (qc QueryClause) Validate() error {
 	return nil
 }
 
+// Expression returns the expression tree of the query clause.
+func (qc QueryClause) Expression() ExpressionNode {
+	return qc.tree
+}
+
 // And starts a logical AND operation, returning a PartialQueryClause to which the right-hand side can be appended.
 func (qc QueryClause) And() PartialQueryClause {
 	return PartialQueryClause{
@@ -56,6 +71,7 @@ func (qc QueryClause) And() PartialQueryClause {
 			Operator: OperatorAnd,
 		},
 		hasIndexedField: qc.hasIndexedField,
+		err:             qc.err,
 	}
 }
 
@@ -67,6 +83,7 @@ func (qc QueryClause) Or() PartialQueryClause {
 			Operator: OperatorOr,
 		},
 		hasIndexedField: qc.hasIndexedField,
+		err:             qc.err,
 	}
 }
 
@@ -75,11 +92,13 @@ type Ident[T comparable] struct {
 	column      string
 	partialExpr PartialQueryClause
 	indexed     bool
+	path        []string
+	err         error
 }
 
 // NewIdent creates a new identifier for a column.
 func NewIdent[T comparable](column string, px PartialQueryClause, indexed bool) Ident[T] {
-	return Ident[T]{column, px, indexed}
+	return Ident[T]{column: column, partialExpr: px, indexed: indexed}
 }
 
 // Equal creates an equality (`=`) or `IN` condition.
@@ -89,6 +108,7 @@ func (i Ident[T]) Equal(v ...T) QueryClause {
 		conditionNode = &ConditionNode{
 			Condition: Condition{
 				Field:    i.column,
+				Path:     i.path,
 				Operator: eqStr,
 				Value:    v[0],
 			},
@@ -101,6 +121,7 @@ func (i Ident[T]) Equal(v ...T) QueryClause {
 		conditionNode = &ConditionNode{
 			Condition: Condition{
 				Field:    i.column,
+				Path:     i.path,
 				Operator: inStr,
 				Values:   values,
 			},
@@ -110,7 +131,7 @@ func (i Ident[T]) Equal(v ...T) QueryClause {
 	finalHasIndexedField := i.partialExpr.hasIndexedField || i.indexed
 
 	if i.partialExpr.tree == nil {
-		return QueryClause{tree: conditionNode, hasIndexedField: finalHasIndexedField}
+		return QueryClause{tree: conditionNode, hasIndexedField: finalHasIndexedField, err: firstErr(i.partialExpr.err, i.err)}
 	}
 
 	logicalNode, ok := i.partialExpr.tree.(*LogicalOpNode)
@@ -119,7 +140,7 @@ func (i Ident[T]) Equal(v ...T) QueryClause {
 	}
 	logicalNode.Right = conditionNode
 
-	return QueryClause{tree: logicalNode, hasIndexedField: finalHasIndexedField}
+	return QueryClause{tree: logicalNode, hasIndexedField: finalHasIndexedField, err: firstErr(i.partialExpr.err, i.err)}
 }
 
 // NotEqual creates a not-equal (`<>`) or `NOT IN` condition.
@@ -129,6 +150,7 @@ func (i Ident[T]) NotEqual(v ...T) QueryClause {
 		conditionNode = &ConditionNode{
 			Condition: Condition{
 				Field:    i.column,
+				Path:     i.path,
 				Operator: neStr,
 				Value:    v[0],
 			},
@@ -141,6 +163,7 @@ func (i Ident[T]) NotEqual(v ...T) QueryClause {
 		conditionNode = &ConditionNode{
 			Condition: Condition{
 				Field:    i.column,
+				Path:     i.path,
 				Operator: notinStr,
 				Values:   values,
 			},
@@ -150,7 +173,7 @@ func (i Ident[T]) NotEqual(v ...T) QueryClause {
 	finalHasIndexedField := i.partialExpr.hasIndexedField || i.indexed
 
 	if i.partialExpr.tree == nil {
-		return QueryClause{tree: conditionNode, hasIndexedField: finalHasIndexedField}
+		return QueryClause{tree: conditionNode, hasIndexedField: finalHasIndexedField, err: firstErr(i.partialExpr.err, i.err)}
 	}
 
 	logicalNode, ok := i.partialExpr.tree.(*LogicalOpNode)
@@ -159,7 +182,7 @@ func (i Ident[T]) NotEqual(v ...T) QueryClause {
 	}
 	logicalNode.Right = conditionNode
 
-	return QueryClause{tree: logicalNode, hasIndexedField: finalHasIndexedField}
+	return QueryClause{tree: logicalNode, hasIndexedField: finalHasIndexedField, err: firstErr(i.partialExpr.err, i.err)}
 }
 
 // IsNull creates an `IS NULL` condition.
@@ -167,6 +190,7 @@ func (i Ident[T]) IsNull() QueryClause {
 	conditionNode := &ConditionNode{
 		Condition: Condition{
 			Field:    i.column,
+			Path:     i.path,
 			Operator: isnullStr,
 			IsNullOp: true,
 		},
@@ -174,7 +198,7 @@ func (i Ident[T]) IsNull() QueryClause {
 	finalHasIndexedField := i.partialExpr.hasIndexedField || i.indexed
 
 	if i.partialExpr.tree == nil {
-		return QueryClause{tree: conditionNode, hasIndexedField: finalHasIndexedField}
+		return QueryClause{tree: conditionNode, hasIndexedField: finalHasIndexedField, err: firstErr(i.partialExpr.err, i.err)}
 	}
 
 	logicalNode, ok := i.partialExpr.tree.(*LogicalOpNode)
@@ -183,7 +207,7 @@ func (i Ident[T]) IsNull() QueryClause {
 	}
 	logicalNode.Right = conditionNode
 
-	return QueryClause{tree: logicalNode, hasIndexedField: finalHasIndexedField}
+	return QueryClause{tree: logicalNode, hasIndexedField: finalHasIndexedField, err: firstErr(i.partialExpr.err, i.err)}
 }
 
 // IsNotNull creates an `IS NOT NULL` condition.
@@ -191,6 +215,7 @@ func (i Ident[T]) IsNotNull() QueryClause {
 	conditionNode := &ConditionNode{
 		Condition: Condition{
 			Field:    i.column,
+			Path:     i.path,
 			Operator: isnotnullStr,
 			IsNullOp: true,
 		},
@@ -198,7 +223,7 @@ func (i Ident[T]) IsNotNull() QueryClause {
 	finalHasIndexedField := i.partialExpr.hasIndexedField || i.indexed
 
 	if i.partialExpr.tree == nil {
-		return QueryClause{tree: conditionNode, hasIndexedField: finalHasIndexedField}
+		return QueryClause{tree: conditionNode, hasIndexedField: finalHasIndexedField, err: firstErr(i.partialExpr.err, i.err)}
 	}
 
 	logicalNode, ok := i.partialExpr.tree.(*LogicalOpNode)
@@ -207,7 +232,7 @@ func (i Ident[T]) IsNotNull() QueryClause {
 	}
 	logicalNode.Right = conditionNode
 
-	return QueryClause{tree: logicalNode, hasIndexedField: finalHasIndexedField}
+	return QueryClause{tree: logicalNode, hasIndexedField: finalHasIndexedField, err: firstErr(i.partialExpr.err, i.err)}
 }
 
 // GreaterThan creates a `>` condition.
@@ -215,6 +240,7 @@ func (i Ident[T]) GreaterThan(v T) QueryClause {
 	conditionNode := &ConditionNode{
 		Condition: Condition{
 			Field:    i.column,
+			Path:     i.path,
 			Operator: gtStr,
 			Value:    v,
 		},
@@ -222,7 +248,7 @@ func (i Ident[T]) GreaterThan(v T) QueryClause {
 	finalHasIndexedField := i.partialExpr.hasIndexedField || i.indexed
 
 	if i.partialExpr.tree == nil {
-		return QueryClause{tree: conditionNode, hasIndexedField: finalHasIndexedField}
+		return QueryClause{tree: conditionNode, hasIndexedField: finalHasIndexedField, err: firstErr(i.partialExpr.err, i.err)}
 	}
 
 	logicalNode, ok := i.partialExpr.tree.(*LogicalOpNode)
@@ -231,7 +257,7 @@ func (i Ident[T]) GreaterThan(v T) QueryClause {
 	}
 	logicalNode.Right = conditionNode
 
-	return QueryClause{tree: logicalNode, hasIndexedField: finalHasIndexedField}
+	return QueryClause{tree: logicalNode, hasIndexedField: finalHasIndexedField, err: firstErr(i.partialExpr.err, i.err)}
 }
 
 // GreaterThanEq creates a `>=` condition.
@@ -239,6 +265,7 @@ func (i Ident[T]) GreaterThanEq(v T) QueryClause {
 	conditionNode := &ConditionNode{
 		Condition: Condition{
 			Field:    i.column,
+			Path:     i.path,
 			Operator: gteStr,
 			Value:    v,
 		},
@@ -246,7 +273,7 @@ func (i Ident[T]) GreaterThanEq(v T) QueryClause {
 	finalHasIndexedField := i.partialExpr.hasIndexedField || i.indexed
 
 	if i.partialExpr.tree == nil {
-		return QueryClause{tree: conditionNode, hasIndexedField: finalHasIndexedField}
+		return QueryClause{tree: conditionNode, hasIndexedField: finalHasIndexedField, err: firstErr(i.partialExpr.err, i.err)}
 	}
 
 	logicalNode, ok := i.partialExpr.tree.(*LogicalOpNode)
@@ -255,7 +282,7 @@ func (i Ident[T]) GreaterThanEq(v T) QueryClause {
 	}
 	logicalNode.Right = conditionNode
 
-	return QueryClause{tree: logicalNode, hasIndexedField: finalHasIndexedField}
+	return QueryClause{tree: logicalNode, hasIndexedField: finalHasIndexedField, err: firstErr(i.partialExpr.err, i.err)}
 }
 
 // LessThan creates a `<` condition.
@@ -263,6 +290,7 @@ func (i Ident[T]) LessThan(v T) QueryClause {
 	conditionNode := &ConditionNode{
 		Condition: Condition{
 			Field:    i.column,
+			Path:     i.path,
 			Operator: ltStr,
 			Value:    v,
 		},
@@ -270,7 +298,7 @@ func (i Ident[T]) LessThan(v T) QueryClause {
 	finalHasIndexedField := i.partialExpr.hasIndexedField || i.indexed
 
 	if i.partialExpr.tree == nil {
-		return QueryClause{tree: conditionNode, hasIndexedField: finalHasIndexedField}
+		return QueryClause{tree: conditionNode, hasIndexedField: finalHasIndexedField, err: firstErr(i.partialExpr.err, i.err)}
 	}
 
 	logicalNode, ok := i.partialExpr.tree.(*LogicalOpNode)
@@ -279,7 +307,7 @@ func (i Ident[T]) LessThan(v T) QueryClause {
 	}
 	logicalNode.Right = conditionNode
 
-	return QueryClause{tree: logicalNode, hasIndexedField: finalHasIndexedField}
+	return QueryClause{tree: logicalNode, hasIndexedField: finalHasIndexedField, err: firstErr(i.partialExpr.err, i.err)}
 }
 
 // LessThanEq creates a `<=` condition.
@@ -287,6 +315,7 @@ func (i Ident[T]) LessThanEq(v T) QueryClause {
 	conditionNode := &ConditionNode{
 		Condition: Condition{
 			Field:    i.column,
+			Path:     i.path,
 			Operator: lteStr,
 			Value:    v,
 		},
@@ -294,7 +323,7 @@ func (i Ident[T]) LessThanEq(v T) QueryClause {
 	finalHasIndexedField := i.partialExpr.hasIndexedField || i.indexed
 
 	if i.partialExpr.tree == nil {
-		return QueryClause{tree: conditionNode, hasIndexedField: finalHasIndexedField}
+		return QueryClause{tree: conditionNode, hasIndexedField: finalHasIndexedField, err: firstErr(i.partialExpr.err, i.err)}
 	}
 
 	logicalNode, ok := i.partialExpr.tree.(*LogicalOpNode)
@@ -303,5 +332,156 @@ func (i Ident[T]) LessThanEq(v T) QueryClause {
 	}
 	logicalNode.Right = conditionNode
 
-	return QueryClause{tree: logicalNode, hasIndexedField: finalHasIndexedField}
+	return QueryClause{tree: logicalNode, hasIndexedField: finalHasIndexedField, err: firstErr(i.partialExpr.err, i.err)}
+}
+
+// Between creates an inclusive `BETWEEN` condition.
+func (i Ident[T]) Between(lo, hi T) QueryClause {
+	conditionNode := &ConditionNode{
+		Condition: Condition{
+			Field:    i.column,
+			Path:     i.path,
+			Operator: betweenStr,
+			Values:   []any{lo, hi},
+		},
+	}
+
+	return i.partialExpr.complete(conditionNode, i.indexed, i.err)
+}
+
+// complete appends a condition to the partial clause as the right-hand side of its logical
+// operation, or starts a new clause when the partial clause is empty. err is the error of
+// building the condition's identifier.
+func (p PartialQueryClause) complete(conditionNode *ConditionNode, indexed bool, err error) QueryClause {
+	finalHasIndexedField := p.hasIndexedField || indexed
+	err = firstErr(p.err, err)
+
+	if p.tree == nil {
+		return QueryClause{tree: conditionNode, hasIndexedField: finalHasIndexedField, err: err}
+	}
+
+	logicalNode, ok := p.tree.(*LogicalOpNode)
+	if !ok {
+		panic(fmt.Sprintf("Expected LogicalOpNode, got %T", p.tree))
+	}
+	logicalNode.Right = conditionNode
+
+	return QueryClause{tree: logicalNode, hasIndexedField: finalHasIndexedField, err: err}
+}
+
+// ArrayIdent represents an ARRAY column identifier in a query, typed by its element type.
+type ArrayIdent[E comparable] struct {
+	column      string
+	partialExpr PartialQueryClause
+	indexed     bool
+}
+
+// NewArrayIdent creates a new identifier for an ARRAY column.
+func NewArrayIdent[E comparable](column string, px PartialQueryClause, indexed bool) ArrayIdent[E] {
+	return ArrayIdent[E]{column: column, partialExpr: px, indexed: indexed}
+}
+
+// Has creates a condition matching arrays that contain v.
+func (i ArrayIdent[E]) Has(v E) QueryClause {
+	return i.partialExpr.complete(&ConditionNode{
+		Condition: Condition{
+			Field:    i.column,
+			Operator: hasStr,
+			Value:    v,
+		},
+	}, i.indexed, nil)
+}
+
+// HasAny creates a condition matching arrays that contain at least one of v.
+func (i ArrayIdent[E]) HasAny(v ...E) QueryClause {
+	return i.partialExpr.complete(&ConditionNode{
+		Condition: Condition{
+			Field:    i.column,
+			Operator: hasanyStr,
+			Values:   anyValues(v),
+		},
+	}, i.indexed, nil)
+}
+
+// HasAll creates a condition matching arrays that contain every one of v.
+func (i ArrayIdent[E]) HasAll(v ...E) QueryClause {
+	return i.partialExpr.complete(&ConditionNode{
+		Condition: Condition{
+			Field:    i.column,
+			Operator: hasallStr,
+			Values:   anyValues(v),
+		},
+	}, i.indexed, nil)
+}
+
+// IsNull creates an `IS NULL` condition.
+func (i ArrayIdent[E]) IsNull() QueryClause {
+	return NewIdent[bool](i.column, i.partialExpr, i.indexed).IsNull()
+}
+
+// IsNotNull creates an `IS NOT NULL` condition.
+func (i ArrayIdent[E]) IsNotNull() QueryClause {
+	return NewIdent[bool](i.column, i.partialExpr, i.indexed).IsNotNull()
+}
+
+// JSONIdent represents a JSON column identifier in a query. Its values are compared by path.
+type JSONIdent struct {
+	column      string
+	partialExpr PartialQueryClause
+	indexed     bool
+}
+
+// NewJSONIdent creates a new identifier for a JSON column.
+func NewJSONIdent(column string, px PartialQueryClause, indexed bool) JSONIdent {
+	return JSONIdent{column: column, partialExpr: px, indexed: indexed}
+}
+
+// Path returns an identifier for the scalar value at path inside the JSON column. Each path
+// element is a member name or an array index. Numbers are compared numerically and booleans
+// as booleans; any other value is compared as a string. An empty path or invalid element is
+// returned as the error of the finished clause, by Err and Validate.
+func (i JSONIdent) Path(path ...string) Ident[any] {
+	ident := Ident[any]{column: i.column, partialExpr: i.partialExpr, indexed: i.indexed, path: path}
+	if len(path) == 0 {
+		ident.err = fmt.Errorf("JSON path of %s must have at least one element", i.column)
+	}
+	for _, segment := range path {
+		if !jsonPathSegment.MatchString(segment) {
+			ident.err = fmt.Errorf("invalid JSON path segment %q of %s", segment, i.column)
+
+			break
+		}
+	}
+
+	return ident
+}
+
+// IsNull creates an `IS NULL` condition.
+func (i JSONIdent) IsNull() QueryClause {
+	return NewIdent[bool](i.column, i.partialExpr, i.indexed).IsNull()
+}
+
+// IsNotNull creates an `IS NOT NULL` condition.
+func (i JSONIdent) IsNotNull() QueryClause {
+	return NewIdent[bool](i.column, i.partialExpr, i.indexed).IsNotNull()
+}
+
+// firstErr returns the first of errs that is not nil.
+func firstErr(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func anyValues[E any](v []E) []any {
+	values := make([]any, len(v))
+	for idx, val := range v {
+		values[idx] = val
+	}
+
+	return values
 }
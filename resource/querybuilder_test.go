@@ -2,7 +2,11 @@ package resource
 
 import (
 	"reflect"
+	"strings"
 	"testing"
+	"time"
+
+	"cloud.google.com/go/civil"
 )
 
 type testQuery struct {
@@ -64,6 +68,18 @@ func (px testQueryPartialExpr) NonIndexedField() testQueryIdent[string] {
 	}
 }
 
+func (px testQueryPartialExpr) Tags() testQueryArrayIdent[string] {
+	return testQueryArrayIdent[string]{
+		ArrayIdent: NewArrayIdent[string]("Tags", px.partialExpr, false),
+	}
+}
+
+func (px testQueryPartialExpr) Details() testQueryJSONIdent {
+	return testQueryJSONIdent{
+		JSONIdent: NewJSONIdent("Details", px.partialExpr, false),
+	}
+}
+
 type testQueryExpr struct {
 	expr QueryClause
 }
@@ -104,6 +120,10 @@ func (i testQueryIdent[T]) LessThanEq(v T) testQueryExpr {
 	return testQueryExpr{expr: i.Ident.LessThanEq(v)}
 }
 
+func (i testQueryIdent[T]) Between(lo, hi T) testQueryExpr {
+	return testQueryExpr{expr: i.Ident.Between(lo, hi)}
+}
+
 func (i testQueryIdent[T]) IsNull() testQueryExpr {
 	return testQueryExpr{expr: i.Ident.IsNull()}
 }
@@ -233,6 +253,16 @@ func Test_QueryClause(t *testing.T) {
 				"_p1": 15,
 			},
 		},
+		{
+			name:    "Between spanner",
+			dbType:  SpannerDBType,
+			filter:  newTestQuery().Where(newTestQueryFilter().ID().Between(5, 15)),
+			wantSQL: "`ID` BETWEEN @_p1 AND @_p2",
+			wantParams: map[string]any{
+				"_p1": 5,
+				"_p2": 15,
+			},
+		},
 		{
 			name:    "IN clause with multiple integer values spanner",
 			dbType:  SpannerDBType,
@@ -316,6 +346,20 @@ func Test_QueryClause(t *testing.T) {
 				"_p12": 11,
 			},
 		},
+		{
+			name:       "array has any spanner",
+			dbType:     SpannerDBType,
+			filter:     newTestQuery().Where(newTestQueryFilter().ID().Equal(1).And().Tags().HasAny("red", "blue")),
+			wantSQL:    "`ID` = @_p1 AND (@_p2 IN UNNEST(`Tags`) OR @_p3 IN UNNEST(`Tags`))",
+			wantParams: map[string]any{"_p1": 1, "_p2": "red", "_p3": "blue"},
+		},
+		{
+			name:       "json path pg",
+			dbType:     PostgresDBType,
+			filter:     newTestQuery().Where(newTestQueryFilter().ID().Equal(1).And().Details().Path("cargo", "weight").GreaterThan(10.5)),
+			wantSQL:    `"ID" = @_p1 AND ("Details" #>> '{cargo,weight}')::numeric > @_p2`,
+			wantParams: map[string]any{"_p1": 1, "_p2": 10.5},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -494,3 +538,203 @@ func TestQueryClause_Validate(t *testing.T) {
 		})
 	}
 }
+
+type testQueryArrayIdent[E comparable] struct {
+	ArrayIdent[E]
+}
+
+func (i testQueryArrayIdent[E]) HasAny(v ...E) testQueryExpr {
+	return testQueryExpr{expr: i.ArrayIdent.HasAny(v...)}
+}
+
+type testQueryJSONIdent struct {
+	JSONIdent
+}
+
+func (i testQueryJSONIdent) Path(path ...string) testQueryIdent[any] {
+	return testQueryIdent[any]{Ident: i.JSONIdent.Path(path...)}
+}
+
+func Test_FormatFilter(t *testing.T) {
+	t.Parallel()
+
+	launched := time.Date(2025, time.March, 13, 8, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		qc      QueryClause
+		want    string
+		wantErr string
+	}{
+		{
+			name: "condition",
+			qc:   newTestQueryFilter().Name().Equal("test").expr,
+			want: "Name:eq:test",
+		},
+		{
+			name: "value list",
+			qc:   newTestQueryFilter().ID().NotEqual(1, 2).expr,
+			want: "ID:notin:(1,2)",
+		},
+		{
+			name: "null check",
+			qc:   newTestQueryFilter().Name().IsNotNull().expr,
+			want: "Name:isnotnull",
+		},
+		{
+			name: "AND and OR are written flat",
+			qc:   newTestQueryFilter().ID().Equal(10).And().Name().Equal("test").Or().ID().GreaterThan(2).expr,
+			want: "ID:eq:10,Name:eq:test|ID:gt:2",
+		},
+		{
+			name: "group",
+			qc:   newTestQueryFilter().ID().Equal(10).And().Group(newTestQueryFilter().Name().Equal("test").Or().ID().Between(2, 5)).expr,
+			want: "ID:eq:10,(Name:eq:test|ID:between:(2,5))",
+		},
+		{
+			name: "array and JSON path",
+			qc:   newTestQueryFilter().Tags().HasAny("a", "b").And().Details().Path("cargo", "weight").GreaterThan(10).expr,
+			want: "Tags:hasany:(a,b),Details.cargo.weight:gt:10",
+		},
+		{
+			name: "time and date values",
+			qc: QueryClause{tree: &LogicalOpNode{
+				Left:     &ConditionNode{Condition: Condition{Field: "Launched", Operator: gteStr, Value: launched}},
+				Operator: OperatorAnd,
+				Right:    &ConditionNode{Condition: Condition{Field: "Docked", Operator: ltStr, Value: civil.DateOf(launched)}},
+			}},
+			want: "Launched:gte:2025-03-13T08:30:00Z,Docked:lt:2025-03-13",
+		},
+		{
+			name: "value with a colon and inner space",
+			qc:   newTestQueryFilter().Name().Equal("Vanta: Mk II").expr,
+			want: "Name:eq:Vanta: Mk II",
+		},
+		{
+			name: "JSON path string that parses as a number is quoted",
+			qc:   newTestQueryFilter().Details().Path("code").Equal("007").expr,
+			want: `Details.code:eq:"007"`,
+		},
+		{
+			name: "JSON path string that parses as a boolean is quoted",
+			qc:   newTestQueryFilter().Details().Path("flag").Equal("true").expr,
+			want: `Details.flag:eq:"true"`,
+		},
+		{
+			name:    "value with a pipe",
+			qc:      newTestQueryFilter().Name().Equal("a|id:isnotnull").expr,
+			wantErr: `value "a|id:isnotnull" of Name can not be written in a filter`,
+		},
+		{
+			name:    "value with a comma",
+			qc:      newTestQueryFilter().Name().Equal("a,b").expr,
+			wantErr: `value "a,b" of Name can not be written in a filter`,
+		},
+		{
+			name:    "value with parentheses",
+			qc:      newTestQueryFilter().Name().Equal("(a)").expr,
+			wantErr: `value "(a)" of Name can not be written in a filter`,
+		},
+		{
+			name:    "value with leading space",
+			qc:      newTestQueryFilter().Name().Equal(" a").expr,
+			wantErr: `value " a" of Name can not be written in a filter`,
+		},
+		{
+			name:    "value with trailing space",
+			qc:      newTestQueryFilter().Name().Equal("a\t").expr,
+			wantErr: `value "a\t" of Name can not be written in a filter`,
+		},
+		{
+			name:    "list value with a comma",
+			qc:      newTestQueryFilter().Tags().HasAny("a", "b,c").expr,
+			wantErr: `value "b,c" of Tags can not be written in a filter`,
+		},
+		{
+			name:    "empty list value",
+			qc:      newTestQueryFilter().Tags().HasAny("a", "").expr,
+			wantErr: "empty value in the hasany list of Tags",
+		},
+		{
+			name:    "invalid JSON path segment",
+			qc:      QueryClause{tree: &ConditionNode{Condition: Condition{Field: "Details", Path: []string{"cargo weight"}, Operator: gtStr, Value: 10}}},
+			wantErr: `JSON path segment "cargo weight" of Details can not be written in a filter`,
+		},
+		{
+			name:    "error inside a group",
+			qc:      newTestQueryFilter().ID().Equal(10).And().Group(newTestQueryFilter().Name().Equal("x").Or().Name().Equal("y)")).expr,
+			wantErr: `value "y)" of Name can not be written in a filter`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := FormatFilter(tt.qc.Expression())
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("FormatFilter() error = %v, want %q", err, tt.wantErr)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("FormatFilter() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("FormatFilter() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONIdent_Path_err(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		qc      QueryClause
+		wantErr string
+	}{
+		{
+			name:    "empty path",
+			qc:      newTestQueryFilter().Details().Path().Equal("x").expr,
+			wantErr: "JSON path of Details must have at least one element",
+		},
+		{
+			name:    "invalid segment",
+			qc:      newTestQueryFilter().ID().Equal(1).And().Details().Path("cargo", "we'ight").GreaterThan(10).expr,
+			wantErr: `invalid JSON path segment "we'ight" of Details`,
+		},
+		{
+			name:    "invalid segment before the right-hand side",
+			qc:      newTestQueryFilter().Details().Path("").Equal("x").Or().ID().Equal(1).expr,
+			wantErr: `invalid JSON path segment "" of Details`,
+		},
+		{
+			name: "valid path",
+			qc:   newTestQueryFilter().ID().Equal(1).And().Details().Path("cargo", "0").Between(1, 2).expr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.qc.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("QueryClause.Validate() error = %v, want nil", err)
+				}
+
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("QueryClause.Validate() error = %v, want %q", err, tt.wantErr)
+			}
+			if err := tt.qc.Err(); err == nil {
+				t.Errorf("QueryClause.Err() = nil, want %q", tt.wantErr)
+			}
+		})
+	}
+}
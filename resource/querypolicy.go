@@ -0,0 +1,82 @@
+package resource
+
+import (
+	"context"
+	"time"
+
+	"github.com/cccteam/ccc/accesstypes"
+	"github.com/cccteam/httpio"
+	"github.com/go-playground/errors/v5"
+)
+
+// defaultLimit is the limit of a decoded query that does not set one.
+const defaultLimit uint64 = 50
+
+// queryPolicy bounds the List queries run against a resource. It is read from the MaxLimit,
+// MaxOffset, QueryTimeout, RequireIndexedFilter and RequireIndexedSort fields of the resource's
+// Config.
+type queryPolicy struct {
+	maxLimit             uint64
+	maxOffset            uint64
+	timeout              time.Duration
+	requireIndexedFilter bool
+	requireIndexedSort   bool
+}
+
+// checkPaging returns a 400 when limit or offset is larger than the policy allows.
+func (p queryPolicy) checkPaging(resource accesstypes.Resource, limit, offset *uint64) error {
+	if p.maxLimit != 0 && limit != nil && *limit > p.maxLimit {
+		return httpio.NewBadRequestMessagef("limit %d exceeds the maximum limit of %d for %s", *limit, p.maxLimit, resource)
+	}
+	if p.maxOffset != 0 && offset != nil && *offset > p.maxOffset {
+		return httpio.NewBadRequestMessagef("offset %d exceeds the maximum offset of %d for %s, narrow the filter instead of paging further", *offset, p.maxOffset, resource)
+	}
+
+	return nil
+}
+
+// applyQueryPolicy enforces the resource's query policy on a List. A query without a limit
+// is limited to the maximum limit.
+func (q *QuerySet[Resource]) applyQueryPolicy() error {
+	policy := q.rMeta.queryPolicy
+
+	if q.limit == nil && policy.maxLimit != 0 {
+		limit := policy.maxLimit
+		q.limit = &limit
+	}
+	if err := policy.checkPaging(q.Resource(), q.limit, q.offset); err != nil {
+		return err
+	}
+
+	if policy.requireIndexedFilter && !q.isNarrowed() {
+		return httpio.NewBadRequestMessagef("%s can not be listed without a filter on an indexed field", q.Resource())
+	}
+
+	return nil
+}
+
+// isNarrowed reports whether the query is restricted by something other than its row policy:
+// a filter, which always includes an indexed field, a key, key range or parent key, or a search.
+func (q *QuerySet[Resource]) isNarrowed() bool {
+	return q.filterAst != nil || q.filterParser != nil || q.KeySet().Len() != 0 || len(q.keySets) != 0 ||
+		q.keyRange != nil || q.parentKey.Len() != 0 || q.search != ""
+}
+
+// withQueryTimeout returns a context that expires after the resource's query timeout.
+func (q *QuerySet[Resource]) withQueryTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if q.rMeta.queryPolicy.timeout == 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, q.rMeta.queryPolicy.timeout)
+}
+
+// queryTimeoutError returns a 400 when err was caused by the resource's query timeout
+// expiring, rather than by ctx, and returns err unchanged otherwise.
+func (q *QuerySet[Resource]) queryTimeoutError(ctx, queryCtx context.Context, err error) error {
+	if ctx.Err() != nil || !errors.Is(queryCtx.Err(), context.DeadlineExceeded) {
+		return err
+	}
+
+	return httpio.NewBadRequestMessageWithErrorf(err, "query on %s exceeded the time limit of %s, narrow the filter or lower the limit", q.Resource(), q.rMeta.queryPolicy.timeout)
+}
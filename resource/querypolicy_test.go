@@ -0,0 +1,208 @@
+package resource
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cccteam/ccc/accesstypes"
+	"github.com/go-playground/errors/v5"
+)
+
+type queryPolicyTestResource struct {
+	ID     string `spanner:"Id"     db:"Id"`
+	ShipID string `spanner:"ShipId" db:"ShipId"`
+	Name   string `spanner:"Name"   db:"Name"`
+}
+
+func (queryPolicyTestResource) Resource() accesstypes.Resource { return "Telemetry" }
+
+func (queryPolicyTestResource) Config() Config {
+	return Config{}.SetMaxLimit(20).SetMaxOffset(100).SetQueryTimeout(time.Second).SetRequireIndexedFilter(true).SetRequireIndexedSort(true)
+}
+
+func (queryPolicyTestResource) PrimaryKeyFields() []accesstypes.Field {
+	return []accesstypes.Field{"ID"}
+}
+
+type queryPolicyTestRequest struct {
+	ID     string `json:"id"     index:"true"`
+	ShipID string `json:"shipId" index:"true"`
+	Name   string `json:"name"`
+}
+
+func TestQuerySet_applyQueryPolicy(t *testing.T) {
+	t.Parallel()
+
+	limit := func(n uint64) *uint64 { return &n }
+
+	tests := []struct {
+		name      string
+		setup     func(q *QuerySet[queryPolicyTestResource])
+		wantLimit uint64
+		wantErr   string
+	}{
+		{
+			name: "filtered query without a limit gets the maximum limit",
+			setup: func(q *QuerySet[queryPolicyTestResource]) {
+				q.SetFilterAst(&ConditionNode{Condition: Condition{Field: "ShipId", Operator: eqStr, Value: "a"}})
+			},
+			wantLimit: 20,
+		},
+		{
+			name: "keyed query",
+			setup: func(q *QuerySet[queryPolicyTestResource]) {
+				q.SetKey("ID", "a")
+				q.SetLimit(limit(5))
+			},
+			wantLimit: 5,
+		},
+		{
+			name:      "searched query",
+			setup:     func(q *QuerySet[queryPolicyTestResource]) { q.SetSearch("warp") },
+			wantLimit: 20,
+		},
+		{
+			name:    "unfiltered query",
+			setup:   func(*QuerySet[queryPolicyTestResource]) {},
+			wantErr: "Telemetry can not be listed without a filter on an indexed field",
+		},
+		{
+			name: "limit over the maximum",
+			setup: func(q *QuerySet[queryPolicyTestResource]) {
+				q.SetKey("ID", "a")
+				q.SetLimit(limit(21))
+			},
+			wantErr: "limit 21 exceeds the maximum limit of 20 for Telemetry",
+		},
+		{
+			name: "offset over the maximum",
+			setup: func(q *QuerySet[queryPolicyTestResource]) {
+				q.SetKey("ID", "a")
+				q.SetOffset(limit(101))
+			},
+			wantErr: "offset 101 exceeds the maximum offset of 100 for Telemetry",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			qSet := NewQuerySet(NewMetadata[queryPolicyTestResource]())
+			tt.setup(qSet)
+
+			err := qSet.applyQueryPolicy()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("QuerySet.applyQueryPolicy() error = %v, want %q", err, tt.wantErr)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("QuerySet.applyQueryPolicy() error = %v", err)
+			}
+			if qSet.limit == nil || *qSet.limit != tt.wantLimit {
+				t.Errorf("QuerySet.limit = %v, want %d", qSet.limit, tt.wantLimit)
+			}
+		})
+	}
+}
+
+func TestQuerySet_queryTimeoutError(t *testing.T) {
+	t.Parallel()
+
+	qSet := NewQuerySet(NewMetadata[queryPolicyTestResource]())
+	queryErr := errors.New("rpc error: code = DeadlineExceeded")
+
+	expired, cancel := context.WithDeadline(t.Context(), time.Now().Add(-time.Second))
+	defer cancel()
+	if err := qSet.queryTimeoutError(t.Context(), expired, queryErr); err == nil || !strings.Contains(err.Error(), "exceeded the time limit of 1s") {
+		t.Errorf("QuerySet.queryTimeoutError() error = %v, want exceeded the time limit of 1s", err)
+	}
+
+	canceled, cancelParent := context.WithCancel(t.Context())
+	cancelParent()
+	if err := qSet.queryTimeoutError(canceled, canceled, queryErr); err == nil || err.Error() != queryErr.Error() {
+		t.Errorf("QuerySet.queryTimeoutError() error = %v, want %v", err, queryErr)
+	}
+}
+
+func TestQueryDecoder_queryPolicy(t *testing.T) {
+	t.Parallel()
+
+	resSet, err := NewSet[queryPolicyTestResource, queryPolicyTestRequest]()
+	if err != nil {
+		t.Fatalf("NewSet() error = %v", err)
+	}
+	decoder, err := NewQueryDecoder[queryPolicyTestResource, queryPolicyTestRequest](resSet)
+	if err != nil {
+		t.Fatalf("NewQueryDecoder() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		urlValues string
+		wantLimit uint64
+		wantErr   string
+	}{
+		{
+			name:      "default limit is capped at the maximum",
+			wantLimit: 20,
+		},
+		{
+			name:      "limit at the maximum",
+			urlValues: "limit=20&offset=100",
+			wantLimit: 20,
+		},
+		{
+			name:      "limit over the maximum",
+			urlValues: "limit=21",
+			wantErr:   "limit 21 exceeds the maximum limit of 20 for Telemetry",
+		},
+		{
+			name:      "offset over the maximum",
+			urlValues: "offset=101",
+			wantErr:   "offset 101 exceeds the maximum offset of 100 for Telemetry",
+		},
+		{
+			name:      "sort on an indexed field",
+			urlValues: "sort=shipId:desc",
+			wantLimit: 20,
+		},
+		{
+			name:      "sort on a field that is not indexed",
+			urlValues: "sort=name",
+			wantErr:   "'name' is not indexed and can not be sorted on",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, "http://test?"+tt.urlValues, http.NoBody)
+			if err != nil {
+				t.Fatalf("http.NewRequestWithContext() error = %v", err)
+			}
+
+			qSet, err := decoder.DecodeWithoutPermissions(req)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("QueryDecoder.DecodeWithoutPermissions() error = %v, want %q", err, tt.wantErr)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("QueryDecoder.DecodeWithoutPermissions() error = %v", err)
+			}
+			if qSet.limit == nil || *qSet.limit != tt.wantLimit {
+				t.Errorf("QuerySet.limit = %v, want %d", qSet.limit, tt.wantLimit)
+			}
+		})
+	}
+}
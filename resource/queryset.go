@@ -5,10 +5,12 @@ import (
 	"fmt"
 	"iter"
 	"maps"
+	"reflect"
 	"slices"
 	"sort"
 	"strings"
 
+	"cloud.google.com/go/spanner"
 	"github.com/cccteam/ccc"
 	"github.com/cccteam/ccc/accesstypes"
 	"github.com/cccteam/httpio"
@@ -22,6 +24,7 @@ import (
 // QuerySet represents a query for a resource, including fields, keys, filters, and permissions.
 type QuerySet[Resource Resourcer] struct {
 	keys                   *fieldSet
+	keySets                []KeySet
 	fields                 []accesstypes.Field
 	sortFields             []SortField
 	limit                  *uint64
@@ -34,6 +37,13 @@ type QuerySet[Resource Resourcer] struct {
 	requiredPermission     accesstypes.Permission
 	filterAst              ExpressionNode
 	filterParser           func(DBType) (ExpressionNode, error)
+	rowFilter              ExpressionNode
+	parentKey              KeySet
+	keyRange               *KeyRange
+	masks                  map[accesstypes.Field]MaskStrategy
+	search                 string
+	searchFields           []accesstypes.Field
+	searchColumns          []string
 }
 
 // NewQuerySet creates a new, empty QuerySet for a given resource metadata.
@@ -97,6 +107,102 @@ func (q *QuerySet[Resource]) EnableUserPermissionEnforcement(rSet *Set[Resource]
 	return q
 }
 
+// applyRowPolicy resolves the resource's row policy for the enforced user. It is a no-op
+// when permission enforcement is disabled or the resource has no row policy.
+func (q *QuerySet[Resource]) applyRowPolicy(ctx context.Context) error {
+	if q.userPermissions == nil {
+		return nil
+	}
+
+	var r Resource
+	policier, ok := any(r).(rowPolicier)
+	if !ok {
+		return nil
+	}
+
+	rowFilter, err := policier.RowPolicy(ctx, q.userPermissions)
+	if err != nil {
+		return errors.Wrapf(err, "%s.RowPolicy()", q.Resource())
+	}
+	q.rowFilter = rowFilter
+
+	return nil
+}
+
+// applyMasks resolves which masked fields the enforced user cannot reveal. Those fields are
+// masked in the results and can not be used to sort or filter, since ordering and matching
+// would disclose the values the mask hides. It is a no-op when permission enforcement is
+// disabled.
+func (q *QuerySet[Resource]) applyMasks(ctx context.Context, dbType DBType) error {
+	if q.resourceSet == nil {
+		return nil
+	}
+
+	masks := make(map[accesstypes.Field]MaskStrategy)
+	for field, m := range q.resourceSet.maskedFields {
+		if ok, _, err := q.userPermissions.Check(ctx, Reveal, q.resourceSet.Resource(field)); err != nil {
+			return errors.Wrap(err, "enforcer.RequireResource()")
+		} else if !ok {
+			masks[field] = m
+		}
+	}
+	if len(masks) == 0 {
+		return nil
+	}
+
+	for _, sf := range q.sortFields {
+		if _, ok := masks[accesstypes.Field(sf.Field)]; ok {
+			return httpio.NewForbiddenMessagef("domain (%s), user (%s) does not have (%s) on %s", q.userPermissions.Domain(), q.userPermissions.User(), Reveal, q.resourceSet.Resource(accesstypes.Field(sf.Field)))
+		}
+	}
+
+	filterAst := q.filterAst
+	if filterAst == nil && q.filterParser != nil {
+		var err error
+		filterAst, err = q.filterParser(dbType)
+		if err != nil {
+			return errors.Wrap(err, "filterParser()")
+		}
+	}
+
+	for field := range masks {
+		dbField, ok := q.rMeta.dbFieldMap(dbType)[field]
+		if ok && expressionReferences(filterAst, dbField.ColumnName) {
+			return httpio.NewForbiddenMessagef("domain (%s), user (%s) does not have (%s) on %s", q.userPermissions.Domain(), q.userPermissions.User(), Reveal, q.resourceSet.Resource(field))
+		}
+	}
+
+	q.masks = masks
+
+	return nil
+}
+
+// mask replaces the values of the fields the user can not reveal with their masked form.
+func (q *QuerySet[Resource]) mask(dst *Resource) {
+	if dst == nil {
+		return
+	}
+
+	v := reflect.ValueOf(dst)
+	for field, m := range q.masks {
+		maskField(v, field, m)
+	}
+}
+
+// expressionReferences reports whether any condition in the expression tree is on column.
+func expressionReferences(node ExpressionNode, column string) bool {
+	switch n := node.(type) {
+	case *ConditionNode:
+		return n.Condition.Field == column
+	case *LogicalOpNode:
+		return expressionReferences(n.Left, column) || expressionReferences(n.Right, column)
+	case *GroupNode:
+		return expressionReferences(n.Expression, column)
+	default:
+		return false
+	}
+}
+
 func (q *QuerySet[Resource]) checkPermissions(ctx context.Context, dbType DBType) error {
 	if q.resourceSet != nil {
 		if ok, missing, err := q.userPermissions.Check(ctx, q.requiredPermission, q.resourceSet.BaseResource()); err != nil {
@@ -208,30 +314,69 @@ func (q *QuerySet[Resource]) KeySet() KeySet {
 	return q.keys.KeySet()
 }
 
-// buildOrderByClause builds an ORDER BY clause from the QuerySet's sort fields.
+// buildOrderByClause builds an ORDER BY clause from the QuerySet's sort fields, or from the search
+// relevance when there are none. Every primary key field not already sorted on is appended in
+// ascending order, so rows with equal sort values keep the same order from one page to the next.
+// A paged query with neither is ordered by its primary key alone, for the same reason.
 func (q *QuerySet[Resource]) buildOrderByClause(dbType DBType) (string, error) {
-	orderByParts := make([]string, 0, len(q.sortFields))
+	orderByParts := make([]string, 0, len(q.sortFields)+len(q.rMeta.primaryKey))
+	sorted := make(map[accesstypes.Field]struct{}, len(q.sortFields))
 	for _, sf := range q.sortFields {
 		dbField, ok := q.rMeta.dbFieldMap(dbType)[accesstypes.Field(sf.Field)]
 		if !ok {
 			return "", errors.Newf("sort field '%s' not found in resource metadata for query", sf.Field)
 		}
+		if q.rMeta.IsEncrypted(accesstypes.Field(sf.Field)) {
+			return "", httpio.NewBadRequestMessagef("cannot sort on encrypted field: %s", sf.Field)
+		}
 
-		var quotedColumnName string
-		switch dbType {
-		case SpannerDBType:
-			quotedColumnName = fmt.Sprintf("`%s`", dbField.ColumnName)
-		case PostgresDBType:
-			quotedColumnName = fmt.Sprintf(`"%s"`, dbField.ColumnName)
-		default:
-			return "", errors.Newf("unsupported dbType for sorting: %s", dbType)
+		quotedColumnName, err := quoteColumn(dbType, dbField.ColumnName)
+		if err != nil {
+			return "", errors.Wrap(err, "quoteColumn()")
 		}
 
 		directionSQL := "ASC"
 		if sf.Direction == SortDescending {
 			directionSQL = "DESC"
 		}
-		orderByParts = append(orderByParts, fmt.Sprintf("%s %s", quotedColumnName, directionSQL))
+
+		var nullsSQL string
+		switch sf.Nulls {
+		case "":
+		case SortNullsFirst:
+			nullsSQL = " NULLS FIRST"
+		case SortNullsLast:
+			nullsSQL = " NULLS LAST"
+		default:
+			return "", errors.Newf("invalid nulls ordering for sort field '%s': %s", sf.Field, sf.Nulls)
+		}
+		orderByParts = append(orderByParts, fmt.Sprintf("%s %s%s", quotedColumnName, directionSQL, nullsSQL))
+		sorted[accesstypes.Field(sf.Field)] = struct{}{}
+	}
+	if len(orderByParts) == 0 && q.search != "" {
+		if score := q.searchScore(dbType); score != "" {
+			orderByParts = append(orderByParts, score+" DESC")
+		}
+	}
+	// ReadMany ignores the limit and offset, so it isn't paged
+	paged := (q.limit != nil || q.offset != nil) && len(q.keySets) == 0
+	if len(orderByParts) == 0 && !paged {
+		return "", nil
+	}
+
+	for _, field := range q.rMeta.primaryKey {
+		if _, ok := sorted[field]; ok {
+			continue
+		}
+		dbField, ok := q.rMeta.dbFieldMap(dbType)[field]
+		if !ok {
+			return "", errors.Newf("primary key field '%s' not found in resource metadata for query", field)
+		}
+		quotedColumnName, err := quoteColumn(dbType, dbField.ColumnName)
+		if err != nil {
+			return "", errors.Wrap(err, "quoteColumn()")
+		}
+		orderByParts = append(orderByParts, quotedColumnName+" ASC")
 	}
 	if len(orderByParts) == 0 {
 		return "", nil
@@ -242,11 +387,28 @@ func (q *QuerySet[Resource]) buildOrderByClause(dbType DBType) (string, error) {
 
 // columns returns the database struct tags for the fields in databaseType that the user has access to view.
 func (q *QuerySet[Resource]) columns(dbType DBType) (Columns, error) {
+	columns, err := q.columnNames(dbType)
+	if err != nil {
+		return "", err
+	}
+
+	switch dbType {
+	case SpannerDBType:
+		return Columns(strings.Join(columns, ", ")), nil
+	case PostgresDBType:
+		return Columns(fmt.Sprintf(`"%s"`, strings.Join(columns, `", "`))), nil
+	default:
+		return "", errors.Newf("unsupported dbType: %s", dbType)
+	}
+}
+
+// columnNames returns the column names of the query's fields, in struct order.
+func (q *QuerySet[Resource]) columnNames(dbType DBType) ([]string, error) {
 	dbFields := make([]dbFieldMetadata, 0, q.Len())
 	for _, field := range q.Fields() {
 		dbField, ok := q.rMeta.dbFieldMap(dbType)[field]
 		if !ok {
-			return "", errors.Newf("field %s not found in db struct", field)
+			return nil, errors.Newf("field %s not found in db struct", field)
 		}
 
 		dbFields = append(dbFields, dbField)
@@ -260,14 +422,7 @@ func (q *QuerySet[Resource]) columns(dbType DBType) (Columns, error) {
 		columns = append(columns, dbField.ColumnName)
 	}
 
-	switch dbType {
-	case SpannerDBType:
-		return Columns(strings.Join(columns, ", ")), nil
-	case PostgresDBType:
-		return Columns(fmt.Sprintf(`"%s"`, strings.Join(columns, `", "`))), nil
-	default:
-		return "", errors.Newf("unsupported dbType: %s", dbType)
-	}
+	return columns, nil
 }
 
 func (q *QuerySet[Resource]) astWhereClause(dbType DBType, filterAst ExpressionNode) (*Statement, error) {
@@ -293,12 +448,24 @@ func (q *QuerySet[Resource]) astWhereClause(dbType DBType, filterAst ExpressionN
 
 // where translates the the fields to database struct tags in databaseType when building the where clause
 func (q *QuerySet[Resource]) where(dbType DBType, filterAst ExpressionNode) (*Statement, error) {
+	if q.keyRange != nil {
+		return q.keyRangeWhere(dbType, filterAst)
+	}
+
 	if filterAst != nil {
-		return q.astWhereClause(dbType, filterAst)
+		return q.astWhereClause(dbType, andExpression(filterAst, q.rowFilter))
+	}
+
+	if len(q.keySets) > 0 {
+		return q.keySetsWhere(dbType)
 	}
 
 	parts := q.KeySet().Parts()
 	if len(parts) == 0 {
+		if q.rowFilter != nil {
+			return q.astWhereClause(dbType, q.rowFilter)
+		}
+
 		return &Statement{Params: map[string]any{}}, nil
 	}
 
@@ -309,6 +476,9 @@ func (q *QuerySet[Resource]) where(dbType DBType, filterAst ExpressionNode) (*St
 		if !ok {
 			return nil, errors.Newf("field %s not found in struct", part.Key)
 		}
+		if q.rMeta.IsEncrypted(part.Key) {
+			return nil, errors.Newf("cannot use encrypted field %s as a key", part.Key)
+		}
 		switch dbType {
 		case SpannerDBType:
 			fmt.Fprintf(&builder, " AND `%s` = @_%s", f.ColumnName, strings.ToLower(f.ColumnName))
@@ -320,12 +490,235 @@ func (q *QuerySet[Resource]) where(dbType DBType, filterAst ExpressionNode) (*St
 		params["_"+strings.ToLower(f.ColumnName)] = part.Value
 	}
 
-	return &Statement{
+	where := &Statement{
 		SQL:    "WHERE " + builder.String()[5:],
 		Params: params,
+	}
+
+	if q.rowFilter != nil {
+		return q.andRowFilter(dbType, where)
+	}
+
+	return where, nil
+}
+
+// keySetsWhere builds the WHERE clause matching any of the key sets passed to ReadMany: an IN
+// list for a single column key, or one parenthesized condition per key for a compound key.
+func (q *QuerySet[Resource]) keySetsWhere(dbType DBType) (*Statement, error) {
+	params := make(map[string]any)
+	conditions := make([]string, 0, len(q.keySets))
+	names := make([]string, 0, len(q.keySets))
+	columns := make(map[string]struct{})
+	for i, keySet := range q.keySets {
+		if keySet.Len() == 0 {
+			return nil, errors.New("ReadMany() key set has no parts")
+		}
+
+		parts := make([]string, 0, keySet.Len())
+		for _, part := range keySet.Parts() {
+			f, ok := q.rMeta.dbFieldMap(dbType)[part.Key]
+			if !ok {
+				return nil, errors.Newf("field %s not found in struct", part.Key)
+			}
+			if q.rMeta.IsEncrypted(part.Key) {
+				return nil, errors.Newf("cannot use encrypted field %s as a key", part.Key)
+			}
+
+			var column string
+			switch dbType {
+			case SpannerDBType:
+				column = fmt.Sprintf("`%s`", f.ColumnName)
+			case PostgresDBType:
+				column = fmt.Sprintf(`"%s"`, f.ColumnName)
+			default:
+				return nil, errors.Newf("unsupported dbType: %s", dbType)
+			}
+
+			name := fmt.Sprintf("_%s_%d", strings.ToLower(f.ColumnName), i)
+			params[name] = part.Value
+			parts = append(parts, fmt.Sprintf("%s = @%s", column, name))
+			names = append(names, "@"+name)
+			columns[column] = struct{}{}
+		}
+		conditions = append(conditions, strings.Join(parts, " AND "))
+	}
+
+	var sql string
+	if len(columns) == 1 && len(names) == len(q.keySets) {
+		sql = fmt.Sprintf("%s IN (%s)", slices.Collect(maps.Keys(columns))[0], strings.Join(names, ", "))
+	} else {
+		sql = "(" + strings.Join(conditions, ") OR (") + ")"
+	}
+
+	where := &Statement{
+		SQL:    fmt.Sprintf("WHERE (%s)", sql),
+		Params: params,
+	}
+
+	if q.rowFilter != nil {
+		return q.andRowFilter(dbType, where)
+	}
+
+	return where, nil
+}
+
+// keyRangeWhere builds the WHERE clause of a KeyRange, ANDed with the filter and row policy.
+// Each bound compares the key fields it has lexicographically, which is how Spanner orders keys.
+func (q *QuerySet[Resource]) keyRangeWhere(dbType DBType, filterAst ExpressionNode) (*Statement, error) {
+	if q.KeySet().Len() != 0 || len(q.keySets) != 0 {
+		return nil, httpio.NewBadRequestMessage("cannot use a KeyRange together with a KeySet")
+	}
+
+	params := make(map[string]any)
+	var conditions []string
+	if q.keyRange.isPrefix() {
+		columns, names, err := q.keyRangeColumns(dbType, q.keyRange.Start, "_prefix", params)
+		if err != nil {
+			return nil, err
+		}
+		for i := range columns {
+			conditions = append(conditions, fmt.Sprintf("%s = @%s", columns[i], names[i]))
+		}
+	} else {
+		if q.keyRange.Start.Len() > 0 {
+			columns, names, err := q.keyRangeColumns(dbType, q.keyRange.Start, "_start", params)
+			if err != nil {
+				return nil, err
+			}
+			conditions = append(conditions, keyBound(columns, names, ">", q.keyRange.startInclusive()))
+		}
+		if q.keyRange.End.Len() > 0 {
+			columns, names, err := q.keyRangeColumns(dbType, q.keyRange.End, "_end", params)
+			if err != nil {
+				return nil, err
+			}
+			conditions = append(conditions, keyBound(columns, names, "<", q.keyRange.endInclusive()))
+		}
+	}
+
+	policy := q.rowFilter
+	if filterAst != nil {
+		policy = andExpression(filterAst, q.rowFilter)
+	}
+	if policy != nil {
+		ast, err := q.astWhereClause(dbType, policy)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range ast.Params {
+			if _, ok := params[k]; ok {
+				return nil, errors.Newf("named parameter collision: %s filter and key range both contain named parameter %q", q.Resource(), k)
+			}
+			params[k] = v
+		}
+		conditions = append(conditions, "("+strings.TrimPrefix(ast.SQL, "WHERE ")+")")
+	}
+
+	if len(conditions) == 0 {
+		return &Statement{Params: params}, nil
+	}
+
+	return &Statement{
+		SQL:    "WHERE " + strings.Join(conditions, " AND "),
+		Params: params,
 	}, nil
 }
 
+// keyRangeColumns returns the quoted columns of a KeyRange bound and the names of the params it
+// adds for their values. The bound must be a leading run of the primary key fields in key order.
+func (q *QuerySet[Resource]) keyRangeColumns(dbType DBType, bound KeySet, prefix string, params map[string]any) (columns, names []string, err error) {
+	if len(q.rMeta.primaryKey) == 0 {
+		return nil, nil, errors.Newf("a KeyRange on %s requires its PrimaryKeyFields", q.Resource())
+	}
+	if bound.Len() > len(q.rMeta.primaryKey) {
+		return nil, nil, httpio.NewBadRequestMessagef("KeyRange bound has %d parts, %s has %d primary key fields", bound.Len(), q.Resource(), len(q.rMeta.primaryKey))
+	}
+
+	for i, part := range bound.Parts() {
+		if part.Key != q.rMeta.primaryKey[i] {
+			return nil, nil, httpio.NewBadRequestMessagef("KeyRange bound part %d is %s, want primary key field %s", i+1, part.Key, q.rMeta.primaryKey[i])
+		}
+		f, ok := q.rMeta.dbFieldMap(dbType)[part.Key]
+		if !ok {
+			return nil, nil, errors.Newf("field %s not found in struct", part.Key)
+		}
+		if q.rMeta.IsEncrypted(part.Key) {
+			return nil, nil, errors.Newf("cannot use encrypted field %s as a key", part.Key)
+		}
+
+		switch dbType {
+		case SpannerDBType:
+			columns = append(columns, fmt.Sprintf("`%s`", f.ColumnName))
+		case PostgresDBType:
+			columns = append(columns, fmt.Sprintf(`"%s"`, f.ColumnName))
+		default:
+			return nil, nil, errors.Newf("unsupported dbType: %s", dbType)
+		}
+
+		name := fmt.Sprintf("%s_%s", prefix, strings.ToLower(f.ColumnName))
+		params[name] = part.Value
+		names = append(names, name)
+	}
+
+	return columns, names, nil
+}
+
+// keyBound compares columns lexicographically with the named params using op (> or <),
+// allowing equality on the last column when inclusive.
+func keyBound(columns, names []string, op string, inclusive bool) string {
+	alternatives := make([]string, 0, len(columns))
+	for i := range columns {
+		terms := make([]string, 0, i+1)
+		for j := range i {
+			terms = append(terms, fmt.Sprintf("%s = @%s", columns[j], names[j]))
+		}
+		cmpOp := op
+		if inclusive && i == len(columns)-1 {
+			cmpOp += "="
+		}
+		terms = append(terms, fmt.Sprintf("%s %s @%s", columns[i], cmpOp, names[i]))
+		alternatives = append(alternatives, strings.Join(terms, " AND "))
+	}
+
+	if len(alternatives) == 1 {
+		return alternatives[0]
+	}
+
+	return "((" + strings.Join(alternatives, ") OR (") + "))"
+}
+
+// andRowFilter appends the row policy to a primary key WHERE clause.
+func (q *QuerySet[Resource]) andRowFilter(dbType DBType, where *Statement) (*Statement, error) {
+	policy, err := q.astWhereClause(dbType, q.rowFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := range policy.Params {
+		if _, ok := where.Params[k]; ok {
+			return nil, errors.Newf("named parameter collision: %s row policy and where clause both contain named parameter %q", q.Resource(), k)
+		}
+		where.Params[k] = v
+	}
+
+	where.SQL = fmt.Sprintf("%s AND (%s)", where.SQL, strings.TrimPrefix(policy.SQL, "WHERE "))
+
+	return where, nil
+}
+
+// andExpression combines two expressions with a logical AND. A nil right-hand side returns left unchanged.
+func andExpression(left, right ExpressionNode) ExpressionNode {
+	if right == nil {
+		return left
+	}
+
+	return &LogicalOpNode{
+		Left:     &GroupNode{Expression: left},
+		Operator: OperatorAnd,
+		Right:    &GroupNode{Expression: right},
+	}
+}
+
 // stmt builds a SQL statement for the given database type from the QuerySet.
 func (q *QuerySet[Resource]) stmt(dbType DBType) (*Statement, error) {
 	filterAst, err := q.FilterAst(dbType)
@@ -333,10 +726,17 @@ func (q *QuerySet[Resource]) stmt(dbType DBType) (*Statement, error) {
 		return nil, errors.Wrap(err, "QuerySet.FilterAst()")
 	}
 
-	if moreThan(1, q.KeySet().Len() != 0, filterAst != nil) {
+	if moreThan(1, q.KeySet().Len() != 0, len(q.keySets) != 0, filterAst != nil) {
 		return nil, httpio.NewBadRequestMessage("cannot use multiple sources for WHERE clause together (e.g. QueryClause and KeySet)")
 	}
 
+	for field := range q.rMeta.encryptedFields {
+		dbField, ok := q.rMeta.dbFieldMap(dbType)[field]
+		if ok && (expressionReferences(filterAst, dbField.ColumnName) || expressionReferences(q.rowFilter, dbField.ColumnName)) {
+			return nil, httpio.NewBadRequestMessagef("cannot filter on encrypted field: %s", field)
+		}
+	}
+
 	columns, err := q.columns(dbType)
 	if err != nil {
 		return nil, errors.Wrap(err, "QuerySet.Columns()")
@@ -347,18 +747,26 @@ func (q *QuerySet[Resource]) stmt(dbType DBType) (*Statement, error) {
 		return nil, errors.Wrap(err, "patcher.Where()")
 	}
 
+	if q.search != "" {
+		where, err = q.andSearch(dbType, where)
+		if err != nil {
+			return nil, errors.Wrap(err, "QuerySet.andSearch()")
+		}
+	}
+
 	orderByClause, err := q.buildOrderByClause(dbType)
 	if err != nil {
 		return nil, errors.Wrap(err, "QuerySet.buildOrderByClause()")
 	}
 
+	// ReadMany returns every matched key, so it ignores the limit and offset
 	var limitClause string
-	if q.limit != nil {
+	if q.limit != nil && len(q.keySets) == 0 {
 		limitClause = fmt.Sprintf("LIMIT %d", *q.limit)
 	}
 
 	var offsetClause string
-	if q.offset != nil {
+	if q.offset != nil && len(q.keySets) == 0 {
 		offsetClause = fmt.Sprintf("OFFSET %d", *q.offset)
 	}
 
@@ -387,7 +795,122 @@ func (q *QuerySet[Resource]) stmt(dbType DBType) (*Statement, error) {
 		return nil, errors.Wrap(err, "failed to substitute SQL params for resolvedWhereClause")
 	}
 
-	return &Statement{resolvedWhereClause: resolvedSQL, SQL: sql, Params: where.Params}, nil
+	stmt := &Statement{resolvedWhereClause: resolvedSQL, SQL: sql, Params: where.Params}
+	switch {
+	case q.isKeyRead(dbType, filterAst):
+		columnNames, err := q.columnNames(dbType)
+		if err != nil {
+			return nil, errors.Wrap(err, "QuerySet.columnNames()")
+		}
+		stmt.keyRead = &keyRead{table: string(q.Resource()), key: q.primaryKey().spannerKey(), columns: columnNames}
+	case q.isKeySetsRead(dbType, filterAst):
+		columnNames, err := q.columnNames(dbType)
+		if err != nil {
+			return nil, errors.Wrap(err, "QuerySet.columnNames()")
+		}
+		keys := make([]spanner.Key, 0, len(q.keySets))
+		for _, keySet := range q.keySets {
+			keys = append(keys, q.primaryKeyOf(keySet).spannerKey())
+		}
+		stmt.keyRead = &keyRead{table: string(q.Resource()), keys: keys, columns: columnNames}
+	case q.isKeyRangeRead(dbType, filterAst):
+		columnNames, err := q.columnNames(dbType)
+		if err != nil {
+			return nil, errors.Wrap(err, "QuerySet.columnNames()")
+		}
+		keyRange := q.keyRange.spannerKeyRange()
+		stmt.keyRead = &keyRead{table: string(q.Resource()), keyRange: &keyRange, columns: columnNames}
+		if q.limit != nil {
+			stmt.keyRead.limit = int(*q.limit)
+		}
+	}
+
+	return stmt, nil
+}
+
+// isKeyRead reports whether the query reads a single table row by its full primary key, and no
+// other clause, so it can be served by the Spanner Read API instead of a SQL query.
+func (q *QuerySet[Resource]) isKeyRead(dbType DBType, filterAst ExpressionNode) bool {
+	if dbType != SpannerDBType || filterAst != nil || q.rowFilter != nil || len(q.keySets) != 0 || q.search != "" {
+		return false
+	}
+	if q.offset != nil && *q.offset != 0 {
+		return false
+	}
+	if _, ok := any(*new(Resource)).(virtualQuerier); ok {
+		return false
+	}
+
+	return q.isPrimaryKey(q.KeySet())
+}
+
+// isKeySetsRead reports whether the query reads the table rows of the full primary keys passed to
+// ReadMany, and no other clause, so it can be served by the Spanner Read API instead of a SQL
+// query.
+func (q *QuerySet[Resource]) isKeySetsRead(dbType DBType, filterAst ExpressionNode) bool {
+	if dbType != SpannerDBType || len(q.keySets) == 0 || filterAst != nil || q.rowFilter != nil || q.search != "" {
+		return false
+	}
+	if _, ok := any(*new(Resource)).(virtualQuerier); ok {
+		return false
+	}
+
+	for _, keySet := range q.keySets {
+		if !q.isPrimaryKey(keySet) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isPrimaryKey reports whether keySet has exactly the resource's primary key fields.
+func (q *QuerySet[Resource]) isPrimaryKey(keySet KeySet) bool {
+	pk := q.rMeta.primaryKey
+	if len(pk) == 0 || keySet.Len() != len(pk) {
+		return false
+	}
+	keyMap := keySet.KeyMap()
+	for _, field := range pk {
+		if _, ok := keyMap[field]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isKeyRangeRead reports whether the query reads a key range of a table, in key order and with
+// no other clause, so it can be served by the Spanner Read API instead of a SQL query.
+func (q *QuerySet[Resource]) isKeyRangeRead(dbType DBType, filterAst ExpressionNode) bool {
+	if dbType != SpannerDBType || q.keyRange == nil || filterAst != nil || q.rowFilter != nil || len(q.sortFields) != 0 || q.search != "" {
+		return false
+	}
+	if q.offset != nil && *q.offset != 0 {
+		return false
+	}
+	if _, ok := any(*new(Resource)).(virtualQuerier); ok {
+		return false
+	}
+
+	return true
+}
+
+// primaryKey returns the query's KeySet with its parts in primary key order.
+func (q *QuerySet[Resource]) primaryKey() KeySet {
+	return q.primaryKeyOf(q.KeySet())
+}
+
+// primaryKeyOf returns keySet with its parts in primary key order.
+func (q *QuerySet[Resource]) primaryKeyOf(keySet KeySet) KeySet {
+	keyMap := keySet.KeyMap()
+
+	var pk KeySet
+	for _, field := range q.rMeta.primaryKey {
+		pk = pk.Add(field, keyMap[field])
+	}
+
+	return pk
 }
 
 // Read executes the query and returns a single result.
@@ -397,6 +920,18 @@ func (q *QuerySet[Resource]) Read(ctx context.Context, txn ReadOnlyTransaction)
 		return nil, err
 	}
 
+	if err := q.applyRowPolicy(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := q.applyMasks(ctx, r.DBType()); err != nil {
+		return nil, err
+	}
+
+	if err := q.applySearch(ctx); err != nil {
+		return nil, err
+	}
+
 	stmt, err := q.stmt(r.DBType())
 	if err != nil {
 		return nil, errors.Wrap(err, "patcher.Stmt()")
@@ -407,9 +942,89 @@ func (q *QuerySet[Resource]) Read(ctx context.Context, txn ReadOnlyTransaction)
 		return nil, errors.Wrapf(err, "Reader[%s].Read()", q.Resource())
 	}
 
+	if err := decryptFields(ctx, txn, q.rMeta.encryptedFields, dst); err != nil {
+		return nil, errors.Wrap(err, "decryptFields()")
+	}
+	q.mask(dst)
+
 	return dst, nil
 }
 
+// ReadMany executes the query for several primary keys in a single statement and returns the
+// rows found, in the order of keys. On Spanner, full primary keys without a filter, row policy or
+// search are read with the Read API. Keys without a row are skipped, and duplicate keys return
+// their row once. The key fields are always returned, since the caller supplied their values.
+func (q *QuerySet[Resource]) ReadMany(ctx context.Context, txn ReadOnlyTransaction, keys []KeySet) ([]*Resource, error) {
+	if len(keys) == 0 {
+		return []*Resource{}, nil
+	}
+
+	r := newReader[Resource](txn)
+	if err := q.checkPermissions(ctx, r.DBType()); err != nil {
+		return nil, err
+	}
+
+	if err := q.applyRowPolicy(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := q.applyMasks(ctx, r.DBType()); err != nil {
+		return nil, err
+	}
+
+	if err := q.applySearch(ctx); err != nil {
+		return nil, err
+	}
+
+	for _, keySet := range keys {
+		for _, field := range keySet.keys() {
+			q.AddField(field)
+		}
+	}
+	q.keySets = keys
+
+	stmt, err := q.stmt(r.DBType())
+	if err != nil {
+		return nil, errors.Wrap(err, "patcher.Stmt()")
+	}
+
+	rows := make(map[string]*Resource, len(keys))
+	for dst, err := range r.List(ctx, stmt) {
+		if err != nil {
+			return nil, errors.Wrapf(err, "Reader[%s].List()", q.Resource())
+		}
+
+		if err := decryptFields(ctx, txn, q.rMeta.encryptedFields, dst); err != nil {
+			return nil, errors.Wrap(err, "decryptFields()")
+		}
+		q.mask(dst)
+
+		rows[rowKeySet(dst, keys[0]).RowID()] = dst
+	}
+
+	results := make([]*Resource, 0, len(rows))
+	for _, keySet := range keys {
+		if dst, ok := rows[keySet.RowID()]; ok {
+			results = append(results, dst)
+			delete(rows, keySet.RowID())
+		}
+	}
+
+	return results, nil
+}
+
+// rowKeySet returns the KeySet of the row dst with the key fields of keySet.
+func rowKeySet[Resource any](dst *Resource, keySet KeySet) KeySet {
+	v := reflect.ValueOf(dst).Elem()
+
+	var rowKeys KeySet
+	for _, field := range keySet.keys() {
+		rowKeys = rowKeys.Add(field, v.FieldByName(string(field)).Interface())
+	}
+
+	return rowKeys
+}
+
 // List executes the query and returns an iterator for the results.
 func (q *QuerySet[Resource]) List(ctx context.Context, txn ReadOnlyTransaction) iter.Seq2[*Resource, error] {
 	return func(yield func(*Resource, error) bool) {
@@ -420,6 +1035,30 @@ func (q *QuerySet[Resource]) List(ctx context.Context, txn ReadOnlyTransaction)
 			return
 		}
 
+		if err := q.applyQueryPolicy(); err != nil {
+			yield(nil, err)
+
+			return
+		}
+
+		if err := q.applyRowPolicy(ctx); err != nil {
+			yield(nil, err)
+
+			return
+		}
+
+		if err := q.applyMasks(ctx, r.DBType()); err != nil {
+			yield(nil, err)
+
+			return
+		}
+
+		if err := q.applySearch(ctx); err != nil {
+			yield(nil, err)
+
+			return
+		}
+
 		stmt, err := q.stmt(r.DBType())
 		if err != nil {
 			yield(nil, errors.Wrap(err, "patcher.Stmt()"))
@@ -427,7 +1066,20 @@ func (q *QuerySet[Resource]) List(ctx context.Context, txn ReadOnlyTransaction)
 			return
 		}
 
-		for r, err := range r.List(ctx, stmt) {
+		queryCtx, cancel := q.withQueryTimeout(ctx)
+		defer cancel()
+
+		for r, err := range r.List(queryCtx, stmt) {
+			if err != nil {
+				err = q.queryTimeoutError(ctx, queryCtx, err)
+			} else {
+				if err = decryptFields(ctx, txn, q.rMeta.encryptedFields, r); err != nil {
+					yield(nil, errors.Wrap(err, "decryptFields()"))
+
+					return
+				}
+				q.mask(r)
+			}
 			if !yield(r, err) {
 				return
 			}
@@ -450,18 +1102,66 @@ func (q *QuerySet[Resource]) SetFilterAst(ast ExpressionNode) {
 	q.filterAst = ast
 }
 
-// FilterAst returns the filter AST for the query.
+// FilterAst returns the filter AST for the query, scoped to the parent key when one is set.
 func (q *QuerySet[Resource]) FilterAst(dbType DBType) (ExpressionNode, error) {
-	if q.filterAst == nil && q.filterParser != nil {
-		filterAst, err := q.filterParser(dbType)
+	filterAst := q.filterAst
+	if filterAst == nil && q.filterParser != nil {
+		var err error
+		filterAst, err = q.filterParser(dbType)
 		if err != nil {
 			return nil, errors.Wrap(err, "filterParser()")
 		}
+	}
+
+	parentFilter, err := q.parentKeyFilter(dbType)
+	if err != nil {
+		return nil, err
+	}
+	if filterAst == nil {
+		return parentFilter, nil
+	}
+
+	return andExpression(filterAst, parentFilter), nil
+}
+
+// SetParentKey scopes the query to the rows of an interleaved child table under a single parent row.
+// The parent key is ANDed with any filter, so it can not be widened by the request.
+func (q *QuerySet[Resource]) SetParentKey(field accesstypes.Field, value any) {
+	q.parentKey = q.parentKey.Add(field, value)
+}
 
-		return filterAst, nil
+// SetKeyRange restricts the query to the rows whose primary keys are in keyRange. It is ANDed
+// with any filter, and can not be combined with a KeySet.
+func (q *QuerySet[Resource]) SetKeyRange(keyRange KeyRange) {
+	q.keyRange = &keyRange
+}
+
+// SetKeyPrefix restricts the query to the rows whose primary keys begin with prefix.
+func (q *QuerySet[Resource]) SetKeyPrefix(prefix KeySet) {
+	q.SetKeyRange(prefix.AsPrefix())
+}
+
+// parentKeyFilter builds an equality condition for each part of the parent key.
+func (q *QuerySet[Resource]) parentKeyFilter(dbType DBType) (ExpressionNode, error) {
+	var node ExpressionNode
+	for _, part := range q.parentKey.Parts() {
+		f, ok := q.rMeta.dbFieldMap(dbType)[part.Key]
+		if !ok {
+			return nil, errors.Newf("parent key field %s not found in struct", part.Key)
+		}
+		if q.rMeta.IsEncrypted(part.Key) {
+			return nil, errors.Newf("cannot use encrypted field %s as a parent key", part.Key)
+		}
+
+		cond := &ConditionNode{Condition: Condition{Field: f.ColumnName, Operator: eqStr, Value: part.Value}}
+		if node == nil {
+			node = cond
+		} else {
+			node = &LogicalOpNode{Left: node, Operator: OperatorAnd, Right: cond}
+		}
 	}
 
-	return q.filterAst, nil
+	return node, nil
 }
 
 // SetFilterParser sets the filter parser.
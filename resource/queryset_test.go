@@ -1,9 +1,12 @@
 package resource
 
 import (
+	"context"
+	"iter"
 	"strings"
 	"testing"
 
+	"cloud.google.com/go/spanner"
 	"github.com/cccteam/ccc/accesstypes"
 	"github.com/cccteam/ccc/securehash"
 	"github.com/google/go-cmp/cmp"
@@ -76,6 +79,17 @@ func TestQuerySet_Stmt_OrderBy_Limit(t *testing.T) {
 			sortFields:        []SortField{{Field: "ID", Direction: SortDescending}},
 			wantQueryContains: "ORDER BY `Id` DESC",
 		},
+		{
+			name:              "nulls first and last",
+			sortFields:        []SortField{{Field: "Name", Direction: SortDescending, Nulls: SortNullsFirst}, {Field: "Date", Direction: SortAscending, Nulls: SortNullsLast}},
+			wantQueryContains: "ORDER BY `Name` DESC NULLS FIRST, `Date` ASC NULLS LAST",
+		},
+		{
+			name:                 "invalid nulls ordering",
+			sortFields:           []SortField{{Field: "Name", Direction: SortAscending, Nulls: "nullsmiddle"}},
+			wantErr:              true,
+			wantErrorMsgContains: "invalid nulls ordering",
+		},
 		{
 			name:                 "invalid sort field",
 			sortFields:           []SortField{{Field: "InvalidField", Direction: SortAscending}},
@@ -122,6 +136,439 @@ func TestQuerySet_Stmt_OrderBy_Limit(t *testing.T) {
 	}
 }
 
+func TestQuerySet_buildOrderByClause_keyTiebreaker(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		dbType     DBType
+		sortFields []SortField
+		limit      *uint64
+		offset     *uint64
+		want       string
+	}{
+		{
+			name:   "no sort fields, not paged",
+			dbType: SpannerDBType,
+			want:   "",
+		},
+		{
+			name:   "no sort fields, limit",
+			dbType: SpannerDBType,
+			limit:  new(uint64(50)),
+			want:   "ORDER BY `ShipId` ASC, `LineNumber` ASC",
+		},
+		{
+			name:   "no sort fields, offset",
+			dbType: PostgresDBType,
+			offset: new(uint64(50)),
+			want:   `ORDER BY "ShipId" ASC, "LineNumber" ASC`,
+		},
+		{
+			name:       "appends every key field",
+			dbType:     SpannerDBType,
+			sortFields: []SortField{{Field: "Details", Direction: SortDescending}},
+			want:       "ORDER BY `Details` DESC, `ShipId` ASC, `LineNumber` ASC",
+		},
+		{
+			name:       "skips key fields already sorted on",
+			dbType:     SpannerDBType,
+			sortFields: []SortField{{Field: "LineNumber", Direction: SortDescending}},
+			want:       "ORDER BY `LineNumber` DESC, `ShipId` ASC",
+		},
+		{
+			name:       "postgres",
+			dbType:     PostgresDBType,
+			sortFields: []SortField{{Field: "Details", Direction: SortAscending, Nulls: SortNullsFirst}},
+			want:       `ORDER BY "Details" ASC NULLS FIRST, "ShipId" ASC, "LineNumber" ASC`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			qSet := NewQuerySet(NewMetadata[keyReadTestResource]())
+			qSet.SetSortFields(tt.sortFields)
+			qSet.SetLimit(tt.limit)
+			qSet.SetOffset(tt.offset)
+
+			got, err := qSet.buildOrderByClause(tt.dbType)
+			if err != nil {
+				t.Fatalf("QuerySet.buildOrderByClause() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("QuerySet.buildOrderByClause() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuerySet_ReadMany(t *testing.T) {
+	t.Parallel()
+
+	keys := []KeySet{
+		KeySet{}.Add("ID", "3"),
+		KeySet{}.Add("ID", "1"),
+		KeySet{}.Add("ID", "404"),
+		KeySet{}.Add("ID", "3"),
+	}
+
+	ctrl := gomock.NewController(t)
+	reader := NewMockReader[SortTestResource](ctrl)
+	reader.EXPECT().DBType().MinTimes(1).Return(SpannerDBType)
+	reader.EXPECT().List(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, stmt *Statement) iter.Seq2[*SortTestResource, error] {
+		if want := "WHERE (`Id` IN (@_id_0, @_id_1, @_id_2, @_id_3))"; !strings.Contains(stmt.SQL, want) {
+			t.Errorf("Statement.SQL = %s, want to contain %q", stmt.SQL, want)
+		}
+		if strings.Contains(stmt.SQL, "LIMIT") {
+			t.Errorf("Statement.SQL = %s, want no LIMIT", stmt.SQL)
+		}
+
+		return MockIterSeq2(nil, &SortTestResource{ID: "1", Name: "Resource 1"}, &SortTestResource{ID: "3", Name: "Resource 3"})
+	})
+
+	qSet := NewQuerySet(NewMetadata[SortTestResource]())
+	qSet.AddField("Name")
+	qSet.SetLimit(new(uint64(1)))
+
+	got, err := qSet.ReadMany(t.Context(), NewMockClient(nil, []any{reader}, nil), keys)
+	if err != nil {
+		t.Fatalf("QuerySet.ReadMany() error = %v", err)
+	}
+
+	want := []*SortTestResource{{ID: "3", Name: "Resource 3"}, {ID: "1", Name: "Resource 1"}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("QuerySet.ReadMany() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestQuerySet_keySetsWhere_compoundKey(t *testing.T) {
+	t.Parallel()
+
+	qSet := NewQuerySet(NewMetadata[SortTestResource]())
+	qSet.keySets = []KeySet{
+		KeySet{}.Add("ID", "1").Add("Name", "a"),
+		KeySet{}.Add("ID", "2").Add("Name", "b"),
+	}
+
+	got, err := qSet.where(SpannerDBType, nil)
+	if err != nil {
+		t.Fatalf("QuerySet.where() error = %v", err)
+	}
+
+	want := &Statement{
+		SQL:    "WHERE ((`Id` = @_id_0 AND `Name` = @_name_0) OR (`Id` = @_id_1 AND `Name` = @_name_1))",
+		Params: map[string]any{"_id_0": "1", "_name_0": "a", "_id_1": "2", "_name_1": "b"},
+	}
+	if diff := cmp.Diff(want, got, cmp.AllowUnexported(Statement{})); diff != "" {
+		t.Errorf("QuerySet.where() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+type keyReadTestResource struct {
+	ShipID     string `spanner:"ShipId"     db:"ShipId"`
+	LineNumber int64  `spanner:"LineNumber" db:"LineNumber"`
+	Details    string `spanner:"Details"    db:"Details"`
+}
+
+func (keyReadTestResource) Resource() accesstypes.Resource {
+	return "CargoManifests"
+}
+
+func (keyReadTestResource) PrimaryKeyFields() []accesstypes.Field {
+	return []accesstypes.Field{"ShipID", "LineNumber"}
+}
+
+func TestQuerySet_SetParentKey(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		filter ExpressionNode
+		want   *Statement
+	}{
+		{
+			name: "parent key only",
+			want: &Statement{
+				SQL:    "WHERE `ShipId` = @_p1",
+				Params: map[string]any{"_p1": "ship-1"},
+			},
+		},
+		{
+			name:   "parent key with filter",
+			filter: &ConditionNode{Condition: Condition{Field: "Details", Operator: eqStr, Value: "fuel"}},
+			want: &Statement{
+				SQL:    "WHERE (`Details` = @_p1) AND (`ShipId` = @_p2)",
+				Params: map[string]any{"_p1": "fuel", "_p2": "ship-1"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			qSet := NewQuerySet(NewMetadata[keyReadTestResource]())
+			qSet.SetFilterAst(tt.filter)
+			qSet.SetParentKey("ShipID", "ship-1")
+
+			filterAst, err := qSet.FilterAst(SpannerDBType)
+			if err != nil {
+				t.Fatalf("QuerySet.FilterAst() error = %v", err)
+			}
+			got, err := qSet.where(SpannerDBType, filterAst)
+			if err != nil {
+				t.Fatalf("QuerySet.where() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got, cmp.AllowUnexported(Statement{})); diff != "" {
+				t.Errorf("QuerySet.where() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestQuerySet_keyRangeWhere(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		dbType   DBType
+		keyRange KeyRange
+		filter   ExpressionNode
+		want     *Statement
+		wantErr  string
+	}{
+		{
+			name:     "prefix",
+			dbType:   SpannerDBType,
+			keyRange: KeySet{}.Add("ShipID", "ship-1").AsPrefix(),
+			want: &Statement{
+				SQL:    "WHERE `ShipId` = @_prefix_shipid",
+				Params: map[string]any{"_prefix_shipid": "ship-1"},
+			},
+		},
+		{
+			name:     "prefix postgres",
+			dbType:   PostgresDBType,
+			keyRange: KeySet{}.Add("ShipID", "ship-1").AsPrefix(),
+			want: &Statement{
+				SQL:    `WHERE "ShipId" = @_prefix_shipid`,
+				Params: map[string]any{"_prefix_shipid": "ship-1"},
+			},
+		},
+		{
+			name:   "closed open range",
+			dbType: SpannerDBType,
+			keyRange: KeyRange{
+				Start: KeySet{}.Add("ShipID", "ship-1").Add("LineNumber", int64(2)),
+				End:   KeySet{}.Add("ShipID", "ship-1").Add("LineNumber", int64(5)),
+				Kind:  spanner.ClosedOpen,
+			},
+			want: &Statement{
+				SQL: "WHERE ((`ShipId` > @_start_shipid) OR (`ShipId` = @_start_shipid AND `LineNumber` >= @_start_linenumber))" +
+					" AND ((`ShipId` < @_end_shipid) OR (`ShipId` = @_end_shipid AND `LineNumber` < @_end_linenumber))",
+				Params: map[string]any{"_start_shipid": "ship-1", "_start_linenumber": int64(2), "_end_shipid": "ship-1", "_end_linenumber": int64(5)},
+			},
+		},
+		{
+			name:     "open start only",
+			dbType:   SpannerDBType,
+			keyRange: KeyRange{Start: KeySet{}.Add("ShipID", "ship-1"), Kind: spanner.OpenClosed},
+			want: &Statement{
+				SQL:    "WHERE `ShipId` > @_start_shipid",
+				Params: map[string]any{"_start_shipid": "ship-1"},
+			},
+		},
+		{
+			name:     "no start or end",
+			dbType:   SpannerDBType,
+			keyRange: KeyRange{Kind: spanner.ClosedOpen},
+			want:     &Statement{Params: map[string]any{}},
+		},
+		{
+			name:     "prefix with filter",
+			dbType:   SpannerDBType,
+			keyRange: KeySet{}.Add("ShipID", "ship-1").AsPrefix(),
+			filter:   &ConditionNode{Condition: Condition{Field: "Details", Operator: eqStr, Value: "fuel"}},
+			want: &Statement{
+				SQL:    "WHERE `ShipId` = @_prefix_shipid AND (`Details` = @_p1)",
+				Params: map[string]any{"_prefix_shipid": "ship-1", "_p1": "fuel"},
+			},
+		},
+		{
+			name:     "bound out of key order",
+			dbType:   SpannerDBType,
+			keyRange: KeySet{}.Add("LineNumber", int64(2)).AsPrefix(),
+			wantErr:  "want primary key field ShipID",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			qSet := NewQuerySet(NewMetadata[keyReadTestResource]())
+			qSet.SetKeyRange(tt.keyRange)
+
+			got, err := qSet.where(tt.dbType, tt.filter)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("QuerySet.where() error = %v, want %q", err, tt.wantErr)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("QuerySet.where() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got, cmp.AllowUnexported(Statement{})); diff != "" {
+				t.Errorf("QuerySet.where() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestQuerySet_Stmt_keyRead(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		dbType DBType
+		setup  func(q *QuerySet[keyReadTestResource])
+		want   *keyRead
+	}{
+		{
+			name:   "full primary key",
+			dbType: SpannerDBType,
+			setup: func(q *QuerySet[keyReadTestResource]) {
+				q.SetKey("LineNumber", int64(2))
+				q.SetKey("ShipID", "ship-1")
+			},
+			want: &keyRead{table: "CargoManifests", key: spanner.Key{"ship-1", int64(2)}, columns: []string{"LineNumber", "Details"}},
+		},
+		{
+			name:   "partial primary key",
+			dbType: SpannerDBType,
+			setup: func(q *QuerySet[keyReadTestResource]) {
+				q.SetKey("ShipID", "ship-1")
+			},
+		},
+		{
+			name:   "offset",
+			dbType: SpannerDBType,
+			setup: func(q *QuerySet[keyReadTestResource]) {
+				q.SetKey("ShipID", "ship-1")
+				q.SetKey("LineNumber", int64(2))
+				q.SetOffset(new(uint64(1)))
+			},
+		},
+		{
+			name:   "postgres",
+			dbType: PostgresDBType,
+			setup: func(q *QuerySet[keyReadTestResource]) {
+				q.SetKey("ShipID", "ship-1")
+				q.SetKey("LineNumber", int64(2))
+			},
+		},
+		{
+			name:   "key prefix",
+			dbType: SpannerDBType,
+			setup: func(q *QuerySet[keyReadTestResource]) {
+				q.SetKeyPrefix(KeySet{}.Add("ShipID", "ship-1"))
+				q.SetLimit(new(uint64(10)))
+			},
+			want: &keyRead{
+				table:    "CargoManifests",
+				keyRange: &spanner.KeyRange{Start: spanner.Key{"ship-1"}, End: spanner.Key{"ship-1"}, Kind: spanner.ClosedClosed},
+				limit:    10,
+				columns:  []string{"LineNumber", "Details"},
+			},
+		},
+		{
+			// An empty bound is unbounded for the Read API too, as it is for SQL, so the open
+			// empty end of the range is closed.
+			name:   "key range open empty start",
+			dbType: SpannerDBType,
+			setup: func(q *QuerySet[keyReadTestResource]) {
+				q.SetKeyRange(KeyRange{End: KeySet{}.Add("ShipID", "ship-5"), Kind: spanner.OpenOpen})
+			},
+			want: &keyRead{
+				table:    "CargoManifests",
+				keyRange: &spanner.KeyRange{Start: spanner.Key{}, End: spanner.Key{"ship-5"}, Kind: spanner.ClosedOpen},
+				columns:  []string{"LineNumber", "Details"},
+			},
+		},
+		{
+			name:   "key range no start or end",
+			dbType: SpannerDBType,
+			setup: func(q *QuerySet[keyReadTestResource]) {
+				q.SetKeyRange(KeyRange{Kind: spanner.ClosedOpen})
+			},
+			want: &keyRead{
+				table:    "CargoManifests",
+				keyRange: &spanner.KeyRange{Start: spanner.Key{}, End: spanner.Key{}, Kind: spanner.ClosedClosed},
+				columns:  []string{"LineNumber", "Details"},
+			},
+		},
+		{
+			name:   "read many",
+			dbType: SpannerDBType,
+			setup: func(q *QuerySet[keyReadTestResource]) {
+				q.keySets = []KeySet{
+					KeySet{}.Add("LineNumber", int64(2)).Add("ShipID", "ship-1"),
+					KeySet{}.Add("ShipID", "ship-2").Add("LineNumber", int64(1)),
+				}
+			},
+			want: &keyRead{
+				table:   "CargoManifests",
+				keys:    []spanner.Key{{"ship-1", int64(2)}, {"ship-2", int64(1)}},
+				columns: []string{"LineNumber", "Details"},
+			},
+		},
+		{
+			name:   "read many partial key",
+			dbType: SpannerDBType,
+			setup: func(q *QuerySet[keyReadTestResource]) {
+				q.keySets = []KeySet{KeySet{}.Add("ShipID", "ship-1")}
+			},
+		},
+		{
+			name:   "read many postgres",
+			dbType: PostgresDBType,
+			setup: func(q *QuerySet[keyReadTestResource]) {
+				q.keySets = []KeySet{KeySet{}.Add("ShipID", "ship-1").Add("LineNumber", int64(2))}
+			},
+		},
+		{
+			name:   "key prefix with sort",
+			dbType: SpannerDBType,
+			setup: func(q *QuerySet[keyReadTestResource]) {
+				q.SetKeyPrefix(KeySet{}.Add("ShipID", "ship-1"))
+				q.SetSortFields([]SortField{{Field: "Details"}})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			qSet := NewQuerySet(NewMetadata[keyReadTestResource]())
+			qSet.AddField("Details").AddField("LineNumber")
+			tt.setup(qSet)
+
+			stmt, err := qSet.stmt(tt.dbType)
+			if err != nil {
+				t.Fatalf("QuerySet.stmt() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, stmt.keyRead, cmp.AllowUnexported(keyRead{})); diff != "" {
+				t.Errorf("Statement.keyRead mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestQuerySet_BatchList(t *testing.T) {
 	t.Parallel()
 
@@ -35,6 +35,13 @@ type ReadWriteTransaction interface {
 	DataChangeEventIndex(res accesstypes.Resource, rowID string) int
 }
 
+// DMLExecutor is implemented by the ReadWriteTransactions that can run DML statements. UpdateWhere,
+// DeleteWhere and the RPC operation queue require it of their transaction.
+type DMLExecutor interface {
+	// ExecuteDML runs a DML statement in the transaction and returns the number of rows it modified
+	ExecuteDML(ctx context.Context, stmt *Statement) (int64, error)
+}
+
 // ReadOnlyTransaction is an interface that represents a database transaction that can be used for reads only.
 type ReadOnlyTransaction interface {
 	SpannerReadOnlyTransaction() spxapi.Querier
@@ -53,6 +60,12 @@ type Executor interface {
 	ExecuteFunc(ctx context.Context, f func(ctx context.Context, txn ReadWriteTransaction) error) error
 }
 
+// PartitionedExecutor is an interface for clients that run DML as Partitioned DML, outside of
+// a transaction.
+type PartitionedExecutor interface {
+	PartitionedExecuteDML(ctx context.Context, stmt *Statement) (int64, error)
+}
+
 // Reader is an interface that wraps methods for reading resources from a database.
 type Reader[Resource Resourcer] interface {
 	DBType() DBType
@@ -9,7 +9,10 @@ import (
 	"github.com/go-playground/errors/v5"
 )
 
-var _ Client = (*MockClient)(nil)
+var (
+	_ Client              = (*MockClient)(nil)
+	_ PartitionedExecutor = (*MockClient)(nil)
+)
 
 // MockClient is a wrapper around the database.
 type MockClient struct {
@@ -17,6 +20,7 @@ type MockClient struct {
 	readOnlyMocks []any
 	txnReadMocks  []any
 	txnMock       ReadWriteTransaction
+	keyProvider   KeyProvider
 }
 
 // NewMockClient creates a new MockClient for testing resource database interactions.
@@ -42,6 +46,18 @@ func NewMockClient(txnMock ReadWriteTransaction, readOnlyMocks, txnReadMocks []a
 func (c *MockClient) Close() {
 }
 
+// SetKeyProvider sets the KeyProvider used to encrypt and decrypt encrypted fields, and returns the client.
+func (c *MockClient) SetKeyProvider(kp KeyProvider) *MockClient {
+	c.keyProvider = kp
+
+	return c
+}
+
+// KeyProvider returns the KeyProvider set with SetKeyProvider.
+func (c *MockClient) KeyProvider() KeyProvider {
+	return c.keyProvider
+}
+
 // ReadOnlyMocks returns the read-only mocks for the Mock client.
 func (c *MockClient) ReadOnlyMocks() []any {
 	return c.readOnlyMocks
@@ -54,13 +70,28 @@ func (c *MockClient) SpannerReadOnlyTransaction() spxapi.Querier {
 
 // ExecuteFunc executes a function within a read-write transaction.
 func (c *MockClient) ExecuteFunc(ctx context.Context, f func(ctx context.Context, txn ReadWriteTransaction) error) error {
-	if err := f(ctx, NewMockReadWriteTransaction(c.txnMock, c.txnReadMocks...)); err != nil {
+	txn := &MockReadWriteTransaction{
+		txnReaderMocks: c.txnReadMocks,
+		txnMock:        c.txnMock,
+		keyProvider:    c.keyProvider,
+	}
+	if err := f(ctx, txn); err != nil {
 		return errors.Wrap(err, "f()")
 	}
 
 	return nil
 }
 
+// PartitionedExecuteDML runs a DML statement on the txnMock.
+func (c *MockClient) PartitionedExecuteDML(ctx context.Context, stmt *Statement) (int64, error) {
+	n, err := executeDML(ctx, c.txnMock, stmt)
+	if err != nil {
+		return 0, errors.Wrap(err, "executeDML()")
+	}
+
+	return n, nil
+}
+
 // ReadOnlyTransaction returns a ReadOnlyTransaction that can be used for multiple reads from the database.
 // You must call Close() when the ReadOnlyTransaction is no longer needed to release resources on the server.
 func (c *MockClient) ReadOnlyTransaction() ReadOnlyTransactionCloser {
@@ -72,12 +103,16 @@ func (c *MockClient) PostgresReadOnlyTransaction() any {
 	panic("MockClient.PostgresReadOnlyTransaction() should never be called.")
 }
 
-var _ ReadWriteTransaction = (*MockReadWriteTransaction)(nil)
+var (
+	_ ReadWriteTransaction = (*MockReadWriteTransaction)(nil)
+	_ DMLExecutor          = (*MockReadWriteTransaction)(nil)
+)
 
 // MockReadWriteTransaction represents a database transaction that can be used for both reads and writes.
 type MockReadWriteTransaction struct {
 	txnReaderMocks []any
 	txnMock        ReadWriteTransaction
+	keyProvider    KeyProvider
 }
 
 // NewMockReadWriteTransaction creates a new MockReadWriteTransaction.
@@ -88,6 +123,11 @@ func NewMockReadWriteTransaction(mock ReadWriteTransaction, txnReaderMocks ...an
 	}
 }
 
+// KeyProvider returns the KeyProvider of the MockClient the transaction was started on.
+func (c *MockReadWriteTransaction) KeyProvider() KeyProvider {
+	return c.keyProvider
+}
+
 // DBType returns the database type.
 func (c *MockReadWriteTransaction) DBType() DBType {
 	return c.txnMock.DBType()
@@ -126,6 +166,16 @@ func (c *MockReadWriteTransaction) BufferStruct(p PatchSetMetadata) error {
 	return nil
 }
 
+// ExecuteDML runs a DML statement on the txnMock, which must implement DMLExecutor.
+func (c *MockReadWriteTransaction) ExecuteDML(ctx context.Context, stmt *Statement) (int64, error) {
+	n, err := executeDML(ctx, c.txnMock, stmt)
+	if err != nil {
+		return 0, errors.Wrap(err, "executeDML()")
+	}
+
+	return n, nil
+}
+
 // PostgresReadOnlyTransaction panics because it is not implemented for the MockReadWriteTransaction.
 func (c *MockReadWriteTransaction) PostgresReadOnlyTransaction() any {
 	panic("MockReadWriteTransaction.PostgresReadOnlyTransaction() should never be called.")
@@ -7,6 +7,7 @@ import (
 
 	"github.com/cccteam/ccc/accesstypes"
 	"github.com/cccteam/spxscan/spxapi"
+	"github.com/go-playground/errors/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -143,6 +144,11 @@ func (c *PostgresReadWriteTransaction) PostgresReadOnlyTransaction() any {
 	panic("PostgresReadOnlyTransaction() not implemented for PostgresReadWriteTransaction")
 }
 
+// ExecuteDML returns an error because DML is not implemented for the PostgresReadWriteTransaction.
+func (c *PostgresReadWriteTransaction) ExecuteDML(_ context.Context, _ *Statement) (int64, error) {
+	return 0, errors.New("ExecuteDML() not implemented for PostgresReadWriteTransaction")
+}
+
 // BufferMap panics because it is not implemented for the PostgresReadWriteTransaction.
 func (c *PostgresReadWriteTransaction) BufferMap(_ PatchSetMetadata, _ map[string]any) error {
 	panic("BufferMap() not implemented for PostgresReadWriteTransaction")
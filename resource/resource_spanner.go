@@ -13,11 +13,15 @@ import (
 	"github.com/go-playground/errors/v5"
 )
 
-var _ Client = (*SpannerClient)(nil)
+var (
+	_ Client              = (*SpannerClient)(nil)
+	_ PartitionedExecutor = (*SpannerClient)(nil)
+)
 
 // SpannerClient is a wrapper around the database.
 type SpannerClient struct {
-	spanner *spanner.Client
+	spanner     *spanner.Client
+	keyProvider KeyProvider
 }
 
 // NewSpannerClient creates a new Client.
@@ -27,15 +31,44 @@ func NewSpannerClient(db *spanner.Client) *SpannerClient {
 	}
 }
 
+// SetKeyProvider sets the KeyProvider used to encrypt and decrypt the encrypted fields read and
+// written with the client and its transactions, and returns the client.
+func (c *SpannerClient) SetKeyProvider(kp KeyProvider) *SpannerClient {
+	c.keyProvider = kp
+
+	return c
+}
+
+// KeyProvider returns the KeyProvider set with SetKeyProvider.
+func (c *SpannerClient) KeyProvider() KeyProvider {
+	return c.keyProvider
+}
+
 // SpannerReadOnlyTransaction returns a read-only transaction for the Spanner client.
 func (c *SpannerClient) SpannerReadOnlyTransaction() spxapi.Querier {
 	return c.spanner.Single()
 }
 
+// PartitionedExecuteDML runs a DML statement as Partitioned DML and returns a lower bound of
+// the number of rows it modified.
+func (c *SpannerClient) PartitionedExecuteDML(ctx context.Context, stmt *Statement) (int64, error) {
+	n, err := c.spanner.PartitionedUpdate(ctx, stmt.SpannerStatement())
+	if err != nil {
+		return 0, errors.Wrap(err, "spanner.Client.PartitionedUpdate()")
+	}
+
+	return n, nil
+}
+
 // ExecuteFunc executes a function within a read-write transaction.
 func (c *SpannerClient) ExecuteFunc(ctx context.Context, f func(ctx context.Context, txn ReadWriteTransaction) error) error {
 	_, err := c.spanner.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
-		if err := f(ctx, NewSpannerReadWriteTransaction(txn)); err != nil {
+		rwTxn := &SpannerReadWriteTransaction{
+			txn:              txn,
+			resourceRowIndex: make(map[string]int),
+			keyProvider:      c.keyProvider,
+		}
+		if err := f(ctx, rwTxn); err != nil {
 			return errors.Wrap(err, "f()")
 		}
 
@@ -51,7 +84,7 @@ func (c *SpannerClient) ExecuteFunc(ctx context.Context, f func(ctx context.Cont
 // ReadOnlyTransaction returns a read-only transaction that can be used for multiple reads from the database.
 // You must call Close() when the ReadOnlyTransaction is no longer needed to release resources on the server.
 func (c *SpannerClient) ReadOnlyTransaction() ReadOnlyTransactionCloser {
-	return newSpannerReadOnlyTransaction(c.spanner)
+	return newSpannerReadOnlyTransaction(c.spanner, c.keyProvider)
 }
 
 // PostgresReadOnlyTransaction panics because it is not implemented for the SpannerClient.
@@ -71,11 +104,51 @@ func (c *spannerReader[Resource]) DBType() DBType {
 	return SpannerDBType
 }
 
-// Read reads a single resource from the database.
+// spannerKeyReader is implemented by the Spanner transactions behind spxapi.Querier.
+type spannerKeyReader interface {
+	ReadWithOptions(ctx context.Context, table string, keys spanner.KeySet, columns []string, opts *spanner.ReadOptions) *spanner.RowIterator
+}
+
+// keyReadQuerier serves a key read with the Spanner Read API, which skips query planning. It
+// is handed to spxscan in place of the transaction, so the rows are decoded the same way as
+// the rows of a query. The statement passed to Query is ignored.
+type keyReadQuerier struct {
+	txn     spannerKeyReader
+	keyRead *keyRead
+}
+
+func (q keyReadQuerier) Query(ctx context.Context, _ spanner.Statement) *spanner.RowIterator {
+	var keys spanner.KeySet = q.keyRead.key
+	switch {
+	case q.keyRead.keys != nil:
+		keys = spanner.KeySetFromKeys(q.keyRead.keys...)
+	case q.keyRead.keyRange != nil:
+		keys = *q.keyRead.keyRange
+	}
+
+	return q.txn.ReadWithOptions(ctx, q.keyRead.table, keys, q.keyRead.columns, &spanner.ReadOptions{Limit: q.keyRead.limit})
+}
+
+// querier returns the transaction stmt is run in, or a keyReadQuerier when stmt can be served
+// by the Read API: a Read by full primary key, or a List of a key range or of ReadMany keys.
+func (c *spannerReader[Resource]) querier(stmt *Statement, isList bool) spxapi.Querier {
+	txn := c.readTxn()
+	if stmt.keyRead == nil || stmt.keyRead.isList() != isList {
+		return txn
+	}
+	if reader, ok := txn.(spannerKeyReader); ok {
+		return keyReadQuerier{txn: reader, keyRead: stmt.keyRead}
+	}
+
+	return txn
+}
+
+// Read reads a single resource from the database. A statement reading by full primary key is
+// served by the Spanner Read API.
 func (c *spannerReader[Resource]) Read(ctx context.Context, stmt *Statement) (*Resource, error) {
 	var res Resource
 	dst := new(Resource)
-	if err := spxscan.Get(ctx, c.readTxn(), dst, stmt.SpannerStatement()); err != nil {
+	if err := spxscan.Get(ctx, c.querier(stmt, false), dst, stmt.SpannerStatement()); err != nil {
 		if errors.Is(err, spxapi.ErrNotFound) {
 			return nil, httpio.NewNotFoundMessagef("%s (%s) not found", res.Resource(), stmt.resolvedWhereClause)
 		}
@@ -86,10 +159,11 @@ func (c *spannerReader[Resource]) Read(ctx context.Context, stmt *Statement) (*R
 	return dst, nil
 }
 
-// List reads a list of resources from the database.
+// List reads a list of resources from the database. A statement reading a key range or the keys
+// of a ReadMany is served by the Spanner Read API.
 func (c *spannerReader[Resource]) List(ctx context.Context, stmt *Statement) iter.Seq2[*Resource, error] {
 	return func(yield func(*Resource, error) bool) {
-		for r, err := range spxscan.SelectSeq[Resource](ctx, c.readTxn(), stmt.SpannerStatement()) {
+		for r, err := range spxscan.SelectSeq[Resource](ctx, c.querier(stmt, true), stmt.SpannerStatement()) {
 			if err != nil {
 				yield(nil, errors.Wrap(err, "spxscan.SelectSeq()"))
 
@@ -108,16 +182,23 @@ var _ ReadOnlyTransactionCloser = (*SpannerReadOnlyTransaction)(nil)
 type SpannerReadOnlyTransaction struct {
 	txn              *spanner.ReadOnlyTransaction
 	resourceRowIndex map[string]int
+	keyProvider      KeyProvider
 }
 
 // newSpannerReadOnlyTransaction creates a new SpannerReadOnlyTransaction from a spanner.Client
-func newSpannerReadOnlyTransaction(client *spanner.Client) ReadOnlyTransactionCloser {
+func newSpannerReadOnlyTransaction(client *spanner.Client, kp KeyProvider) ReadOnlyTransactionCloser {
 	return &SpannerReadOnlyTransaction{
 		txn:              client.ReadOnlyTransaction(),
 		resourceRowIndex: make(map[string]int),
+		keyProvider:      kp,
 	}
 }
 
+// KeyProvider returns the KeyProvider of the client the transaction was started on.
+func (c *SpannerReadOnlyTransaction) KeyProvider() KeyProvider {
+	return c.keyProvider
+}
+
 // Close closes the readonly transaction
 func (c *SpannerReadOnlyTransaction) Close() {
 	c.txn.Close()
@@ -133,12 +214,16 @@ func (c *SpannerReadOnlyTransaction) PostgresReadOnlyTransaction() any {
 	panic("SpannerReadOnlyTransaction.PostgresReadOnlyTransaction() should never be called.")
 }
 
-var _ ReadWriteTransaction = (*SpannerReadWriteTransaction)(nil)
+var (
+	_ ReadWriteTransaction = (*SpannerReadWriteTransaction)(nil)
+	_ DMLExecutor          = (*SpannerReadWriteTransaction)(nil)
+)
 
 // SpannerReadWriteTransaction represents a database transaction that can be used for both reads and writes.
 type SpannerReadWriteTransaction struct {
 	txn              *spanner.ReadWriteTransaction
 	resourceRowIndex map[string]int
+	keyProvider      KeyProvider
 }
 
 // NewSpannerReadWriteTransaction creates a new SpannerReadWriteTransaction from a spanner.ReadWriteTransaction
@@ -149,6 +234,11 @@ func NewSpannerReadWriteTransaction(txn *spanner.ReadWriteTransaction) ReadWrite
 	}
 }
 
+// KeyProvider returns the KeyProvider of the client the transaction was started on.
+func (c *SpannerReadWriteTransaction) KeyProvider() KeyProvider {
+	return c.keyProvider
+}
+
 // DBType returns the database type.
 func (c *SpannerReadWriteTransaction) DBType() DBType {
 	return SpannerDBType
@@ -223,6 +313,16 @@ func (c *SpannerReadWriteTransaction) BufferStruct(patch PatchSetMetadata) error
 	return nil
 }
 
+// ExecuteDML runs a DML statement in the transaction and returns the number of rows it modified.
+func (c *SpannerReadWriteTransaction) ExecuteDML(ctx context.Context, stmt *Statement) (int64, error) {
+	n, err := c.txn.Update(ctx, stmt.SpannerStatement())
+	if err != nil {
+		return 0, errors.Wrap(err, "spanner.ReadWriteTransaction.Update()")
+	}
+
+	return n, nil
+}
+
 // PostgresReadOnlyTransaction panics because it is not implemented for the SpannerReadWriteTransaction.
 func (c *SpannerReadWriteTransaction) PostgresReadOnlyTransaction() any {
 	panic("SpannerReadWriteTransaction.PostgresReadOnlyTransaction() should never be called.")
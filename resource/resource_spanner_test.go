@@ -0,0 +1,110 @@
+package resource
+
+import (
+	"context"
+	"testing"
+
+	"cloud.google.com/go/spanner"
+	"github.com/cccteam/spxscan/spxapi"
+	"github.com/google/go-cmp/cmp"
+)
+
+// queryOnlyTxn is a transaction without the Spanner Read API.
+type queryOnlyTxn struct{}
+
+func (queryOnlyTxn) Query(context.Context, spanner.Statement) *spanner.RowIterator { return nil }
+
+// keyReadTxn records the Read API call it serves.
+type keyReadTxn struct {
+	queryOnlyTxn
+	table   string
+	keys    spanner.KeySet
+	columns []string
+	opts    *spanner.ReadOptions
+}
+
+func (t *keyReadTxn) ReadWithOptions(_ context.Context, table string, keys spanner.KeySet, columns []string, opts *spanner.ReadOptions) *spanner.RowIterator {
+	t.table, t.keys, t.columns, t.opts = table, keys, columns, opts
+
+	return nil
+}
+
+func Test_spannerReader_querier(t *testing.T) {
+	t.Parallel()
+
+	key := spanner.Key{"ship-1", int64(2)}
+	keyRange := &spanner.KeyRange{Start: spanner.Key{"ship-1"}, End: spanner.Key{"ship-1"}, Kind: spanner.ClosedClosed}
+	columns := []string{"ShipId", "LineNumber", "Details"}
+
+	tests := []struct {
+		name     string
+		txn      spxapi.Querier
+		keyRead  *keyRead
+		isList   bool
+		wantRead *keyReadTxn // nil when the statement is queried
+	}{
+		{
+			name: "query",
+			txn:  &keyReadTxn{},
+		},
+		{
+			name:     "read by key",
+			txn:      &keyReadTxn{},
+			keyRead:  &keyRead{table: "CargoManifests", key: key, columns: columns},
+			wantRead: &keyReadTxn{table: "CargoManifests", keys: key, columns: columns, opts: &spanner.ReadOptions{}},
+		},
+		{
+			name:     "list of a key range",
+			txn:      &keyReadTxn{},
+			keyRead:  &keyRead{table: "CargoManifests", keyRange: keyRange, limit: 5, columns: columns},
+			isList:   true,
+			wantRead: &keyReadTxn{table: "CargoManifests", keys: *keyRange, columns: columns, opts: &spanner.ReadOptions{Limit: 5}},
+		},
+		{
+			name:     "list of keys",
+			txn:      &keyReadTxn{},
+			keyRead:  &keyRead{table: "CargoManifests", keys: []spanner.Key{key, {"ship-1", int64(3)}}, columns: columns},
+			isList:   true,
+			wantRead: &keyReadTxn{table: "CargoManifests", keys: spanner.KeySetFromKeys(key, spanner.Key{"ship-1", int64(3)}), columns: columns, opts: &spanner.ReadOptions{}},
+		},
+		{
+			name:    "read of a key range is queried",
+			txn:     &keyReadTxn{},
+			keyRead: &keyRead{table: "CargoManifests", keyRange: keyRange, columns: columns},
+		},
+		{
+			name:    "list by key is queried",
+			txn:     &keyReadTxn{},
+			keyRead: &keyRead{table: "CargoManifests", key: key, columns: columns},
+			isList:  true,
+		},
+		{
+			name:    "transaction without the Read API",
+			txn:     queryOnlyTxn{},
+			keyRead: &keyRead{table: "CargoManifests", key: key, columns: columns},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			reader := &spannerReader[keyReadTestResource]{readTxn: func() spxapi.Querier { return tt.txn }}
+			got := reader.querier(&Statement{keyRead: tt.keyRead}, tt.isList)
+			if tt.wantRead == nil {
+				if got != tt.txn {
+					t.Fatalf("querier() = %T, want the transaction", got)
+				}
+
+				return
+			}
+
+			if _, ok := got.(keyReadQuerier); !ok {
+				t.Fatalf("querier() = %T, want keyReadQuerier", got)
+			}
+			got.Query(t.Context(), spanner.Statement{SQL: "SELECT 1"})
+			if diff := cmp.Diff(tt.wantRead, tt.txn, cmp.AllowUnexported(keyReadTxn{})); diff != "" {
+				t.Errorf("ReadWithOptions() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
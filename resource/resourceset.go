@@ -49,12 +49,19 @@ type virtualQuerier interface {
 	Subquery() (string, map[string]any)
 }
 
+// rowPolicier is an interface for types that restrict the rows a user can access. The returned
+// expression is ANDed into every permission-enforced read, and a nil expression places no restriction.
+type rowPolicier interface {
+	RowPolicy(ctx context.Context, userPermissions UserPermissions) (ExpressionNode, error)
+}
+
 // Set holds metadata about a resource, including its permissions and field-to-tag mappings.
 type Set[Resource Resourcer] struct {
 	permissions     []accesstypes.Permission
 	requiredTagPerm accesstypes.TagPermissions
 	fieldToTag      map[accesstypes.Field]accesstypes.Tag
 	immutableFields map[accesstypes.Tag]struct{}
+	maskedFields    map[accesstypes.Field]MaskStrategy
 	rMeta           *Metadata[Resource]
 }
 
@@ -65,11 +72,17 @@ func NewSet[Resource Resourcer, Request any](permissions ...accesstypes.Permissi
 		return nil, errors.Wrap(err, "permissionsFromTags()")
 	}
 
+	maskedFields, err := maskedFieldsFromTags(reflect.TypeFor[Request]())
+	if err != nil {
+		return nil, errors.Wrap(err, "maskedFieldsFromTags()")
+	}
+
 	return &Set[Resource]{
 		permissions:     permissions,
 		requiredTagPerm: requiredTagPerm,
 		fieldToTag:      fieldToTag,
 		immutableFields: immutableFields,
+		maskedFields:    maskedFields,
 		rMeta:           NewMetadata[Resource](),
 	}, nil
 }
@@ -91,6 +104,13 @@ func (r *Set[Resource]) ResourceMetadata() *Metadata[Resource] {
 	return r.rMeta
 }
 
+// MaskStrategy returns the mask strategy of a field, reporting false if the field is not masked.
+func (r *Set[Resource]) MaskStrategy(fieldName accesstypes.Field) (MaskStrategy, bool) {
+	m, ok := r.maskedFields[fieldName]
+
+	return m, ok
+}
+
 // PermissionRequired checks if a specific permission is required for a given field.
 func (r *Set[Resource]) PermissionRequired(fieldName accesstypes.Field, perm accesstypes.Permission) bool {
 	return slices.Contains(r.requiredTagPerm[r.fieldToTag[fieldName]], perm)
@@ -132,6 +152,26 @@ func permissionsFromTags(t reflect.Type, perms []accesstypes.Permission) (tags a
 	return permissionsFromFieldTags(fields, perms, false)
 }
 
+// maskedFieldsFromTags collects the mask strategy of every field in t with a mask tag.
+func maskedFieldsFromTags(t reflect.Type) (map[accesstypes.Field]MaskStrategy, error) {
+	maskedFields := make(map[accesstypes.Field]MaskStrategy)
+	for field := range t.Fields() {
+		tag, ok := field.Tag.Lookup(maskTagKey)
+		if !ok {
+			continue
+		}
+
+		m, err := ParseMaskStrategy(tag)
+		if err != nil {
+			return nil, errors.Wrapf(err, "field %s", field.Name)
+		}
+
+		maskedFields[accesstypes.Field(field.Name)] = m
+	}
+
+	return maskedFields, nil
+}
+
 // classifyPermission records a permission into the mutating or non-mutating set and the
 // overall permission set, skipping NullPermission.
 func classifyPermission(perm accesstypes.Permission, permissionMap, mutating, nonmutating map[accesstypes.Permission]struct{}) {
@@ -199,6 +239,16 @@ func permissionsFromFieldTags(fields []FieldTags, perms []accesstypes.Permission
 			permissionMap[permission] = struct{}{}
 			collected = true
 		}
+		if field.Mask != "" {
+			// Reveal is a field-level exemption from the mask, never a resource-level
+			// permission, so it stays out of the permission classification above
+			if jsonTag == "" || jsonTag == "-" {
+				return nil, nil, nil, nil, errors.Newf("can not mask the %s field when json tag is empty", field.Field)
+			}
+			tags[accesstypes.Tag(jsonTag)] = append(tags[accesstypes.Tag(jsonTag)], Reveal)
+			fieldToTag[field.Field] = accesstypes.Tag(jsonTag)
+			collected = true
+		}
 		if !collected && registerAll {
 			if jsonTag != "" && jsonTag != "-" {
 				tags[accesstypes.Tag(jsonTag)] = append(tags[accesstypes.Tag(jsonTag)], accesstypes.NullPermission)
@@ -226,6 +276,10 @@ type Metadata[Resource Resourcer] struct {
 	dbMap               map[DBType]map[accesstypes.Field]dbFieldMetadata
 	changeTrackingTable string
 	trackChanges        bool
+	encryptedFields     map[accesstypes.Field]struct{}
+	primaryKey          []accesstypes.Field
+	searchTokens        map[accesstypes.Field]string
+	queryPolicy         queryPolicy
 }
 
 // NewMetadata creates or retrieves cached metadata for a resource.
@@ -238,6 +292,16 @@ func NewMetadata[Resource Resourcer]() *Metadata[Resource] {
 		dbMap:               c.dbMap,
 		changeTrackingTable: c.cfg.ChangeTrackingTable,
 		trackChanges:        c.cfg.TrackChanges,
+		encryptedFields:     c.encryptedFields,
+		primaryKey:          c.primaryKey,
+		searchTokens:        c.searchTokens,
+		queryPolicy: queryPolicy{
+			maxLimit:             c.cfg.MaxLimit,
+			maxOffset:            c.cfg.MaxOffset,
+			timeout:              c.cfg.QueryTimeout,
+			requireIndexedFilter: c.cfg.RequireIndexedFilter,
+			requireIndexedSort:   c.cfg.RequireIndexedSort,
+		},
 	}
 }
 
@@ -256,13 +320,23 @@ func (r *Metadata[Resource]) DBFieldCount(dbType DBType) int {
 	return len(r.dbMap[dbType])
 }
 
+// IsEncrypted reports whether a field is encrypted before it is written to the database.
+func (r *Metadata[Resource]) IsEncrypted(field accesstypes.Field) bool {
+	_, ok := r.encryptedFields[field]
+
+	return ok
+}
+
 var resMetadataCache = resourceMetadataCache{
 	cache: make(map[reflect.Type]*resourceMetadataCacheEntry),
 }
 
 type resourceMetadataCacheEntry struct {
-	dbMap map[DBType]map[accesstypes.Field]dbFieldMetadata
-	cfg   Config
+	dbMap           map[DBType]map[accesstypes.Field]dbFieldMetadata
+	cfg             Config
+	encryptedFields map[accesstypes.Field]struct{}
+	primaryKey      []accesstypes.Field
+	searchTokens    map[accesstypes.Field]string
 }
 
 type resourceMetadataCache struct {
@@ -304,6 +378,23 @@ func (c *resourceMetadataCache) get(res Resourcer) *resourceMetadataCacheEntry {
 		cfg = t.DefaultConfig()
 	}
 
+	encryptedFields := make(map[accesstypes.Field]struct{})
+	if e, ok := res.(encryptedFielder); ok {
+		for _, field := range e.EncryptedFields() {
+			encryptedFields[field] = struct{}{}
+		}
+	}
+
+	var primaryKey []accesstypes.Field
+	if p, ok := res.(primaryKeyer); ok {
+		primaryKey = p.PrimaryKeyFields()
+	}
+
+	var searchTokens map[accesstypes.Field]string
+	if s, ok := res.(searcher); ok {
+		searchTokens = s.SearchTokens()
+	}
+
 	dbMap := make(map[DBType]map[accesstypes.Field]dbFieldMetadata)
 	for _, dbType := range dbTypes() {
 		dbFieldMap := dbStructTags(t, dbType)
@@ -311,18 +402,27 @@ func (c *resourceMetadataCache) get(res Resourcer) *resourceMetadataCacheEntry {
 	}
 
 	c.cache[t] = &resourceMetadataCacheEntry{
-		dbMap: dbMap,
-		cfg:   cfg,
+		dbMap:           dbMap,
+		cfg:             cfg,
+		encryptedFields: encryptedFields,
+		primaryKey:      primaryKey,
+		searchTokens:    searchTokens,
 	}
 
 	return c.cache[t]
 }
 
+// dbStructTags maps each field to its column in dbType, read from the field's struct tag
+// named for dbType. A Postgres field without a postgres tag falls back to its db tag, the
+// tag pgx scans rows by.
 func dbStructTags(t reflect.Type, dbType DBType) map[accesstypes.Field]dbFieldMetadata {
 	tagMap := make(map[accesstypes.Field]dbFieldMetadata)
 	for i := range t.NumField() {
 		field := t.Field(i)
-		tag := field.Tag.Get(string(dbType))
+		tag, ok := field.Tag.Lookup(string(dbType))
+		if !ok && dbType == PostgresDBType {
+			tag = field.Tag.Get(pgxTagKey)
+		}
 
 		parts := strings.Split(tag, ",")
 		if len(parts) == 0 || parts[0] == "" || parts[0] == "-" {
@@ -1,6 +1,7 @@
 package resource
 
 import (
+	"reflect"
 	"testing"
 	"time"
 
@@ -173,6 +174,7 @@ func testNewSetRun[Resource Resourcer, Request any](t *testing.T, name string, p
 			requiredTagPerm: w.requiredTagPerm,
 			fieldToTag:      w.fieldToTag,
 			immutableFields: w.immutableFields,
+			maskedFields:    map[accesstypes.Field]MaskStrategy{},
 			rMeta:           NewMetadata[Resource](),
 		}
 	}
@@ -184,7 +186,7 @@ func testNewSetRun[Resource Resourcer, Request any](t *testing.T, name string, p
 			t.Errorf("NewSet() error = %v, wantErr %v", err, w.wantErr)
 			return
 		}
-		if diff := cmp.Diff(want, got, cmp.AllowUnexported(Set[Resource]{}, Metadata[Resource]{}, dbFieldMetadata{})); diff != "" {
+		if diff := cmp.Diff(want, got, cmp.AllowUnexported(Set[Resource]{}, Metadata[Resource]{}, dbFieldMetadata{}, queryPolicy{})); diff != "" {
 			t.Errorf("NewSet() mismatch (-want +got):\n%s", diff)
 		}
 	})
@@ -216,6 +218,51 @@ var defaultConfig = Config{
 	TrackChanges:        true,
 }
 
+func Test_dbStructTags(t *testing.T) {
+	t.Parallel()
+
+	type dockingBay struct {
+		ID       string `spanner:"Id"       postgres:"id"`
+		Name     string `spanner:"Name"     db:"name"`
+		Capacity int    `spanner:"Capacity" postgres:"capacity" db:"berths"`
+		Notes    string `db:"-"`
+		Internal string
+	}
+
+	tests := []struct {
+		dbType DBType
+		want   map[accesstypes.Field]dbFieldMetadata
+	}{
+		{
+			dbType: SpannerDBType,
+			want: map[accesstypes.Field]dbFieldMetadata{
+				"ID":       {index: 0, ColumnName: "Id"},
+				"Name":     {index: 1, ColumnName: "Name"},
+				"Capacity": {index: 2, ColumnName: "Capacity"},
+			},
+		},
+		{
+			dbType: PostgresDBType,
+			want: map[accesstypes.Field]dbFieldMetadata{
+				"ID":       {index: 0, ColumnName: "id"},
+				"Name":     {index: 1, ColumnName: "name"},
+				"Capacity": {index: 2, ColumnName: "capacity"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.dbType), func(t *testing.T) {
+			t.Parallel()
+
+			got := dbStructTags(reflect.TypeFor[dockingBay](), tt.dbType)
+			if diff := cmp.Diff(tt.want, got, cmp.AllowUnexported(dbFieldMetadata{})); diff != "" {
+				t.Errorf("dbStructTags() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func BenchmarkNewMetadata(b *testing.B) {
 	b.ResetTimer()
 
@@ -0,0 +1,643 @@
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"github.com/cccteam/ccc"
+	"github.com/cccteam/ccc/accesstypes"
+	"github.com/cccteam/httpio"
+	"github.com/cccteam/session/sessioninfo"
+	"github.com/go-playground/errors/v5"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RPCOperationStatus is the state of an asynchronous RPC operation.
+type RPCOperationStatus string
+
+const (
+	// RPCOperationPending is an operation waiting for a worker to claim it.
+	RPCOperationPending RPCOperationStatus = "pending"
+	// RPCOperationRunning is an operation a worker holds the lease on.
+	RPCOperationRunning RPCOperationStatus = "running"
+	// RPCOperationSucceeded is an operation whose method returned without error.
+	RPCOperationSucceeded RPCOperationStatus = "succeeded"
+	// RPCOperationFailed is an operation whose method returned an error, or that was abandoned
+	// after its maximum number of attempts.
+	RPCOperationFailed RPCOperationStatus = "failed"
+)
+
+const (
+	rpcOperationColumns = "Id, Method, Status, Request, Progress, Result, Error, Attempts, LeaseOwner, LeaseExpiresAt, Owner, Domain, EventSource, TraceParent, CreatedAt, UpdatedAt"
+
+	// rpcOperationInternalError is the error recorded for a failure that is not a client error, so
+	// internal details are not exposed by the status endpoint.
+	rpcOperationInternalError = "internal error"
+)
+
+// RPCOperation is a row of the RPCOperations table, which records the executions of @async RPC
+// methods. The table is created by the application's migrations; see the README for its DDL.
+//
+// Owner and Domain are the user and domain that enqueued the operation, the only ones it is read
+// by. EventSource and TraceParent carry the rest of the enqueuing request's context, which the
+// worker restores before running the method.
+//
+// It encodes as the body of the generated operation status endpoint: the request, lease and
+// enqueuing context columns are omitted.
+type RPCOperation struct {
+	ID             ccc.UUID             `spanner:"Id"             json:"id"`
+	Method         accesstypes.Resource `spanner:"Method"         json:"method"`
+	Status         RPCOperationStatus   `spanner:"Status"         json:"status"`
+	Request        spanner.NullJSON     `spanner:"Request"        json:"-"`
+	Progress       int64                `spanner:"Progress"       json:"progress"`
+	Result         spanner.NullJSON     `spanner:"Result"         json:"result"`
+	Error          spanner.NullString   `spanner:"Error"          json:"error"`
+	Attempts       int64                `spanner:"Attempts"       json:"attempts"`
+	LeaseOwner     spanner.NullString   `spanner:"LeaseOwner"     json:"-"`
+	LeaseExpiresAt spanner.NullTime     `spanner:"LeaseExpiresAt" json:"-"`
+	Owner          accesstypes.User     `spanner:"Owner"          json:"-"`
+	Domain         accesstypes.Domain   `spanner:"Domain"         json:"-"`
+	EventSource    string               `spanner:"EventSource"    json:"-"`
+	TraceParent    spanner.NullString   `spanner:"TraceParent"    json:"-"`
+	CreatedAt      time.Time            `spanner:"CreatedAt"      json:"createdAt"`
+	UpdatedAt      time.Time            `spanner:"UpdatedAt"      json:"updatedAt"`
+}
+
+// PatchType returns the PatchType for RPCOperation
+func (RPCOperation) PatchType() PatchType {
+	return CreatePatchType
+}
+
+// Resource returns the Resource name for RPCOperation
+func (RPCOperation) Resource() accesstypes.Resource {
+	return "RPCOperations"
+}
+
+// PrimaryKey returns an empty key set since this resource is only buffered for inserts.
+func (o *RPCOperation) PrimaryKey() KeySet {
+	return KeySet{}
+}
+
+// DecodeRequest unmarshals the request the operation was enqueued with into dst.
+func (o *RPCOperation) DecodeRequest(dst any) error {
+	b, err := json.Marshal(o.Request.Value)
+	if err != nil {
+		return errors.Wrap(err, "json.Marshal()")
+	}
+
+	if err := json.Unmarshal(b, dst); err != nil {
+		return errors.Wrap(err, "json.Unmarshal()")
+	}
+
+	return nil
+}
+
+// enqueuedContext returns ctx carrying the user, request information and trace the operation was
+// enqueued with, so its method runs as if in the enqueuing request: CurrentUser and the change
+// events it writes record that user.
+func (o *RPCOperation) enqueuedContext(ctx context.Context) context.Context {
+	e, err := ParseEventSource(o.EventSource)
+	if err != nil {
+		return ctx
+	}
+
+	if id, err := ccc.UUIDFromString(e.ActorID); err == nil {
+		ctx = context.WithValue(ctx, sessioninfo.CtxSessionInfo, &sessioninfo.SessionInfo{ID: id, Username: e.Username})
+	}
+	if e.RequestID != "" || e.ClientIP != "" {
+		ctx = WithRequestInfo(ctx, e.RequestID, e.ClientIP)
+	}
+	if sc, ok := parseTraceParent(o.TraceParent.StringVal); ok {
+		ctx = trace.ContextWithRemoteSpanContext(ctx, sc)
+	}
+
+	return ctx
+}
+
+// RPCOperationAccepted is the body of the 202 Accepted response of an @async RPC method.
+type RPCOperationAccepted struct {
+	OperationID ccc.UUID `json:"operationId"`
+}
+
+// EnqueueRPCOperation records a pending operation running method with request, and returns its ID.
+// The request is stored as JSON and decoded by the worker with RPCOperation.DecodeRequest. The
+// operation is owned by the user and domain of userPermissions, and the worker runs it with the
+// user, request information and trace of ctx.
+func EnqueueRPCOperation(ctx context.Context, e Executor, userPermissions UserPermissions, method accesstypes.Resource, request any) (ccc.UUID, error) {
+	id, err := ccc.NewUUID()
+	if err != nil {
+		return ccc.UUID{}, errors.Wrap(err, "ccc.NewUUID()")
+	}
+
+	eventSource, err := NewUserEventSource(ctx).String()
+	if err != nil {
+		return ccc.UUID{}, errors.Wrap(err, "EventSource.String()")
+	}
+
+	now := time.Now()
+	op := &RPCOperation{
+		ID:          id,
+		Method:      method,
+		Status:      RPCOperationPending,
+		Request:     spanner.NullJSON{Value: request, Valid: true},
+		Owner:       userPermissions.User(),
+		Domain:      userPermissions.Domain(),
+		EventSource: eventSource,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		op.TraceParent = spanner.NullString{StringVal: formatTraceParent(sc), Valid: true}
+	}
+
+	if err := e.ExecuteFunc(ctx, func(_ context.Context, txn ReadWriteTransaction) error {
+		if err := txn.BufferStruct(op); err != nil {
+			return errors.Wrap(err, "ReadWriteTransaction.BufferStruct()")
+		}
+
+		return nil
+	}); err != nil {
+		return ccc.UUID{}, errors.Wrap(err, "Executor.ExecuteFunc()")
+	}
+
+	return id, nil
+}
+
+// ReadRPCOperation reads the operation with the given ID enqueued by the user and domain of
+// userPermissions. It returns a not found error for another user's operation, as it does for a
+// missing one, so the IDs of other users' operations can't be probed.
+func ReadRPCOperation(ctx context.Context, txn ReadOnlyTransaction, userPermissions UserPermissions, id ccc.UUID) (*RPCOperation, error) {
+	op, err := newReader[RPCOperation](txn).Read(ctx, &Statement{
+		resolvedWhereClause: fmt.Sprintf("Id = %s", id),
+		SQL:                 fmt.Sprintf("SELECT %s FROM RPCOperations WHERE Id = @id AND Owner = @owner AND Domain = @domain", rpcOperationColumns),
+		Params:              map[string]any{"id": id, "owner": string(userPermissions.User()), "domain": string(userPermissions.Domain())},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "Reader.Read()")
+	}
+
+	return op, nil
+}
+
+// EncodeRPCOperationAccepted writes the 202 Accepted response of an @async RPC method, with a
+// Location header addressing the operation under statusRoute, the generated status route.
+func EncodeRPCOperationAccepted(w http.ResponseWriter, id ccc.UUID, statusRoute string) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", fmt.Sprintf("%s/%s", strings.TrimSuffix(statusRoute, "/"), id))
+	w.WriteHeader(http.StatusAccepted)
+
+	if err := json.NewEncoder(w).Encode(RPCOperationAccepted{OperationID: id}); err != nil {
+		return errors.Wrap(err, "json.Encoder.Encode()")
+	}
+
+	return nil
+}
+
+type rpcOperationContextKey struct{}
+
+// rpcOperationLease identifies the operation a worker is running, for SetRPCOperationProgress.
+type rpcOperationLease struct {
+	worker *RPCOperationWorker
+	id     ccc.UUID
+}
+
+// SetRPCOperationProgress records the progress, from 0 to 100, of the operation running with ctx.
+// It does nothing when ctx is not an operation's, so a method can report progress whether it runs
+// asynchronously or not.
+func SetRPCOperationProgress(ctx context.Context, progress int64) error {
+	lease, ok := ctx.Value(rpcOperationContextKey{}).(*rpcOperationLease)
+	if !ok {
+		return nil
+	}
+
+	if progress < 0 || progress > 100 {
+		return errors.Newf("progress must be between 0 and 100, got %d", progress)
+	}
+
+	if _, err := lease.worker.update(ctx, lease.id, "Progress = @progress", map[string]any{"progress": progress}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RPCOperationRunner runs an operation's method with the request it was enqueued with, and returns
+// the result to record, or nil.
+type RPCOperationRunner func(ctx context.Context, op *RPCOperation) (result any, err error)
+
+type workerOptions struct {
+	owner         string
+	leaseDuration time.Duration
+	pollInterval  time.Duration
+	maxAttempts   int64
+	errorHandler  func(ctx context.Context, op *RPCOperation, err error)
+}
+
+// RPCOperationWorkerOption is a function that configures an RPCOperationWorker.
+type RPCOperationWorkerOption func(opt workerOptions) workerOptions
+
+// WithWorkerID sets the lease owner the worker records on the operations it claims. It defaults
+// to a random UUID.
+func WithWorkerID(id string) RPCOperationWorkerOption {
+	return func(o workerOptions) workerOptions {
+		o.owner = id
+
+		return o
+	}
+}
+
+// WithLeaseDuration sets how long a claimed operation is leased for. The worker renews the lease
+// while the operation runs; an operation whose lease expires is claimed again by another worker.
+func WithLeaseDuration(d time.Duration) RPCOperationWorkerOption {
+	return func(o workerOptions) workerOptions {
+		o.leaseDuration = d
+
+		return o
+	}
+}
+
+// WithPollInterval sets how long the worker waits before looking again when no operation is pending.
+func WithPollInterval(d time.Duration) RPCOperationWorkerOption {
+	return func(o workerOptions) workerOptions {
+		o.pollInterval = d
+
+		return o
+	}
+}
+
+// WithMaxAttempts sets how many times an operation is claimed before it is failed. An operation is
+// claimed again when the worker running it stops without finishing it.
+func WithMaxAttempts(n int64) RPCOperationWorkerOption {
+	return func(o workerOptions) workerOptions {
+		o.maxAttempts = n
+
+		return o
+	}
+}
+
+// WithOperationErrorHandler sets a function called with the error of each failed operation, and
+// with the error of each outcome the worker cannot record, lease it cannot renew, and claim that
+// fails; op is nil for a failed claim. The operation only records the message of a client error,
+// so use it to log internal errors.
+func WithOperationErrorHandler(f func(ctx context.Context, op *RPCOperation, err error)) RPCOperationWorkerOption {
+	return func(o workerOptions) workerOptions {
+		o.errorHandler = f
+
+		return o
+	}
+}
+
+// RPCOperationWorker runs the pending operations of the RPC methods registered with it. Several
+// workers, in one process or many, can run against the same table: an operation is claimed with a
+// lease inside a read-write transaction, so each is run by one worker at a time.
+//
+// The worker is only supported on Spanner.
+type RPCOperationWorker struct {
+	client  Client
+	opts    workerOptions
+	runners map[accesstypes.Resource]RPCOperationRunner
+}
+
+// NewRPCOperationWorker creates a worker running operations against client.
+func NewRPCOperationWorker(client Client, opts ...RPCOperationWorkerOption) (*RPCOperationWorker, error) {
+	o := workerOptions{
+		leaseDuration: time.Minute,
+		pollInterval:  5 * time.Second,
+		maxAttempts:   3,
+	}
+	for _, opt := range opts {
+		o = opt(o)
+	}
+
+	if o.owner == "" {
+		id, err := ccc.NewUUID()
+		if err != nil {
+			return nil, errors.Wrap(err, "ccc.NewUUID()")
+		}
+		o.owner = id.String()
+	}
+	if o.leaseDuration <= 0 {
+		return nil, errors.Newf("lease duration must be positive, got %s", o.leaseDuration)
+	}
+	if o.pollInterval <= 0 {
+		return nil, errors.Newf("poll interval must be positive, got %s", o.pollInterval)
+	}
+	if o.maxAttempts < 1 {
+		return nil, errors.Newf("max attempts must be at least 1, got %d", o.maxAttempts)
+	}
+
+	return &RPCOperationWorker{
+		client:  client,
+		opts:    o,
+		runners: make(map[accesstypes.Resource]RPCOperationRunner),
+	}, nil
+}
+
+// Register sets the runner of method's operations. Only the operations of registered methods are
+// claimed by the worker.
+func (w *RPCOperationWorker) Register(method accesstypes.Resource, runner RPCOperationRunner) {
+	w.runners[method] = runner
+}
+
+// Run claims and runs operations, one at a time, until ctx is canceled. An operation still running
+// when ctx is canceled is released for another worker to claim. A failed claim is passed to the
+// error handler and retried after the poll interval; an outcome that cannot be recorded is passed
+// to the error handler, and the operation is claimed again once its lease expires.
+func (w *RPCOperationWorker) Run(ctx context.Context) error {
+	if len(w.runners) == 0 {
+		return errors.New("no RPC methods are registered with the worker")
+	}
+
+	for {
+		op, found, err := w.claim(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			w.handleError(ctx, nil, err)
+		}
+
+		if err != nil || !found {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(w.opts.pollInterval):
+			}
+
+			continue
+		}
+
+		if op != nil {
+			w.run(ctx, op)
+		}
+	}
+}
+
+// claim leases the oldest pending operation, or running operation whose lease has expired, of a
+// registered method. An operation that has reached the maximum attempts is failed instead, and
+// claim returns a nil operation with found set, so the next one is claimed without waiting. found
+// is false when there is no operation to claim.
+func (w *RPCOperationWorker) claim(ctx context.Context) (op *RPCOperation, found bool, err error) {
+	methods := make([]string, 0, len(w.runners))
+	for method := range w.runners {
+		methods = append(methods, string(method))
+	}
+
+	var claimed *RPCOperation
+	if err := w.client.ExecuteFunc(ctx, func(ctx context.Context, txn ReadWriteTransaction) error {
+		claimed, found = nil, false
+		if txn.DBType() != SpannerDBType {
+			return errors.Newf("RPC operations are not supported on %s", txn.DBType())
+		}
+
+		now := time.Now()
+		var op *RPCOperation
+		for row, err := range newReader[RPCOperation](txn).List(ctx, &Statement{
+			SQL: fmt.Sprintf(`SELECT %s FROM RPCOperations
+WHERE Method IN UNNEST(@methods) AND (Status = @pending OR (Status = @running AND LeaseExpiresAt < @now))
+ORDER BY CreatedAt LIMIT 1`, rpcOperationColumns),
+			Params: map[string]any{
+				"methods": methods,
+				"pending": string(RPCOperationPending),
+				"running": string(RPCOperationRunning),
+				"now":     now,
+			},
+		}) {
+			if err != nil {
+				return errors.Wrap(err, "Reader.List()")
+			}
+			op = row
+		}
+		if op == nil {
+			return nil
+		}
+		found = true
+
+		if op.Attempts >= w.opts.maxAttempts {
+			if _, err := executeDML(ctx, txn, &Statement{
+				SQL: `UPDATE RPCOperations SET Status = @failed, Error = @error, LeaseOwner = NULL, LeaseExpiresAt = NULL, UpdatedAt = @now
+WHERE Id = @id`,
+				Params: map[string]any{
+					"id":     op.ID,
+					"failed": string(RPCOperationFailed),
+					"error":  fmt.Sprintf("abandoned after %d attempts", op.Attempts),
+					"now":    now,
+				},
+			}); err != nil {
+				return errors.Wrap(err, "executeDML()")
+			}
+
+			return nil
+		}
+
+		op.Status = RPCOperationRunning
+		op.Attempts++
+		op.LeaseOwner = spanner.NullString{StringVal: w.opts.owner, Valid: true}
+		op.LeaseExpiresAt = spanner.NullTime{Time: now.Add(w.opts.leaseDuration), Valid: true}
+		op.UpdatedAt = now
+		if _, err := executeDML(ctx, txn, &Statement{
+			SQL: `UPDATE RPCOperations SET Status = @running, Attempts = @attempts, LeaseOwner = @owner, LeaseExpiresAt = @expires, UpdatedAt = @now
+WHERE Id = @id`,
+			Params: map[string]any{
+				"id":       op.ID,
+				"running":  string(RPCOperationRunning),
+				"attempts": op.Attempts,
+				"owner":    w.opts.owner,
+				"expires":  op.LeaseExpiresAt.Time,
+				"now":      now,
+			},
+		}); err != nil {
+			return errors.Wrap(err, "executeDML()")
+		}
+		claimed = op
+
+		return nil
+	}); err != nil {
+		return nil, false, errors.Wrap(err, "Executor.ExecuteFunc()")
+	}
+
+	return claimed, found, nil
+}
+
+// run runs a claimed operation, with the context it was enqueued with, while renewing its lease,
+// and records its outcome. The outcome is dropped when the lease is lost to another worker. An
+// operation whose lease can not be renewed is canceled and released, since the lease may lapse.
+func (w *RPCOperationWorker) run(ctx context.Context, op *RPCOperation) {
+	ctx = op.enqueuedContext(ctx)
+	runCtx, cancel := context.WithCancel(context.WithValue(ctx, rpcOperationContextKey{}, &rpcOperationLease{worker: w, id: op.ID}))
+	defer cancel()
+
+	var renewErr error
+	renewed := make(chan struct{})
+	go func() {
+		defer close(renewed)
+		renewErr = w.renew(runCtx, cancel, op.ID)
+	}()
+
+	result, runErr := w.runner(runCtx, op)
+	cancel()
+	<-renewed
+
+	if renewErr != nil {
+		w.handleError(ctx, op, renewErr)
+	}
+
+	// The outcome is recorded after the run's context is canceled, and even when the worker is
+	// stopping, so it is not lost.
+	recordCtx, recordCancel := context.WithTimeout(context.WithoutCancel(ctx), w.opts.leaseDuration)
+	defer recordCancel()
+
+	if (ctx.Err() != nil || renewErr != nil) && runErr != nil {
+		// The worker is stopping or lost track of the lease: release the operation instead of failing it.
+		if _, err := w.update(recordCtx, op.ID, "Status = @pending, LeaseOwner = NULL, LeaseExpiresAt = NULL", map[string]any{"pending": string(RPCOperationPending)}); err != nil {
+			w.handleError(ctx, op, err)
+		}
+
+		return
+	}
+
+	if runErr != nil {
+		w.handleError(ctx, op, runErr)
+
+		if _, err := w.update(recordCtx, op.ID, "Status = @failed, Error = @error, LeaseOwner = NULL, LeaseExpiresAt = NULL", map[string]any{
+			"failed": string(RPCOperationFailed),
+			"error":  rpcOperationErrorMessage(runErr),
+		}); err != nil {
+			w.handleError(ctx, op, err)
+		}
+
+		return
+	}
+
+	if _, err := w.update(recordCtx, op.ID, "Status = @succeeded, Progress = 100, Result = @result, LeaseOwner = NULL, LeaseExpiresAt = NULL", map[string]any{
+		"succeeded": string(RPCOperationSucceeded),
+		"result":    spanner.NullJSON{Value: result, Valid: result != nil},
+	}); err != nil {
+		w.handleError(ctx, op, err)
+	}
+}
+
+// handleError passes err to the error handler, if one is set.
+func (w *RPCOperationWorker) handleError(ctx context.Context, op *RPCOperation, err error) {
+	if w.opts.errorHandler != nil {
+		w.opts.errorHandler(ctx, op, err)
+	}
+}
+
+// runner runs an operation with its registered runner, recovering a panic as the operation's error.
+func (w *RPCOperationWorker) runner(ctx context.Context, op *RPCOperation) (result any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			result, err = nil, errors.Newf("%s panicked: %v", op.Method, r)
+		}
+	}()
+
+	return w.runners[op.Method](ctx, op)
+}
+
+// renew extends the lease of a running operation until ctx is canceled. It cancels the run when the
+// lease has been lost to another worker, and when the lease can not be renewed, returning the error.
+func (w *RPCOperationWorker) renew(ctx context.Context, cancel context.CancelFunc, id ccc.UUID) error {
+	ticker := time.NewTicker(w.opts.leaseDuration / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			n, err := w.update(ctx, id, "LeaseExpiresAt = @expires", map[string]any{"expires": time.Now().Add(w.opts.leaseDuration)})
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				cancel()
+
+				return errors.Wrap(err, "renew lease")
+			}
+			if n == 0 {
+				cancel()
+
+				return nil
+			}
+		}
+	}
+}
+
+// update sets columns on a running operation this worker holds the lease on, and returns the number
+// of rows updated: zero when the lease has been lost.
+func (w *RPCOperationWorker) update(ctx context.Context, id ccc.UUID, set string, params map[string]any) (int64, error) {
+	params["id"] = id
+	params["owner"] = w.opts.owner
+	params["running"] = string(RPCOperationRunning)
+	params["now"] = time.Now()
+
+	var n int64
+	if err := w.client.ExecuteFunc(ctx, func(ctx context.Context, txn ReadWriteTransaction) error {
+		var err error
+		n, err = executeDML(ctx, txn, &Statement{
+			SQL:    fmt.Sprintf("UPDATE RPCOperations SET %s, UpdatedAt = @now WHERE Id = @id AND Status = @running AND LeaseOwner = @owner", set),
+			Params: params,
+		})
+		if err != nil {
+			return errors.Wrap(err, "executeDML()")
+		}
+
+		return nil
+	}); err != nil {
+		return 0, errors.Wrap(err, "Executor.ExecuteFunc()")
+	}
+
+	return n, nil
+}
+
+// rpcOperationErrorMessage returns the error an operation records: the message of a client error,
+// or a generic message that does not expose internal details.
+func rpcOperationErrorMessage(err error) string {
+	if httpio.HasBadRequest(err) || httpio.HasForbidden(err) || httpio.HasNotFound(err) {
+		return errors.Cause(err).Error()
+	}
+
+	return rpcOperationInternalError
+}
+
+// formatTraceParent returns sc as a W3C traceparent header value.
+func formatTraceParent(sc trace.SpanContext) string {
+	return fmt.Sprintf("00-%s-%s-%s", sc.TraceID(), sc.SpanID(), sc.TraceFlags())
+}
+
+// parseTraceParent parses a W3C traceparent header value into a remote span context.
+func parseTraceParent(s string) (trace.SpanContext, bool) {
+	parts := strings.Split(s, "-")
+	if len(parts) != 4 || parts[0] != "00" {
+		return trace.SpanContext{}, false
+	}
+
+	traceID, err := trace.TraceIDFromHex(parts[1])
+	if err != nil {
+		return trace.SpanContext{}, false
+	}
+	spanID, err := trace.SpanIDFromHex(parts[2])
+	if err != nil {
+		return trace.SpanContext{}, false
+	}
+	flags, err := strconv.ParseUint(parts[3], 16, 8)
+	if err != nil {
+		return trace.SpanContext{}, false
+	}
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.TraceFlags(flags),
+		Remote:     true,
+	})
+
+	return sc, sc.IsValid()
+}
@@ -0,0 +1,434 @@
+package resource
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"github.com/cccteam/ccc/accesstypes"
+	"github.com/cccteam/httpio"
+	"github.com/cccteam/session/sessioninfo"
+	"github.com/go-playground/errors/v5"
+	"github.com/google/go-cmp/cmp"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+)
+
+// operationTxn records the mutations and DML statements of the RPC operation functions.
+type operationTxn struct {
+	recordingTxn
+	buffered []PatchSetMetadata
+	dml      []*Statement
+	dmlErr   func(stmt *Statement) error
+}
+
+func (o *operationTxn) BufferStruct(p PatchSetMetadata) error {
+	o.buffered = append(o.buffered, p)
+
+	return nil
+}
+
+func (o *operationTxn) ExecuteDML(_ context.Context, stmt *Statement) (int64, error) {
+	o.dml = append(o.dml, stmt)
+	if o.dmlErr != nil {
+		if err := o.dmlErr(stmt); err != nil {
+			return 0, err
+		}
+	}
+
+	return 1, nil
+}
+
+type launchRequest struct {
+	ShipID     string
+	LaunchCode string
+}
+
+// enqueuingContext returns the context of a request enqueuing an operation, and the EventSource
+// it records.
+func enqueuingContext(t *testing.T) (context.Context, EventSource) {
+	t.Helper()
+
+	sessionID := mustUUIDFromString("4c3e1a52-5d0e-4f07-9f3b-0b5a7f1c2d3e")
+	traceID, err := trace.TraceIDFromHex("0af7651916cd43dd8448eb211c80319c")
+	if err != nil {
+		t.Fatalf("trace.TraceIDFromHex() error = %v", err)
+	}
+	spanID, err := trace.SpanIDFromHex("b7ad6b7169203331")
+	if err != nil {
+		t.Fatalf("trace.SpanIDFromHex() error = %v", err)
+	}
+
+	ctx := context.WithValue(t.Context(), sessioninfo.CtxSessionInfo, &sessioninfo.SessionInfo{ID: sessionID, Username: "janeway"})
+	ctx = trace.ContextWithSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID, TraceFlags: trace.FlagsSampled}))
+	ctx = WithRequestInfo(ctx, "req-74656", "10.0.0.1")
+
+	return ctx, EventSource{
+		ActorID:   sessionID.String(),
+		Username:  "janeway",
+		RequestID: "req-74656",
+		TraceID:   "0af7651916cd43dd8448eb211c80319c",
+		ClientIP:  "10.0.0.1",
+	}
+}
+
+func TestEnqueueRPCOperation(t *testing.T) {
+	t.Parallel()
+
+	ctx, wantSource := enqueuingContext(t)
+	txn := &operationTxn{}
+	id, err := EnqueueRPCOperation(ctx, NewMockClient(txn, nil, nil), &fakeUserPermissions{}, "AuthorizeLaunch", &launchRequest{ShipID: "ship-1", LaunchCode: "go"})
+	if err != nil {
+		t.Fatalf("EnqueueRPCOperation() error = %v", err)
+	}
+
+	if len(txn.buffered) != 1 {
+		t.Fatalf("EnqueueRPCOperation() buffered %d mutations, want 1", len(txn.buffered))
+	}
+	op, ok := txn.buffered[0].(*RPCOperation)
+	if !ok {
+		t.Fatalf("EnqueueRPCOperation() buffered %T, want *RPCOperation", txn.buffered[0])
+	}
+	if op.ID != id {
+		t.Errorf("RPCOperation.ID = %s, want %s", op.ID, id)
+	}
+	if op.Method != "AuthorizeLaunch" || op.Status != RPCOperationPending {
+		t.Errorf("RPCOperation = %s %s, want AuthorizeLaunch %s", op.Method, op.Status, RPCOperationPending)
+	}
+	if op.Owner != "testUser" || op.Domain != "testDomain" {
+		t.Errorf("RPCOperation owner = %s in %s, want testUser in testDomain", op.Owner, op.Domain)
+	}
+	gotSource, err := ParseEventSource(op.EventSource)
+	if err != nil {
+		t.Fatalf("ParseEventSource() error = %v", err)
+	}
+	if diff := cmp.Diff(wantSource, gotSource); diff != "" {
+		t.Errorf("RPCOperation.EventSource mismatch (-want +got):\n%s", diff)
+	}
+	if got, want := op.TraceParent, (spanner.NullString{StringVal: "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01", Valid: true}); got != want {
+		t.Errorf("RPCOperation.TraceParent = %v, want %v", got, want)
+	}
+
+	var got launchRequest
+	if err := op.DecodeRequest(&got); err != nil {
+		t.Fatalf("RPCOperation.DecodeRequest() error = %v", err)
+	}
+	if diff := cmp.Diff(launchRequest{ShipID: "ship-1", LaunchCode: "go"}, got); diff != "" {
+		t.Errorf("RPCOperation.DecodeRequest() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestReadRPCOperation(t *testing.T) {
+	t.Parallel()
+
+	id := mustUUIDFromString("0e8f6e3e-4b1c-4d0a-9a55-2f2d0e0c1a11")
+	reader := NewMockReader[RPCOperation](gomock.NewController(t))
+	reader.EXPECT().Read(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, stmt *Statement) (*RPCOperation, error) {
+		if !strings.Contains(stmt.SQL, "Owner = @owner AND Domain = @domain") {
+			t.Errorf("Read() SQL = %q, want it filtered by owner and domain", stmt.SQL)
+		}
+		if diff := cmp.Diff(map[string]any{"id": id, "owner": "testUser", "domain": "testDomain"}, stmt.Params); diff != "" {
+			t.Errorf("Read() params mismatch (-want +got):\n%s", diff)
+		}
+
+		return nil, httpio.NewNotFoundMessagef("%s (%s) not found", accesstypes.Resource("RPCOperations"), stmt.resolvedWhereClause)
+	})
+
+	_, err := ReadRPCOperation(t.Context(), NewMockClient(nil, []any{reader}, nil), &fakeUserPermissions{}, id)
+	if !httpio.HasNotFound(err) {
+		t.Fatalf("ReadRPCOperation() error = %v, want a not found error", err)
+	}
+	if got, want := errors.Cause(err).Error(), "RPCOperations (Id = "+id.String()+") not found"; got != want {
+		t.Errorf("ReadRPCOperation() error = %q, want %q", got, want)
+	}
+}
+
+func TestEncodeRPCOperationAccepted(t *testing.T) {
+	t.Parallel()
+
+	id := mustUUIDFromString("0e8f6e3e-4b1c-4d0a-9a55-2f2d0e0c1a11")
+	w := httptest.NewRecorder()
+	if err := EncodeRPCOperationAccepted(w, id, "/api/rpc-operations"); err != nil {
+		t.Fatalf("EncodeRPCOperationAccepted() error = %v", err)
+	}
+
+	if w.Code != http.StatusAccepted {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusAccepted)
+	}
+	if got, want := w.Header().Get("Location"), "/api/rpc-operations/"+id.String(); got != want {
+		t.Errorf("Location = %q, want %q", got, want)
+	}
+	if got, want := strings.TrimSpace(w.Body.String()), `{"operationId":"`+id.String()+`"}`; got != want {
+		t.Errorf("body = %s, want %s", got, want)
+	}
+}
+
+func TestSetRPCOperationProgress(t *testing.T) {
+	t.Parallel()
+
+	if err := SetRPCOperationProgress(t.Context(), 50); err != nil {
+		t.Errorf("SetRPCOperationProgress() outside an operation error = %v, want nil", err)
+	}
+
+	txn := &operationTxn{}
+	w, err := NewRPCOperationWorker(NewMockClient(txn, nil, nil), WithWorkerID("worker-1"))
+	if err != nil {
+		t.Fatalf("NewRPCOperationWorker() error = %v", err)
+	}
+	id := mustUUIDFromString("0e8f6e3e-4b1c-4d0a-9a55-2f2d0e0c1a11")
+	ctx := context.WithValue(t.Context(), rpcOperationContextKey{}, &rpcOperationLease{worker: w, id: id})
+
+	if err := SetRPCOperationProgress(ctx, 101); err == nil {
+		t.Error("SetRPCOperationProgress(101) error = nil, want an error")
+	}
+	if err := SetRPCOperationProgress(ctx, 40); err != nil {
+		t.Fatalf("SetRPCOperationProgress() error = %v", err)
+	}
+	if len(txn.dml) != 1 {
+		t.Fatalf("SetRPCOperationProgress() ran %d statements, want 1", len(txn.dml))
+	}
+	if got := txn.dml[0].Params["progress"]; got != int64(40) {
+		t.Errorf("progress param = %v, want 40", got)
+	}
+	if got := txn.dml[0].Params["owner"]; got != "worker-1" {
+		t.Errorf("owner param = %v, want worker-1", got)
+	}
+}
+
+func TestNewRPCOperationWorker_options(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		opts []RPCOperationWorkerOption
+	}{
+		{name: "lease duration", opts: []RPCOperationWorkerOption{WithLeaseDuration(0)}},
+		{name: "poll interval", opts: []RPCOperationWorkerOption{WithPollInterval(-time.Second)}},
+		{name: "max attempts", opts: []RPCOperationWorkerOption{WithMaxAttempts(0)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := NewRPCOperationWorker(NewMockClient(&operationTxn{}, nil, nil), tt.opts...); err == nil {
+				t.Error("NewRPCOperationWorker() error = nil, want an error")
+			}
+		})
+	}
+}
+
+func TestRPCOperationWorker_Run(t *testing.T) {
+	t.Parallel()
+
+	id := mustUUIDFromString("0e8f6e3e-4b1c-4d0a-9a55-2f2d0e0c1a11")
+	tests := []struct {
+		name       string
+		attempts   int64
+		runErr     error
+		recordErr  error
+		wantRun    bool
+		wantStatus string
+		wantError  string
+	}{
+		{name: "succeeded", wantRun: true, wantStatus: "succeeded"},
+		{name: "outcome not recorded", recordErr: errors.New("session expired"), wantRun: true, wantStatus: "succeeded"},
+		{name: "client error", runErr: errors.Wrap(httpio.NewBadRequestMessage("launchCode is required"), "Execute()"), wantRun: true, wantStatus: "failed", wantError: "launchCode is required"},
+		{name: "internal error", runErr: errors.New("connection reset"), wantRun: true, wantStatus: "failed", wantError: rpcOperationInternalError},
+		{name: "abandoned", attempts: 3, wantStatus: "failed", wantError: "abandoned after 3 attempts"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			enqueueCtx, wantSource := enqueuingContext(t)
+			ctrl := gomock.NewController(t)
+			reader := NewMockReader[RPCOperation](ctrl)
+			reader.EXPECT().List(gomock.Any(), gomock.Any()).Return(MockIterSeq2(nil, &RPCOperation{
+				ID:          id,
+				Method:      "AuthorizeLaunch",
+				Status:      RPCOperationPending,
+				Request:     spanner.NullJSON{Value: map[string]any{"LaunchCode": "go"}, Valid: true},
+				Attempts:    tt.attempts,
+				EventSource: UserEvent(enqueueCtx),
+				TraceParent: spanner.NullString{StringVal: formatTraceParent(trace.SpanContextFromContext(enqueueCtx)), Valid: true},
+			})).Times(1)
+			// Stop the worker once the operation has been recorded and the table is polled again.
+			ctx, cancel := context.WithCancel(t.Context())
+			reader.EXPECT().List(gomock.Any(), gomock.Any()).DoAndReturn(func(context.Context, *Statement) iter.Seq2[*RPCOperation, error] {
+				cancel()
+
+				return MockIterSeq2[RPCOperation](nil)
+			}).AnyTimes()
+
+			txn := &operationTxn{dmlErr: func(stmt *Statement) error {
+				if strings.Contains(stmt.SQL, "@succeeded") {
+					return tt.recordErr
+				}
+
+				return nil
+			}}
+			var handled []error
+			// The poll interval outlasts the test, so the worker must claim again without waiting
+			// after each operation, including an abandoned one.
+			w, err := NewRPCOperationWorker(NewMockClient(txn, nil, []any{reader}), WithWorkerID("worker-1"), WithLeaseDuration(time.Hour), WithPollInterval(time.Hour),
+				WithOperationErrorHandler(func(_ context.Context, _ *RPCOperation, err error) {
+					handled = append(handled, err)
+				}),
+			)
+			if err != nil {
+				t.Fatalf("NewRPCOperationWorker() error = %v", err)
+			}
+
+			var ran bool
+			w.Register("AuthorizeLaunch", func(ctx context.Context, op *RPCOperation) (any, error) {
+				ran = true
+				if diff := cmp.Diff(wantSource, NewUserEventSource(ctx)); diff != "" {
+					t.Errorf("runner context EventSource mismatch (-want +got):\n%s", diff)
+				}
+				var req launchRequest
+				if err := op.DecodeRequest(&req); err != nil {
+					return nil, err
+				}
+				if tt.runErr != nil {
+					return nil, tt.runErr
+				}
+
+				return map[string]string{"launchCode": req.LaunchCode}, nil
+			})
+
+			if err := w.Run(ctx); err != nil {
+				t.Fatalf("RPCOperationWorker.Run() error = %v", err)
+			}
+
+			if ran != tt.wantRun {
+				t.Errorf("runner called = %v, want %v", ran, tt.wantRun)
+			}
+			var wantHandled []error
+			if tt.runErr != nil {
+				wantHandled = append(wantHandled, tt.runErr)
+			}
+			if tt.recordErr != nil {
+				wantHandled = append(wantHandled, tt.recordErr)
+			}
+			if len(handled) != len(wantHandled) {
+				t.Fatalf("error handler called with %v, want %v", handled, wantHandled)
+			}
+			for i := range wantHandled {
+				if !errors.Is(handled[i], wantHandled[i]) {
+					t.Errorf("error handler call %d = %v, want %v", i, handled[i], wantHandled[i])
+				}
+			}
+			last := txn.dml[len(txn.dml)-1]
+			if got := last.Params[tt.wantStatus]; got != tt.wantStatus {
+				t.Errorf("last statement %q sets %s = %v, want %s", last.SQL, tt.wantStatus, got, tt.wantStatus)
+			}
+			if tt.wantError != "" {
+				if got, _ := last.Params["error"].(string); !strings.Contains(got, tt.wantError) {
+					t.Errorf("error param = %q, want it to contain %q", got, tt.wantError)
+				}
+			}
+			if tt.wantStatus == "succeeded" {
+				if diff := cmp.Diff(spanner.NullJSON{Value: map[string]string{"launchCode": "go"}, Valid: true}, last.Params["result"]); diff != "" {
+					t.Errorf("result param mismatch (-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestRPCOperationWorker_Run_claimError(t *testing.T) {
+	t.Parallel()
+
+	claimErr := errors.New("session expired")
+	ctrl := gomock.NewController(t)
+	reader := NewMockReader[RPCOperation](ctrl)
+	reader.EXPECT().List(gomock.Any(), gomock.Any()).Return(MockIterSeq2[RPCOperation](claimErr)).Times(1)
+	// Stop the worker once it claims again after the poll interval.
+	ctx, cancel := context.WithCancel(t.Context())
+	reader.EXPECT().List(gomock.Any(), gomock.Any()).DoAndReturn(func(context.Context, *Statement) iter.Seq2[*RPCOperation, error] {
+		cancel()
+
+		return MockIterSeq2[RPCOperation](nil)
+	}).Times(1)
+
+	var handled []error
+	w, err := NewRPCOperationWorker(NewMockClient(&operationTxn{}, nil, []any{reader}), WithPollInterval(time.Millisecond),
+		WithOperationErrorHandler(func(_ context.Context, op *RPCOperation, err error) {
+			if op != nil {
+				t.Errorf("error handler op = %v, want nil", op)
+			}
+			handled = append(handled, err)
+		}),
+	)
+	if err != nil {
+		t.Fatalf("NewRPCOperationWorker() error = %v", err)
+	}
+	w.Register("AuthorizeLaunch", func(context.Context, *RPCOperation) (any, error) { return nil, nil })
+
+	if err := w.Run(ctx); err != nil {
+		t.Fatalf("RPCOperationWorker.Run() error = %v", err)
+	}
+	if len(handled) != 1 || !errors.Is(handled[0], claimErr) {
+		t.Errorf("error handler called with %v, want %v", handled, claimErr)
+	}
+}
+
+func TestRPCOperationWorker_Run_renewError(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	reader := NewMockReader[RPCOperation](ctrl)
+	reader.EXPECT().List(gomock.Any(), gomock.Any()).Return(MockIterSeq2(nil, &RPCOperation{
+		ID:      mustUUIDFromString("0e8f6e3e-4b1c-4d0a-9a55-2f2d0e0c1a11"),
+		Method:  "AuthorizeLaunch",
+		Status:  RPCOperationPending,
+		Request: spanner.NullJSON{Value: map[string]any{"LaunchCode": "go"}, Valid: true},
+	})).Times(1)
+	ctx, cancel := context.WithCancel(t.Context())
+	reader.EXPECT().List(gomock.Any(), gomock.Any()).DoAndReturn(func(context.Context, *Statement) iter.Seq2[*RPCOperation, error] {
+		cancel()
+
+		return MockIterSeq2[RPCOperation](nil)
+	}).AnyTimes()
+
+	renewErr := errors.New("deadline exceeded")
+	txn := &operationTxn{dmlErr: func(stmt *Statement) error {
+		if strings.HasPrefix(stmt.SQL, "UPDATE RPCOperations SET LeaseExpiresAt = @expires") {
+			return renewErr
+		}
+
+		return nil
+	}}
+	var handled []error
+	w, err := NewRPCOperationWorker(NewMockClient(txn, nil, []any{reader}), WithLeaseDuration(30*time.Millisecond), WithPollInterval(time.Hour),
+		WithOperationErrorHandler(func(_ context.Context, _ *RPCOperation, err error) {
+			handled = append(handled, err)
+		}),
+	)
+	if err != nil {
+		t.Fatalf("NewRPCOperationWorker() error = %v", err)
+	}
+	// The runner runs until the failed renewal cancels it.
+	w.Register("AuthorizeLaunch", func(ctx context.Context, _ *RPCOperation) (any, error) {
+		<-ctx.Done()
+
+		return nil, ctx.Err()
+	})
+
+	if err := w.Run(ctx); err != nil {
+		t.Fatalf("RPCOperationWorker.Run() error = %v", err)
+	}
+
+	if len(handled) == 0 || !errors.Is(handled[0], renewErr) {
+		t.Errorf("error handler called with %v, want %v first", handled, renewErr)
+	}
+	last := txn.dml[len(txn.dml)-1]
+	if got := last.Params["pending"]; got != string(RPCOperationPending) {
+		t.Errorf("last statement %q sets pending = %v, want %s", last.SQL, got, RPCOperationPending)
+	}
+}
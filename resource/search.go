@@ -0,0 +1,157 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/cccteam/ccc/accesstypes"
+	"github.com/cccteam/httpio"
+	"github.com/go-playground/errors/v5"
+)
+
+// searcher is an interface for resources with search-indexed TOKENLIST columns. SearchTokens maps
+// each searchable field to the TOKENLIST column tokenized from it. Fields tokenized into the same
+// column (TOKENLIST_CONCAT) map to the same column.
+type searcher interface {
+	SearchTokens() map[accesstypes.Field]string
+}
+
+const searchParamName = "_search"
+
+// SetSearch restricts the query to the rows whose searchable fields match the full-text query
+// text. It is ANDed with any filter or key, and orders the results by relevance unless sort
+// fields are set.
+func (q *QuerySet[Resource]) SetSearch(text string) {
+	q.search = strings.TrimSpace(text)
+}
+
+// applySearch resolves the searchable fields and TOKENLIST columns the query can match. A field
+// the user can not read, or can not reveal when it is masked, is left out, along with every column
+// tokenized from it, since a match would disclose its value. It is a no-op when the query has no
+// search text.
+func (q *QuerySet[Resource]) applySearch(ctx context.Context) error {
+	if q.search == "" {
+		return nil
+	}
+	if len(q.rMeta.searchTokens) == 0 {
+		return httpio.NewBadRequestMessagef("%s does not support search", q.Resource())
+	}
+
+	fields := make([]accesstypes.Field, 0, len(q.rMeta.searchTokens))
+	for field := range q.rMeta.searchTokens {
+		if q.rMeta.IsEncrypted(field) {
+			return errors.Newf("cannot search encrypted field %s", field)
+		}
+		if _, ok := q.masks[field]; ok {
+			continue
+		}
+		if q.resourceSet != nil && q.resourceSet.PermissionRequired(field, q.requiredPermission) {
+			if ok, _, err := q.userPermissions.Check(ctx, q.requiredPermission, q.resourceSet.Resource(field)); err != nil {
+				return errors.Wrap(err, "enforcer.RequireResource()")
+			} else if !ok {
+				continue
+			}
+		}
+		fields = append(fields, field)
+	}
+
+	denied := make(map[string]struct{})
+	for field, column := range q.rMeta.searchTokens {
+		if !slices.Contains(fields, field) {
+			denied[column] = struct{}{}
+		}
+	}
+	var columns []string
+	for _, column := range q.rMeta.searchTokens {
+		if _, ok := denied[column]; !ok && !slices.Contains(columns, column) {
+			columns = append(columns, column)
+		}
+	}
+
+	if len(fields) == 0 || len(columns) == 0 {
+		return httpio.NewForbiddenMessagef("domain (%s), user (%s) can not search any field of %s", q.userPermissions.Domain(), q.userPermissions.User(), q.Resource())
+	}
+	slices.Sort(fields)
+	slices.Sort(columns)
+	q.searchFields = fields
+	q.searchColumns = columns
+
+	return nil
+}
+
+// andSearch appends the search condition to the WHERE clause. Spanner matches the TOKENLIST
+// columns with SEARCH. PostgreSQL has no search index, so each word of the query must be a
+// case-insensitive substring of one of the searchable columns.
+func (q *QuerySet[Resource]) andSearch(dbType DBType, where *Statement) (*Statement, error) {
+	if len(q.searchFields) == 0 {
+		return nil, errors.Newf("search on %s has no searchable fields", q.Resource())
+	}
+
+	var condition string
+	params := make(map[string]any)
+	switch dbType {
+	case SpannerDBType:
+		matches := make([]string, 0, len(q.searchColumns))
+		for _, column := range q.searchColumns {
+			matches = append(matches, fmt.Sprintf("SEARCH(`%s`, @%s)", column, searchParamName))
+		}
+		condition = "(" + strings.Join(matches, " OR ") + ")"
+		params[searchParamName] = q.search
+	case PostgresDBType:
+		words := strings.Fields(q.search)
+		conditions := make([]string, 0, len(words))
+		for i, word := range words {
+			name := fmt.Sprintf("%s_%d", searchParamName, i+1)
+			matches := make([]string, 0, len(q.searchFields))
+			for _, field := range q.searchFields {
+				f, ok := q.rMeta.dbFieldMap(dbType)[field]
+				if !ok {
+					return nil, errors.Newf("field %s not found in struct", field)
+				}
+				matches = append(matches, fmt.Sprintf(`"%s" ILIKE @%s`, f.ColumnName, name))
+			}
+			conditions = append(conditions, "("+strings.Join(matches, " OR ")+")")
+			params[name] = "%" + escapeLike(word) + "%"
+		}
+		condition = "(" + strings.Join(conditions, " AND ") + ")"
+	default:
+		return nil, errors.Newf("unsupported dbType: %s", dbType)
+	}
+
+	for k, v := range params {
+		if _, ok := where.Params[k]; ok {
+			return nil, errors.Newf("named parameter collision: %s search and where clause both contain named parameter %q", q.Resource(), k)
+		}
+		where.Params[k] = v
+	}
+
+	if where.SQL == "" {
+		where.SQL = "WHERE " + condition
+	} else {
+		where.SQL = fmt.Sprintf("WHERE (%s) AND %s", strings.TrimPrefix(where.SQL, "WHERE "), condition)
+	}
+
+	return where, nil
+}
+
+// searchScore sums the SCORE of each searchable TOKENLIST column, to order the results by
+// relevance. PostgreSQL has no relevance score, so it returns an empty string.
+func (q *QuerySet[Resource]) searchScore(dbType DBType) string {
+	if dbType != SpannerDBType {
+		return ""
+	}
+
+	scores := make([]string, 0, len(q.searchColumns))
+	for _, column := range q.searchColumns {
+		scores = append(scores, fmt.Sprintf("SCORE(`%s`, @%s)", column, searchParamName))
+	}
+
+	return strings.Join(scores, " + ")
+}
+
+// escapeLike escapes the LIKE wildcards in s, so they match literally.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
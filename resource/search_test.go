@@ -0,0 +1,228 @@
+package resource
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cccteam/ccc/accesstypes"
+	"github.com/google/go-cmp/cmp"
+)
+
+type searchTestResource struct {
+	ID      string `spanner:"Id"      db:"Id"`
+	Name    string `spanner:"Name"    db:"Name"`
+	Details string `spanner:"Details" db:"Details"`
+}
+
+func (searchTestResource) Resource() accesstypes.Resource { return "SearchTestResources" }
+
+func (searchTestResource) DefaultConfig() Config { return Config{} }
+
+func (searchTestResource) PrimaryKeyFields() []accesstypes.Field {
+	return []accesstypes.Field{"ID"}
+}
+
+func (searchTestResource) SearchTokens() map[accesstypes.Field]string {
+	return map[accesstypes.Field]string{
+		"Name":    "NameTokens",
+		"Details": "DetailsTokens",
+	}
+}
+
+type searchTestRequest struct {
+	ID      string `json:"id"      index:"true"`
+	Name    string `json:"name"`
+	Details string `json:"details" pii:"true"`
+}
+
+func TestQuerySet_Stmt_search(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		dbType     DBType
+		search     string
+		filter     ExpressionNode
+		sortFields []SortField
+		masks      map[accesstypes.Field]MaskStrategy
+		wantWhere  string
+		wantOrder  string
+		wantParams map[string]any
+		wantErr    string
+	}{
+		{
+			name:       "spanner",
+			dbType:     SpannerDBType,
+			search:     "warp core",
+			wantWhere:  "WHERE (SEARCH(`DetailsTokens`, @_search) OR SEARCH(`NameTokens`, @_search))",
+			wantOrder:  "ORDER BY SCORE(`DetailsTokens`, @_search) + SCORE(`NameTokens`, @_search) DESC, `Id` ASC",
+			wantParams: map[string]any{"_search": "warp core"},
+		},
+		{
+			name:       "spanner with filter and sort",
+			dbType:     SpannerDBType,
+			search:     "warp",
+			filter:     &ConditionNode{Condition: Condition{Field: "Id", Operator: eqStr, Value: "a"}},
+			sortFields: []SortField{{Field: "Name", Direction: SortAscending}},
+			wantWhere:  "WHERE (`Id` = @_p1) AND (SEARCH(`DetailsTokens`, @_search) OR SEARCH(`NameTokens`, @_search))",
+			wantOrder:  "ORDER BY `Name` ASC, `Id` ASC",
+			wantParams: map[string]any{"_p1": "a", "_search": "warp"},
+		},
+		{
+			name:       "spanner masked field is not searched",
+			dbType:     SpannerDBType,
+			search:     "warp",
+			masks:      map[accesstypes.Field]MaskStrategy{"Details": MaskFull},
+			wantWhere:  "WHERE (SEARCH(`NameTokens`, @_search))",
+			wantOrder:  "ORDER BY SCORE(`NameTokens`, @_search) DESC, `Id` ASC",
+			wantParams: map[string]any{"_search": "warp"},
+		},
+		{
+			name:      "postgres tokenized like",
+			dbType:    PostgresDBType,
+			search:    "warp 100%",
+			wantWhere: `WHERE (("Details" ILIKE @_search_1 OR "Name" ILIKE @_search_1) AND ("Details" ILIKE @_search_2 OR "Name" ILIKE @_search_2))`,
+			wantParams: map[string]any{
+				"_search_1": "%warp%",
+				"_search_2": `%100\%%`,
+			},
+		},
+		{
+			name:    "every field masked",
+			dbType:  SpannerDBType,
+			search:  "warp",
+			masks:   map[accesstypes.Field]MaskStrategy{"Name": MaskFull, "Details": MaskFull},
+			wantErr: "can not search any field",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			qSet := NewQuerySet(NewMetadata[searchTestResource]())
+			qSet.AddField("ID")
+			qSet.SetSearch(tt.search)
+			qSet.SetSortFields(tt.sortFields)
+			if tt.filter != nil {
+				qSet.SetFilterAst(tt.filter)
+			}
+			qSet.masks = tt.masks
+			if tt.masks != nil {
+				qSet.userPermissions = &fakeUserPermissions{}
+			}
+
+			if err := qSet.applySearch(t.Context()); err != nil {
+				if tt.wantErr == "" || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("QuerySet.applySearch() error = %v, want %q", err, tt.wantErr)
+				}
+
+				return
+			} else if tt.wantErr != "" {
+				t.Fatalf("QuerySet.applySearch() error = nil, want %q", tt.wantErr)
+			}
+
+			stmt, err := qSet.stmt(tt.dbType)
+			if err != nil {
+				t.Fatalf("QuerySet.stmt() error = %v", err)
+			}
+			if !strings.Contains(stmt.SQL, tt.wantWhere) {
+				t.Errorf("Statement.SQL = %q, want WHERE %q", stmt.SQL, tt.wantWhere)
+			}
+			if tt.wantOrder != "" && !strings.Contains(stmt.SQL, tt.wantOrder) {
+				t.Errorf("Statement.SQL = %q, want ORDER BY %q", stmt.SQL, tt.wantOrder)
+			}
+			if tt.wantOrder == "" && strings.Contains(stmt.SQL, "ORDER BY") {
+				t.Errorf("Statement.SQL = %q, want no ORDER BY", stmt.SQL)
+			}
+			if diff := cmp.Diff(tt.wantParams, stmt.Params); diff != "" {
+				t.Errorf("Statement.Params mismatch (-want +got):\n%s", diff)
+			}
+			if stmt.keyRead != nil {
+				t.Errorf("Statement.keyRead = %v, want nil", stmt.keyRead)
+			}
+		})
+	}
+}
+
+func TestQuerySet_applySearch_notSearchable(t *testing.T) {
+	t.Parallel()
+
+	qSet := NewQuerySet(NewMetadata[keyReadTestResource]())
+	qSet.SetSearch("warp")
+	if err := qSet.applySearch(t.Context()); err == nil || !strings.Contains(err.Error(), "does not support search") {
+		t.Errorf("QuerySet.applySearch() error = %v, want does not support search", err)
+	}
+}
+
+func TestQueryDecoder_search(t *testing.T) {
+	t.Parallel()
+
+	resSet, err := NewSet[searchTestResource, searchTestRequest]()
+	if err != nil {
+		t.Fatalf("NewSet() error = %v", err)
+	}
+	decoder, err := NewQueryDecoder[searchTestResource, searchTestRequest](resSet)
+	if err != nil {
+		t.Fatalf("NewQueryDecoder() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		urlValues  string
+		body       string
+		wantSearch string
+		wantErr    string
+	}{
+		{
+			name:       "POST with search in body",
+			method:     http.MethodPost,
+			body:       `{"search": " warp core "}`,
+			wantSearch: "warp core",
+		},
+		{
+			name:      "GET with search on pii field in URL",
+			method:    http.MethodGet,
+			urlValues: "search=warp",
+			wantErr:   "cannot search sensitive fields in URL: details",
+		},
+		{
+			name:      "POST with search in body and query",
+			method:    http.MethodPost,
+			urlValues: "search=warp",
+			body:      `{"search": "core"}`,
+			wantErr:   "cannot search sensitive fields in URL",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req, err := http.NewRequestWithContext(t.Context(), tt.method, "http://test?"+tt.urlValues, strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatalf("http.NewRequestWithContext() error = %v", err)
+			}
+			if tt.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+
+			qSet, err := decoder.DecodeWithoutPermissions(req)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("QueryDecoder.DecodeWithoutPermissions() error = %v, want %q", err, tt.wantErr)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("QueryDecoder.DecodeWithoutPermissions() error = %v", err)
+			}
+			if qSet.search != tt.wantSearch {
+				t.Errorf("QuerySet.search = %q, want %q", qSet.search, tt.wantSearch)
+			}
+		})
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
+	"cloud.google.com/go/civil"
 	"github.com/go-playground/errors/v5"
 )
 
@@ -84,11 +86,84 @@ func (s *sqlGenerator) nextPlaceholder() string {
 	return fmt.Sprintf("@_p%d", s.paramCount)
 }
 
+// jsonPathExpr extracts the value at a JSON path as a scalar, cast to the type of the values
+// it is compared with. The path segments are validated by the FilterParser and QueryClause, so
+// they are safe to write into the SQL.
+func (s *sqlGenerator) jsonPathExpr(field string, path []string, values ...any) string {
+	if s.dialect == Spanner {
+		var jsonPath strings.Builder
+		jsonPath.WriteString("$")
+		for _, segment := range path {
+			if strings.Trim(segment, "0123456789") == "" {
+				fmt.Fprintf(&jsonPath, "[%s]", segment)
+			} else {
+				fmt.Fprintf(&jsonPath, ".%s", segment)
+			}
+		}
+		expr := fmt.Sprintf("JSON_VALUE(%s, '%s')", field, jsonPath.String())
+		switch jsonValueType(values) {
+		case "number":
+			return fmt.Sprintf("SAFE_CAST(%s AS FLOAT64)", expr)
+		case "bool":
+			return fmt.Sprintf("SAFE_CAST(%s AS BOOL)", expr)
+		default:
+			return expr
+		}
+	}
+
+	expr := fmt.Sprintf("(%s #>> '{%s}')", field, strings.Join(path, ","))
+	switch jsonValueType(values) {
+	case "number":
+		return expr + "::numeric"
+	case "bool":
+		return expr + "::boolean"
+	default:
+		return expr
+	}
+}
+
+// jsonValueType returns "number" or "bool" when every value is of that type, and "string" otherwise.
+func jsonValueType(values []any) string {
+	valueType := ""
+	for _, v := range values {
+		t := "string"
+		switch v.(type) {
+		case float64, float32, int, int64, int32:
+			t = "number"
+		case bool:
+			t = "bool"
+		}
+		if valueType != "" && valueType != t {
+			return "string"
+		}
+		valueType = t
+	}
+
+	return valueType
+}
+
+// arrayContains matches rows whose array column contains the placeholder's value.
+func (s *sqlGenerator) arrayContains(field, placeholder string) string {
+	if s.dialect == Spanner {
+		return fmt.Sprintf("%s IN UNNEST(%s)", placeholder, field)
+	}
+
+	return fmt.Sprintf("%s = ANY(%s)", placeholder, field)
+}
+
 func (s *sqlGenerator) generateConditionSQL(cn *ConditionNode) (string, []QueryParam, error) {
 	field := s.quoteIdentifier(cn.Condition.Field)
 	op := strings.ToLower(cn.Condition.Operator)
 	var params []QueryParam
 
+	if len(cn.Condition.Path) > 0 {
+		if cn.Condition.Value != nil {
+			field = s.jsonPathExpr(field, cn.Condition.Path, cn.Condition.Value)
+		} else {
+			field = s.jsonPathExpr(field, cn.Condition.Path, cn.Condition.Values...)
+		}
+	}
+
 	switch op {
 	case eqStr, neStr, gtStr, ltStr, gteStr, lteStr:
 		placeholder := s.nextPlaceholder()
@@ -124,6 +199,36 @@ func (s *sqlGenerator) generateConditionSQL(cn *ConditionNode) (string, []QueryP
 		}
 
 		return fmt.Sprintf("%s %s (%s)", field, sqlOp, strings.Join(placeholders, ", ")), params, nil
+	case betweenStr:
+		if len(cn.Condition.Values) != 2 {
+			return "", nil, errors.Newf("operator %s requires 2 values, got %d", op, len(cn.Condition.Values))
+		}
+		lo, hi := s.nextPlaceholder(), s.nextPlaceholder()
+		params = append(params,
+			QueryParam{Name: strings.TrimPrefix(lo, "@"), Value: cn.Condition.Values[0]},
+			QueryParam{Name: strings.TrimPrefix(hi, "@"), Value: cn.Condition.Values[1]},
+		)
+
+		return fmt.Sprintf("%s BETWEEN %s AND %s", field, lo, hi), params, nil
+	case hasStr:
+		placeholder := s.nextPlaceholder()
+		params = append(params, QueryParam{Name: strings.TrimPrefix(placeholder, "@"), Value: cn.Condition.Value})
+
+		return s.arrayContains(field, placeholder), params, nil
+	case hasanyStr, hasallStr:
+		conditions := make([]string, len(cn.Condition.Values))
+		params = make([]QueryParam, 0, len(cn.Condition.Values))
+		for i, v := range cn.Condition.Values {
+			placeholder := s.nextPlaceholder()
+			conditions[i] = s.arrayContains(field, placeholder)
+			params = append(params, QueryParam{Name: strings.TrimPrefix(placeholder, "@"), Value: v})
+		}
+		sqlOp := " OR "
+		if op == hasallStr {
+			sqlOp = " AND "
+		}
+
+		return "(" + strings.Join(conditions, sqlOp) + ")", params, nil
 	case isnullStr:
 		return fmt.Sprintf("%s IS NULL", field), nil, nil
 	case isnotnullStr:
@@ -192,12 +297,22 @@ func (g *PostgreSQLGenerator) GenerateSQL(node ExpressionNode) (sqlStr string, p
 
 	namedParams := make(map[string]any)
 	for _, qp := range queryParams {
-		namedParams[qp.Name] = qp.Value
+		namedParams[qp.Name] = postgresParamValue(qp.Value)
 	}
 
 	return sqlStr, namedParams, nil
 }
 
+// postgresParamValue sends a civil.Date as a time.Time at midnight UTC, which the PostgreSQL
+// driver encodes as the same calendar date Spanner compares.
+func postgresParamValue(v any) any {
+	if d, ok := v.(civil.Date); ok {
+		return d.In(time.UTC)
+	}
+
+	return v
+}
+
 // SpannerGenerator is a SQL generator for Spanner.
 type SpannerGenerator struct {
 	*sqlGenerator
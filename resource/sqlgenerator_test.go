@@ -4,6 +4,10 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"cloud.google.com/go/spanner"
 )
 
 // sqlGeneratorTestMap provides the necessary field name mappings for these tests.
@@ -19,6 +23,9 @@ var sqlGeneratorTestMap = map[jsonFieldName]FilterFieldInfo{
 	"email":    {dbColumnNames: map[DBType]string{SpannerDBType: "Email", PostgresDBType: "Email"}, Kind: reflect.String, Indexed: true},
 	"active":   {dbColumnNames: map[DBType]string{SpannerDBType: "Active", PostgresDBType: "Active"}, Kind: reflect.Bool, Indexed: true},
 	"field":    {dbColumnNames: map[DBType]string{SpannerDBType: "Field", PostgresDBType: "Field"}, Kind: reflect.String, Indexed: true},
+	"tags":     {dbColumnNames: map[DBType]string{SpannerDBType: "Tags", PostgresDBType: "Tags"}, Kind: reflect.Slice, FieldType: reflect.TypeFor[[]string](), Indexed: true},
+	"details":  {dbColumnNames: map[DBType]string{SpannerDBType: "Details", PostgresDBType: "Details"}, Kind: reflect.Struct, FieldType: reflect.TypeFor[spanner.NullJSON](), Indexed: true},
+	"launched": {dbColumnNames: map[DBType]string{SpannerDBType: "Launched", PostgresDBType: "Launched"}, Kind: reflect.Struct, FieldType: reflect.TypeFor[civil.Date](), Indexed: true},
 }
 
 func TestSQLGenerator_GenerateSQL(t *testing.T) {
@@ -301,6 +308,111 @@ func TestSQLGenerator_GenerateSQL(t *testing.T) {
 			wantSQL:      `"Rating" > @_p1`,
 			wantParams:   map[string]any{"_p1": 4},
 		},
+		// Array operators
+		{
+			name:         "tags:has:red spanner",
+			filterString: "tags:has:red",
+			dialect:      Spanner,
+			wantSQL:      "@_p1 IN UNNEST(`Tags`)",
+			wantParams:   map[string]any{"_p1": "red"},
+		},
+		{
+			name:         "tags:has:red pg",
+			filterString: "tags:has:red",
+			dialect:      PostgreSQL,
+			wantSQL:      `@_p1 = ANY("Tags")`,
+			wantParams:   map[string]any{"_p1": "red"},
+		},
+		{
+			name:         "tags:hasany:(red,blue) spanner",
+			filterString: "tags:hasany:(red,blue)",
+			dialect:      Spanner,
+			wantSQL:      "(@_p1 IN UNNEST(`Tags`) OR @_p2 IN UNNEST(`Tags`))",
+			wantParams:   map[string]any{"_p1": "red", "_p2": "blue"},
+		},
+		{
+			name:         "tags:hasall:(red,blue) pg",
+			filterString: "tags:hasall:(red,blue)",
+			dialect:      PostgreSQL,
+			wantSQL:      `(@_p1 = ANY("Tags") AND @_p2 = ANY("Tags"))`,
+			wantParams:   map[string]any{"_p1": "red", "_p2": "blue"},
+		},
+		// between
+		{
+			name:         "rating:between:(1,5) pg",
+			filterString: "rating:between:(1,5)",
+			dialect:      PostgreSQL,
+			wantSQL:      `"Rating" BETWEEN @_p1 AND @_p2`,
+			wantParams:   map[string]any{"_p1": 1, "_p2": 5},
+		},
+		{
+			name:         "rating:between:(1,5) spanner",
+			filterString: "rating:between:(1,5)",
+			dialect:      Spanner,
+			wantSQL:      "`Rating` BETWEEN @_p1 AND @_p2",
+			wantParams:   map[string]any{"_p1": 1, "_p2": 5},
+		},
+		{
+			name:         "date between pg sends midnight UTC",
+			filterString: "launched:between:(2025-03-01,2025-03-31T23:00:00-05:00)",
+			dialect:      PostgreSQL,
+			wantSQL:      `"Launched" BETWEEN @_p1 AND @_p2`,
+			wantParams:   map[string]any{"_p1": time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC), "_p2": time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:         "date between spanner",
+			filterString: "launched:between:(2025-03-01,2025-03-31T23:00:00-05:00)",
+			dialect:      Spanner,
+			wantSQL:      "`Launched` BETWEEN @_p1 AND @_p2",
+			wantParams:   map[string]any{"_p1": civil.Date{Year: 2025, Month: time.March, Day: 1}, "_p2": civil.Date{Year: 2025, Month: time.April, Day: 1}},
+		},
+		{
+			name:         "has on a non-array field",
+			filterString: "name:has:red",
+			dialect:      Spanner,
+			wantErrMsg:   "operator 'has' requires an array field",
+		},
+		// JSON path comparisons
+		{
+			name:         "details.cargo.weight:gt:10 spanner",
+			filterString: "details.cargo.weight:gt:10",
+			dialect:      Spanner,
+			wantSQL:      "SAFE_CAST(JSON_VALUE(`Details`, '$.cargo.weight') AS FLOAT64) > @_p1",
+			wantParams:   map[string]any{"_p1": float64(10)},
+		},
+		{
+			name:         "details.cargo.weight:gt:10 pg",
+			filterString: "details.cargo.weight:gt:10",
+			dialect:      PostgreSQL,
+			wantSQL:      `("Details" #>> '{cargo,weight}')::numeric > @_p1`,
+			wantParams:   map[string]any{"_p1": float64(10)},
+		},
+		{
+			name:         "details.items.0.name:in:(fuel,ore) spanner",
+			filterString: "details.items.0.name:in:(fuel,ore)",
+			dialect:      Spanner,
+			wantSQL:      "JSON_VALUE(`Details`, '$.items[0].name') IN (@_p1, @_p2)",
+			wantParams:   map[string]any{"_p1": "fuel", "_p2": "ore"},
+		},
+		{
+			name:         "details.hazardous:eq:true pg",
+			filterString: "details.hazardous:eq:true",
+			dialect:      PostgreSQL,
+			wantSQL:      `("Details" #>> '{hazardous}')::boolean = @_p1`,
+			wantParams:   map[string]any{"_p1": true},
+		},
+		{
+			name:         "JSON path with invalid segment",
+			filterString: "details.car-go:eq:1",
+			dialect:      Spanner,
+			wantErrMsg:   "invalid JSON path segment",
+		},
+		{
+			name:         "path on a non-JSON field",
+			filterString: "name.first:eq:John",
+			dialect:      Spanner,
+			wantErrMsg:   "is not a JSON field",
+		},
 	}
 
 	for _, tt := range tests {
@@ -18,8 +18,13 @@ import (
 
 func (a *App) AuthorizeLaunch() http.HandlerFunc {
 	type request struct {
-		ShipID     ccc.UUID `json:"shipId"`
-		LaunchCode string   `json:"launchCode"`
+		ShipID          ccc.UUID `json:"shipId"`
+		LaunchCode      string   `json:"launchCode"`
+		AuthorizationID ccc.UUID `json:"-"`
+	}
+
+	type response struct {
+		AuthorizationID ccc.UUID `json:"authorizationId"`
 	}
 
 	decoder := NewRPCDecoder[rpc.AuthorizeLaunch, request](a, accesstypes.Execute)
@@ -44,6 +49,8 @@ func (a *App) AuthorizeLaunch() http.HandlerFunc {
 			return httpio.NewEncoder(w).ClientMessage(ctx, errors.Wrap(err, "spanner.Client.ReadWriteTransaction()"))
 		}
 
-		return httpio.NewEncoder(w).Ok(nil)
+		return httpio.NewEncoder(w).Ok(response{
+			AuthorizationID: p.AuthorizationID,
+		})
 	})
 }
@@ -3,19 +3,28 @@ package main
 
 import (
 	"context"
+	"flag"
 	"log"
+	"os"
+	"os/signal"
 
 	"github.com/cccteam/ccc/resource/generation"
 	"github.com/go-playground/errors/v5"
 )
 
 func main() {
-	if err := run(context.Background()); err != nil {
+	watch := flag.Bool("watch", false, "regenerate when the resources or migrations change, until interrupted")
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := run(ctx, *watch); err != nil {
 		log.Fatal(err)
 	}
 }
 
-func run(ctx context.Context) error {
+func run(ctx context.Context, watch bool) error {
 	generator, err := generation.NewResourceGenerator(
 		ctx,
 		"pkg/resources",
@@ -27,6 +36,7 @@ func run(ctx context.Context) error {
 		},
 		generation.GenerateHandlers("app"),
 		generation.GenerateRoutes("pkg/router", "api"),
+		generation.GenerateGoClient("pkg/client"),
 		generation.WithRPC("pkg/rpc"),
 		// CrewMember is excluded from consolidation so the app exercises both mutation
 		// surfaces: the consolidated PATCH /api/resources handler and a standalone
@@ -43,6 +53,14 @@ func run(ctx context.Context) error {
 	}
 	defer generator.Close()
 
+	if watch {
+		if err := generation.Watch(ctx, generator, 0); err != nil {
+			return errors.Wrap(err, "generation.Watch()")
+		}
+
+		return nil
+	}
+
 	if err := generator.Generate(); err != nil {
 		return errors.Wrap(err, "generation.Generator.Generate()")
 	}
@@ -88,6 +88,9 @@ export namespace Ships {
     registryCode: 'registryCode' as FieldName,
     updatedAt: 'updatedAt' as FieldName,
   };
+  export const outputOnlyFieldName = {
+    updatedAt: 'updatedAt' as FieldName,
+  };
   export const resourceName = {
     cargoValue: 'Ships.cargoValue' as Resource,
     dockingBayId: 'Ships.dockingBayId' as Resource,
@@ -112,6 +115,9 @@ export namespace SupplyCrates {
   export const piiFieldName = {
     inspectorBadge: 'inspectorBadge' as FieldName,
   };
+  export const outputOnlyFieldName = {
+    barcode: 'barcode' as FieldName,
+  };
   export const resourceName = {
     assignedShipId: 'SupplyCrates.assignedShipId' as Resource,
     barcode: 'SupplyCrates.barcode' as Resource,
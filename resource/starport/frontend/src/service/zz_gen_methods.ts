@@ -14,6 +14,9 @@ export interface AuthorizeLaunch {
   shipId: string;
   launchCode: string;
 }
+export interface AuthorizeLaunchResponse {
+  authorizationId: string;
+}
 
 export interface RPCFieldMeta {
   fieldName: string;
@@ -24,6 +27,8 @@ export interface RPCFieldMeta {
 export interface MethodMeta {
   route: string;
   fields: RPCFieldMeta[];
+  responseFields?: RPCFieldMeta[];
+  async?: boolean;
 }
 
 export type MethodMap = Record<Method, MethodMeta>;
@@ -35,6 +40,9 @@ const methodMap: MethodMap = {
       { fieldName: 'shipId', displayType: 'uuid' },
       { fieldName: 'launchCode', displayType: 'string' },
     ],
+    responseFields: [
+      { fieldName: 'authorizationId', displayType: 'uuid' },
+    ],
   },
 };
 
@@ -52,59 +52,59 @@ const resourceMap: ResourceMap = {
     route: 'cargo-manifests',
     consolidatedRoute: 'resources',
     fields: [
-      { fieldName: 'shipId', primaryKey: { ordinalPosition: 0 }, displayType: 'enumerated', required: true, isIndex: true, enumeratedResource: Resources.Ships },
-      { fieldName: 'lineNumber', primaryKey: { ordinalPosition: 1 }, displayType: 'number', required: true, isIndex: true },
-      { fieldName: 'details', displayType: 'string', required: true, isIndex: false },
-      { fieldName: 'quantity', displayType: 'number', required: true, isIndex: false },
-      { fieldName: 'declaredValue', displayType: 'number', required: true, isIndex: false },
+      { fieldName: 'shipId', primaryKey: { ordinalPosition: 0 }, displayType: 'enumerated', required: true, isIndex: true, isSortable: true, enumeratedResource: Resources.Ships },
+      { fieldName: 'lineNumber', primaryKey: { ordinalPosition: 1 }, displayType: 'number', required: true, isIndex: true, isSortable: true },
+      { fieldName: 'details', displayType: 'string', required: true, isIndex: false, isSortable: true },
+      { fieldName: 'quantity', displayType: 'number', required: true, isIndex: false, isSortable: true },
+      { fieldName: 'declaredValue', displayType: 'number', required: true, isIndex: false, isSortable: true },
     ],
   },
   [Resources.CrewMembers]: {
     route: 'crew-members',
     fields: [
-      { fieldName: 'id', primaryKey: { ordinalPosition: 0 }, displayType: 'uuid', required: false, isIndex: true },
-      { fieldName: 'shipId', displayType: 'enumerated', required: true, isIndex: true, enumeratedResource: Resources.Ships },
-      { fieldName: 'name', displayType: 'string', required: true, isIndex: false },
-      { fieldName: 'rank', displayType: 'string', required: true, isIndex: false },
-      { fieldName: 'clearanceLevel', displayType: 'number', required: true, isIndex: false },
-      { fieldName: 'medicalNotes', displayType: 'string', required: false, isIndex: false },
+      { fieldName: 'id', primaryKey: { ordinalPosition: 0 }, displayType: 'uuid', required: false, isIndex: true, isSortable: true },
+      { fieldName: 'shipId', displayType: 'enumerated', required: true, isIndex: true, isSortable: true, enumeratedResource: Resources.Ships },
+      { fieldName: 'name', displayType: 'string', required: true, isIndex: false, isSortable: true },
+      { fieldName: 'rank', displayType: 'string', required: true, isIndex: false, isSortable: true },
+      { fieldName: 'clearanceLevel', displayType: 'number', required: true, isIndex: false, isSortable: true },
+      { fieldName: 'medicalNotes', displayType: 'string', required: false, isIndex: false, isSortable: true },
     ],
   },
   [Resources.DockingBays]: {
     route: 'docking-bays',
     consolidatedRoute: 'resources',
     fields: [
-      { fieldName: 'id', primaryKey: { ordinalPosition: 0 }, displayType: 'uuid', required: false, isIndex: true },
-      { fieldName: 'name', displayType: 'string', required: true, isIndex: true },
-      { fieldName: 'deckLevel', displayType: 'number', required: true, isIndex: false },
-      { fieldName: 'maxTonnage', displayType: 'number', required: true, isIndex: false },
+      { fieldName: 'id', primaryKey: { ordinalPosition: 0 }, displayType: 'uuid', required: false, isIndex: true, isSortable: true },
+      { fieldName: 'name', displayType: 'string', required: true, isIndex: true, isSortable: true },
+      { fieldName: 'deckLevel', displayType: 'number', required: true, isIndex: false, isSortable: true },
+      { fieldName: 'maxTonnage', displayType: 'number', required: true, isIndex: false, isSortable: true },
     ],
   },
   [Resources.Ships]: {
     route: 'ships',
     consolidatedRoute: 'resources',
     fields: [
-      { fieldName: 'id', primaryKey: { ordinalPosition: 0 }, displayType: 'uuid', required: false, isIndex: true },
-      { fieldName: 'registryCode', displayType: 'string', required: true, isIndex: true },
-      { fieldName: 'name', displayType: 'string', required: true, isIndex: true },
-      { fieldName: 'dockingBayId', displayType: 'enumerated', required: false, isIndex: true, enumeratedResource: Resources.DockingBays },
-      { fieldName: 'cargoValue', displayType: 'number', required: true, isIndex: false },
-      { fieldName: 'updatedAt', displayType: 'date', required: false, isIndex: false },
+      { fieldName: 'id', primaryKey: { ordinalPosition: 0 }, displayType: 'uuid', required: false, isIndex: true, isSortable: true },
+      { fieldName: 'registryCode', displayType: 'string', required: true, isIndex: true, isSortable: true },
+      { fieldName: 'name', displayType: 'string', required: true, isIndex: true, isSortable: true },
+      { fieldName: 'dockingBayId', displayType: 'enumerated', required: false, isIndex: true, isSortable: true, enumeratedResource: Resources.DockingBays },
+      { fieldName: 'cargoValue', displayType: 'number', required: true, isIndex: false, isSortable: true },
+      { fieldName: 'updatedAt', displayType: 'date', required: false, isIndex: false, isSortable: true },
     ],
   },
   [Resources.SupplyCrates]: {
     route: 'supply-crates',
     consolidatedRoute: 'resources',
     fields: [
-      { fieldName: 'id', primaryKey: { ordinalPosition: 0 }, displayType: 'uuid', required: false, isIndex: true },
-      { fieldName: 'label', displayType: 'string', required: true, isIndex: true },
-      { fieldName: 'quantity', displayType: 'number', required: true, isIndex: false },
-      { fieldName: 'priority', displayType: 'number', required: true, isIndex: false },
-      { fieldName: 'status', displayType: 'string', required: false, isIndex: false },
-      { fieldName: 'barcode', displayType: 'string', required: false, isIndex: false },
-      { fieldName: 'notes', displayType: 'string', required: false, isIndex: false },
-      { fieldName: 'inspectorBadge', displayType: 'string', required: false, isIndex: false },
-      { fieldName: 'assignedShipId', displayType: 'enumerated', required: false, isIndex: true, enumeratedResource: Resources.Ships },
+      { fieldName: 'id', primaryKey: { ordinalPosition: 0 }, displayType: 'uuid', required: false, isIndex: true, isSortable: true },
+      { fieldName: 'label', displayType: 'string', required: true, isIndex: true, isSortable: true },
+      { fieldName: 'quantity', displayType: 'number', required: true, isIndex: false, isSortable: true },
+      { fieldName: 'priority', displayType: 'number', required: true, isIndex: false, isSortable: true },
+      { fieldName: 'status', displayType: 'string', required: false, isIndex: false, isSortable: true },
+      { fieldName: 'barcode', displayType: 'string', required: false, isIndex: false, isSortable: true },
+      { fieldName: 'notes', displayType: 'string', required: false, isIndex: false, isSortable: true },
+      { fieldName: 'inspectorBadge', displayType: 'string', required: false, isIndex: false, isSortable: true },
+      { fieldName: 'assignedShipId', displayType: 'enumerated', required: false, isIndex: true, isSortable: true, enumeratedResource: Resources.Ships },
     ],
   },
 };
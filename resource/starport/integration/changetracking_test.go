@@ -11,11 +11,11 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"strings"
 	"testing"
 
 	"cloud.google.com/go/spanner"
 	"github.com/cccteam/ccc/accesstypes"
+	"github.com/cccteam/ccc/resource"
 	initiator "github.com/cccteam/db-initiator"
 	"github.com/go-playground/errors/v5"
 )
@@ -107,8 +107,12 @@ func TestChangeTracking(t *testing.T) {
 				}
 
 				ev := events[0]
-				if !strings.HasPrefix(ev.eventSource, "integration-test-user (") {
-					t.Errorf("EventSource = %q, want username+session prefix", ev.eventSource)
+				source, err := resource.ParseEventSource(ev.eventSource)
+				if err != nil {
+					t.Fatalf("ParseEventSource(%q) error = %v", ev.eventSource, err)
+				}
+				if source.Username != "integration-test-user" || source.ActorID == "" {
+					t.Errorf("EventSource = %q, want username and session ID", ev.eventSource)
 				}
 				if d := ev.diff(t, "Label"); d["New"] != "Med Kits" || d["Old"] != nil {
 					t.Errorf("Label diff = %v, want New=Med Kits Old=nil", d)
@@ -0,0 +1,78 @@
+package integration
+
+// This suite covers the reads QuerySet serves with the Spanner Read API instead of a SQL
+// query: a Read by full primary key and a List of a key range. Their rows are decoded by
+// spxscan like the rows of a query, NULL columns included. It runs read-only against the
+// baseline seed data.
+
+import (
+	"testing"
+
+	"cloud.google.com/go/spanner"
+	"github.com/cccteam/ccc"
+	"github.com/cccteam/ccc/resource"
+	"github.com/cccteam/ccc/resource/starport/pkg/resources"
+	"github.com/cccteam/httpio"
+)
+
+func TestReadAPI(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	db, err := prepareDatabase(ctx, t, "file://../schema/migrations", "file://testdata/seed")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	txn := resource.NewSpannerClient(db.Client).ReadOnlyTransaction()
+	defer txn.Close()
+
+	comostID, err := ccc.UUIDFromString(shipComostID)
+	if err != nil {
+		t.Fatalf("ccc.UUIDFromString(): %v", err)
+	}
+	vantaID, err := ccc.UUIDFromString(shipVantaID)
+	if err != nil {
+		t.Fatalf("ccc.UUIDFromString(): %v", err)
+	}
+
+	t.Run("read by key", func(t *testing.T) {
+		ship, err := resources.NewShipQuery().SetID(comostID).Read(ctx, txn)
+		if err != nil {
+			t.Fatalf("ShipQuery.Read(): %v", err)
+		}
+		if ship.ID != comostID || ship.Name != "Comost" || ship.CargoValue != 125000 {
+			t.Errorf("ShipQuery.Read() = %+v, want Comost", ship)
+		}
+		if ship.DockingBayID.Valid || ship.UpdatedAt != nil {
+			t.Errorf("ShipQuery.Read() DockingBayID = %v, UpdatedAt = %v, want NULL", ship.DockingBayID, ship.UpdatedAt)
+		}
+	})
+
+	t.Run("read by key not found", func(t *testing.T) {
+		missingID, err := ccc.NewUUID()
+		if err != nil {
+			t.Fatalf("ccc.NewUUID(): %v", err)
+		}
+		if _, err := resources.NewShipQuery().SetID(missingID).Read(ctx, txn); !httpio.HasNotFound(err) {
+			t.Errorf("ShipQuery.Read() error = %v, want not found", err)
+		}
+	})
+
+	t.Run("list of a key range", func(t *testing.T) {
+		var details []string
+		for manifest, err := range resources.NewCargoManifestQuery().SetShipIDRange(vantaID, vantaID, spanner.ClosedClosed).List(ctx, txn) {
+			if err != nil {
+				t.Fatalf("CargoManifestQuery.List(): %v", err)
+			}
+			if manifest.ShipID != vantaID || manifest.Quantity != 120 {
+				t.Errorf("CargoManifestQuery.List() row = %+v", manifest)
+			}
+			details = append(details, manifest.Details)
+		}
+		if len(details) != 1 || details[0] != "Hull plating" {
+			t.Errorf("CargoManifestQuery.List() details = %v, want [Hull plating]", details)
+		}
+	})
+}
@@ -0,0 +1,243 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/cccteam/ccc"
+	"github.com/cccteam/ccc/resource/starport/pkg/mock/mock_router"
+	"github.com/cccteam/ccc/resource/starport/pkg/router"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/mock/gomock"
+)
+
+func TestClient_routes(t *testing.T) {
+	t.Parallel()
+
+	shipID := ccc.Must(ccc.NewUUID())
+	filter, err := NewCrewMemberQueryClause().ShipID().Equal(shipID).String()
+	if err != nil {
+		t.Fatalf("CrewMemberQueryClause.String() error = %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		call        func(ctx context.Context, c *Client) error
+		method      string
+		handlerFunc string
+		parameters  map[string]string
+	}{
+		{
+			name: "AuthorizeLaunch",
+			call: func(ctx context.Context, c *Client) error {
+				_, err := c.AuthorizeLaunch(ctx, &AuthorizeLaunchRequest{ShipID: shipID, LaunchCode: "alpha"})
+
+				return err
+			},
+			method: http.MethodPost, handlerFunc: "AuthorizeLaunch",
+		},
+		{
+			name: "CargoManifests.List",
+			call: func(ctx context.Context, c *Client) error {
+				_, err := c.CargoManifests().List(ctx, nil)
+
+				return err
+			},
+			method: http.MethodGet, handlerFunc: "CargoManifests",
+		},
+		{
+			name: "CargoManifests.Read",
+			call: func(ctx context.Context, c *Client) error {
+				_, err := c.CargoManifests().Read(ctx, shipID, 7)
+
+				return err
+			},
+			method: http.MethodGet, handlerFunc: "CargoManifest",
+			parameters: map[string]string{"cargoManifestShipID": shipID.String(), "cargoManifestLineNumber": strconv.Itoa(7)},
+		},
+		{
+			name: "CrewMembers.List",
+			call: func(ctx context.Context, c *Client) error {
+				_, err := c.CrewMembers().List(ctx, &ListQuery{Columns: []string{"name"}, Limit: 10})
+
+				return err
+			},
+			method: http.MethodGet, handlerFunc: "CrewMembers",
+		},
+		{
+			name: "CrewMembers.List with a filter",
+			call: func(ctx context.Context, c *Client) error {
+				_, err := c.CrewMembers().List(ctx, &ListQuery{Filter: filter})
+
+				return err
+			},
+			method: http.MethodPost, handlerFunc: "CrewMembers",
+		},
+		{
+			name: "CrewMembers.Read",
+			call: func(ctx context.Context, c *Client) error {
+				_, err := c.CrewMembers().Read(ctx, shipID, "name")
+
+				return err
+			},
+			method: http.MethodGet, handlerFunc: "CrewMember",
+			parameters: map[string]string{"crewMemberID": shipID.String()},
+		},
+		{
+			name: "CrewMembers.Patch",
+			call: func(ctx context.Context, c *Client) error {
+				_, err := c.CrewMembers().Patch(ctx, NewCrewMemberOperations().Delete(shipID))
+
+				return err
+			},
+			method: http.MethodPatch, handlerFunc: "PatchCrewMembers",
+		},
+		{
+			name: "DockingBays.List",
+			call: func(ctx context.Context, c *Client) error {
+				_, err := c.DockingBays().List(ctx, nil)
+
+				return err
+			},
+			method: http.MethodGet, handlerFunc: "DockingBays",
+		},
+		{
+			name: "DockingBays.Read",
+			call: func(ctx context.Context, c *Client) error {
+				_, err := c.DockingBays().Read(ctx, shipID)
+
+				return err
+			},
+			method: http.MethodGet, handlerFunc: "DockingBay",
+			parameters: map[string]string{"dockingBayID": shipID.String()},
+		},
+		{
+			name: "Ships.List",
+			call: func(ctx context.Context, c *Client) error {
+				_, err := c.Ships().List(ctx, nil)
+
+				return err
+			},
+			method: http.MethodGet, handlerFunc: "Ships",
+		},
+		{
+			name: "Ships.Read",
+			call: func(ctx context.Context, c *Client) error {
+				_, err := c.Ships().Read(ctx, shipID)
+
+				return err
+			},
+			method: http.MethodGet, handlerFunc: "Ship",
+			parameters: map[string]string{"shipID": shipID.String()},
+		},
+		{
+			name: "SupplyCrates.List",
+			call: func(ctx context.Context, c *Client) error {
+				_, err := c.SupplyCrates().List(ctx, nil)
+
+				return err
+			},
+			method: http.MethodGet, handlerFunc: "SupplyCrates",
+		},
+		{
+			name: "SupplyCrates.Read",
+			call: func(ctx context.Context, c *Client) error {
+				_, err := c.SupplyCrates().Read(ctx, shipID)
+
+				return err
+			},
+			method: http.MethodGet, handlerFunc: "SupplyCrate",
+			parameters: map[string]string{"supplyCrateID": shipID.String()},
+		},
+		{
+			name: "PatchResources",
+			call: func(ctx context.Context, c *Client) error {
+				ops := NewResourceOperations().
+					CreateShip(NewShipPatch().SetName("Vanta")).
+					DeleteCargoManifest(shipID, 7)
+				_, err := c.PatchResources(ctx, ops)
+
+				return err
+			},
+			method: http.MethodPatch, handlerFunc: "PatchResources",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c, rec := newRoutedClient(t)
+			if err := tt.call(t.Context(), c); err != nil {
+				t.Fatalf("%s() error = %v", tt.name, err)
+			}
+
+			want := recordedCall{method: tt.method, handlerFunc: tt.handlerFunc, parameters: tt.parameters}
+			if want.parameters == nil {
+				want.parameters = map[string]string{}
+			}
+			if diff := cmp.Diff([]recordedCall{want}, rec.calls, cmp.AllowUnexported(recordedCall{})); diff != "" {
+				t.Errorf("%s() routed calls mismatch (-want +got):\n%s", tt.name, diff)
+			}
+		})
+	}
+}
+
+// newRoutedClient returns a Client of a server running the generated routes, with every
+// handler answering through the returned recorder.
+func newRoutedClient(t *testing.T) (*Client, *callRecorder) {
+	t.Helper()
+
+	rec := &callRecorder{}
+	handlers := mock_router.NewMockHandlers(gomock.NewController(t))
+	handlers.EXPECT().AuthorizeLaunch().Return(rec.RecordHandlerCall("AuthorizeLaunch", `{}`))
+	handlers.EXPECT().CargoManifests().Return(rec.RecordHandlerCall("CargoManifests", `[]`))
+	handlers.EXPECT().CargoManifest().Return(rec.RecordHandlerCall("CargoManifest", `{}`))
+	handlers.EXPECT().CrewMembers().Return(rec.RecordHandlerCall("CrewMembers", `[]`))
+	handlers.EXPECT().CrewMember().Return(rec.RecordHandlerCall("CrewMember", `{}`))
+	handlers.EXPECT().PatchCrewMembers().Return(rec.RecordHandlerCall("PatchCrewMembers", `{}`))
+	handlers.EXPECT().DockingBays().Return(rec.RecordHandlerCall("DockingBays", `[]`))
+	handlers.EXPECT().DockingBay().Return(rec.RecordHandlerCall("DockingBay", `{}`))
+	handlers.EXPECT().Ships().Return(rec.RecordHandlerCall("Ships", `[]`))
+	handlers.EXPECT().Ship().Return(rec.RecordHandlerCall("Ship", `{}`))
+	handlers.EXPECT().SupplyCrates().Return(rec.RecordHandlerCall("SupplyCrates", `[]`))
+	handlers.EXPECT().SupplyCrate().Return(rec.RecordHandlerCall("SupplyCrate", `{}`))
+	handlers.EXPECT().PatchResources().Return(rec.RecordHandlerCall("PatchResources", `{}`))
+
+	srv := httptest.NewServer(router.New(handlers))
+	t.Cleanup(srv.Close)
+
+	return New(srv.URL + "/api"), rec
+}
+
+type recordedCall struct {
+	method      string
+	handlerFunc string
+	parameters  map[string]string
+}
+
+type callRecorder struct {
+	mu    sync.Mutex
+	calls []recordedCall
+}
+
+func (rec *callRecorder) RecordHandlerCall(name, body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		call := recordedCall{method: r.Method, handlerFunc: name, parameters: make(map[string]string)}
+		params := chi.RouteContext(r.Context()).URLParams
+		for i, key := range params.Keys {
+			call.parameters[key] = params.Values[i]
+		}
+
+		rec.mu.Lock()
+		rec.calls = append(rec.calls, call)
+		rec.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"iter"
 
+	"cloud.google.com/go/spanner"
 	"github.com/cccteam/ccc"
 	"github.com/cccteam/ccc/accesstypes"
 	"github.com/cccteam/ccc/resource"
@@ -21,6 +22,11 @@ func (CargoManifest) DefaultConfig() resource.Config {
 	return defaultConfig()
 }
 
+// PrimaryKeyFields returns the primary key fields in key order.
+func (CargoManifest) PrimaryKeyFields() []accesstypes.Field {
+	return []accesstypes.Field{"ShipID", "LineNumber"}
+}
+
 type CargoManifestQuery struct {
 	qSet *resource.QuerySet[CargoManifest]
 }
@@ -57,10 +63,43 @@ func (q *CargoManifestQuery) LineNumber() int64 {
 	return v
 }
 
+// SetKeyPrefixShipID restricts the query to the rows whose primary key begins with ShipID.
+func (q *CargoManifestQuery) SetKeyPrefixShipID(shipID ccc.UUID) *CargoManifestQuery {
+	q.qSet.SetKeyPrefix(resource.KeySet{}.Add("ShipID", shipID))
+
+	return q
+}
+
+// SetShipIDRange restricts the query to the rows whose ShipID is between start and end.
+func (q *CargoManifestQuery) SetShipIDRange(start, end ccc.UUID, kind spanner.KeyRangeKind) *CargoManifestQuery {
+	q.qSet.SetKeyRange(resource.KeyRange{
+		Start: resource.KeySet{}.Add("ShipID", start),
+		End:   resource.KeySet{}.Add("ShipID", end),
+		Kind:  kind,
+	})
+
+	return q
+}
+
+// SetLineNumberRange restricts the query to the rows under the given ShipID whose LineNumber is between start and end.
+func (q *CargoManifestQuery) SetLineNumberRange(shipID ccc.UUID, start, end int64, kind spanner.KeyRangeKind) *CargoManifestQuery {
+	q.qSet.SetKeyRange(resource.KeyRange{
+		Start: resource.KeySet{}.Add("ShipID", shipID).Add("LineNumber", start),
+		End:   resource.KeySet{}.Add("ShipID", shipID).Add("LineNumber", end),
+		Kind:  kind,
+	})
+
+	return q
+}
+
 func (q *CargoManifestQuery) Read(ctx context.Context, txn resource.ReadOnlyTransaction) (*CargoManifest, error) {
 	return q.qSet.Read(ctx, txn)
 }
 
+func (q *CargoManifestQuery) ReadMany(ctx context.Context, txn resource.ReadOnlyTransaction, keys []resource.KeySet) ([]*CargoManifest, error) {
+	return q.qSet.ReadMany(ctx, txn, keys)
+}
+
 func (q *CargoManifestQuery) List(ctx context.Context, txn resource.ReadOnlyTransaction) iter.Seq2[*CargoManifest, error] {
 	return q.qSet.List(ctx, txn)
 }
@@ -69,6 +108,22 @@ func (q *CargoManifestQuery) BatchList(ctx context.Context, client resource.Clie
 	return q.qSet.BatchList(ctx, client, size)
 }
 
+func (q *CargoManifestQuery) UpdateWhere(ctx context.Context, txn resource.ReadWriteTransaction, patch *resource.PatchSet[CargoManifest], eventSource ...string) (int64, error) {
+	return q.qSet.UpdateWhere(ctx, txn, patch, eventSource...)
+}
+
+func (q *CargoManifestQuery) DeleteWhere(ctx context.Context, txn resource.ReadWriteTransaction, eventSource ...string) (int64, error) {
+	return q.qSet.DeleteWhere(ctx, txn, eventSource...)
+}
+
+func (q *CargoManifestQuery) PartitionedUpdateWhere(ctx context.Context, client resource.PartitionedExecutor, patch *resource.PatchSet[CargoManifest]) (int64, error) {
+	return q.qSet.PartitionedUpdateWhere(ctx, client, patch)
+}
+
+func (q *CargoManifestQuery) PartitionedDeleteWhere(ctx context.Context, client resource.PartitionedExecutor) (int64, error) {
+	return q.qSet.PartitionedDeleteWhere(ctx, client)
+}
+
 func (q *CargoManifestQuery) AddColumns(c *CargoManifestColumns) *CargoManifestQuery {
 	for _, field := range c.fields {
 		q.qSet.AddField(field)
@@ -219,6 +274,10 @@ func (i CargoManifestQueryIdent[T]) LessThanEq(v T) CargoManifestQueryClause {
 	return CargoManifestQueryClause{clause: i.Ident.LessThanEq(v)}
 }
 
+func (i CargoManifestQueryIdent[T]) Between(lo, hi T) CargoManifestQueryClause {
+	return CargoManifestQueryClause{clause: i.Ident.Between(lo, hi)}
+}
+
 func (i CargoManifestQueryIdent[T]) IsNull() CargoManifestQueryClause {
 	return CargoManifestQueryClause{clause: i.Ident.IsNull()}
 }
@@ -281,6 +340,18 @@ func (s *CargoManifestSort) Desc() *CargoManifestSort {
 	return s
 }
 
+func (s *CargoManifestSort) NullsFirst() *CargoManifestSort {
+	s.sortFields[len(s.sortFields)-1].Nulls = resource.SortNullsFirst
+
+	return s
+}
+
+func (s *CargoManifestSort) NullsLast() *CargoManifestSort {
+	s.sortFields[len(s.sortFields)-1].Nulls = resource.SortNullsLast
+
+	return s
+}
+
 type CargoManifestCreatePatch struct {
 	patchSet *resource.PatchSet[CargoManifest]
 }
@@ -22,6 +22,11 @@ func (CrewMember) DefaultConfig() resource.Config {
 	return defaultConfig()
 }
 
+// PrimaryKeyFields returns the primary key fields in key order.
+func (CrewMember) PrimaryKeyFields() []accesstypes.Field {
+	return []accesstypes.Field{"ID"}
+}
+
 type CrewMemberQuery struct {
 	qSet *resource.QuerySet[CrewMember]
 }
@@ -50,6 +55,10 @@ func (q *CrewMemberQuery) Read(ctx context.Context, txn resource.ReadOnlyTransac
 	return q.qSet.Read(ctx, txn)
 }
 
+func (q *CrewMemberQuery) ReadMany(ctx context.Context, txn resource.ReadOnlyTransaction, keys []resource.KeySet) ([]*CrewMember, error) {
+	return q.qSet.ReadMany(ctx, txn, keys)
+}
+
 func (q *CrewMemberQuery) List(ctx context.Context, txn resource.ReadOnlyTransaction) iter.Seq2[*CrewMember, error] {
 	return q.qSet.List(ctx, txn)
 }
@@ -58,6 +67,22 @@ func (q *CrewMemberQuery) BatchList(ctx context.Context, client resource.Client,
 	return q.qSet.BatchList(ctx, client, size)
 }
 
+func (q *CrewMemberQuery) UpdateWhere(ctx context.Context, txn resource.ReadWriteTransaction, patch *resource.PatchSet[CrewMember], eventSource ...string) (int64, error) {
+	return q.qSet.UpdateWhere(ctx, txn, patch, eventSource...)
+}
+
+func (q *CrewMemberQuery) DeleteWhere(ctx context.Context, txn resource.ReadWriteTransaction, eventSource ...string) (int64, error) {
+	return q.qSet.DeleteWhere(ctx, txn, eventSource...)
+}
+
+func (q *CrewMemberQuery) PartitionedUpdateWhere(ctx context.Context, client resource.PartitionedExecutor, patch *resource.PatchSet[CrewMember]) (int64, error) {
+	return q.qSet.PartitionedUpdateWhere(ctx, client, patch)
+}
+
+func (q *CrewMemberQuery) PartitionedDeleteWhere(ctx context.Context, client resource.PartitionedExecutor) (int64, error) {
+	return q.qSet.PartitionedDeleteWhere(ctx, client)
+}
+
 func (q *CrewMemberQuery) AddColumns(c *CrewMemberColumns) *CrewMemberQuery {
 	for _, field := range c.fields {
 		q.qSet.AddField(field)
@@ -215,6 +240,10 @@ func (i CrewMemberQueryIdent[T]) LessThanEq(v T) CrewMemberQueryClause {
 	return CrewMemberQueryClause{clause: i.Ident.LessThanEq(v)}
 }
 
+func (i CrewMemberQueryIdent[T]) Between(lo, hi T) CrewMemberQueryClause {
+	return CrewMemberQueryClause{clause: i.Ident.Between(lo, hi)}
+}
+
 func (i CrewMemberQueryIdent[T]) IsNull() CrewMemberQueryClause {
 	return CrewMemberQueryClause{clause: i.Ident.IsNull()}
 }
@@ -281,6 +310,18 @@ func (s *CrewMemberSort) Desc() *CrewMemberSort {
 	return s
 }
 
+func (s *CrewMemberSort) NullsFirst() *CrewMemberSort {
+	s.sortFields[len(s.sortFields)-1].Nulls = resource.SortNullsFirst
+
+	return s
+}
+
+func (s *CrewMemberSort) NullsLast() *CrewMemberSort {
+	s.sortFields[len(s.sortFields)-1].Nulls = resource.SortNullsLast
+
+	return s
+}
+
 type CrewMemberCreatePatch struct {
 	patchSet *resource.PatchSet[CrewMember]
 }
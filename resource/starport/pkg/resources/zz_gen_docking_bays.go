@@ -22,6 +22,11 @@ func (DockingBay) DefaultConfig() resource.Config {
 	return defaultConfig()
 }
 
+// PrimaryKeyFields returns the primary key fields in key order.
+func (DockingBay) PrimaryKeyFields() []accesstypes.Field {
+	return []accesstypes.Field{"ID"}
+}
+
 type DockingBayQuery struct {
 	qSet *resource.QuerySet[DockingBay]
 }
@@ -62,6 +67,10 @@ func (q *DockingBayQuery) Read(ctx context.Context, txn resource.ReadOnlyTransac
 	return q.qSet.Read(ctx, txn)
 }
 
+func (q *DockingBayQuery) ReadMany(ctx context.Context, txn resource.ReadOnlyTransaction, keys []resource.KeySet) ([]*DockingBay, error) {
+	return q.qSet.ReadMany(ctx, txn, keys)
+}
+
 func (q *DockingBayQuery) List(ctx context.Context, txn resource.ReadOnlyTransaction) iter.Seq2[*DockingBay, error] {
 	return q.qSet.List(ctx, txn)
 }
@@ -70,6 +79,22 @@ func (q *DockingBayQuery) BatchList(ctx context.Context, client resource.Client,
 	return q.qSet.BatchList(ctx, client, size)
 }
 
+func (q *DockingBayQuery) UpdateWhere(ctx context.Context, txn resource.ReadWriteTransaction, patch *resource.PatchSet[DockingBay], eventSource ...string) (int64, error) {
+	return q.qSet.UpdateWhere(ctx, txn, patch, eventSource...)
+}
+
+func (q *DockingBayQuery) DeleteWhere(ctx context.Context, txn resource.ReadWriteTransaction, eventSource ...string) (int64, error) {
+	return q.qSet.DeleteWhere(ctx, txn, eventSource...)
+}
+
+func (q *DockingBayQuery) PartitionedUpdateWhere(ctx context.Context, client resource.PartitionedExecutor, patch *resource.PatchSet[DockingBay]) (int64, error) {
+	return q.qSet.PartitionedUpdateWhere(ctx, client, patch)
+}
+
+func (q *DockingBayQuery) PartitionedDeleteWhere(ctx context.Context, client resource.PartitionedExecutor) (int64, error) {
+	return q.qSet.PartitionedDeleteWhere(ctx, client)
+}
+
 func (q *DockingBayQuery) AddColumns(c *DockingBayColumns) *DockingBayQuery {
 	for _, field := range c.fields {
 		q.qSet.AddField(field)
@@ -213,6 +238,10 @@ func (i DockingBayQueryIdent[T]) LessThanEq(v T) DockingBayQueryClause {
 	return DockingBayQueryClause{clause: i.Ident.LessThanEq(v)}
 }
 
+func (i DockingBayQueryIdent[T]) Between(lo, hi T) DockingBayQueryClause {
+	return DockingBayQueryClause{clause: i.Ident.Between(lo, hi)}
+}
+
 func (i DockingBayQueryIdent[T]) IsNull() DockingBayQueryClause {
 	return DockingBayQueryClause{clause: i.Ident.IsNull()}
 }
@@ -271,6 +300,18 @@ func (s *DockingBaySort) Desc() *DockingBaySort {
 	return s
 }
 
+func (s *DockingBaySort) NullsFirst() *DockingBaySort {
+	s.sortFields[len(s.sortFields)-1].Nulls = resource.SortNullsFirst
+
+	return s
+}
+
+func (s *DockingBaySort) NullsLast() *DockingBaySort {
+	s.sortFields[len(s.sortFields)-1].Nulls = resource.SortNullsLast
+
+	return s
+}
+
 type DockingBayCreatePatch struct {
 	patchSet *resource.PatchSet[DockingBay]
 }
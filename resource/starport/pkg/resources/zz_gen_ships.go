@@ -23,6 +23,11 @@ func (Ship) DefaultConfig() resource.Config {
 	return defaultConfig()
 }
 
+// PrimaryKeyFields returns the primary key fields in key order.
+func (Ship) PrimaryKeyFields() []accesstypes.Field {
+	return []accesstypes.Field{"ID"}
+}
+
 type ShipQuery struct {
 	qSet *resource.QuerySet[Ship]
 }
@@ -63,6 +68,10 @@ func (q *ShipQuery) Read(ctx context.Context, txn resource.ReadOnlyTransaction)
 	return q.qSet.Read(ctx, txn)
 }
 
+func (q *ShipQuery) ReadMany(ctx context.Context, txn resource.ReadOnlyTransaction, keys []resource.KeySet) ([]*Ship, error) {
+	return q.qSet.ReadMany(ctx, txn, keys)
+}
+
 func (q *ShipQuery) List(ctx context.Context, txn resource.ReadOnlyTransaction) iter.Seq2[*Ship, error] {
 	return q.qSet.List(ctx, txn)
 }
@@ -71,6 +80,22 @@ func (q *ShipQuery) BatchList(ctx context.Context, client resource.Client, size
 	return q.qSet.BatchList(ctx, client, size)
 }
 
+func (q *ShipQuery) UpdateWhere(ctx context.Context, txn resource.ReadWriteTransaction, patch *resource.PatchSet[Ship], eventSource ...string) (int64, error) {
+	return q.qSet.UpdateWhere(ctx, txn, patch, eventSource...)
+}
+
+func (q *ShipQuery) DeleteWhere(ctx context.Context, txn resource.ReadWriteTransaction, eventSource ...string) (int64, error) {
+	return q.qSet.DeleteWhere(ctx, txn, eventSource...)
+}
+
+func (q *ShipQuery) PartitionedUpdateWhere(ctx context.Context, client resource.PartitionedExecutor, patch *resource.PatchSet[Ship]) (int64, error) {
+	return q.qSet.PartitionedUpdateWhere(ctx, client, patch)
+}
+
+func (q *ShipQuery) PartitionedDeleteWhere(ctx context.Context, client resource.PartitionedExecutor) (int64, error) {
+	return q.qSet.PartitionedDeleteWhere(ctx, client)
+}
+
 func (q *ShipQuery) AddColumns(c *ShipColumns) *ShipQuery {
 	for _, field := range c.fields {
 		q.qSet.AddField(field)
@@ -236,6 +261,10 @@ func (i ShipQueryIdent[T]) LessThanEq(v T) ShipQueryClause {
 	return ShipQueryClause{clause: i.Ident.LessThanEq(v)}
 }
 
+func (i ShipQueryIdent[T]) Between(lo, hi T) ShipQueryClause {
+	return ShipQueryClause{clause: i.Ident.Between(lo, hi)}
+}
+
 func (i ShipQueryIdent[T]) IsNull() ShipQueryClause {
 	return ShipQueryClause{clause: i.Ident.IsNull()}
 }
@@ -302,6 +331,18 @@ func (s *ShipSort) Desc() *ShipSort {
 	return s
 }
 
+func (s *ShipSort) NullsFirst() *ShipSort {
+	s.sortFields[len(s.sortFields)-1].Nulls = resource.SortNullsFirst
+
+	return s
+}
+
+func (s *ShipSort) NullsLast() *ShipSort {
+	s.sortFields[len(s.sortFields)-1].Nulls = resource.SortNullsLast
+
+	return s
+}
+
 type ShipCreatePatch struct {
 	patchSet *resource.PatchSet[Ship]
 }
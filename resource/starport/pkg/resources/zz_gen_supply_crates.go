@@ -22,6 +22,11 @@ func (SupplyCrate) DefaultConfig() resource.Config {
 	return defaultConfig()
 }
 
+// PrimaryKeyFields returns the primary key fields in key order.
+func (SupplyCrate) PrimaryKeyFields() []accesstypes.Field {
+	return []accesstypes.Field{"ID"}
+}
+
 type SupplyCrateQuery struct {
 	qSet *resource.QuerySet[SupplyCrate]
 }
@@ -50,6 +55,10 @@ func (q *SupplyCrateQuery) Read(ctx context.Context, txn resource.ReadOnlyTransa
 	return q.qSet.Read(ctx, txn)
 }
 
+func (q *SupplyCrateQuery) ReadMany(ctx context.Context, txn resource.ReadOnlyTransaction, keys []resource.KeySet) ([]*SupplyCrate, error) {
+	return q.qSet.ReadMany(ctx, txn, keys)
+}
+
 func (q *SupplyCrateQuery) List(ctx context.Context, txn resource.ReadOnlyTransaction) iter.Seq2[*SupplyCrate, error] {
 	return q.qSet.List(ctx, txn)
 }
@@ -58,6 +67,22 @@ func (q *SupplyCrateQuery) BatchList(ctx context.Context, client resource.Client
 	return q.qSet.BatchList(ctx, client, size)
 }
 
+func (q *SupplyCrateQuery) UpdateWhere(ctx context.Context, txn resource.ReadWriteTransaction, patch *resource.PatchSet[SupplyCrate], eventSource ...string) (int64, error) {
+	return q.qSet.UpdateWhere(ctx, txn, patch, eventSource...)
+}
+
+func (q *SupplyCrateQuery) DeleteWhere(ctx context.Context, txn resource.ReadWriteTransaction, eventSource ...string) (int64, error) {
+	return q.qSet.DeleteWhere(ctx, txn, eventSource...)
+}
+
+func (q *SupplyCrateQuery) PartitionedUpdateWhere(ctx context.Context, client resource.PartitionedExecutor, patch *resource.PatchSet[SupplyCrate]) (int64, error) {
+	return q.qSet.PartitionedUpdateWhere(ctx, client, patch)
+}
+
+func (q *SupplyCrateQuery) PartitionedDeleteWhere(ctx context.Context, client resource.PartitionedExecutor) (int64, error) {
+	return q.qSet.PartitionedDeleteWhere(ctx, client)
+}
+
 func (q *SupplyCrateQuery) AddColumns(c *SupplyCrateColumns) *SupplyCrateQuery {
 	for _, field := range c.fields {
 		q.qSet.AddField(field)
@@ -248,6 +273,10 @@ func (i SupplyCrateQueryIdent[T]) LessThanEq(v T) SupplyCrateQueryClause {
 	return SupplyCrateQueryClause{clause: i.Ident.LessThanEq(v)}
 }
 
+func (i SupplyCrateQueryIdent[T]) Between(lo, hi T) SupplyCrateQueryClause {
+	return SupplyCrateQueryClause{clause: i.Ident.Between(lo, hi)}
+}
+
 func (i SupplyCrateQueryIdent[T]) IsNull() SupplyCrateQueryClause {
 	return SupplyCrateQueryClause{clause: i.Ident.IsNull()}
 }
@@ -326,6 +355,18 @@ func (s *SupplyCrateSort) Desc() *SupplyCrateSort {
 	return s
 }
 
+func (s *SupplyCrateSort) NullsFirst() *SupplyCrateSort {
+	s.sortFields[len(s.sortFields)-1].Nulls = resource.SortNullsFirst
+
+	return s
+}
+
+func (s *SupplyCrateSort) NullsLast() *SupplyCrateSort {
+	s.sortFields[len(s.sortFields)-1].Nulls = resource.SortNullsLast
+
+	return s
+}
+
 type SupplyCrateCreatePatch struct {
 	patchSet *resource.PatchSet[SupplyCrate]
 }
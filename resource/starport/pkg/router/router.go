@@ -2,6 +2,7 @@
 package router
 
 import (
+	"github.com/cccteam/ccc/resource"
 	"github.com/go-chi/chi/v5"
 )
 
@@ -13,6 +14,7 @@ type Handlers interface {
 // New wires the generated routes to their handlers.
 func New(h Handlers) *chi.Mux {
 	r := chi.NewRouter()
+	r.Use(resource.RequestInfoMiddleware)
 
 	generatedRoutes(r, h)
 
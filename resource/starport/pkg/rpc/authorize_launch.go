@@ -6,17 +6,19 @@ import (
 	"github.com/cccteam/ccc"
 	"github.com/cccteam/ccc/resource"
 	"github.com/cccteam/httpio"
+	"github.com/go-playground/errors/v5"
 )
 
 type (
-	// AuthorizeLaunch authorizes a ship for departure. The starport only exercises the
-	// generated wiring and the Execute permission gate, so the implementation is
-	// intentionally minimal.
+	// AuthorizeLaunch authorizes a ship for departure and responds with the authorization's
+	// ID. The starport only exercises the generated wiring and the Execute permission gate,
+	// so the implementation is intentionally minimal.
 	//
 	// @rpc
 	AuthorizeLaunch struct {
-		ShipID     ccc.UUID
-		LaunchCode string
+		ShipID          ccc.UUID
+		LaunchCode      string
+		AuthorizationID ccc.UUID `conditions:"output_only"`
 	}
 )
 
@@ -26,5 +28,11 @@ func (a *AuthorizeLaunch) Execute(_ context.Context, _ resource.ReadWriteTransac
 		return httpio.NewBadRequestMessage("launchCode is required")
 	}
 
+	id, err := ccc.NewUUID()
+	if err != nil {
+		return errors.Wrap(err, "ccc.NewUUID()")
+	}
+	a.AuthorizationID = id
+
 	return nil
 }
@@ -11,7 +11,9 @@ const (
 	immutableTagKey   = "immutable"
 	indexTagKey       = "index"
 	allowFilterTagKey = "allow_filter"
+	allowSortTagKey   = "allow_sort"
 	piiTagKey         = "pii"
+	maskTagKey        = "mask"
 )
 
 // runtimeTagKeys registers every runtime-read struct-tag key for the README.md
@@ -22,7 +24,9 @@ var runtimeTagKeys = []string{
 	immutableTagKey,
 	indexTagKey,
 	allowFilterTagKey,
+	allowSortTagKey,
 	piiTagKey,
+	maskTagKey,
 }
 
 // Reserved query-string parameter names consumed by QueryDecoder; they can never be used
@@ -34,6 +38,8 @@ const (
 	sortParam    = "sort"
 	limitParam   = "limit"
 	offsetParam  = "offset"
+	idsParam     = "ids"
+	searchParam  = "search"
 )
 
 // reservedQueryParams registers every reserved query parameter for the README.md
@@ -44,4 +50,6 @@ var reservedQueryParams = []string{
 	sortParam,
 	limitParam,
 	offsetParam,
+	idsParam,
+	searchParam,
 }
@@ -19,13 +19,20 @@ const (
 	inStr    = "in"
 	notinStr = "notin"
 
+	betweenStr = "between"
+
 	isnullStr    = "isnull"
 	isnotnullStr = "isnotnull"
+
+	hasStr    = "has"
+	hasanyStr = "hasany"
+	hasallStr = "hasall"
 )
 
 var _ PatchSetMetadata = (*DataChangeEvent)(nil)
 
-// DataChangeEvent represents a record of a change made to a database table.
+// DataChangeEvent represents a record of a change made to a database table. EventSource holds
+// the JSON form of an EventSource; read it with ParseEventSource.
 type DataChangeEvent struct {
 	TableName   accesstypes.Resource `spanner:"TableName"`
 	RowID       string               `spanner:"RowId"`
@@ -83,10 +90,23 @@ const (
 	SortDescending SortDirection = "desc"
 )
 
-// SortField represents a field to sort by, including the field name and sort direction.
+// SortNulls defines where NULL values are placed in a sort. The zero value leaves them where the
+// database puts them: first in ascending order on Spanner, last on PostgreSQL.
+type SortNulls string
+
+const (
+	// SortNullsFirst places NULL values before all other values.
+	SortNullsFirst SortNulls = "nullsfirst"
+	// SortNullsLast places NULL values after all other values.
+	SortNullsLast SortNulls = "nullslast"
+)
+
+// SortField represents a field to sort by, including the field name, sort direction, and
+// placement of NULL values.
 type SortField struct {
 	Field     string
 	Direction SortDirection
+	Nulls     SortNulls
 }
 
 var _ FieldDefaultFunc = CommitTimestamp
@@ -103,6 +123,22 @@ func CommitTimestampPtr(_ context.Context, _ ReadWriteTransaction) (any, error)
 	return &spanner.CommitTimestamp, nil
 }
 
+var _ FieldDefaultFunc = CurrentUser
+
+// CurrentUser is a FieldDefaultFunc that returns the username and ID of the user making the request.
+func CurrentUser(ctx context.Context, _ ReadWriteTransaction) (any, error) {
+	return userDisplayName(ctx), nil
+}
+
+var _ FieldDefaultFunc = CurrentUserPtr
+
+// CurrentUserPtr is a FieldDefaultFunc that returns a pointer to the username and ID of the user making the request.
+func CurrentUserPtr(ctx context.Context, _ ReadWriteTransaction) (any, error) {
+	user := userDisplayName(ctx)
+
+	return &user, nil
+}
+
 var _ FieldDefaultFunc = DefaultFalse
 
 // DefaultFalse is a FieldDefaultFunc that returns false.